@@ -101,6 +101,9 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
 	ap.SupportsFlag(SkipVerificationFlag, "", "Skip commit verification before merge")
+	ap.SupportsFlag(AllowUnrelatedFlag, "", "Allow merging a branch that shares no history with the current branch, such as one fetched from a database created separately. Rows are matched by primary key and columns by name, and rows that differ are recorded as conflicts.")
+	ap.SupportsString(BaseParam, "", "commit", "With {{.EmphasisLeft}}--allow-unrelated-histories{{.EmphasisRight}}, use {{.LessThan}}commit{{.GreaterThan}} as the common ancestor of the branches instead of an empty database, so that changes made since it merge as they would with shared history.")
+	ap.SupportsFlag(DetectRenamesFlag, "", "Merge a column that a branch dropped and added again under a new name, such as when an import recreated the table, as a rename of the dropped column when the values of the two columns match in most rows.")
 
 	return ap
 }
@@ -224,6 +227,13 @@ func CreateFetchArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateApplyArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("apply", 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patchfile",
+		"The file with the SQL patch to apply, as written by {{.EmphasisLeft}}dolt diff -r sql{{.EmphasisRight}} or selected from {{.EmphasisLeft}}dolt_patch(){{.EmphasisRight}}."})
+	return ap
+}
+
 func CreateRevertArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("revert")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
@@ -261,6 +271,7 @@ func createTracklessBranchArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(MoveFlag, "m", "Move/rename a branch")
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a branch. The branch must be fully merged in its upstream branch.")
 	ap.SupportsFlag(DeleteForceFlag, "", "Shortcut for {{.EmphasisLeft}}--delete --force{{.EmphasisRight}}.")
+	ap.SupportsFlag(RestoreFlag, "", "Restore a deleted branch as it was when it was most recently deleted.")
 
 	return ap
 }
@@ -279,6 +290,7 @@ func CreateTagArgParser() *argparser.ArgParser {
 	ap.SupportsString(MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the tag message.")
 	ap.SupportsFlag(VerboseFlag, "v", "list tags along with their metadata.")
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a tag.")
+	ap.SupportsFlag(RestoreFlag, "", "Restore a deleted tag as it was when it was most recently deleted.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
 	return ap
 }
@@ -295,6 +307,10 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(ToTimeFlag, "", "time", "Only valid with {{.EmphasisLeft}}restore{{.EmphasisRight}}. After restoring the backup, replay its journal archive up to the last root committed at or before {{.LessThan}}time{{.GreaterThan}}, e.g. 2026-10-01T12:00. Times without an offset are UTC.")
+	ap.SupportsString(RestorePointFlag, "", "name", "Only valid with {{.EmphasisLeft}}restore{{.EmphasisRight}}. Restore the database to the restore point {{.LessThan}}name{{.GreaterThan}} kept in the backup by a scheduled sync, instead of to the latest sync.")
+	ap.SupportsString(ToWorkingSetFlag, "", "hash", "Only valid with {{.EmphasisLeft}}restore{{.EmphasisRight}}. After restoring the backup, replay its journal archive up to the first root with a working set whose hash, or whose working root hash, is {{.LessThan}}hash{{.GreaterThan}}.")
+	ap.SupportsString(IntervalFlag, "", "duration", "Only valid with {{.EmphasisLeft}}ship-journal{{.EmphasisRight}}. Keep shipping the journal every {{.LessThan}}duration{{.GreaterThan}} (e.g. 30s, 5m) until interrupted.")
 	ap.SupportsString(PruneWithGracePeriod, "", "duration", "Only valid with {{.EmphasisLeft}}sync{{.EmphasisRight}} and {{.EmphasisLeft}}sync-url{{.EmphasisRight}} against a {{.EmphasisLeft}}file://{{.EmphasisRight}} backup. Before syncing, delete table files in the destination that no manifest references and that are older than {{.LessThan}}duration{{.GreaterThan}} (e.g. 1h, 30m). Nothing is deleted if any file in the destination has been modified more recently than {{.LessThan}}duration{{.GreaterThan}}, so the duration must be shorter than the interval between syncs for a prune to ever run.")
 	return ap
 }
@@ -384,19 +400,23 @@ func CreateDiffArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("diff")
 	ap.SupportsFlag(SkinnyFlag, "sk", "Shows only primary key columns and any columns with data changes.")
 	ap.SupportsStringList(IncludeCols, "ic", "columns", "A list of columns to include in the diff.")
+	if isTableFunction {
+		ap.SupportsFlag(CellDiffFlag, "", "Adds a cell_diff column with a line diff of each TEXT and JSON column changed by a modified row.")
+	}
 	if !isTableFunction { // TODO: support for table function
 		ap.SupportsFlag(DataFlag, "d", "Show only the data changes, do not show the schema changes (Both shown by default).")
 		ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 		ap.SupportsFlag(StatFlag, "", "Show stats of data changes")
 		ap.SupportsFlag(SummaryFlag, "", "Show summary of data and schema changes")
 		ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
+		ap.SupportsFlag(PreimageFlag, "", "With sql output, matches the rows changed by UPDATE and DELETE statements by all their old values, so that {{.EmphasisLeft}}dolt apply{{.EmphasisRight}} can detect rows that changed since.")
 		ap.SupportsString(WhereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 		ap.SupportsInt(LimitParam, "", "record_count", "limits to the first N diffs.")
 		ap.SupportsString(FilterParam, "", "diff_type", "filters results based on the type of change (added, modified, renamed, dropped). 'removed' is accepted as an alias for 'dropped'.")
 		ap.SupportsFlag(StagedFlag, "", "Show only the staged data changes.")
 		ap.SupportsFlag(CachedFlag, "c", "Synonym for --staged")
 		ap.SupportsFlag(MergeBase, "", "Uses merge base of the first commit and second commit (or HEAD if not supplied) as the first commit")
-		ap.SupportsString(DiffMode, "", "diff mode", "Determines how to display modified rows with tabular output. Valid values are row, line, in-place, context. Defaults to context.")
+		ap.SupportsString(DiffMode, "", "diff mode", "Determines how to display modified rows with tabular output. Valid values are row, line, in-place, context, unified. Defaults to context.")
 		ap.SupportsFlag(ReverseFlag, "R", "Reverses the direction of the diff.")
 		ap.SupportsFlag(NameOnlyFlag, "", "Only shows table names.")
 		ap.SupportsFlag(SystemFlag, "", "Show system tables in addition to user tables")
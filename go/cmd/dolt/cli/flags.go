@@ -20,9 +20,11 @@ const (
 	AbortParam             = "abort"
 	AllFlag                = "all"
 	AllowEmptyFlag         = "allow-empty"
+	AllowUnrelatedFlag     = "allow-unrelated-histories"
 	AmendFlag              = "amend"
 	AuthorParam            = "author"
 	ArchiveLevelParam      = "archive-level"
+	BaseParam              = "base"
 	BranchParam            = "branch"
 	CachedFlag             = "cached"
 	CheckoutCreateBranch   = "b"
@@ -35,6 +37,7 @@ const (
 	DeleteFlag             = "delete"
 	DeleteForceFlag        = "D"
 	DepthFlag              = "depth"
+	DetectRenamesFlag      = "detect-renames"
 	DryRunFlag             = "dry-run"
 	EmptyParam             = "empty"
 	ExcludeIgnoreRulesFlag = "x"
@@ -47,6 +50,7 @@ const (
 	IncludeUntrackedFlag   = "include-untracked"
 	IncrementalGCFileSize  = "incremental-file-size"
 	InteractiveFlag        = "interactive"
+	IntervalFlag           = "interval"
 	JobFlag                = "job"
 	ListFlag               = "list"
 	MergesFlag             = "merges"
@@ -76,6 +80,8 @@ const (
 	QuietFlag              = "quiet"
 	RebaseParam            = "rebase"
 	RemoteParam            = "remote"
+	RestoreFlag            = "restore"
+	RestorePointFlag       = "restore-point"
 	SetUpstreamFlag        = "set-upstream"
 	SetUpstreamToFlag      = "set-upstream-to"
 	ShallowFlag            = "shallow"
@@ -93,6 +99,8 @@ const (
 	SystemFlag             = "system"
 	TablesFlag             = "tables"
 	TheirsFlag             = "theirs"
+	ToTimeFlag             = "to-time"
+	ToWorkingSetFlag       = "to-working-set"
 	TrackFlag              = "track"
 	UpperCaseAllFlag       = "ALL"
 	UserFlag               = "user"
@@ -102,6 +110,8 @@ const (
 const (
 	SkinnyFlag   = "skinny"
 	IncludeCols  = "include-cols"
+	CellDiffFlag = "cell-diff"
+	PreimageFlag = "preimage"
 	DataFlag     = "data"
 	SchemaFlag   = "schema"
 	NameOnlyFlag = "name-only"
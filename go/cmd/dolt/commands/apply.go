@@ -0,0 +1,141 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var applyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply a SQL patch to the working set",
+	LongDesc: "Applies the statements of a SQL patch, such as the output of {{.EmphasisLeft}}dolt diff -r sql{{.EmphasisRight}} " +
+		"or the statements of {{.EmphasisLeft}}dolt_patch(){{.EmphasisRight}}, to the working set, and stages the tables " +
+		"it changes. This lets changes move between databases that don't share history. It requires a clean working set." +
+		"\n\nUnlike running the patch with {{.EmphasisLeft}}dolt sql{{.EmphasisRight}}, each UPDATE and DELETE only changes " +
+		"a row that still has the values in its WHERE clause. A patch created with {{.EmphasisLeft}}--preimage{{.EmphasisRight}} " +
+		"matches rows by all their old values, so rows changed in this database since aren't overwritten. A statement " +
+		"whose row already has its new values is skipped. Otherwise the row is merged with the change, as in a merge, " +
+		"and if both changed the same values, the row is recorded as a conflict and its table is left unstaged. " +
+		"Resolve the conflicts with {{.EmphasisLeft}}dolt conflicts{{.EmphasisRight}}, then stage and commit the tables.",
+	Synopsis: []string{
+		"{{.LessThan}}patchfile{{.GreaterThan}}",
+	},
+}
+
+type ApplyCmd struct{}
+
+var _ cli.Command = ApplyCmd{}
+
+// Name implements the interface cli.Command.
+func (cmd ApplyCmd) Name() string {
+	return "apply"
+}
+
+// Description implements the interface cli.Command.
+func (cmd ApplyCmd) Description() string {
+	return "Apply a SQL patch to the working set."
+}
+
+func (cmd ApplyCmd) Docs() *cli.CommandDocumentation {
+	ap := cli.CreateApplyArgParser()
+	return cli.NewCommandDocumentation(applyDocs, ap)
+}
+
+func (cmd ApplyCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateApplyArgParser()
+}
+
+// Exec implements the interface cli.Command.
+func (cmd ApplyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cli.CreateApplyArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, applyDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	patch, err := os.ReadFile(apr.Arg(0))
+	if err != nil {
+		cli.PrintErrln(fmt.Sprintf("error: failed to read patch file: %s", err.Error()))
+		return 1
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+
+	// Conflicts are left in the working set for the user to resolve
+	_, err = cli.GetRowsForSql(queryist.Queryist, queryist.Context, "set @@dolt_allow_commit_conflicts = 1")
+	if err != nil {
+		cli.PrintErrln(fmt.Errorf("error: failed to set @@dolt_allow_commit_conflicts: %w", err).Error())
+		return 1
+	}
+
+	query, err := dbr.InterpolateForDialect("CALL DOLT_APPLY_PATCH(?)", []interface{}{string(patch)}, dialect.MySQL)
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+	_, rowIter, _, err := queryist.Queryist.Query(queryist.Context, query)
+	if err != nil {
+		cli.PrintErrln(fmt.Sprintf("error: %s", err.Error()))
+		return 1
+	}
+	rows, err := sql.RowIterToRows(queryist.Context, rowIter)
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+	if len(rows) != 1 || len(rows[0]) != 3 {
+		cli.PrintErrln("error: unexpected result from dolt_apply_patch")
+		return 1
+	}
+
+	counts := make([]int64, 3)
+	for i := range counts {
+		counts[i], err = cli.QueryValueAsInt64(rows[0][i])
+		if err != nil {
+			cli.PrintErrln(err.Error())
+			return 1
+		}
+	}
+	applied, skipped, conflicts := counts[0], counts[1], counts[2]
+
+	cli.Printf("Applied %s", pluralize("statement", "statements", uint64(applied)))
+	if skipped > 0 {
+		cli.Printf(", skipped %s already applied", pluralize("statement", "statements", uint64(skipped)))
+	}
+	cli.Println()
+	if conflicts > 0 {
+		cli.Printf("%s could not be applied and recorded as conflicts.\n", pluralize("row", "rows", uint64(conflicts)))
+		cli.Println(`hint: Resolve the conflicts with "dolt conflicts", then stage the tables with "dolt add".`)
+		return 1
+	}
+	return 0
+}
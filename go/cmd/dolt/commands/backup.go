@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -59,6 +60,13 @@ Remove the backup named {{.LessThan}}name{{.GreaterThan}}. All configuration set
 {{.EmphasisLeft}}restore{{.EmphasisRight}}
 Restore a Dolt database from a given {{.LessThan}}url{{.GreaterThan}} into a specified directory {{.LessThan}}name{{.GreaterThan}}. This will fail if {{.LessThan}}name{{.GreaterThan}} is already a Dolt database unless '--force' is provided, in which case the existing database will be overwritten with the contents of the restored backup.
 
+If the backup has a journal archive, {{.EmphasisLeft}}--to-time {{.LessThan}}time{{.GreaterThan}}{{.EmphasisRight}} replays the archive on top of the restored backup and restores the database as it was at {{.LessThan}}time{{.GreaterThan}}, e.g. {{.EmphasisLeft}}2026-10-01T12:00{{.EmphasisRight}}. Times without an offset are UTC. {{.EmphasisLeft}}--to-working-set {{.LessThan}}hash{{.GreaterThan}}{{.EmphasisRight}} instead restores the first root that contains a working set with that hash, or with a working root with that hash. Because every root update is archived, this recovers uncommitted changes in a working set as well as commits.
+
+Backups synced on a schedule by {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}} keep a number of restore points, listed in the {{.EmphasisLeft}}restore_points{{.EmphasisRight}} column of the {{.EmphasisLeft}}dolt_backups{{.EmphasisRight}} table. {{.EmphasisLeft}}--restore-point {{.LessThan}}name{{.GreaterThan}}{{.EmphasisRight}} restores the database as it was at that sync, instead of at the latest one.
+
+{{.EmphasisLeft}}ship-journal{{.EmphasisRight}}
+Ship the chunk journal records written since the last shipment to the journal archive of the backup {{.LessThan}}name{{.GreaterThan}}. With {{.EmphasisLeft}}--interval {{.LessThan}}duration{{.GreaterThan}}{{.EmphasisRight}}, keep shipping every {{.LessThan}}duration{{.GreaterThan}} until interrupted. Journal archives are supported for backups stored in a blobstore, such as {{.EmphasisLeft}}file://{{.EmphasisRight}}, {{.EmphasisLeft}}s3://{{.EmphasisRight}} and {{.EmphasisLeft}}gs://{{.EmphasisRight}} backups, but not {{.EmphasisLeft}}aws://{{.EmphasisRight}} backups. They are only useful together with regular syncs of the same backup: restore replays the archive on top of the synced snapshot.
+
 {{.EmphasisLeft}}sync{{.EmphasisRight}}
 Snapshot the database and upload to the backup {{.LessThan}}name{{.GreaterThan}}. This includes branches, tags, working sets, and remote tracking refs.
 
@@ -73,7 +81,8 @@ A prune infers that there are no concurrent in flight writers by checking if the
 		"[-v | --verbose]",
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
-		"restore [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--force] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+		"restore [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--force] [--to-time {{.LessThan}}time{{.GreaterThan}} | --to-working-set {{.LessThan}}hash{{.GreaterThan}} | --restore-point {{.LessThan}}name{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+		"ship-journal [--interval {{.LessThan}}duration{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}}",
 		"sync [--prune-with-grace-period {{.LessThan}}duration{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}}",
 		"sync-url [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--prune-with-grace-period {{.LessThan}}duration{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}}",
 	},
@@ -144,6 +153,19 @@ func (cmd BackupCmd) Exec(ctx context.Context, commandStr string, args []string,
 		if apr.NArg() < 3 {
 			return HandleVErrAndExitCode(VerboseErrUsage, usage)
 		}
+	case dprocedures.DoltBackupParamShipJournal:
+		if apr.NArg() != 2 {
+			return HandleVErrAndExitCode(VerboseErrUsage, usage)
+		}
+		if intervalStr, ok := apr.GetValue(cli.IntervalFlag); ok {
+			interval, err := time.ParseDuration(intervalStr)
+			if err != nil || interval <= 0 {
+				verr := errhand.BuildDError("error: invalid --%s '%s', expected a positive duration such as 30s or 5m", cli.IntervalFlag, intervalStr).Build()
+				return HandleVErrAndExitCode(verr, usage)
+			}
+			verboseErr := shipJournalContinuously(ctx, &queryEngine, apr.Arg(1), interval)
+			return HandleVErrAndExitCode(verboseErr, usage)
+		}
 	default:
 		return HandleVErrAndExitCode(VerboseErrUsage, usage)
 	}
@@ -167,6 +189,24 @@ func callDoltBackupProc(queryEngine *cli.QueryEngineResult, params []string) err
 	return nil
 }
 
+// shipJournalContinuously ships the journal of the current database to the backup |name| every |interval|, until
+// |ctx| is canceled or a shipment fails.
+func shipJournalContinuously(ctx context.Context, queryEngine *cli.QueryEngineResult, name string, interval time.Duration) errhand.VerboseError {
+	params := []string{dprocedures.DoltBackupParamShipJournal, name}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if verr := callDoltBackupProc(queryEngine, params); verr != nil {
+			return verr
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 // printDoltBackupsTable queries the dolt_backups table and prints the results. If the verbose flag is set, it prints
 // name, url, and params columns. Otherwise, it prints only the name column.
 func printDoltBackupsTable(queryEngine *cli.QueryEngineResult, showVerbose bool) errhand.VerboseError {
@@ -51,13 +51,16 @@ With a {{.EmphasisLeft}}-m{{.EmphasisRight}}, {{.LessThan}}oldbranch{{.GreaterTh
 
 The {{.EmphasisLeft}}-c{{.EmphasisRight}} options have the exact same semantics as {{.EmphasisLeft}}-m{{.EmphasisRight}}, except instead of the branch being renamed it will be copied to a new name.
 
-With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.`,
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.
+
+With a {{.EmphasisLeft}}--restore{{.EmphasisRight}}, the deleted branch {{.LessThan}}branchname{{.GreaterThan}} is recreated at the commit it pointed to when it was most recently deleted. Uncommitted changes on the branch when it was deleted are not restored. Deleted branches are kept for {{.EmphasisLeft}}@@dolt_deleted_refs_retention_days{{.EmphasisRight}} days, and are listed in the {{.EmphasisLeft}}dolt_deleted_refs{{.EmphasisRight}} system table.`,
 	Synopsis: []string{
 		`[--list] [-v] [-a] [-r]`,
 		`[-f] {{.LessThan}}branchname{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`-m [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-c [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-d [-f] [-r] {{.LessThan}}branchname{{.GreaterThan}}...`,
+		`--restore {{.LessThan}}branchname{{.GreaterThan}}...`,
 	},
 }
 
@@ -116,8 +119,8 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return HandleVErrAndExitCode(errorBuilder.AddCause(err).Build(), nil)
 	}
 
-	if len(apr.ContainsMany(cli.MoveFlag, cli.CopyFlag, cli.DeleteFlag, cli.DeleteForceFlag, cli.ListFlag, showCurrentFlag)) > 1 {
-		cli.PrintErrln("Must specify exactly one of --move/-m, --copy/-c, --delete/-d, -D, --show-current, or --list.")
+	if len(apr.ContainsMany(cli.MoveFlag, cli.CopyFlag, cli.DeleteFlag, cli.DeleteForceFlag, cli.RestoreFlag, cli.ListFlag, showCurrentFlag)) > 1 {
+		cli.PrintErrln("Must specify exactly one of --move/-m, --copy/-c, --delete/-d, -D, --restore, --show-current, or --list.")
 		return 1
 	}
 
@@ -130,6 +133,8 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return deleteBranches(queryist.Context, queryist.Queryist, apr, args, usage)
 	case apr.Contains(cli.DeleteForceFlag):
 		return deleteBranches(queryist.Context, queryist.Queryist, apr, args, usage)
+	case apr.Contains(cli.RestoreFlag):
+		return restoreBranches(queryist.Context, queryist.Queryist, apr, args, usage)
 	case apr.Contains(cli.ListFlag):
 		return printBranches(queryist.Context, queryist.Queryist, apr, usage)
 	case apr.Contains(showCurrentFlag):
@@ -634,6 +639,20 @@ func deleteBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparse
 	return callStoredProcedure(sqlCtx, queryEngine, args)
 }
 
+func restoreBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser.ArgParseResults, args []string, usage cli.UsagePrinter) int {
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	if apr.ContainsAny(cli.AllFlag, cli.VerboseFlag, cli.RemoteParam, cli.ForceFlag) {
+		cli.PrintErrln("--restore can't be combined with other options")
+		return 1
+	}
+
+	return callStoredProcedure(sqlCtx, queryEngine, args)
+}
+
 func generateForceDeleteMessage(args []string) string {
 	newArgs := ""
 	for _, arg := range args {
@@ -319,8 +319,17 @@ func getMergeStatus(queryist cli.Queryist, sqlCtx *sql.Context) (mergeStatus, er
 		} else {
 			ms.unmergedTables = strings.Split(unmergedTables, ", ")
 		}
+		return ms, nil
 	}
 
+	// Conflicts can also be left outside of a merge, such as by dolt apply, so list the tables that have them
+	rows, err = cli.GetRowsForSql(queryist, sqlCtx, "select `table` from dolt_conflicts;")
+	if err != nil {
+		return ms, err
+	}
+	for _, row := range rows {
+		ms.unmergedTables = append(ms.unmergedTables, row[0].(string))
+	}
 	return ms, nil
 }
 
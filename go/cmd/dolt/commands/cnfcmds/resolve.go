@@ -34,9 +34,12 @@ var resDocumentation = cli.CommandDocumentationContent{
 When a merge finds conflicting changes, it documents them in the dolt_conflicts table. A conflict is between two versions: ours (the rows at the destination branch head) and theirs (the rows at the source branch head).
 
 dolt conflicts resolve will automatically resolve the conflicts by taking either the ours or theirs versions for each row.
+
+With {{.EmphasisLeft}}--interactive{{.EmphasisRight}}, each conflicting row is shown in turn with its base, ours and theirs versions, and the conflict is resolved cell by cell: for each column the two sides disagree on, keep our value, take their value or the base value, or type a new one. Rows deleted on one side are resolved by keeping either side's row. Resolutions are written to the working set as each row is completed, and rows that are skipped stay in conflict. If no tables are given, the conflicts of all tables are resolved.
 `,
 	Synopsis: []string{
 		`--ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
+		`--interactive [{{.LessThan}}table{{.GreaterThan}}...]`,
 	},
 }
 
@@ -86,6 +89,7 @@ func (cmd ResolveCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "List of tables to be resolved. '.' can be used to resolve all tables."})
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsFlag(cli.InteractiveFlag, "i", "Resolve each conflict interactively, choosing the value of each conflicting cell")
 	return ap
 }
 
@@ -108,7 +112,13 @@ func (cmd ResolveCmd) Exec(ctx context.Context, commandStr string, args []string
 	}
 
 	var verr errhand.VerboseError
-	if apr.ContainsAny(autoResolverParams...) {
+	if apr.Contains(cli.InteractiveFlag) {
+		if apr.ContainsAny(autoResolverParams...) {
+			verr = errhand.BuildDError("--interactive can't be used with --ours or --theirs").SetPrintUsage().Build()
+		} else {
+			verr = interactiveResolve(queryist.Queryist, queryist.Context, apr.Args)
+		}
+	} else if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(queryist.Queryist, queryist.Context, apr)
 	} else {
 		verr = errhand.BuildDError("--ours or --theirs must be supplied").SetPrintUsage().Build()
@@ -0,0 +1,496 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/ishell"
+	"github.com/fatih/color"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/tabular"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+const (
+	conflictIdCol = "dolt_conflict_id"
+	nullInput     = "NULL"
+)
+
+// interactiveResolve walks the data conflicts of |tables| row by row, letting the user pick ours, theirs or base for
+// each conflicting cell, or edit its value. Each row's resolution is written through the dolt_conflicts_<table>
+// table, which also marks it resolved. If no tables are given, all tables with data conflicts are resolved.
+func interactiveResolve(queryist cli.Queryist, sqlCtx *sql.Context, tables []string) errhand.VerboseError {
+	if len(tables) == 0 || (len(tables) == 1 && tables[0] == ".") {
+		rows, err := cli.GetRowsForSql(queryist, sqlCtx, "select `table` from dolt_conflicts;")
+		if err != nil {
+			return errhand.BuildDError("error: failed to get tables with conflicts").AddCause(err).Build()
+		}
+		tables = tables[:0]
+		for _, row := range rows {
+			tables = append(tables, row[0].(string))
+		}
+	}
+	if len(tables) == 0 {
+		cli.Println("No conflicts to resolve.")
+		return nil
+	}
+	slices.Sort(tables)
+
+	state := &resolveState{sqlCtx: sqlCtx, queryist: queryist, tables: tables, tableIdx: -1}
+
+	shell := ishell.New()
+	shell.AutoHelp(false)
+	shell.NotFound(resolveHelp)
+	state.shell = shell
+
+	shell.AddCmd(&ishell.Cmd{Name: "?", Help: "show this help", Func: resolveHelp})
+	shell.AddCmd(&ishell.Cmd{Name: "o", Help: "keep our value", Func: state.takeOurs})
+	shell.AddCmd(&ishell.Cmd{Name: "t", Help: "take their value", Func: state.takeTheirs})
+	shell.AddCmd(&ishell.Cmd{Name: "b", Help: "take the base value", Func: state.takeBase})
+	shell.AddCmd(&ishell.Cmd{Name: "e", Help: "edit the value", Func: state.editValue})
+	shell.AddCmd(&ishell.Cmd{Name: "O", Help: "keep our values for the rest of this row", Func: state.takeRemainingOurs})
+	shell.AddCmd(&ishell.Cmd{Name: "T", Help: "take their values for the rest of this row", Func: state.takeRemainingTheirs})
+	shell.AddCmd(&ishell.Cmd{Name: "s", Help: "leave this conflict unresolved", Func: state.skipConflict})
+	shell.AddCmd(&ishell.Cmd{Name: "d", Help: "leave the remaining conflicts in this table unresolved", Func: state.skipTable})
+	shell.AddCmd(&ishell.Cmd{Name: "q", Help: "quit", Func: func(c *ishell.Context) { c.Stop() }})
+
+	state.nextTable()
+	if state.err == nil && state.tableIdx < len(state.tables) {
+		// run shell. This blocks until the stop() function is called on the ishell context.
+		shell.Run()
+	}
+
+	if state.err != nil {
+		return errhand.VerboseErrorFromError(state.err)
+	}
+	cli.Printf("Resolved %d of %d conflicts.\n", state.resolved, state.total)
+	return nil
+}
+
+func resolveHelp(_ *ishell.Context) {
+	help := `o - keep our value
+t - take their value
+b - take the base value
+e - edit the value
+O - keep our values for the rest of this row
+T - take their values for the rest of this row
+s - leave this conflict unresolved
+d - leave the remaining conflicts in this table unresolved
+q - quit
+? - show this help`
+	help = color.CyanString(help)
+	cli.Println(help)
+}
+
+// resolveState is the state of the interactive resolution of data conflicts.
+type resolveState struct {
+	sqlCtx   *sql.Context
+	queryist cli.Queryist
+	shell    *ishell.Shell
+	tables   []string
+	tableIdx int
+
+	// the table being resolved, and the columns and primary key of its working set version
+	table     string
+	tableCols []string
+	pkCols    []string
+	// the union of the columns of all versions of the table, which the conflict rows are split into
+	targetSch sql.Schema
+	splitter  *conflictSplitter
+	idIdx     int
+
+	conflicts   []sql.Row
+	conflictIdx int
+
+	// the versions of the current conflict, indexed like targetSch. base is nil if the row has no base version.
+	base, ours, theirs sql.Row
+	ourDiffType        diff.ChangeType
+	theirDiffType      diff.ChangeType
+	// the cells of the current conflict that differ between ours and theirs, and the value chosen for each so far
+	cells   []int
+	choices []interface{}
+
+	resolved, total int
+	err             error
+}
+
+// nextTable moves on to the first conflict of the next table. If there are no more tables, the shell is stopped.
+func (rs *resolveState) nextTable() {
+	for rs.tableIdx++; rs.tableIdx < len(rs.tables); rs.tableIdx++ {
+		ok, err := rs.loadTable(rs.tables[rs.tableIdx])
+		if err != nil {
+			rs.fail(err)
+			return
+		}
+		if ok {
+			cli.Print(resolveTableHeader(rs.table, len(rs.conflicts)))
+			rs.conflictIdx = -1
+			rs.nextConflict()
+			return
+		}
+	}
+	rs.shell.Stop()
+}
+
+// loadTable reads the conflicts of |table|. Returns false if the table has none that can be resolved interactively.
+func (rs *resolveState) loadTable(table string) (bool, error) {
+	q, err := dbr.InterpolateForDialect("select column_name, column_key from information_schema.columns "+
+		"where table_schema = database() and table_name = ? order by ordinal_position;", []interface{}{table}, dialect.MySQL)
+	if err != nil {
+		return false, err
+	}
+	rows, err := cli.GetRowsForSql(rs.queryist, rs.sqlCtx, q)
+	if err != nil {
+		return false, err
+	}
+	rs.table, rs.tableCols, rs.pkCols = table, nil, nil
+	for _, row := range rows {
+		col := row[0].(string)
+		rs.tableCols = append(rs.tableCols, col)
+		if row[1] == "PRI" {
+			rs.pkCols = append(rs.pkCols, col)
+		}
+	}
+	if len(rs.pkCols) == 0 {
+		cli.Printf("Skipping table %s: conflicts can only be resolved interactively for tables with a primary key.\n", table)
+		return false, nil
+	}
+
+	q, err = dbr.InterpolateForDialect("select * from ?;", []interface{}{dbr.I("dolt_conflicts_" + table)}, dialect.MySQL)
+	if err != nil {
+		return false, err
+	}
+	confSch, iter, _, err := rs.queryist.Query(rs.sqlCtx, q)
+	if err != nil {
+		return false, fmt.Errorf("error: failed to get conflict rows for table '%s': %w", table, err)
+	}
+	rs.conflicts, err = sql.RowIterToRows(rs.sqlCtx, iter)
+	if err != nil {
+		return false, err
+	}
+	if len(rs.conflicts) == 0 {
+		return false, nil
+	}
+	rs.total += len(rs.conflicts)
+
+	rs.targetSch = conflictVersionsSchema(confSch)
+	if rs.splitter, err = newConflictSplitter(confSch, rs.targetSch); err != nil {
+		return false, err
+	}
+	rs.idIdx = confSch.IndexOfColName(conflictIdCol)
+	return true, nil
+}
+
+// conflictVersionsSchema returns the schema of the columns of all versions of the table in |conflictsSch|, with our
+// columns first.
+func conflictVersionsSchema(conflictsSch sql.Schema) sql.Schema {
+	var sch sql.Schema
+	for _, prefix := range []string{ourPrefix, theirPrefix, basePrefix} {
+		for _, col := range conflictsSch {
+			if conflictColsToIgnore[col.Name] || !strings.HasPrefix(col.Name, prefix) {
+				continue
+			}
+			name := col.Name[len(prefix):]
+			if sch.IndexOfColName(name) < 0 {
+				cpy := *col
+				cpy.Name = name
+				sch = append(sch, &cpy)
+			}
+		}
+	}
+	return sch
+}
+
+// nextConflict moves on to the next conflict of the current table, or to the next table if there are no more.
+func (rs *resolveState) nextConflict() {
+	rs.conflictIdx++
+	if rs.conflictIdx >= len(rs.conflicts) {
+		rs.nextTable()
+		return
+	}
+	rs.startConflict()
+}
+
+// startConflict prints the current conflict and prompts for the resolution of its first cell.
+func (rs *resolveState) startConflict() {
+	versions, err := rs.splitter.splitConflictRow(rs.conflicts[rs.conflictIdx])
+	if err != nil {
+		rs.fail(err)
+		return
+	}
+	rs.base = nil
+	for _, v := range versions {
+		switch v.version {
+		case "base":
+			rs.base = v.row
+		case "ours":
+			rs.ours, rs.ourDiffType = v.row, v.diffType
+		case "theirs":
+			rs.theirs, rs.theirDiffType = v.row, v.diffType
+		}
+	}
+
+	rs.cells, rs.choices = rs.cells[:0], rs.choices[:0]
+	if !rs.isRowConflict() {
+		for i, col := range rs.targetSch {
+			if slices.Contains(rs.tableCols, col.Name) && !slices.Contains(rs.pkCols, col.Name) &&
+				!reflect.DeepEqual(rs.ours[i], rs.theirs[i]) {
+				rs.cells = append(rs.cells, i)
+			}
+		}
+	}
+
+	cli.Printf("Conflict %d of %d in %s:\n", rs.conflictIdx+1, len(rs.conflicts), rs.table)
+	if err = rs.printConflict(versions); err != nil {
+		rs.fail(err)
+		return
+	}
+	if !rs.isRowConflict() && len(rs.cells) == 0 {
+		rs.apply()
+		return
+	}
+	rs.setPrompt()
+}
+
+func (rs *resolveState) printConflict(versions []conflictRow) error {
+	tw := tabular.NewFixedWidthConflictTableWriter(rs.targetSch, iohelp.NopWrCloser(cli.CliOut), len(versions))
+	for _, v := range versions {
+		if err := tw.WriteRow(rs.sqlCtx, v.version, v.row, v.diffType); err != nil {
+			return err
+		}
+	}
+	return tw.Close(rs.sqlCtx)
+}
+
+// isRowConflict returns whether one side of the current conflict deleted the row, in which case it's resolved by
+// keeping either side's row as a whole.
+func (rs *resolveState) isRowConflict() bool {
+	return rs.ourDiffType == diff.Removed || rs.theirDiffType == diff.Removed
+}
+
+func (rs *resolveState) setPrompt() {
+	var prompt string
+	if rs.isRowConflict() {
+		prompt = "Keep which version of this row [o,t,s,d,q,?]? "
+	} else {
+		col := rs.targetSch[rs.cells[len(rs.choices)]].Name
+		prompt = fmt.Sprintf("Resolve column %s [o,t,b,e,O,T,s,d,q,?]? ", col)
+	}
+	rs.shell.SetPrompt(color.HiGreenString(prompt))
+}
+
+// takeOurs keeps our value of the current cell, or our row for a row conflict. "o" command.
+func (rs *resolveState) takeOurs(c *ishell.Context) {
+	rs.choose(c, ourPrefix)
+}
+
+// takeTheirs takes their value of the current cell, or their row for a row conflict. "t" command.
+func (rs *resolveState) takeTheirs(c *ishell.Context) {
+	rs.choose(c, theirPrefix)
+}
+
+// takeBase takes the base value of the current cell. "b" command.
+func (rs *resolveState) takeBase(c *ishell.Context) {
+	if rs.isRowConflict() {
+		cli.Println("Only ours or theirs can be kept for a deleted row.")
+		return
+	} else if rs.base == nil {
+		cli.Println("This row has no base version.")
+		return
+	}
+	rs.choose(c, basePrefix)
+}
+
+// editValue prompts for the value of the current cell. "e" command.
+func (rs *resolveState) editValue(c *ishell.Context) {
+	if rs.isRowConflict() {
+		cli.Println("Only ours or theirs can be kept for a deleted row.")
+		return
+	}
+	col := rs.targetSch[rs.cells[len(rs.choices)]].Name
+	c.SetPrompt(fmt.Sprintf("New value for %s (%s for null): ", col, nullInput))
+	line, err := c.ReadLineErr()
+	if err != nil {
+		rs.fail(err)
+		return
+	}
+	if line == nullInput {
+		rs.choices = append(rs.choices, nil)
+	} else {
+		rs.choices = append(rs.choices, line)
+	}
+	rs.nextCell()
+}
+
+// takeRemainingOurs keeps our values for the remaining cells of the current row. "O" command.
+func (rs *resolveState) takeRemainingOurs(c *ishell.Context) {
+	rs.chooseRemaining(c, ourPrefix)
+}
+
+// takeRemainingTheirs takes their values for the remaining cells of the current row. "T" command.
+func (rs *resolveState) takeRemainingTheirs(c *ishell.Context) {
+	rs.chooseRemaining(c, theirPrefix)
+}
+
+// skipConflict leaves the current conflict unresolved. "s" command.
+func (rs *resolveState) skipConflict(_ *ishell.Context) {
+	rs.nextConflict()
+}
+
+// skipTable leaves the remaining conflicts of the current table unresolved. "d" command.
+func (rs *resolveState) skipTable(_ *ishell.Context) {
+	rs.nextTable()
+}
+
+// choose resolves the current cell, or the current row for a row conflict, with the version of |prefix|.
+func (rs *resolveState) choose(_ *ishell.Context, prefix string) {
+	if rs.isRowConflict() {
+		rs.applyRow(prefix == theirPrefix)
+		return
+	}
+	rs.choices = append(rs.choices, dbr.I(prefix+rs.targetSch[rs.cells[len(rs.choices)]].Name))
+	rs.nextCell()
+}
+
+func (rs *resolveState) chooseRemaining(c *ishell.Context, prefix string) {
+	if rs.isRowConflict() {
+		rs.choose(c, prefix)
+		return
+	}
+	for len(rs.choices) < len(rs.cells) {
+		rs.choices = append(rs.choices, dbr.I(prefix+rs.targetSch[rs.cells[len(rs.choices)]].Name))
+	}
+	rs.apply()
+}
+
+func (rs *resolveState) nextCell() {
+	if len(rs.choices) < len(rs.cells) {
+		rs.setPrompt()
+		return
+	}
+	rs.apply()
+}
+
+// apply writes the values chosen for the cells of the current conflict to our row through the conflicts table, and
+// marks the conflict resolved.
+func (rs *resolveState) apply() {
+	var sets []string
+	args := []interface{}{dbr.I("dolt_conflicts_" + rs.table)}
+	for i, choice := range rs.choices {
+		ourCol := ourPrefix + rs.targetSch[rs.cells[i]].Name
+		if choice == dbr.I(ourCol) {
+			continue
+		}
+		sets = append(sets, "? = ?")
+		args = append(args, dbr.I(ourCol), choice)
+	}
+	if len(sets) > 0 {
+		args = append(args, rs.conflictId())
+		if !rs.exec("update ? set "+strings.Join(sets, ", ")+" where dolt_conflict_id = ?;", args...) {
+			return
+		}
+	}
+	rs.markResolved()
+}
+
+// applyRow resolves a conflict in which one side deleted the row, by keeping our row or by replacing it with theirs.
+func (rs *resolveState) applyRow(takeTheirs bool) {
+	conflicts := dbr.I("dolt_conflicts_" + rs.table)
+	if takeTheirs && rs.theirDiffType == diff.Removed {
+		var where []string
+		args := []interface{}{dbr.I(rs.table)}
+		for _, pk := range rs.pkCols {
+			where = append(where, "? = (select ? from ? where dolt_conflict_id = ?)")
+			args = append(args, dbr.I(pk), dbr.I(ourPrefix+pk), conflicts, rs.conflictId())
+		}
+		if !rs.exec("delete from ? where "+strings.Join(where, " and ")+";", args...) {
+			return
+		}
+	} else if takeTheirs {
+		var cols []string
+		var names, values []interface{}
+		for _, col := range rs.tableCols {
+			if rs.splitter.conflictQuerySch.IndexOfColName(theirPrefix+col) >= 0 {
+				cols = append(cols, "?")
+				names = append(names, dbr.I(col))
+				values = append(values, dbr.I(theirPrefix+col))
+			}
+		}
+		args := append([]interface{}{dbr.I(rs.table)}, names...)
+		args = append(args, values...)
+		args = append(args, conflicts, rs.conflictId())
+		list := strings.Join(cols, ", ")
+		if !rs.exec("insert into ? ("+list+") select "+list+" from ? where dolt_conflict_id = ?;", args...) {
+			return
+		}
+	}
+	rs.markResolved()
+}
+
+func (rs *resolveState) markResolved() {
+	if !rs.exec("delete from ? where dolt_conflict_id = ?;", dbr.I("dolt_conflicts_"+rs.table), rs.conflictId()) {
+		return
+	}
+	rs.resolved++
+	rs.nextConflict()
+}
+
+// exec runs |query| with |args|. If the query fails, such as for an edited value that isn't valid for its column,
+// the error is printed and the current conflict is started over. Returns whether the query succeeded.
+func (rs *resolveState) exec(query string, args ...interface{}) bool {
+	q, err := dbr.InterpolateForDialect(query, args, dialect.MySQL)
+	if err != nil {
+		rs.fail(err)
+		return false
+	}
+	if _, err = cli.GetRowsForSql(rs.queryist, rs.sqlCtx, q); err != nil {
+		cli.PrintErrln(color.RedString(err.Error()))
+		rs.startConflict()
+		return false
+	}
+	return true
+}
+
+func (rs *resolveState) conflictId() string {
+	return rs.conflicts[rs.conflictIdx][rs.idIdx].(string)
+}
+
+func (rs *resolveState) fail(err error) {
+	rs.err = err
+	rs.shell.Stop()
+}
+
+// resolveTableHeader returns a header for the conflicts of |table|, like:
+// ========================
+// Table: t (3 conflicts)
+// ========================
+func resolveTableHeader(table string, n int) string {
+	text := fmt.Sprintf("Table: %s (%d conflicts)", table, n)
+	if n == 1 {
+		text = fmt.Sprintf("Table: %s (1 conflict)", table)
+	}
+	eqs := strings.Repeat("=", len(text))
+	return color.YellowString("%s\n%s\n%s\n", eqs, text, eqs)
+}
@@ -88,7 +88,7 @@ To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expre
 
 To filter diff output by change type, use {{.EmphasisLeft}}--filter <type>{{.EmphasisRight}} where {{.EmphasisLeft}}<type>{{.EmphasisRight}} is one of {{.EmphasisLeft}}added{{.EmphasisRight}}, {{.EmphasisLeft}}modified{{.EmphasisRight}}, {{.EmphasisLeft}}renamed{{.EmphasisRight}}, or {{.EmphasisLeft}}dropped{{.EmphasisRight}}. The {{.EmphasisLeft}}added{{.EmphasisRight}} filter shows only additions (new tables or rows), {{.EmphasisLeft}}modified{{.EmphasisRight}} shows only schema modifications or row updates, {{.EmphasisLeft}}renamed{{.EmphasisRight}} shows only renamed tables, and {{.EmphasisLeft}}dropped{{.EmphasisRight}} shows only deletions (dropped tables or deleted rows). You can also use {{.EmphasisLeft}}removed{{.EmphasisRight}} as an alias for {{.EmphasisLeft}}dropped{{.EmphasisRight}}. For example, {{.EmphasisLeft}}dolt diff --filter=dropped{{.EmphasisRight}} shows only deleted rows and dropped tables.
 
-The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
+The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. When set to {{.EmphasisLeft}}unified{{.EmphasisRight}}, modified rows are presented as a single row, and changes to TEXT and JSON columns are presented as a unified diff of their lines (or of the JSON paths that changed), with the changed words of each line highlighted; other columns are presented as with {{.EmphasisLeft}}line{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
 		`[options] [{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}tables{{.GreaterThan}}...]`,
@@ -103,6 +103,7 @@ type diffDisplaySettings struct {
 	limit       int
 	where       string
 	skinny      bool
+	preimage    bool
 	includeCols []string
 	filter      *diffTypeFilter
 }
@@ -359,6 +360,15 @@ func (cmd DiffCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
 
+	if apr.Contains(cli.PreimageFlag) {
+		if !strings.EqualFold(f, "sql") {
+			return errhand.BuildDError("invalid Arguments: --%s requires --%s sql", cli.PreimageFlag, FormatFlag).Build()
+		}
+		if apr.Contains(cli.SkinnyFlag) {
+			return errhand.BuildDError("invalid Arguments: --%s cannot be combined with --%s", cli.PreimageFlag, cli.SkinnyFlag).Build()
+		}
+	}
+
 	filterValue, hasFilter := apr.GetValue(cli.FilterParam)
 	if hasFilter {
 		filter := newDiffTypeFilter(filterValue)
@@ -388,6 +398,7 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 	}
 
 	displaySettings.skinny = apr.Contains(cli.SkinnyFlag)
+	displaySettings.preimage = apr.Contains(cli.PreimageFlag)
 
 	if cols, ok := apr.GetValueList(cli.IncludeCols); ok {
 		displaySettings.includeCols = cols
@@ -406,6 +417,8 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 			displaySettings.diffMode = diff.ModeInPlace
 		case "context":
 			displaySettings.diffMode = diff.ModeContext
+		case "unified":
+			displaySettings.diffMode = diff.ModeUnified
 		}
 	case "sql":
 		displaySettings.diffOutput = SQLDiffOutput
@@ -922,7 +935,7 @@ func diffUserTables(queryist cli.Queryist, sqlCtx *sql.Context, dArgs *diffArgs)
 		}
 	}
 
-	dw, err := newDiffWriter(dArgs.diffOutput)
+	dw, err := newDiffWriter(dArgs.diffOutput, dArgs.preimage)
 	if err != nil {
 		return errhand.VerboseErrorFromError(err)
 	}
@@ -61,12 +61,12 @@ type diffWriter interface {
 }
 
 // newDiffWriter returns a diffWriter for the output format given
-func newDiffWriter(diffOutput diffOutput) (diffWriter, error) {
+func newDiffWriter(diffOutput diffOutput, preimage bool) (diffWriter, error) {
 	switch diffOutput {
 	case TabularDiffOutput:
 		return tabularDiffWriter{}, nil
 	case SQLDiffOutput:
-		return sqlDiffWriter{}, nil
+		return sqlDiffWriter{preimage: preimage}, nil
 	case JsonDiffOutput:
 		return newJsonDiffWriter(iohelp.NopWrCloser(cli.CliOut))
 	default:
@@ -226,7 +226,10 @@ func (t tabularDiffWriter) RowWriter(ctx context.Context, fromTableInfo, toTable
 	return tabular.NewFixedWidthDiffTableWriter(unionSch, iohelp.NopWrCloser(cli.CliOut), 100), nil
 }
 
-type sqlDiffWriter struct{}
+type sqlDiffWriter struct {
+	// preimage matches the rows changed by UPDATE and DELETE statements by all their old values
+	preimage bool
+}
 
 var _ diffWriter = (*tabularDiffWriter)(nil)
 
@@ -315,6 +318,9 @@ func (s sqlDiffWriter) RowWriter(ctx context.Context, fromTableInfo, toTableInfo
 	}
 
 	// TODO: schema names
+	if s.preimage && fromTableInfo != nil {
+		return sqlexport.NewSqlPreimageDiffWriter(tds.ToTableName.Name, targetSch, fromTableInfo.Sch, iohelp.NopWrCloser(cli.CliOut)), nil
+	}
 	return sqlexport.NewSqlDiffWriter(tds.ToTableName.Name, targetSch, iohelp.NopWrCloser(cli.CliOut)), nil
 }
 
@@ -228,6 +228,9 @@ func NewSqlEngine(
 		IsReadOnly:     config.IsReadOnly,
 		IsServerLocked: config.IsServerLocked,
 	}).WithBackgroundThreads(bThreads)
+	// The statements clients send support FOR SYSTEM_TIME ranges, see SqlEngine.QueryWithBindings for the ones that
+	// aren't parsed by the engine's parser
+	engine.Parser = sqle.NewSystemTimeParser(engine.Parser)
 
 	if err := configureBinlogPrimaryController(engine); err != nil {
 		return nil, err
@@ -305,8 +308,10 @@ func NewSqlEngine(
 
 	branchActivityTracker := doltdb.NewBranchActivityTracker(ctx, config.BranchActivityTracking)
 
-	engine.Analyzer.ExecBuilder = rowexec.NewBuilder(kvexec.Builder{}, engine.Analyzer.Overrides)
+	engine.Analyzer.ExecBuilder = rowexec.NewBuilder(nil, engine.Analyzer.Overrides)
+	engine.Analyzer.ExecBuilder.PriorityBuilder = sqle.NewQueryCacheBuilder(kvexec.Builder{}, engine.Analyzer.ExecBuilder)
 	engine.Analyzer.ExecBuilder.Runner = engine.Analyzer.Runner
+	pro.SetStatementRunner(engine)
 	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, gcSafepointController, config.Autocommit, branchActivityTracker)
 	sqlEngine.provider = pro
 	sqlEngine.dsessFactory = sessFactory
@@ -478,10 +483,24 @@ func (se *SqlEngine) NewDoltSession(_ context.Context, mysqlSess *sql.BaseSessio
 
 // Query execute a SQL statement and return values for printing.
 func (se *SqlEngine) Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
-	return se.engine.Query(ctx, query)
+	return se.QueryWithBindings(ctx, query, nil, nil, nil)
 }
 
 func (se *SqlEngine) QueryWithBindings(ctx *sql.Context, query string, parsed sqlparser.Statement, bindings map[string]sqlparser.Expr, qFlags *sql.QueryFlags) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	if ctx.Query() == "" {
+		// The shell and scripts run their statements in a context without a query, which the exec builder reads
+		// clauses the engine doesn't keep from, such as the USING COLUMNSTORE of an index definition
+		ctx = ctx.WithQuery(query)
+	}
+	if parsed == nil && len(bindings) == 0 {
+		// The engine parses the queries it isn't given with the builder's parser, which doesn't support FOR
+		// SYSTEM_TIME ranges
+		var err error
+		parsed, err = sqle.ParseSystemTimeQuery(ctx, se.engine.Parser, query)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	return se.engine.QueryWithBindings(ctx, query, parsed, bindings, qFlags)
 }
 
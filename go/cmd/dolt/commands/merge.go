@@ -48,6 +48,8 @@ var mergeDocs = cli.CommandDocumentationContent{
 
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
+Branches that share no history, such as branches fetched from databases that were created separately, are only merged with {{.EmphasisLeft}}--allow-unrelated-histories{{.EmphasisRight}}. Without a common ancestor, tables that both branches have are merged by matching their rows by primary key and their columns by name, and any row that differs between the branches is a conflict. If the branches started from the same data, pass a commit with that data as {{.EmphasisLeft}}--base{{.EmphasisRight}} to use it as the common ancestor instead.
+
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
 `,
 
@@ -55,6 +57,7 @@ The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be
 		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--ff-only {{.LessThan}}branch{{.GreaterThan}}",
+		"--allow-unrelated-histories [--base {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
 }
@@ -234,6 +237,9 @@ func validateDoltMergeArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinte
 		}
 	}
 
+	if apr.Contains(cli.BaseParam) && !apr.Contains(cli.AllowUnrelatedFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s can only be used with --%s", cli.BaseParam, cli.AllowUnrelatedFlag).Build(), usage)
+	}
 	if apr.ContainsAll(cli.CommitFlag, cli.NoCommitFlag) {
 		return HandleVErrAndExitCode(errhand.BuildDError(ErrConflictingFlags, cli.CommitFlag, cli.NoCommitFlag).Build(), usage)
 	}
@@ -327,6 +333,17 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 	if apr.Contains(cli.SkipVerificationFlag) {
 		writeToBuffer("--skip-verification", false)
 	}
+	if apr.Contains(cli.AllowUnrelatedFlag) {
+		writeToBuffer("--allow-unrelated-histories", false)
+	}
+	if apr.Contains(cli.DetectRenamesFlag) {
+		writeToBuffer("--detect-renames", false)
+	}
+	if base, ok := apr.GetValue(cli.BaseParam); ok {
+		writeToBuffer("--base", false)
+		writeToBuffer("?", true)
+		params = append(params, base)
+	}
 
 	if !apr.Contains(cli.AbortParam) && !apr.Contains(cli.SquashParam) {
 		writeToBuffer("?", true)
@@ -0,0 +1,132 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opcmds
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/util/outputpager"
+)
+
+var logDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Shows the operation log",
+	LongDesc: `Shows the operation log of the current database, newest operation first.
+
+Every operation that changes a branch, tag, remote ref, workspace or stash is recorded in the operation log, along with the value of every ref and working set it changed before and after it ran. Operations that change working sets, like {{.EmphasisLeft}}dolt reset --hard{{.EmphasisRight}}, are recorded too, but writes to tables are not. The operation log keeps the last 1000 operations, and can also be queried with the {{.EmphasisLeft}}dolt_operations{{.EmphasisRight}} system table.
+
+Use {{.EmphasisLeft}}dolt op restore{{.EmphasisRight}} to undo the operations after one in the log.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type LogCmd struct{}
+
+// Name implements cli.Command.
+func (cmd LogCmd) Name() string {
+	return "log"
+}
+
+// Description implements cli.Command.
+func (cmd LogCmd) Description() string {
+	return logDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd LogCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(logDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd LogCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// Exec implements cli.Command.
+func (cmd LogCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, logDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err := cli.GetRowsForSql(queryist.Queryist, queryist.Context, "SELECT id, time, user, description, ref, before_hash, after_hash FROM dolt_operations ORDER BY id DESC, ref")
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	printOperations(rows)
+	return 0
+}
+
+// printOperations prints the operations in |rows|, which have one row for every ref changed by each operation, to
+// stdout.
+func printOperations(rows []sql.Row) {
+	if cli.ExecuteWithStdioRestored == nil {
+		return
+	}
+	cli.ExecuteWithStdioRestored(func() {
+		pager := outputpager.Start()
+		defer pager.Stop()
+
+		prevID := ""
+		for _, row := range rows {
+			id := fmt.Sprint(row[0])
+			if id != prevID {
+				if prevID != "" {
+					pager.Writer.Write([]byte("\n"))
+				}
+				prevID = id
+
+				header := fmt.Sprintf("\033[33moperation %s\033[0m %s", id, formatTime(row[1])) // id in yellow (33m)
+				if row[2] != nil {
+					header += fmt.Sprintf(" by %s", row[2])
+				}
+				pager.Writer.Write([]byte(header + "\n"))
+				if row[3] != nil {
+					pager.Writer.Write([]byte(fmt.Sprintf("    %s\n", row[3])))
+				}
+			}
+			pager.Writer.Write([]byte(fmt.Sprintf("    %s: %s -> %s\n", row[4], hashOrNone(row[5]), hashOrNone(row[6]))))
+		}
+	})
+}
+
+func formatTime(col interface{}) string {
+	if t, ok := col.(time.Time); ok {
+		return t.UTC().Format(time.DateTime)
+	}
+	return fmt.Sprint(col)
+}
+
+func hashOrNone(col interface{}) string {
+	if col == nil {
+		return "(none)"
+	}
+	return fmt.Sprint(col)
+}
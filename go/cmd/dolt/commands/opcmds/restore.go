@@ -0,0 +1,79 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opcmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var restoreDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Restores the refs and working sets of the database to their state after an operation",
+	LongDesc: `Restores every branch, tag, remote ref, workspace, stash and working set changed by the operations recorded after operation {{.LessThan}}id{{.GreaterThan}} to its value after that operation, undoing the later operations. Use {{.EmphasisLeft}}dolt op log{{.EmphasisRight}} to find the id of an operation.
+
+Changes to tables made after the operation that were never recorded by a later one, like uncommitted writes, are lost. The restore is itself recorded as an operation, so it can be undone with another restore. A value removed by {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} since it was recorded can't be restored.`,
+	Synopsis: []string{
+		"{{.LessThan}}id{{.GreaterThan}}",
+	},
+}
+
+type RestoreCmd struct{}
+
+// Name implements cli.Command.
+func (cmd RestoreCmd) Name() string {
+	return "restore"
+}
+
+// Description implements cli.Command.
+func (cmd RestoreCmd) Description() string {
+	return restoreDocs.ShortDesc
+}
+
+// Docs implements cli.Command.
+func (cmd RestoreCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(restoreDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd RestoreCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+}
+
+// Exec implements cli.Command.
+func (cmd RestoreCmd) Exec(ctx context.Context, commandStr string, args []string, _ *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, restoreDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("expected 1 argument").SetPrintUsage().Build(), usage)
+	}
+
+	queryist, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	_, err = commands.InterpolateAndRunQuery(queryist.Queryist, queryist.Context, "CALL dolt_op('restore', ?)", apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	return 0
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/libraries/utils/osutil"
@@ -643,7 +644,7 @@ func execBatchMode(ctx *sql.Context, qryist cli.Queryist, input io.Reader, conti
 
 		sqlMode := sql.LoadSqlMode(ctx)
 
-		sqlStatement, err := sqlparser.ParseWithOptions(ctx, query, sqlMode.ParserOptions())
+		sqlStatement, _, _, err := dsqle.NewSystemTimeParser(overrides.ParserFromContext(ctx)).ParseWithOptions(ctx, query, ';', false, sqlMode.ParserOptions())
 		if err == sqlparser.ErrEmpty {
 			continue
 		} else if err != nil {
@@ -849,7 +850,7 @@ func execShell(sqlCtx *sql.Context, qryist cli.Queryist, format engine.PrintResu
 					trackHistory(shell, query+";")
 				}
 				lastSqlCmd = query
-				sqlStmt, err := sqlparser.Parse(query)
+				sqlStmt, err := dsqle.NewSystemTimeParser(overrides.ParserFromContext(sqlCtx)).ParseSimple(query)
 				// silently skip empty statements
 				if err == nil || err == sqlparser.ErrEmpty {
 					var sqlSch sql.Schema
@@ -1128,7 +1129,7 @@ func prepend(s string, ss []string) []string {
 // processQuery processes a single query. The Root of the sqlEngine will be updated if necessary.
 // Returns the schema and the row iterator for the results, which may be nil, and an error if one occurs.
 func processQuery(ctx *sql.Context, query string, qryist cli.Queryist) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
-	sqlStatement, err := sqlparser.Parse(query)
+	sqlStatement, err := dsqle.NewSystemTimeParser(overrides.ParserFromContext(ctx)).ParseSimple(query)
 	if err == sqlparser.ErrEmpty {
 		// silently skip empty statements
 		return nil, nil, nil, nil
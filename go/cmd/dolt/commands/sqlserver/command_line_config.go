@@ -62,6 +62,7 @@ type commandLineServerConfig struct {
 	remotesapiReadOnly      *bool
 	goldenMysqlConn         string
 	eventSchedulerStatus    string
+	queryParallelism        int
 	valuesSet               map[string]struct{}
 }
 
@@ -100,6 +101,7 @@ func DefaultCommandLineServerConfig() *commandLineServerConfig {
 		branchControlFilePath:   filepath.Join(servercfg.DefaultDataDir, servercfg.DefaultCfgDir, servercfg.DefaultBranchControlFilePath),
 		allowCleartextPasswords: servercfg.DefaultAllowCleartextPasswords,
 		maxLoggedQueryLen:       servercfg.DefaultMaxLoggedQueryLen,
+		queryParallelism:        servercfg.DefaultQueryParallelism,
 		valuesSet:               map[string]struct{}{},
 	}
 }
@@ -223,6 +225,10 @@ func NewCommandLineConfig(creds *cli.UserPassword, apr *argparser.ArgParseResult
 		config.withEventScheduler(strings.ToUpper(esStatus))
 	}
 
+	if queryParallelism, ok := apr.GetInt(queryParallelismFlag); ok {
+		config.withQueryParallelism(queryParallelism)
+	}
+
 	return config, nil
 }
 
@@ -401,6 +407,14 @@ func (cfg *commandLineServerConfig) ClusterConfig() servercfg.ClusterConfig {
 	return nil
 }
 
+func (cfg *commandLineServerConfig) PostgresReplicationConfig() servercfg.PostgresReplicationConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) BackupSchedules() []servercfg.BackupScheduleConfig {
+	return nil
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
@@ -595,6 +609,16 @@ func (cfg *commandLineServerConfig) withEventScheduler(es string) *commandLineSe
 	return cfg
 }
 
+func (cfg *commandLineServerConfig) QueryParallelism() int {
+	return cfg.queryParallelism
+}
+
+func (cfg *commandLineServerConfig) withQueryParallelism(queryParallelism int) *commandLineServerConfig {
+	cfg.queryParallelism = queryParallelism
+	cfg.valuesSet[servercfg.QueryParallelismKey] = struct{}{}
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) ValueSet(value string) bool {
 	_, ok := cfg.valuesSet[value]
 	return ok
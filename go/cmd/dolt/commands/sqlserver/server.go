@@ -50,10 +50,12 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/backupscheduler"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/pgreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
@@ -884,27 +886,25 @@ func ConfigureServices(
 	var sqlServerClosed bool
 	InitSQLServer := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
+			wrapHandler := newXAHandler
 			v, ok := cfg.ServerConfig.(servercfg.ValidatingServerConfig)
 			if ok && v.GoldenMysqlConnectionString() != "" {
-				mySQLServer, err = server.NewServerWithHandler(
-					serverConf,
-					sqlEngine.GetUnderlyingEngine(),
-					sqlEngine.ContextFactory,
-					newSessionBuilder(sqlEngine, cfg.ServerConfig),
-					metListener,
-					func(h mysql.Handler) (mysql.Handler, error) {
-						return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
-					},
-				)
-			} else {
-				mySQLServer, err = server.NewServer(
-					serverConf,
-					sqlEngine.GetUnderlyingEngine(),
-					sqlEngine.ContextFactory,
-					newSessionBuilder(sqlEngine, cfg.ServerConfig),
-					metListener,
-				)
+				wrapHandler = func(h mysql.Handler) (mysql.Handler, error) {
+					h, err := golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
+					if err != nil {
+						return nil, err
+					}
+					return newXAHandler(h)
+				}
 			}
+			mySQLServer, err = server.NewServerWithHandler(
+				serverConf,
+				sqlEngine.GetUnderlyingEngine(),
+				sqlEngine.ContextFactory,
+				newSessionBuilder(sqlEngine, cfg.ServerConfig),
+				metListener,
+				wrapHandler,
+			)
 			if errors.Is(err, server.UnixSocketInUseError) {
 				lgr.Warn("unix socket set up failed: file already in use: ", serverConf.Socket)
 				err = nil
@@ -939,6 +939,82 @@ func ConfigureServices(
 	}
 	controller.Register(AutoStartBinlogReplica)
 
+	// Stream changes from a Postgres logical replication slot if one is configured
+	var pgReplica *pgreplication.Replica
+	var pgReplicaCtx *sql.Context
+	StartPostgresReplica := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			pgCfg := cfg.ServerConfig.PostgresReplicationConfig()
+			if pgCfg == nil {
+				return nil
+			}
+
+			// The replica's session lives as long as the replica, so it must not be tied to the init context
+			sqlCtx, err := sqlEngine.NewDefaultContext(context.Background())
+			if err != nil {
+				return err
+			}
+			replica := pgreplication.NewReplica(pgreplication.Config{
+				Host:                 pgCfg.Host(),
+				Port:                 pgCfg.Port(),
+				User:                 pgCfg.User(),
+				Password:             pgCfg.Password(),
+				Database:             pgCfg.Database(),
+				SSL:                  pgCfg.SSL(),
+				SlotName:             pgCfg.SlotName(),
+				Publication:          pgCfg.Publication(),
+				TargetDatabase:       pgCfg.TargetDatabase(),
+				CommitPerTransaction: pgCfg.CommitPerTransaction(),
+			}, sqlEngine.GetUnderlyingEngine())
+			if err := replica.Go(sqlCtx); err != nil {
+				sql.SessionEnd(sqlCtx.Session)
+				return fmt.Errorf("unable to start postgres replication: %w", err)
+			}
+			pgReplica, pgReplicaCtx = replica, sqlCtx
+			return nil
+		},
+		StopF: func(svcs.RunState) error {
+			if pgReplica != nil {
+				pgReplica.Stop()
+				sql.SessionEnd(pgReplicaCtx.Session)
+			}
+			return nil
+		},
+	}
+	controller.Register(StartPostgresReplica)
+
+	// Sync backups on the schedules in the config, if there are any
+	var backupScheduler *backupscheduler.Scheduler
+	StartBackupScheduler := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			schedCfgs := cfg.ServerConfig.BackupSchedules()
+			if len(schedCfgs) == 0 {
+				return nil
+			}
+
+			schedules := make([]backupscheduler.Schedule, len(schedCfgs))
+			for i, schedCfg := range schedCfgs {
+				schedules[i] = backupscheduler.Schedule{
+					Database:       schedCfg.Database(),
+					Backup:         schedCfg.Backup(),
+					SyncInterval:   schedCfg.SyncInterval(),
+					RestorePoints:  schedCfg.RestorePoints(),
+					VerifyInterval: schedCfg.VerifyInterval(),
+				}
+			}
+			backupScheduler = backupscheduler.NewScheduler(schedules, sqlEngine.NewDefaultContext)
+			backupScheduler.Start()
+			return nil
+		},
+		StopF: func(svcs.RunState) error {
+			if backupScheduler != nil {
+				backupScheduler.Stop()
+			}
+			return nil
+		},
+	}
+	controller.Register(StartBackupScheduler)
+
 	RunClusterController := &svcs.AnonService{
 		InitF: func(context.Context) error {
 			if clusterController == nil {
@@ -650,6 +650,9 @@ listener:
   # allow_cleartext_passwords: false
   # socket: /tmp/mysql.sock
 
+# performance:
+  # query_parallelism: 1
+
 # data_dir: .
 
 # cfg_dir: .doltcfg
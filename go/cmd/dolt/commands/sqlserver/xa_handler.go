@@ -0,0 +1,141 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+var xaStatementRegex = regexp.MustCompile(`(?is)^\s*xa\s+(start|begin|end|prepare|commit|rollback|recover)\b(.*?)[\s;]*$`)
+
+// xaStatementSuffixes are the clauses that may end each XA statement, and the argument of dolt_xa each is translated to.
+// JOIN, RESUME and SUSPEND have no effect, as in MySQL.
+var xaStatementSuffixes = map[string][]struct {
+	regex *regexp.Regexp
+	arg   string
+}{
+	"start":   {{regex: regexp.MustCompile(`(?is)\s+(join|resume)$`)}},
+	"begin":   {{regex: regexp.MustCompile(`(?is)\s+(join|resume)$`)}},
+	"end":     {{regex: regexp.MustCompile(`(?is)\s+suspend(\s+for\s+migrate)?$`)}},
+	"commit":  {{regex: regexp.MustCompile(`(?is)\s+one\s+phase$`), arg: "--one-phase"}},
+	"recover": {{regex: regexp.MustCompile(`(?is)^\s*convert\s+xid$`), arg: "--convert-xid"}},
+}
+
+// translateXAStatement returns the call of the dolt_xa or dolt_xa_recover procedure that runs |query|, if it's an XA
+// statement, which the parser doesn't support.
+func translateXAStatement(query string) (string, bool) {
+	match := xaStatementRegex.FindStringSubmatch(query)
+	if match == nil {
+		return "", false
+	}
+	command, xid := strings.ToLower(match[1]), strings.TrimSpace(match[2])
+
+	var flag string
+	for _, suffix := range xaStatementSuffixes[command] {
+		if loc := suffix.regex.FindStringIndex(xid); loc != nil {
+			xid, flag = strings.TrimSpace(xid[:loc[0]]), suffix.arg
+		}
+	}
+
+	if command == "recover" {
+		if xid != "" {
+			return "", false
+		} else if flag != "" {
+			return fmt.Sprintf("CALL dolt_xa_recover('%s')", flag), true
+		}
+		return "CALL dolt_xa_recover()", true
+	}
+
+	args := []string{"'" + command + "'"}
+	if flag != "" {
+		args = append(args, "'"+flag+"'")
+	}
+	if xid != "" {
+		args = append(args, xid)
+	}
+	return fmt.Sprintf("CALL dolt_xa(%s)", strings.Join(args, ", ")), true
+}
+
+// xaHandler is a mysql.Handler that runs XA statements as calls to the procedures that implement them
+type xaHandler struct {
+	mysql.Handler
+}
+
+var _ mysql.BinlogReplicaHandler = xaHandler{}
+
+func newXAHandler(h mysql.Handler) (mysql.Handler, error) {
+	return xaHandler{Handler: h}, nil
+}
+
+func (h xaHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	if translated, ok := translateXAStatement(query); ok {
+		return h.Handler.ComQuery(ctx, c, translated, xaResultCallback(translated, callback))
+	}
+	return h.Handler.ComQuery(ctx, c, query, callback)
+}
+
+func (h xaHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	statement, remainder, err := sqlparser.SplitStatement(query)
+	if err != nil {
+		return h.Handler.ComMultiQuery(ctx, c, query, callback)
+	}
+	translated, ok := translateXAStatement(statement)
+	if !ok {
+		return h.Handler.ComMultiQuery(ctx, c, query, callback)
+	}
+
+	if remainder != "" {
+		// the handler runs the first statement, and reports that more follow it
+		translated += ";" + remainder
+	}
+	return h.Handler.ComMultiQuery(ctx, c, translated, xaResultCallback(translated, callback))
+}
+
+// xaResultCallback returns the callback for the results of |translated|. XA statements other than XA RECOVER return no
+// result set, so the status returned by dolt_xa is replaced with an OK result.
+func xaResultCallback(translated string, callback mysql.ResultSpoolFn) mysql.ResultSpoolFn {
+	if strings.HasPrefix(translated, "CALL dolt_xa_recover") {
+		return callback
+	}
+	sent := false
+	return func(res *sqltypes.Result, more bool) error {
+		if sent {
+			return nil
+		}
+		sent = true
+		return callback(&sqltypes.Result{}, more)
+	}
+}
+
+func (h xaHandler) ComRegisterReplica(c *mysql.Conn, replicaHost string, replicaPort uint16, replicaUser string, replicaPassword string) error {
+	if rh, ok := h.Handler.(mysql.BinlogReplicaHandler); ok {
+		return rh.ComRegisterReplica(c, replicaHost, replicaPort, replicaUser, replicaPassword)
+	}
+	return fmt.Errorf("binlog replication is not supported")
+}
+
+func (h xaHandler) ComBinlogDumpGTID(c *mysql.Conn, logFile string, logPos uint64, gtidSet mysql.GTIDSet) error {
+	if rh, ok := h.Handler.(mysql.BinlogReplicaHandler); ok {
+		return rh.ComBinlogDumpGTID(c, logFile, logPos, gtidSet)
+	}
+	return fmt.Errorf("binlog replication is not supported")
+}
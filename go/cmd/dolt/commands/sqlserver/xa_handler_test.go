@@ -0,0 +1,52 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateXAStatement(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{query: "XA START 'tx1'", expected: "CALL dolt_xa('start', 'tx1')"},
+		{query: "xa begin 'tx1', 'b1', 3 join;", expected: "CALL dolt_xa('begin', 'tx1', 'b1', 3)"},
+		{query: "XA START X'7478', 0x6231 RESUME", expected: "CALL dolt_xa('start', X'7478', 0x6231)"},
+		{query: "XA END 'tx1'", expected: "CALL dolt_xa('end', 'tx1')"},
+		{query: "XA END 'tx1' SUSPEND FOR MIGRATE", expected: "CALL dolt_xa('end', 'tx1')"},
+		{query: "  XA PREPARE 'tx1'  ", expected: "CALL dolt_xa('prepare', 'tx1')"},
+		{query: "XA COMMIT 'tx1'", expected: "CALL dolt_xa('commit', 'tx1')"},
+		{query: "XA COMMIT 'tx1' ONE  PHASE", expected: "CALL dolt_xa('commit', '--one-phase', 'tx1')"},
+		{query: "XA COMMIT 'one phase'", expected: "CALL dolt_xa('commit', 'one phase')"},
+		{query: "XA ROLLBACK 'tx1'", expected: "CALL dolt_xa('rollback', 'tx1')"},
+		{query: "XA RECOVER", expected: "CALL dolt_xa_recover()"},
+		{query: "XA RECOVER CONVERT XID", expected: "CALL dolt_xa_recover('--convert-xid')"},
+		{query: "XA RECOVER 'tx1'"},
+		{query: "XAR START 'tx1'"},
+		{query: "select 'XA START'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			translated, ok := translateXAStatement(test.query)
+			assert.Equal(t, test.expected != "", ok)
+			assert.Equal(t, test.expected, translated)
+		})
+	}
+}
@@ -47,11 +47,14 @@ var tagDocs = cli.CommandDocumentationContent{
 
 The command's second form creates a new tag named {{.LessThan}}tagname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}ref{{.GreaterThan}} if given. Optionally, a tag message can be passed using the {{.EmphasisLeft}}-m{{.EmphasisRight}} option. 
 
-With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted.`,
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted.
+
+With a {{.EmphasisLeft}}--restore{{.EmphasisRight}}, the deleted tag {{.LessThan}}tagname{{.GreaterThan}} is recreated as it was when it was most recently deleted. Deleted tags are kept for {{.EmphasisLeft}}@@dolt_deleted_refs_retention_days{{.EmphasisRight}} days, and are listed in the {{.EmphasisLeft}}dolt_deleted_refs{{.EmphasisRight}} system table.`,
 	Synopsis: []string{
 		`[-v]`,
 		`[-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
 		`-d {{.LessThan}}tagname{{.GreaterThan}}`,
+		`--restore {{.LessThan}}tagname{{.GreaterThan}}...`,
 	},
 }
 
@@ -104,6 +107,12 @@ func (cmd TagCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	// restore deleted tags
+	if apr.Contains(cli.RestoreFlag) {
+		err = restoreTags(queryist.Queryist, queryist.Context, apr)
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
 	// create tag
 	err = createTag(queryist.Queryist, queryist.Context, apr)
 	return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -167,6 +176,21 @@ func deleteTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPa
 	return nil
 }
 
+func restoreTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
+	if apr.Contains(cli.MessageArg) {
+		return errors.New("restore and tag message options are incompatible")
+	} else if apr.Contains(cli.VerboseFlag) {
+		return errors.New("restore and verbose options are incompatible")
+	}
+	for _, tagName := range apr.Args {
+		_, err := InterpolateAndRunQuery(queryist, sqlCtx, "call dolt_tag('--restore', ?)", tagName)
+		if err != nil {
+			return fmt.Errorf("error: failed to restore tag %s: %w", tagName, err)
+		}
+	}
+	return nil
+}
+
 func listTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
 	if apr.Contains(cli.DeleteFlag) {
 		return errors.New("must specify a tag name to delete")
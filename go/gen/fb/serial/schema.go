@@ -789,7 +789,19 @@ func (rcv *Index) Predicate() []byte {
 	return nil
 }
 
-const IndexNumFields = 15
+func (rcv *Index) ColumnstoreKey() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(34))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *Index) MutateColumnstoreKey(n bool) bool {
+	return rcv._tab.MutateBoolSlot(34, n)
+}
+
+const IndexNumFields = 16
 
 func IndexStart(builder *flatbuffers.Builder) {
 	builder.StartObject(IndexNumFields)
@@ -851,6 +863,9 @@ func IndexAddVectorInfo(builder *flatbuffers.Builder, vectorInfo flatbuffers.UOf
 func IndexAddPredicate(builder *flatbuffers.Builder, predicate flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(14, flatbuffers.UOffsetT(predicate), 0)
 }
+func IndexAddColumnstoreKey(builder *flatbuffers.Builder, columnstoreKey bool) {
+	builder.PrependBoolSlot(15, columnstoreKey, false)
+}
 func IndexEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
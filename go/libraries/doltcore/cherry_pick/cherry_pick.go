@@ -120,9 +120,14 @@ func CherryPick(ctx *sql.Context, commitString string, options CherryPickOptions
 		return "", nil, err
 	}
 
-	// If there were merge conflicts, just return the merge result.
+	// If there were merge conflicts the merge resolvers can't resolve, just return the merge result.
 	if mergeResult.HasMergeArtifacts() {
-		return "", mergeResult, nil
+		resolved, err := resolveConflictsWithProcedures(ctx, doltSession, dbName, mergeResult)
+		if err != nil {
+			return "", nil, err
+		} else if !resolved {
+			return "", mergeResult, nil
+		}
 	}
 
 	commitProps, err := CreateCommitStagedPropsFromCherryPickOptions(ctx, options, commitToCherryPick)
@@ -560,6 +565,33 @@ func rootsEqual(root1, root2 doltdb.RootValue) (bool, error) {
 	return root1Hash.Equal(root2Hash), nil
 }
 
+// resolveConflictsWithProcedures resolves the data conflicts of a cherry-pick with the session's merge resolvers. If
+// every conflict and constraint violation was resolved, the resolved tables are staged, the cherry-pick is no longer
+// in progress and true is returned, so the cherry-pick can be committed.
+func resolveConflictsWithProcedures(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, result *merge.Result) (bool, error) {
+	if result.HasSchemaConflicts() {
+		return false, nil
+	}
+	resolvedTables, resolved, err := dSess.ResolveMergeConflicts(ctx, dbName)
+	if err != nil || !resolved {
+		return false, err
+	}
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return false, err
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return false, fmt.Errorf("unable to get roots for database '%s' from session", dbName)
+	}
+	roots, err = actions.StageTables(ctx, roots, resolvedTables, true)
+	if err != nil {
+		return false, err
+	}
+	ws = ws.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged).ClearMerge()
+	return true, dSess.SetWorkingSet(ctx, dbName, ws)
+}
+
 // stageCherryPickedTables stages the tables from |mergeStats| that don't have any merge artifacts – i.e.
 // tables that don't have any data or schema conflicts and don't have any constraint violations.
 func stageCherryPickedTables(ctx *sql.Context, mergeStats map[doltdb.TableName]*merge.MergeStats) (err error) {
@@ -41,17 +41,15 @@ func (fact AzureDBFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinForm
 	return nil
 }
 
-// CreateDB creates an Azure Blob Storage backed database
+// CreateBlobstore implements BlobstoreFactory
 // URL format: az://STORAGE_ACCOUNT.blob.core.windows.net/container_name/path
-func (fact AzureDBFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
-	var db datas.Database
-
+func (fact AzureDBFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
 	// Parse the container name from the path
 	// urlObj.Host is STORAGE_ACCOUNT.blob.core.windows.net
 	// urlObj.Path is /container_name/path
 	pathParts := strings.SplitN(strings.TrimPrefix(urlObj.Path, "/"), "/", 2)
 	if len(pathParts) == 0 || pathParts[0] == "" {
-		return nil, nil, nil, errors.New("azure url must include container name in path")
+		return nil, errors.New("azure url must include container name in path")
 	}
 
 	containerName := pathParts[0]
@@ -63,17 +61,28 @@ func (fact AzureDBFactory) CreateDB(ctx context.Context, nbf *types.NomsBinForma
 	// Create Azure credential using default authentication
 	credential, err := azidentity.NewDefaultAzureCredential(nil)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	// Create Azure client using the full service URL from the host
 	serviceURL := fmt.Sprintf("https://%s/", urlObj.Host)
 	azClient, err := azblob.NewClient(serviceURL, credential, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return blobstore.NewAzureBlobstore(azClient, containerName, blobPrefix), nil
+}
+
+// CreateDB creates an Azure Blob Storage backed database
+// URL format: az://STORAGE_ACCOUNT.blob.core.windows.net/container_name/path
+func (fact AzureDBFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	var db datas.Database
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	bs := blobstore.NewAzureBlobstore(azClient, containerName, blobPrefix)
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	azStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
 
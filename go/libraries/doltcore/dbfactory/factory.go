@@ -16,11 +16,13 @@ package dbfactory
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
@@ -84,6 +86,9 @@ type DBFactory interface {
 
 // DBFactories is a map from url scheme name to DBFactory.  Additional factories can be added to the DBFactories map
 // from external packages.
+// ErrBlobstoreUnsupported is returned by CreateBlobstore for URL schemes whose databases aren't stored in a blobstore.
+var ErrBlobstoreUnsupported = errors.New("url scheme does not support blobstores")
+
 var DBFactories = map[string]DBFactory{
 	AWSScheme:      AWSFactory{},
 	S3Scheme:       S3Factory{},
@@ -124,6 +129,36 @@ func CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlStr string, para
 	return nil, nil, nil, fmt.Errorf("unknown url scheme: '%s'", urlObj.Scheme)
 }
 
+// BlobstoreFactory is implemented by the DBFactories for URL schemes whose databases are stored in a blobstore.
+type BlobstoreFactory interface {
+	// CreateBlobstore returns the blobstore at the URL given.
+	CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error)
+}
+
+// CreateBlobstore returns the blobstore at the supplied urlStr. The DBFactory used is determined by the scheme of the
+// url, and must be a BlobstoreFactory. Naked urls will use https by default.
+func CreateBlobstore(ctx context.Context, urlStr string, params map[string]interface{}) (blobstore.Blobstore, error) {
+	urlObj, err := earl.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := urlObj.Scheme
+	if len(scheme) == 0 {
+		scheme = defaultScheme
+	}
+
+	fact, ok := DBFactories[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("unknown url scheme: '%s'", urlObj.Scheme)
+	}
+	bsFact, ok := fact.(BlobstoreFactory)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrBlobstoreUnsupported, scheme)
+	}
+	return bsFact.CreateBlobstore(ctx, urlObj, params)
+}
+
 // PrepareDB does the necessary work to create a database at the URL given, e.g. to ready a new remote for pushing. Not
 // all URL schemes can support this operation. The DBFactory used for preparing the DB is determined by the scheme of
 // the url. Naked urls will use https by default.
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/memlimit"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -152,6 +153,15 @@ func (fact FileFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat,
 	return nil
 }
 
+// CreateBlobstore implements BlobstoreFactory. Use PrepareDB to create the blobstore's directory.
+func (fact FileFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
+	path, err := url.PathUnescape(urlObj.Path)
+	if err != nil {
+		return nil, err
+	}
+	return blobstore.NewLocalBlobstore(urlObj.Host + filepath.FromSlash(path)), nil
+}
+
 // CreateDB creates a local filesys backed database
 func (fact FileFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
 	// Some embedded-driver use-cases require deterministic reopen semantics. When this flag is set,
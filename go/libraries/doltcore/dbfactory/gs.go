@@ -17,6 +17,7 @@ package dbfactory
 import (
 	"context"
 	"net/url"
+	"os"
 	"path/filepath"
 
 	"cloud.google.com/go/storage"
@@ -38,16 +39,23 @@ func (fact GSFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, u
 	return nil
 }
 
+// CreateBlobstore implements BlobstoreFactory
+func (fact GSFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
+	gcs, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path), nil
+}
+
 // CreateDB creates an GCS backed database
 func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
 	var db datas.Database
-	gcs, err := storage.NewClient(ctx)
-
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	bs := blobstore.NewGCSBlobstore(gcs, urlObj.Host, urlObj.Path)
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	gcsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
 
@@ -66,21 +74,32 @@ func (fact GSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, ur
 type LocalBSFactory struct {
 }
 
+// PrepareDB creates the directory of the blobstore if it doesn't exist
 func (fact LocalBSFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
-	// nothing to prepare
-	return nil
+	absPath, err := filepath.Abs(filepath.Join(urlObj.Host, urlObj.Path))
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(absPath, os.ModePerm)
+}
+
+// CreateBlobstore implements BlobstoreFactory
+func (fact LocalBSFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
+	absPath, err := filepath.Abs(filepath.Join(urlObj.Host, urlObj.Path))
+	if err != nil {
+		return nil, err
+	}
+	return blobstore.NewLocalBlobstore(absPath), nil
 }
 
 // CreateDB creates a local filesystem blobstore backed database
 func (fact LocalBSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
 	var db datas.Database
-	absPath, err := filepath.Abs(filepath.Join(urlObj.Host, urlObj.Path))
-
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	bs := blobstore.NewLocalBlobstore(absPath)
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	bsStore, err := nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
 
@@ -38,17 +38,22 @@ func (fact OCIFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat,
 	return nil
 }
 
-// CreateDB creates an OCI backed database
-func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
-	var db datas.Database
+// CreateBlobstore implements BlobstoreFactory
+func (fact OCIFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
 	provider := common.DefaultConfigProvider()
 
 	client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(provider)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	bs, err := blobstore.NewOCIBlobstore(ctx, provider, client, urlObj.Host, urlObj.Path)
+	return blobstore.NewOCIBlobstore(ctx, provider, client, urlObj.Host, urlObj.Path)
+}
+
+// CreateDB creates an OCI backed database
+func (fact OCIFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	var db datas.Database
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -81,7 +81,8 @@ func (fact OSSFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, u
 	return db, vrw, ns, nil
 }
 
-func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+// CreateBlobstore implements BlobstoreFactory
+func (fact OSSFactory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
 	// oss://[bucket]/[key]
 	bucket := urlObj.Hostname()
 	prefix := urlObj.Path
@@ -96,6 +97,14 @@ func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinForm
 	if err != nil {
 		return nil, errors.New("failed to initialize oss blob store")
 	}
+	return bs, nil
+}
+
+func (fact OSSFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
+	if err != nil {
+		return nil, err
+	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, memlimit.MemtableSize(), q)
@@ -122,19 +122,27 @@ func parseS3Url(urlObj *url.URL) (bucket, prefix string, routing s3Routing, err
 	return bucket, urlObj.Path, routing, nil
 }
 
-// CreateDB creates a database backed by a generic S3-compatible object store
-func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+// CreateBlobstore implements BlobstoreFactory
+func (fact S3Factory) CreateBlobstore(ctx context.Context, urlObj *url.URL, params map[string]interface{}) (blobstore.Blobstore, error) {
 	bucket, prefix, routing, err := parseS3Url(urlObj)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	client, err := newS3Client(ctx, routing)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	bs := blobstore.NewS3Blobstore(client, bucket, prefix)
+	return blobstore.NewS3Blobstore(client, bucket, prefix), nil
+}
+
+// CreateDB creates a database backed by a generic S3-compatible object store
+func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	bs, err := fact.CreateBlobstore(ctx, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	q := nbs.NewUnlimitedMemQuotaProvider()
 	// The S3 blobstore has no server-side compose operation, so use the
@@ -24,14 +24,17 @@ import (
 // We are more permissive than what is documented.
 var SupportedLayouts = []string{
 	"2006/01/02",
+	"2006/01/02T15:04",
 	"2006/01/02T15:04:05",
 	"2006/01/02T15:04:05Z07:00",
 
 	"2006.01.02",
+	"2006.01.02T15:04",
 	"2006.01.02T15:04:05",
 	"2006.01.02T15:04:05Z07:00",
 
 	"2006-01-02",
+	"2006-01-02T15:04",
 	"2006-01-02T15:04:05",
 	"2006-01-02T15:04:05Z07:00",
 }
@@ -0,0 +1,227 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	computeDiff "github.com/kylelemons/godebug/diff"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+// cellDiffContextLines is the number of unchanged lines shown around the changes of a text cell diff.
+const cellDiffContextLines = 3
+
+// CellDiff returns a line-oriented diff of two values of a cell of type |typ|, for cells too long to read whole.
+// TEXT values are diffed as a unified diff of their lines, and JSON values as a removed and an added line for each
+// path that changed, as reported by dolt_json_diff. It returns false for other types, and when either value is NULL.
+func CellDiff(ctx *sql.Context, typ sql.Type, from, to interface{}) (string, bool, error) {
+	if from == nil || to == nil {
+		return "", false, nil
+	}
+	switch {
+	case types.IsJSON(typ):
+		s, err := jsonCellDiff(ctx, from, to)
+		return s, err == nil, err
+	case types.IsTextBlob(typ) && types.IsTextOnly(typ):
+		fromStr, err := cellString(ctx, from)
+		if err != nil {
+			return "", false, err
+		}
+		toStr, err := cellString(ctx, to)
+		if err != nil {
+			return "", false, err
+		}
+		return UnifiedLineDiff(fromStr, toStr), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// UnifiedLineDiff returns the hunks of a unified diff of the lines of |from| and |to|. Each hunk starts with a header
+// of the lines it covers, followed by its lines prefixed with "-" when removed, "+" when added and " " when
+// unchanged. Up to three unchanged lines are shown around the changes of a hunk.
+func UnifiedLineDiff(from, to string) string {
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	for _, c := range computeDiff.DiffChunks(splitLines(from), splitLines(to)) {
+		for _, l := range c.Deleted {
+			lines = append(lines, line{'-', l})
+		}
+		for _, l := range c.Added {
+			lines = append(lines, line{'+', l})
+		}
+		for _, l := range c.Equal {
+			lines = append(lines, line{' ', l})
+		}
+	}
+	// Within a run of changes, the removed lines are shown before the added ones
+	for start := 0; start < len(lines); start++ {
+		end := start
+		for end < len(lines) && lines[end].op != ' ' {
+			end++
+		}
+		run := lines[start:end]
+		sorted := make([]line, 0, len(run))
+		for _, op := range []byte{'-', '+'} {
+			for _, l := range run {
+				if l.op == op {
+					sorted = append(sorted, l)
+				}
+			}
+		}
+		copy(run, sorted)
+		start = end
+	}
+
+	var sb strings.Builder
+	fromLine, toLine := 1, 1
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			fromLine, toLine = fromLine+1, toLine+1
+			i++
+			continue
+		}
+		// A hunk takes in the following changes separated by no more than twice the context
+		start := max(0, i-cellDiffContextLines)
+		end := i
+		for unchanged := 0; end < len(lines) && unchanged <= 2*cellDiffContextLines; end++ {
+			if lines[end].op == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		for end > i && lines[end-1].op == ' ' {
+			end--
+		}
+		end = min(len(lines), end+cellDiffContextLines)
+
+		hunkFrom, hunkTo := fromLine-(i-start), toLine-(i-start)
+		fromCount, toCount := 0, 0
+		for _, l := range lines[start:end] {
+			if l.op != '+' {
+				fromCount++
+			}
+			if l.op != '-' {
+				toCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(hunkFrom, fromCount), hunkRange(hunkTo, toCount))
+		for _, l := range lines[start:end] {
+			sb.WriteByte(l.op)
+			sb.WriteString(l.text)
+			sb.WriteByte('\n')
+		}
+		for _, l := range lines[i:end] {
+			if l.op != '+' {
+				fromLine++
+			}
+			if l.op != '-' {
+				toLine++
+			}
+		}
+		i = end
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// hunkRange formats the lines of a hunk header the way diff does: the count is left out for a single line, and an
+// empty range is given by the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// jsonCellDiff returns a line for the value removed and a line for the value added at each path of the JSON
+// documents |from| and |to| that changed.
+func jsonCellDiff(ctx *sql.Context, from, to interface{}) (string, error) {
+	var docs [2]sql.JSONWrapper
+	for i, v := range []interface{}{from, to} {
+		doc, _, err := types.JSON.Convert(ctx, v)
+		if err != nil {
+			return "", err
+		}
+		docs[i] = doc.(sql.JSONWrapper)
+	}
+	differ, err := tree.NewJsonDiffer(ctx, docs[0], docs[1])
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for {
+		d, err := differ.Next(ctx)
+		if err == io.EOF {
+			return strings.Join(lines, "\n"), nil
+		} else if err != nil {
+			return "", err
+		}
+		path := tree.MySqlJsonPathFromKey(d.Key)
+		for _, v := range []struct {
+			op  string
+			doc sql.JSONWrapper
+		}{{"-", d.From}, {"+", d.To}} {
+			if v.doc == nil {
+				continue
+			}
+			s, err := types.JsonToMySqlString(ctx, v.doc)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, fmt.Sprintf("%s%s: %s", v.op, path, s))
+		}
+	}
+}
+
+func cellString(ctx *sql.Context, v interface{}) (string, error) {
+	v, err := sql.UnwrapAny(ctx, v)
+	if err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		s, _, err := types.LongText.Convert(ctx, v)
+		if err != nil {
+			return "", err
+		}
+		return s.(string), nil
+	}
+}
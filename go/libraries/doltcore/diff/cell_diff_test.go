@@ -0,0 +1,116 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedLineDiff(t *testing.T) {
+	numbered := func(lines ...string) string {
+		return strings.Join(lines, "\n") + "\n"
+	}
+	tests := []struct {
+		name     string
+		from, to string
+		expected string
+	}{
+		{
+			name:     "single line",
+			from:     "hello",
+			to:       "goodbye",
+			expected: "@@ -1 +1 @@\n-hello\n+goodbye",
+		},
+		{
+			name:     "changed line with context",
+			from:     numbered("1", "2", "3", "4", "5", "6", "7", "8", "9", "10"),
+			to:       numbered("1", "2", "3", "4", "5", "six", "7", "8", "9", "10"),
+			expected: "@@ -3,7 +3,7 @@\n 3\n 4\n 5\n-6\n+six\n 7\n 8\n 9",
+		},
+		{
+			name:     "removed lines come before added lines",
+			from:     numbered("a", "b", "c"),
+			to:       numbered("x", "b", "y", "z"),
+			expected: "@@ -1,3 +1,4 @@\n-a\n+x\n b\n-c\n+y\n+z",
+		},
+		{
+			name: "distant changes are separate hunks",
+			from: numbered("1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"),
+			to:   numbered("one", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "twelve"),
+			expected: "@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve",
+		},
+		{
+			name:     "added lines",
+			from:     numbered("a"),
+			to:       numbered("a", "b"),
+			expected: "@@ -1 +1,2 @@\n a\n+b",
+		},
+		{
+			name:     "from empty",
+			from:     "",
+			to:       numbered("a"),
+			expected: "@@ -0,0 +1 @@\n+a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, UnifiedLineDiff(test.from, test.to))
+		})
+	}
+}
+
+func TestCellDiff(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+
+	t.Run("text", func(t *testing.T) {
+		d, ok, err := CellDiff(ctx, types.LongText, "a\nb\n", "a\nc\n")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "@@ -1,2 +1,2 @@\n a\n-b\n+c", d)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		d, ok, err := CellDiff(ctx, types.JSON, `{"a": 1, "b": {"c": "x"}}`, `{"b": {"c": "y"}, "d": [1]}`)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "-$.a: 1\n-$.b.c: \"x\"\n+$.b.c: \"y\"\n+$.d: [1]", d)
+	})
+
+	t.Run("unsupported types", func(t *testing.T) {
+		_, ok, err := CellDiff(ctx, types.Int64, 1, 2)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = CellDiff(ctx, types.MustCreateStringWithDefaults(sqltypes.VarChar, 100), "a", "b")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = CellDiff(ctx, types.LongBlob, []byte("a"), []byte("b"))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		_, ok, err := CellDiff(ctx, types.LongText, nil, "a")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// renameSampleSize is the number of rows compared to detect a rename between columns with different tags.
+	renameSampleSize = 1000
+	// renameMinSampleSize is the number of rows with the same primary key that both tables must have for columns with
+	// different tags to be compared, since a few matching values don't tell a rename from a coincidence.
+	renameMinSampleSize = 10
+	// renameSimilarityThreshold is the fraction of the sampled rows in which the values of a dropped column and an
+	// added column must match for the added column to be considered a rename of the dropped one.
+	renameSimilarityThreshold = 0.9
+)
+
+// ColumnRename is a column that was renamed between two versions of a table.
+type ColumnRename struct {
+	From schema.Column
+	To   schema.Column
+}
+
+// IsTagMatch returns whether the two columns of the rename have the same tag, rather than having been matched by
+// their values.
+func (r ColumnRename) IsTagMatch() bool {
+	return r.From.Tag == r.To.Tag
+}
+
+// DetectColumnRenames returns the columns of |fromTbl| that were renamed in |toTbl|, in the order of the columns of
+// |toTbl|. A column with the same tag and a different name is a rename. With |byValue|, columns whose tags differ,
+// such as the columns of a table that was recreated by an import, are matched by their values as well: a column
+// dropped from |fromTbl| is renamed to a column added in |toTbl| when both have the same type and their values match
+// in most of the rows with the same primary key, of which there must be at least renameMinSampleSize.
+func DetectColumnRenames(ctx context.Context, fromTbl, toTbl *doltdb.Table, byValue bool) ([]ColumnRename, error) {
+	fromSch, err := fromTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toSch, err := toTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fromCols, toCols := fromSch.GetAllCols(), toSch.GetAllCols()
+	var dropped, added []schema.Column
+	_ = fromCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if _, ok := toCols.GetByTag(tag); ok {
+			return false, nil
+		}
+		if _, ok := toCols.GetByNameCaseInsensitive(col.Name); !ok {
+			dropped = append(dropped, col)
+		}
+		return false, nil
+	})
+	_ = toCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if _, ok := fromCols.GetByTag(tag); ok {
+			return false, nil
+		}
+		if _, ok := fromCols.GetByNameCaseInsensitive(col.Name); !ok {
+			added = append(added, col)
+		}
+		return false, nil
+	})
+
+	var matched map[uint64]schema.Column
+	if byValue && len(dropped) > 0 && len(added) > 0 {
+		matched, err = matchColumnsByValue(ctx, fromTbl, toTbl, fromSch, toSch, dropped, added)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var renames []ColumnRename
+	_ = toCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if fromCol, ok := fromCols.GetByTag(tag); ok {
+			if fromCol.Name != col.Name {
+				renames = append(renames, ColumnRename{From: fromCol, To: col})
+			}
+		} else if fromCol, ok := matched[tag]; ok {
+			renames = append(renames, ColumnRename{From: fromCol, To: col})
+		}
+		return false, nil
+	})
+	return renames, nil
+}
+
+// matchColumnsByValue pairs each column of |added| with the column of |dropped| of the same type whose values it
+// matches in at least renameSimilarityThreshold of the sampled rows, returning the dropped columns by the tag of the
+// added column. Only non-primary key columns of tables with the same primary key are compared.
+func matchColumnsByValue(ctx context.Context, fromTbl, toTbl *doltdb.Table, fromSch, toSch schema.Schema, dropped, added []schema.Column) (map[uint64]schema.Column, error) {
+	if schema.IsKeyless(fromSch) || schema.IsKeyless(toSch) || !schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+		return nil, nil
+	}
+
+	type candidate struct {
+		from, to       schema.Column
+		fromIdx, toIdx int
+		matches        int
+	}
+	var candidates []*candidate
+	for _, to := range added {
+		toIdx, ok := toSch.GetNonPKCols().StoredIndexByTag(to.Tag)
+		if !ok {
+			continue
+		}
+		for _, from := range dropped {
+			fromIdx, ok := fromSch.GetNonPKCols().StoredIndexByTag(from.Tag)
+			if !ok || !from.TypeInfo.Equals(to.TypeInfo) {
+				continue
+			}
+			candidates = append(candidates, &candidate{from: from, to: to, fromIdx: fromIdx, toIdx: toIdx})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	fromIdx, err := fromTbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fromRows, err := durable.ProllyMapFromIndex(fromIdx)
+	if err != nil {
+		return nil, err
+	}
+	toIdx, err := toTbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toRows, err := durable.ProllyMapFromIndex(toIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := fromRows.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	common := 0
+	for sampled := 0; sampled < renameSampleSize; sampled++ {
+		key, fromVal, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		var toVal val.Tuple
+		err = toRows.Get(ctx, key, func(_, v val.Tuple) error {
+			toVal = v
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if toVal == nil {
+			continue
+		}
+		common++
+		for _, c := range candidates {
+			// NULLs don't count as matches, so that two columns without values aren't matched to each other
+			if f := fromVal.GetField(c.fromIdx); f != nil && bytes.Equal(f, toVal.GetField(c.toIdx)) {
+				c.matches++
+			}
+		}
+	}
+	if common < renameMinSampleSize {
+		return nil, nil
+	}
+
+	// The best matching pairs are taken first, so that each column is matched at most once
+	matched := make(map[uint64]schema.Column)
+	used := make(map[uint64]bool)
+	for {
+		var best *candidate
+		for _, c := range candidates {
+			if used[c.from.Tag] || used[c.to.Tag] {
+				continue
+			}
+			if float64(c.matches) < renameSimilarityThreshold*float64(common) {
+				continue
+			}
+			if best == nil || c.matches > best.matches {
+				best = c
+			}
+		}
+		if best == nil {
+			return matched, nil
+		}
+		matched[best.to.Tag] = best.from
+		used[best.from.Tag], used[best.to.Tag] = true, true
+	}
+}
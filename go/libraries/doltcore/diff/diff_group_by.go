@@ -0,0 +1,293 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// DiffGroup is the number of rows added, modified and deleted between two revisions of a table that share a value of
+// the column the diff is grouped by.
+type DiffGroup struct {
+	Value                  interface{}
+	Adds, Changes, Removes uint64
+}
+
+// GroupByColumn returns the column of |td| named |colName|, taken from the new schema of the table if it has the
+// column, and whether it was found. Virtual columns have no stored values to group by, and return an error.
+func GroupByColumn(ctx context.Context, td TableDelta, colName string) (schema.Column, bool, error) {
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return schema.Column{}, false, err
+	}
+	for _, sch := range []schema.Schema{toSch, fromSch} {
+		if sch == nil {
+			continue
+		}
+		if col, ok := sch.GetAllCols().GetByNameCaseInsensitive(colName); ok {
+			if col.Virtual {
+				return schema.Column{}, false, fmt.Errorf("cannot group the diff of %s by virtual column %s", td.CurName(), col.Name)
+			}
+			return col, true, nil
+		}
+	}
+	return schema.Column{}, false, nil
+}
+
+// GroupByForTableDelta counts the rows changed by |td|, grouped by the value of column |colName|, from the diff of the
+// table's row data. Added and modified rows are grouped by their new value of the column, and deleted rows by their
+// old value. A modified row whose value of the column changed is counted as deleted from the group of its old value
+// and added to the group of its new value. The groups are returned ordered by their value, with NULL first.
+func GroupByForTableDelta(ctx *sql.Context, td TableDelta, colName string) ([]DiffGroup, error) {
+	if td.FromRootObject != nil || td.ToRootObject != nil {
+		return nil, fmt.Errorf("cannot group the diff of %s by column: not a table", td.CurName())
+	}
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !schema.ArePrimaryKeySetsDiffable(fromSch, toSch) {
+		return nil, fmt.Errorf("failed to group diff for table %s: %w", td.CurName(), ErrPrimaryKeySetChanged)
+	}
+	col, ok, err := GroupByColumn(ctx, td, colName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("column %s does not exist in table %s", colName, td.CurName())
+	}
+	keyless, err := td.IsKeyless(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRows, toRows, err := td.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := prollyMapOrEmpty(fromRows)
+	if err != nil {
+		return nil, err
+	}
+	to, err := prollyMapOrEmpty(toRows)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := col.TypeInfo.ToSqlType()
+	fromCol := newGroupByReader(fromSch, col, from, keyless, typ)
+	toCol := newGroupByReader(toSch, col, to, keyless, typ)
+	_, fromVD := from.Descriptors()
+	_, toVD := to.Descriptors()
+
+	acc := groupByAccumulator{typ: typ, groups: make(map[string]*DiffGroup)}
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, d tree.Diff) error {
+		fromKey, fromVal := val.Tuple(d.Key), val.Tuple(d.From)
+		toKey, toVal := val.Tuple(d.Key), val.Tuple(d.To)
+		switch d.Type {
+		case tree.AddedDiff:
+			n := uint64(1)
+			if keyless {
+				n, _ = toVD.GetUint64(0, toVal)
+			}
+			g, err := acc.group(ctx, toCol, toKey, toVal)
+			if err != nil {
+				return err
+			}
+			g.Adds += n
+		case tree.RemovedDiff:
+			n := uint64(1)
+			if keyless {
+				n, _ = fromVD.GetUint64(0, fromVal)
+			}
+			g, err := acc.group(ctx, fromCol, fromKey, fromVal)
+			if err != nil {
+				return err
+			}
+			g.Removes += n
+		case tree.ModifiedDiff:
+			if !keyless {
+				from, err := acc.group(ctx, fromCol, fromKey, fromVal)
+				if err != nil {
+					return err
+				}
+				to, err := acc.group(ctx, toCol, toKey, toVal)
+				if err != nil {
+					return err
+				}
+				if from == to {
+					to.Changes++
+				} else {
+					from.Removes++
+					to.Adds++
+				}
+				return nil
+			}
+			// A keyless row is only modified by a change to its cardinality, which adds or removes copies of it
+			fromN, _ := fromVD.GetUint64(0, fromVal)
+			toN, _ := toVD.GetUint64(0, toVal)
+			g, err := acc.group(ctx, toCol, toKey, toVal)
+			if err != nil {
+				return err
+			}
+			if fromN < toN {
+				g.Adds += toN - fromN
+			} else {
+				g.Removes += fromN - toN
+			}
+		default:
+			return errors.New("unknown change type")
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return acc.sorted(ctx)
+}
+
+func prollyMapOrEmpty(idx durable.Index) (prolly.Map, error) {
+	if idx == nil {
+		return prolly.Map{}, nil
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+// groupByReader reads the column a diff is grouped by from the rows of one side of the diff.
+type groupByReader struct {
+	inKey bool
+	idx   int
+	kd    *val.TupleDesc
+	vd    *val.TupleDesc
+	ns    tree.NodeStore
+	// typ is the type of the column in this schema, and groupTyp the type its values are grouped as
+	typ, groupTyp sql.Type
+}
+
+// newGroupByReader returns a reader of column |col| from the rows of |m|, which have the schema |sch|. The column is
+// matched by tag, then by name. It returns nil if |sch| does not have the column, or only has it as a virtual column.
+func newGroupByReader(sch schema.Schema, col schema.Column, m prolly.Map, keyless bool, groupTyp sql.Type) *groupByReader {
+	if sch == nil {
+		return nil
+	}
+	schCol, ok := sch.GetAllCols().GetByTag(col.Tag)
+	if !ok {
+		schCol, ok = sch.GetAllCols().GetByNameCaseInsensitive(col.Name)
+		if !ok {
+			return nil
+		}
+	}
+	if schCol.Virtual {
+		return nil
+	}
+	kd, vd := m.Descriptors()
+	r := &groupByReader{kd: kd, vd: vd, ns: m.NodeStore(), typ: schCol.TypeInfo.ToSqlType(), groupTyp: groupTyp}
+	if idx, ok := sch.GetPKCols().TagToIdx[schCol.Tag]; ok {
+		r.inKey, r.idx = true, idx
+	} else {
+		// virtual columns aren't stored in the row
+		r.idx, _ = sch.GetNonPKCols().StoredIndexByTag(schCol.Tag)
+		if keyless {
+			// the values of keyless rows start with their cardinality
+			r.idx++
+		}
+	}
+	return r
+}
+
+// value returns the value of the column in the row |key|, |value|, converted to the type it is grouped as.
+func (r *groupByReader) value(ctx *sql.Context, key, value val.Tuple) (interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+	desc, tup := r.vd, value
+	if r.inKey {
+		desc, tup = r.kd, key
+	}
+	v, err := tree.GetField(ctx, desc, r.idx, tup, r.ns)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if r.typ.Equals(r.groupTyp) {
+		return v, nil
+	}
+	v, _, err = r.groupTyp.Convert(ctx, v)
+	return v, err
+}
+
+// groupByAccumulator holds the groups of a diff, keyed by the SQL representation of their value.
+type groupByAccumulator struct {
+	typ    sql.Type
+	groups map[string]*DiffGroup
+}
+
+// group returns the group of the row |key|, |value| read by |r|.
+func (a *groupByAccumulator) group(ctx *sql.Context, r *groupByReader, key, value val.Tuple) (*DiffGroup, error) {
+	v, err := r.value(ctx, key, value)
+	if err != nil {
+		return nil, err
+	}
+	// NULL is keyed apart from every other value, whose key starts with a byte
+	groupKey := ""
+	if v != nil {
+		sqlVal, err := a.typ.SQL(ctx, nil, v)
+		if err != nil {
+			return nil, err
+		}
+		groupKey = "v" + sqlVal.ToString()
+	}
+	g, ok := a.groups[groupKey]
+	if !ok {
+		g = &DiffGroup{Value: v}
+		a.groups[groupKey] = g
+	}
+	return g, nil
+}
+
+func (a *groupByAccumulator) sorted(ctx *sql.Context) ([]DiffGroup, error) {
+	groups := make([]DiffGroup, 0, len(a.groups))
+	for _, g := range a.groups {
+		groups = append(groups, *g)
+	}
+	var err error
+	sort.Slice(groups, func(i, j int) bool {
+		l, r := groups[i].Value, groups[j].Value
+		if l == nil || r == nil {
+			return l == nil && r != nil
+		}
+		cmp, cmpErr := a.typ.Compare(ctx, l, r)
+		if cmpErr != nil {
+			err = cmpErr
+		}
+		return cmp < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
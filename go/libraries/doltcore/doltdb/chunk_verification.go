@@ -0,0 +1,181 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// verifyBatchSize is the number of chunks fetched at a time while walking the chunk graph.
+const verifyBatchSize = 4096
+
+// ChunkVerification is the result of VerifyChunks.
+type ChunkVerification struct {
+	// Chunks is the number of chunks in the store.
+	Chunks int
+	// Reachable is the number of chunks reachable from the verified roots.
+	Reachable int
+	// Corrupt holds the addresses of chunks whose contents do not match their address.
+	Corrupt []hash.Hash
+	// Missing holds the addresses of chunks that are referenced from the verified roots, but not present in the store.
+	Missing []hash.Hash
+}
+
+// OK returns whether the verification found no problems.
+func (v ChunkVerification) OK() bool {
+	return len(v.Corrupt) == 0 && len(v.Missing) == 0
+}
+
+func (v ChunkVerification) String() string {
+	if v.OK() {
+		return fmt.Sprintf("ok: %d chunks, %d reachable", v.Chunks, v.Reachable)
+	}
+	var problems []string
+	if len(v.Corrupt) > 0 {
+		problems = append(problems, fmt.Sprintf("%d corrupt chunks (first %s)", len(v.Corrupt), v.Corrupt[0].String()))
+	}
+	if len(v.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("%d missing chunks (first %s)", len(v.Missing), v.Missing[0].String()))
+	}
+	return "failed: " + strings.Join(problems, ", ")
+}
+
+// VerifyChunks checks the integrity of the chunks in this DoltDB, in the manner of dolt fsck: every chunk in the store
+// must hash to its address, and every chunk reachable from the current root and from each of |extraRoots| must be
+// present. Problems are reported in the result; an error is only returned if the verification could not be run.
+func (ddb *DoltDB) VerifyChunks(ctx context.Context, extraRoots ...hash.Hash) (ChunkVerification, error) {
+	var result ChunkVerification
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+
+	gcs, ok := cs.(chunks.ChunkStoreGarbageCollector)
+	if !ok {
+		return result, fmt.Errorf("chunk verification is not supported for %T", cs)
+	}
+	err := gcs.IterateAllChunks(ctx, func(c chunks.Chunk) {
+		result.Chunks++
+		if !chunkMatchesAddress(c) {
+			result.Corrupt = append(result.Corrupt, c.Hash())
+		}
+	})
+	if err != nil {
+		return result, err
+	}
+
+	root, err := cs.Root(ctx)
+	if err != nil {
+		return result, err
+	}
+	walkAddrs, err := types.WalkAddrsForChunkStore(cs)
+	if err != nil {
+		return result, err
+	}
+
+	visited := make(hash.HashSet)
+	pending := make(hash.HashSet)
+	leaves := make(hash.HashSet)
+	for _, h := range append([]hash.Hash{root}, extraRoots...) {
+		if !h.IsEmpty() && !visited.Has(h) {
+			visited.Insert(h)
+			pending.Insert(h)
+		}
+	}
+
+	for len(pending) > 0 || len(leaves) > 0 {
+		// Leaf chunks don't reference other chunks, so it's enough to check that they are present.
+		if len(leaves) > 0 {
+			absent, err := cs.HasMany(ctx, leaves)
+			if err != nil {
+				return result, err
+			}
+			result.Reachable += len(leaves) - len(absent)
+			for h := range absent {
+				result.Missing = append(result.Missing, h)
+			}
+			leaves = make(hash.HashSet)
+		}
+
+		batch := make(hash.HashSet)
+		for h := range pending {
+			batch.Insert(h)
+			pending.Remove(h)
+			if len(batch) == verifyBatchSize {
+				break
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		// |found| may be called concurrently
+		var mu sync.Mutex
+		found := make(hash.HashSet, len(batch))
+		var walkErr error
+		err = cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			found.Insert(c.Hash())
+			if walkErr != nil {
+				return
+			}
+			walkErr = walkAddrs(*c, func(h hash.Hash, isleaf bool) error {
+				if visited.Has(h) {
+					return nil
+				}
+				visited.Insert(h)
+				if isleaf {
+					leaves.Insert(h)
+				} else {
+					pending.Insert(h)
+				}
+				return nil
+			})
+		})
+		if err == nil {
+			err = walkErr
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.Reachable += len(found)
+		for h := range batch {
+			if !found.Has(h) {
+				result.Missing = append(result.Missing, h)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// chunkMatchesAddress returns whether the contents of |c| hash to its address.
+func chunkMatchesAddress(c chunks.Chunk) bool {
+	h, sum := c.Hash(), hash.Of(c.Data())
+	if h == sum {
+		return true
+	}
+	// The chunk journal can index a chunk by an address with its last 4 bytes zeroed, as dolt fsck allows for.
+	ln := hash.ByteLen - 4
+	return h[ln] == 0 && h[ln+1] == 0 && h[ln+2] == 0 && h[ln+3] == 0 && bytes.Equal(h[:ln], sum[:ln])
+}
@@ -81,6 +81,32 @@ func NewCommit(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore
 	return &Commit{vrw, ns, parents, commit}, nil
 }
 
+// NewDanglingCommit writes a commit of |root| with |parents|, which may be empty, and |meta| that isn't referenced by
+// any ref, and returns it. The commit is only reachable from the values that reference it, such as the merge
+// artifacts of a working set.
+func NewDanglingCommit(ctx context.Context, root RootValue, parents []*Commit, meta *datas.CommitMeta) (*Commit, error) {
+	vrw, ns := root.VRW(), root.NodeStore()
+	var dcommit *datas.Commit
+	var err error
+	if len(parents) == 0 {
+		dcommit, err = datas.NewParentlessCommitForValue(ctx, vrw, ns, root.NomsValue(), meta)
+	} else {
+		addrs := make([]hash.Hash, len(parents))
+		for i, parent := range parents {
+			addrs[i] = parent.dCommit.Addr()
+		}
+		// The parent closure of the commit is written through |vrw| and |ns|, so no chunk store is needed
+		dcommit, err = datas.NewCommitForValue(ctx, nil, vrw, ns, root.NomsValue(), datas.CommitOptions{Parents: addrs, Meta: meta})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err = vrw.WriteValue(ctx, dcommit.NomsValue()); err != nil {
+		return nil, err
+	}
+	return NewCommit(ctx, vrw, ns, dcommit)
+}
+
 // HashOf returns the hash of the commit
 func (c *Commit) HashOf() (hash.Hash, error) {
 	return c.dCommit.Addr(), nil
@@ -0,0 +1,186 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// DeletedRefsRetentionDays is the system variable for the number of days deleted branches and tags are kept
+	// under refs/deleted, defined here to avoid circular imports. Zero means deleted refs aren't kept.
+	DeletedRefsRetentionDays = "dolt_deleted_refs_retention_days"
+
+	defaultDeletedRefsRetentionDays = 30
+)
+
+var ErrDeletedRefNotFound = errors.New("no deleted ref found")
+
+var deletedRefFilter = map[ref.RefType]struct{}{ref.DeletedRefType: {}}
+
+// DeletedRefWithHash is a deleted branch or tag and the hash of the commit it pointed to.
+type DeletedRefWithHash struct {
+	Ref  ref.DeletedRef
+	Hash hash.Hash
+}
+
+// DeletedRefsRetention returns how long deleted branches and tags are kept before they're pruned.
+func DeletedRefsRetention() time.Duration {
+	days := int64(defaultDeletedRefsRetentionDays)
+	if sql.SystemVariables != nil {
+		if _, val, ok := sql.SystemVariables.GetGlobal(DeletedRefsRetentionDays); ok {
+			if v, ok := val.(int64); ok {
+				days = v
+			}
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// deletedRefID returns the dataset under which the branch or tag |dref| is kept if it's deleted now, so it can be
+// restored with RestoreDeletedRef, or "" if deleted refs aren't kept.
+func deletedRefID(dref ref.DoltRef) string {
+	if DeletedRefsRetention() <= 0 {
+		return ""
+	}
+	return ref.NewDeletedRef(dref, time.Now()).String()
+}
+
+// pruneDeletedRefs deletes the deleted refs that are older than DeletedRefsRetention, so that what they point to can
+// be garbage collected.
+func (ddb *DoltDB) pruneDeletedRefs(ctx context.Context) error {
+	expiry := time.Now().Add(-DeletedRefsRetention())
+	expired := make(map[string]hash.Hash)
+	err := ddb.VisitRefsOfType(ctx, deletedRefFilter, func(r ref.DoltRef, _ hash.Hash) error {
+		if r.(ref.DeletedRef).DeletedAt().Before(expiry) {
+			expired[r.String()] = hash.Hash{}
+		}
+		return nil
+	})
+	if err != nil || len(expired) == 0 {
+		return err
+	}
+
+	return ddb.db.Database.SetHeads(ctx, expired)
+}
+
+// DeletedRefs returns the deleted branches and tags that are kept, newest first.
+func (ddb *DoltDB) DeletedRefs(ctx context.Context) ([]DeletedRefWithHash, error) {
+	var refs []DeletedRefWithHash
+	err := ddb.VisitRefsOfType(ctx, deletedRefFilter, func(r ref.DoltRef, addr hash.Hash) error {
+		dr := r.(ref.DeletedRef)
+		if dr.Ref().GetType() == ref.TagRefType {
+			ds, err := ddb.db.GetDataset(ctx, dr.String())
+			if err != nil {
+				return err
+			}
+			_, addr, err = ds.HeadTag()
+			if err != nil {
+				return err
+			}
+		}
+		refs = append(refs, DeletedRefWithHash{Ref: dr, Hash: addr})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].Ref.DeletedAt().After(refs[j].Ref.DeletedAt())
+	})
+	return refs, nil
+}
+
+// RestoreDeletedRef recreates the branch or tag |dref| as it was when it was most recently deleted, and stops keeping
+// that deletion. Restored branches get a new working set, uncommitted changes on them when they were deleted are lost.
+func (ddb *DoltDB) RestoreDeletedRef(ctx context.Context, dref ref.DoltRef, replicationStatus *ReplicationStatusController) error {
+	exists, err := ddb.HasRef(ctx, dref)
+	if err != nil {
+		return err
+	} else if exists {
+		return &ExistingRefError{Ref: dref}
+	}
+
+	deleted, err := ddb.DeletedRefs(ctx)
+	if err != nil {
+		return err
+	}
+	var restore *ref.DeletedRef
+	for _, dr := range deleted {
+		if ref.Equals(dr.Ref.Ref(), dref) {
+			restore = &dr.Ref
+			break
+		}
+	}
+	if restore == nil {
+		return fmt.Errorf("%w for %s '%s'", ErrDeletedRefNotFound, refTypeName(dref), dref.GetPath())
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, restore.String())
+	if err != nil {
+		return err
+	}
+	addr, ok := ds.MaybeHeadAddr()
+	if !ok {
+		return fmt.Errorf("%w for %s '%s'", ErrDeletedRefNotFound, refTypeName(dref), dref.GetPath())
+	}
+
+	switch dref.GetType() {
+	case ref.BranchRefType:
+		optCmt, err := ddb.ReadCommit(ctx, addr)
+		if err != nil {
+			return err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return ErrGhostCommitEncountered
+		}
+		err = ddb.NewBranchAtCommit(ctx, dref, cm, replicationStatus)
+		if err != nil {
+			return err
+		}
+	case ref.TagRefType:
+		tagDs, err := ddb.db.GetDataset(ctx, dref.String())
+		if err != nil {
+			return err
+		}
+		// The tag's own value is restored, so it keeps its message and tagger
+		if _, err = ddb.db.SetHead(ctx, tagDs, addr, ""); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("only branches and tags can be restored, not %s", dref.String())
+	}
+
+	_, err = ddb.db.Database.Delete(ctx, ds, "")
+	return err
+}
+
+func refTypeName(dref ref.DoltRef) string {
+	if dref.GetType() == ref.TagRefType {
+		return "tag"
+	}
+	return "branch"
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+func TestDeletedRefs(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestFileDoltDB(t)
+
+	main, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	mainAddr, err := main.HashOf()
+	require.NoError(t, err)
+
+	feature := ref.NewBranchRef("feature")
+	tag := ref.NewTagRef("v1")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, feature, main, nil))
+	require.NoError(t, ddb.NewTagAtCommit(ctx, tag, main, datas.NewTagMeta("name", "name@example.com", "release")))
+
+	require.NoError(t, ddb.DeleteBranch(ctx, feature, nil))
+	require.NoError(t, ddb.DeleteTag(ctx, tag))
+
+	deleted, err := ddb.DeletedRefs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	for _, d := range deleted {
+		assert.Equal(t, mainAddr, d.Hash)
+		assert.WithinDuration(t, time.Now(), d.Ref.DeletedAt(), time.Minute)
+	}
+
+	// Branches deleted because they were renamed aren't kept
+	renamed := ref.NewBranchRef("renamed")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, renamed, main, nil))
+	require.NoError(t, ddb.DeleteRenamedBranch(ctx, renamed, nil))
+	deleted, err = ddb.DeletedRefs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+
+	// Restoring a branch brings it back at the commit it pointed to, and stops keeping its deletion
+	require.NoError(t, ddb.RestoreDeletedRef(ctx, feature, nil))
+	restored, err := ddb.ResolveCommitRef(ctx, feature)
+	require.NoError(t, err)
+	restoredAddr, err := restored.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, mainAddr, restoredAddr)
+	wsRef, err := ref.WorkingSetRefForHead(feature)
+	require.NoError(t, err)
+	_, err = ddb.ResolveWorkingSet(ctx, wsRef)
+	require.NoError(t, err)
+
+	deleted, err = ddb.DeletedRefs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, tag, deleted[0].Ref.Ref())
+
+	// Restoring a tag keeps its message
+	require.NoError(t, ddb.RestoreDeletedRef(ctx, tag, nil))
+	restoredTag, err := ddb.ResolveTag(ctx, tag)
+	require.NoError(t, err)
+	assert.Equal(t, "release", restoredTag.Meta.Description)
+
+	err = ddb.RestoreDeletedRef(ctx, feature, nil)
+	var existing *ExistingRefError
+	assert.True(t, errors.As(err, &existing))
+	err = ddb.RestoreDeletedRef(ctx, ref.NewBranchRef("never_existed"), nil)
+	assert.True(t, errors.Is(err, ErrDeletedRefNotFound))
+
+	// Deleted refs older than the retention period are pruned
+	ds, err := ddb.db.GetDataset(ctx, ref.NewDeletedRef(ref.NewBranchRef("old"), time.Now().Add(-DeletedRefsRetention()-time.Hour)).String())
+	require.NoError(t, err)
+	_, err = ddb.db.SetHead(ctx, ds, mainAddr, "")
+	require.NoError(t, err)
+	deleted, err = ddb.DeletedRefs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	require.NoError(t, ddb.pruneDeletedRefs(ctx))
+	deleted, err = ddb.DeletedRefs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
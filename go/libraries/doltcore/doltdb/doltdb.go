@@ -778,6 +778,17 @@ func (ddb *DoltDB) ResolveWorkingSetAtRoot(ctx context.Context, workingSetRef re
 	return ddb.workingSetFromDataset(ctx, workingSetRef, ds)
 }
 
+// ResolveWorkingSetAtAddr returns the working set object stored at |addr|, which need not be the current head of
+// |workingSetRef|.
+func (ddb *DoltDB) ResolveWorkingSetAtAddr(ctx context.Context, workingSetRef ref.WorkingSetRef, addr hash.Hash) (*WorkingSet, error) {
+	dsws, err := datas.LoadWorkingSetHead(ctx, ddb.vrw, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWorkingSetFromHead(ctx, workingSetRef.GetPath(), ddb.vrw, ddb.ns, dsws, addr)
+}
+
 func (ddb *DoltDB) workingSetFromDataset(ctx context.Context, workingSetRef ref.WorkingSetRef, ds datas.Dataset) (*WorkingSet, error) {
 	if !ds.HasHead() {
 		return nil, ErrWorkingSetNotFound
@@ -1714,7 +1725,19 @@ func (ddb *DoltDB) DeleteBranch(ctx context.Context, branch ref.DoltRef, replica
 	return ddb.deleteRef(ctx, branch, replicationStatus, "")
 }
 
+// DeleteRenamedBranch deletes the branch given after it was copied to its new name. Unlike DeleteBranch, the branch
+// isn't kept as a deleted ref, since it wasn't deleted.
+func (ddb *DoltDB) DeleteRenamedBranch(ctx context.Context, branch ref.DoltRef, replicationStatus *ReplicationStatusController) error {
+	return ddb.doDeleteRef(ctx, branch, replicationStatus, "", false)
+}
+
 func (ddb *DoltDB) deleteRef(ctx context.Context, dref ref.DoltRef, replicationStatus *ReplicationStatusController, wsPath string) error {
+	return ddb.doDeleteRef(ctx, dref, replicationStatus, wsPath, true)
+}
+
+// doDeleteRef deletes |dref|. Deleted branches and tags are kept under refs/deleted when |keep| is true, in the same
+// update that deletes them.
+func (ddb *DoltDB) doDeleteRef(ctx context.Context, dref ref.DoltRef, replicationStatus *ReplicationStatusController, wsPath string, keep bool) error {
 	ds, err := ddb.db.GetDataset(ctx, dref.String())
 
 	if err != nil {
@@ -1735,8 +1758,27 @@ func (ddb *DoltDB) deleteRef(ctx context.Context, dref ref.DoltRef, replicationS
 		}
 	}
 
-	_, err = ddb.db.withReplicationStatusController(replicationStatus).Delete(ctx, ds, wsPath)
-	return err
+	t := dref.GetType()
+	keptType := t == ref.BranchRefType || t == ref.TagRefType
+	var keepID string
+	if keep && keptType {
+		keepID = deletedRefID(dref)
+	}
+
+	db := ddb.db.withReplicationStatusController(replicationStatus)
+	if keepID != "" {
+		_, err = db.DeleteAndKeep(ctx, ds, wsPath, keepID)
+	} else {
+		_, err = db.Delete(ctx, ds, wsPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if keptType {
+		return ddb.pruneDeletedRefs(ctx)
+	}
+	return nil
 }
 
 // DeleteAllRefs Very destructive, use with caution. Not only does this drop all data, Dolt assume there is always
@@ -1748,6 +1790,14 @@ func (ddb *DoltDB) DeleteAllRefs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// The heads of restore points are roots, not values a Dataset can hold
+	if err = ddb.SetRestorePoints(ctx, nil); err != nil {
+		return err
+	}
+	dss, err = ddb.db.Datasets(ctx)
+	if err != nil {
+		return err
+	}
 	err = dss.IterAll(ctx, func(key string, addr hash.Hash) error {
 		ds, e := ddb.db.GetDataset(ctx, key)
 		if e != nil {
@@ -1872,6 +1922,63 @@ func (ddb *DoltDB) UpdateWorkingSet(
 	return err
 }
 
+// WorkingSetUpdate is one of the working sets written by UpdateWorkingSets
+type WorkingSetUpdate struct {
+	Ref ref.WorkingSetRef
+	// WorkingSet is the new value of the working set, or nil to delete it
+	WorkingSet *WorkingSet
+	// PrevHash is the hash of the working set that the update replaces
+	PrevHash hash.Hash
+}
+
+// UpdateWorkingSets updates or deletes several working sets of this database in a single write of the database root,
+// so that either all of them are changed or none are. Like UpdateWorkingSet, it asserts that each working set still
+// has the previous hash given for it, and returns datas.ErrOptimisticLockFailed if any of them changed.
+func (ddb *DoltDB) UpdateWorkingSets(
+	ctx context.Context,
+	updates []WorkingSetUpdate,
+	meta *datas.WorkingSetMeta,
+	replicationStatus *ReplicationStatusController,
+) error {
+	dsUpdates := make([]datas.WorkingSetUpdate, len(updates))
+	for i, u := range updates {
+		ds, err := ddb.db.GetDataset(ctx, u.Ref.String())
+		if err != nil {
+			return err
+		}
+		if u.WorkingSet == nil {
+			dsUpdates[i] = datas.WorkingSetUpdate{Dataset: ds, PrevHash: u.PrevHash, Delete: true}
+			continue
+		}
+
+		wsSpec, err := ddb.writeWorkingSet(ctx, u.Ref, u.WorkingSet, meta, ds)
+		if err != nil {
+			return err
+		}
+		dsUpdates[i] = datas.WorkingSetUpdate{Dataset: ds, WorkingSet: *wsSpec, PrevHash: u.PrevHash}
+	}
+
+	_, err := ddb.db.withReplicationStatusController(replicationStatus).UpdateWorkingSets(ctx, dsUpdates)
+	if err != nil {
+		return err
+	}
+
+	if sqlCtx, ok := ctx.(*sql.Context); ok {
+		db, _ := SplitRevisionDbName(sqlCtx.GetCurrentDatabase())
+		for _, u := range updates {
+			if u.WorkingSet == nil {
+				continue
+			}
+			if headRef, err := u.Ref.ToHeadRef(); err == nil && headRef.GetType() == ref.BranchRefType {
+				// record branch write activity for dolt_branch_activity. Errors here are non-fatal, ignored.
+				BranchActivityWriteEvent(sqlCtx, db, headRef.GetPath())
+			}
+		}
+	}
+
+	return nil
+}
+
 // CommitWithWorkingSet combines the functionality of CommitWithParents with UpdateWorking set, and takes a combination
 // of their parameters. It's a way to update the working set and current HEAD in the same atomic transaction. It commits
 // to disk a pending commit value previously created with NewPendingCommit, asserting that the working set hash given
@@ -1976,6 +2083,24 @@ func (ddb *DoltDB) writeWorkingSet(ctx context.Context, workingSetRef ref.Workin
 	return wsSpec, nil
 }
 
+// GetWorkingSetRefs returns the refs of the working sets whose names start with |prefix|
+func (ddb *DoltDB) GetWorkingSetRefs(ctx context.Context, prefix string) ([]ref.WorkingSetRef, error) {
+	datasets, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = ref.WorkingSetRefPrefix + "/" + prefix
+	var refs []ref.WorkingSetRef
+	err = datasets.IterAll(ctx, func(id string, _ hash.Hash) error {
+		if strings.HasPrefix(id, prefix) {
+			refs = append(refs, ref.NewWorkingSetRef(id))
+		}
+		return nil
+	})
+	return refs, err
+}
+
 // DeleteWorkingSet deletes the working set given
 func (ddb *DoltDB) DeleteWorkingSet(ctx context.Context, workingSetRef ref.WorkingSetRef) error {
 	ds, err := ddb.db.GetDataset(ctx, workingSetRef.String())
@@ -2059,6 +2184,11 @@ func (ddb *DoltDB) GC(ctx context.Context, gcConfig chunks.GCConfig, safepointCo
 		return err
 	}
 
+	err = ddb.pruneDeletedRefs(ctx)
+	if err != nil {
+		return err
+	}
+
 	datasets, err := ddb.db.Datasets(ctx)
 	if err != nil {
 		return err
@@ -2107,7 +2237,7 @@ func (ddb *DoltDB) pruneUnreferencedDatasets(ctx context.Context) error {
 
 	var deletes []string
 	_ = dd.IterAll(ctx, func(dsID string, _ hash.Hash) (err error) {
-		if !ref.IsRef(dsID) && !ref.IsWorkingSet(dsID) {
+		if !ref.IsRef(dsID) && !ref.IsWorkingSet(dsID) && !isRestorePoint(dsID) {
 			deletes = append(deletes, dsID)
 		}
 		return nil
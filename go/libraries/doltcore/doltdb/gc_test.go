@@ -63,6 +63,7 @@ type gcTest struct {
 	stages     []stage
 	query      string
 	expected   []sql.Row
+	preGCFunc  func(ctx context.Context, t *testing.T)
 	postGCFunc func(ctx context.Context, t *testing.T, ddb *doltdb.DoltDB, prevRes interface{})
 }
 
@@ -102,6 +103,13 @@ var gcTests = []gcTest{
 		},
 		query:    "select * from test;",
 		expected: []sql.Row{{int32(4)}, {int32(5)}, {int32(6)}},
+		preGCFunc: func(ctx context.Context, t *testing.T) {
+			// The deleted branch is kept under refs/deleted, and what it points to isn't collected, until it expires
+			require.NoError(t, sql.SystemVariables.SetGlobal(sql.NewContext(ctx), doltdb.DeletedRefsRetentionDays, 0))
+			t.Cleanup(func() {
+				require.NoError(t, sql.SystemVariables.SetGlobal(sql.NewContext(ctx), doltdb.DeletedRefsRetentionDays, 30))
+			})
+		},
 		postGCFunc: func(ctx context.Context, t *testing.T, ddb *doltdb.DoltDB, prevRes interface{}) {
 			h := prevRes.(hash.Hash)
 			cs, err := doltdb.NewCommitSpec(h.String())
@@ -145,6 +153,10 @@ func testGarbageCollection(t *testing.T, test gcTest) {
 		}
 	}
 
+	if test.preGCFunc != nil {
+		test.preGCFunc(ctx, t)
+	}
+
 	ddb := dEnv.DoltDB(ctx)
 	gcConfig := chunks.GCConfig{
 		Mode:                chunks.GCMode_Default,
@@ -18,6 +18,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -197,6 +198,21 @@ func (db hooksDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHe
 	return ds, err
 }
 
+func (db hooksDatabase) SetHeads(ctx context.Context, heads map[string]hash.Hash) error {
+	err := db.Database.SetHeads(ctx, heads)
+	if err != nil {
+		return err
+	}
+	for id := range heads {
+		ds, err := db.Database.GetDataset(ctx, id)
+		if err != nil {
+			return err
+		}
+		db.ExecuteCommitHooks(ctx, ds, ref.IsWorkingSet(id), false)
+	}
+	return nil
+}
+
 func (db hooksDatabase) Delete(ctx context.Context, ds datas.Dataset, workingSetPath string) (datas.Dataset, error) {
 	ds, err := db.Database.Delete(ctx, ds, workingSetPath)
 	if err == nil {
@@ -205,6 +221,14 @@ func (db hooksDatabase) Delete(ctx context.Context, ds datas.Dataset, workingSet
 	return ds, err
 }
 
+func (db hooksDatabase) DeleteAndKeep(ctx context.Context, ds datas.Dataset, workingSetPath string, keepID string) (datas.Dataset, error) {
+	ds, err := db.Database.DeleteAndKeep(ctx, ds, workingSetPath, keepID)
+	if err == nil {
+		db.ExecuteCommitHooks(ctx, datas.NewHeadlessDataset(ds.Database(), ds.ID()), false, false)
+	}
+	return ds, err
+}
+
 func (db hooksDatabase) UpdateWorkingSet(ctx context.Context, ds datas.Dataset, workingSet datas.WorkingSetSpec, prevHash hash.Hash) (datas.Dataset, error) {
 	ds, err := db.Database.UpdateWorkingSet(ctx, ds, workingSet, prevHash)
 	if err == nil {
@@ -213,6 +237,20 @@ func (db hooksDatabase) UpdateWorkingSet(ctx context.Context, ds datas.Dataset,
 	return ds, err
 }
 
+func (db hooksDatabase) UpdateWorkingSets(ctx context.Context, updates []datas.WorkingSetUpdate) ([]datas.Dataset, error) {
+	datasets, err := db.Database.UpdateWorkingSets(ctx, updates)
+	if err == nil {
+		for i, ds := range datasets {
+			if updates[i].Delete {
+				db.ExecuteCommitHooks(ctx, datas.NewHeadlessDataset(ds.Database(), ds.ID()), false, false)
+			} else {
+				db.ExecuteCommitHooks(ctx, ds, true, false)
+			}
+		}
+	}
+	return datasets, err
+}
+
 func (db hooksDatabase) Tag(ctx context.Context, ds datas.Dataset, commitAddr hash.Hash, opts datas.TagOptions) (datas.Dataset, error) {
 	ds, err := db.Database.Tag(ctx, ds, commitAddr, opts)
 	if err == nil {
@@ -0,0 +1,174 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+var ErrNoArchivedRoot = errors.New("the journal archive does not contain a root matching the restore target")
+
+// JournalArchiveTarget identifies the root to restore from a journal archive. If WorkingSet is set, the target is the
+// first archived root with a working set whose address, or whose working root value hash, is WorkingSet. Otherwise,
+// the target is the last archived root committed at or before Time.
+type JournalArchiveTarget struct {
+	Time       time.Time
+	WorkingSet hash.Hash
+}
+
+// ChunkJournalPath returns the path of the chunk journal file this DoltDB writes to, and false if its storage is not
+// journaled.
+func (ddb *DoltDB) ChunkJournalPath(ctx context.Context) (string, bool, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	if generationalNBS, ok := cs.(*nbs.GenerationalNBS); ok {
+		cs = generationalNBS.NewGen()
+	}
+
+	if nbsStore, ok := cs.(*nbs.NomsBlockStore); ok {
+		return nbsStore.ChunkJournalPath(ctx)
+	}
+	return "", false, nil
+}
+
+// RestoreFromJournalArchive replays the chunk journal records archived in |bs| into this DoltDB, up to the root
+// identified by |target|, and then sets that root as the root of this DoltDB. Replay stops at the root record of the
+// target: the chunks archived after it are never written, and neither are the chunks this DoltDB already has. The
+// chunks referenced by the target root must be present in either this DoltDB or the archive. Returns the restored
+// root.
+func (ddb *DoltDB) RestoreFromJournalArchive(ctx context.Context, bs blobstore.Blobstore, target JournalArchiveTarget) (hash.Hash, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+
+	// The chunks archived since the last root record. They're written only once the root after them is replayed.
+	var pending []chunks.Chunk
+	flush := func() error {
+		addrs := make(hash.HashSet, len(pending))
+		for _, c := range pending {
+			addrs.Insert(c.Hash())
+		}
+		absent, err := cs.HasMany(ctx, addrs)
+		if err != nil {
+			return err
+		}
+		for _, c := range pending {
+			if absent.Has(c.Hash()) {
+				if err = cs.Put(ctx, c, ddb.getAddrs); err != nil {
+					return err
+				}
+			}
+		}
+		pending = pending[:0]
+		return nil
+	}
+
+	var restored hash.Hash
+	err := nbs.IterateJournalArchive(ctx, bs, func(rec nbs.ArchivedJournalRecord) error {
+		if rec.Chunk != nil {
+			pending = append(pending, *rec.Chunk)
+			return nil
+		}
+
+		if target.WorkingSet.IsEmpty() {
+			if rec.Timestamp.After(target.Time) {
+				return io.EOF
+			}
+			restored = rec.Root
+			return flush()
+		}
+
+		// The working sets of the root can only be read once its chunks are written
+		if err := flush(); err != nil {
+			return err
+		}
+		found, err := ddb.rootHasWorkingSet(ctx, rec.Root, target.WorkingSet)
+		if err != nil {
+			return err
+		}
+		if found {
+			restored = rec.Root
+			return io.EOF
+		}
+		return nil
+	})
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	if restored.IsEmpty() {
+		return hash.Hash{}, ErrNoArchivedRoot
+	}
+
+	last, err := cs.Root(ctx)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	ok, err := cs.Commit(ctx, restored, last)
+	if err != nil {
+		return hash.Hash{}, fmt.Errorf("unable to restore root %s from the journal archive: %w", restored.String(), err)
+	}
+	if !ok {
+		return hash.Hash{}, fmt.Errorf("unable to restore root %s from the journal archive: the database was modified concurrently", restored.String())
+	}
+	return restored, nil
+}
+
+// rootHasWorkingSet returns whether any working set at |root| has the address |wsHash|, or has a working root value
+// with the hash |wsHash|.
+func (ddb *DoltDB) rootHasWorkingSet(ctx context.Context, root, wsHash hash.Hash) (bool, error) {
+	datasets, err := ddb.db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return false, err
+	}
+
+	var wsRefs []ref.WorkingSetRef
+	var found bool
+	err = datasets.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if !ref.IsWorkingSet(id) {
+			return nil
+		}
+		if addr == wsHash {
+			found = true
+		}
+		wsRefs = append(wsRefs, ref.NewWorkingSetRef(id))
+		return nil
+	})
+	if err != nil || found {
+		return found, err
+	}
+
+	for _, wsRef := range wsRefs {
+		ws, err := ddb.ResolveWorkingSetAtRoot(ctx, wsRef, root)
+		if err != nil {
+			return false, err
+		}
+		workingHash, err := ws.WorkingRoot().HashOf()
+		if err != nil {
+			return false, err
+		}
+		if workingHash == wsHash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
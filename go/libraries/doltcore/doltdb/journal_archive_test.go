@@ -0,0 +1,111 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestRestoreFromJournalArchive(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDBWithParams(ctx, types.Format_DOLT, "file://"+t.TempDir(), filesys.LocalFS, map[string]any{dbfactory.ChunkJournalParam: struct{}{}})
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	journalPath, ok, err := ddb.ChunkJournalPath(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	bs := blobstore.NewInMemoryBlobstore("")
+	shipper := nbs.NewJournalShipper(journalPath, bs)
+	_, err = shipper.Ship(ctx)
+	require.NoError(t, err)
+	first, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+
+	// Root records are timestamped to the second
+	time.Sleep(time.Second)
+	head, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("other"), head, nil))
+	_, err = shipper.Ship(ctx)
+	require.NoError(t, err)
+	second, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+
+	var firstCommitted time.Time
+	err = nbs.IterateJournalArchive(ctx, bs, func(rec nbs.ArchivedJournalRecord) error {
+		if rec.Root == first {
+			firstCommitted = rec.Timestamp
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, firstCommitted.IsZero())
+
+	restoredDb := func(t *testing.T) *DoltDB {
+		restored, err := LoadDoltDB(ctx, types.Format_DOLT, "file://"+t.TempDir(), filesys.LocalFS)
+		require.NoError(t, err)
+		t.Cleanup(func() { restored.Close() })
+		// Restores replay the archive into a database just synced from the backup, never into an empty one
+		require.NoError(t, restored.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+		return restored
+	}
+
+	t.Run("to time", func(t *testing.T) {
+		restored := restoredDb(t)
+		root, err := restored.RestoreFromJournalArchive(ctx, bs, JournalArchiveTarget{Time: firstCommitted})
+		require.NoError(t, err)
+		assert.Equal(t, first, root)
+
+		// Replay stopped at the target root, so the chunks archived after it were never written
+		has, err := datas.ChunkStoreFromDatabase(restored.db).Has(ctx, second)
+		require.NoError(t, err)
+		assert.False(t, has)
+		branches, err := restored.GetBranches(ctx)
+		require.NoError(t, err)
+		assert.Len(t, branches, 1)
+	})
+
+	t.Run("to the latest root", func(t *testing.T) {
+		restored := restoredDb(t)
+		root, err := restored.RestoreFromJournalArchive(ctx, bs, JournalArchiveTarget{Time: time.Now()})
+		require.NoError(t, err)
+		assert.Equal(t, second, root)
+		branches, err := restored.GetBranches(ctx)
+		require.NoError(t, err)
+		assert.Len(t, branches, 2)
+	})
+
+	t.Run("no matching root", func(t *testing.T) {
+		restored := restoredDb(t)
+		_, err := restored.RestoreFromJournalArchive(ctx, bs, JournalArchiveTarget{WorkingSet: hash.Of([]byte("not a working set"))})
+		assert.ErrorIs(t, err, ErrNoArchivedRoot)
+	})
+}
@@ -0,0 +1,476 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// operationPrefix is the prefix of the tuple keys under which the operation log is stored. Each operation is stored
+// under its zero-padded ID, so that the keys sort in the order the operations were recorded.
+const operationPrefix = "operations/"
+
+// operationLogKey is the tuple key under which the ID of the newest operation and the number of operations in the log
+// are stored, so that recording an operation doesn't read the keys of the whole log.
+const operationLogKey = "operation_log"
+
+// maxOperations is the number of operations kept in the operation log. Older operations are dropped as new ones are
+// recorded.
+const maxOperations = 1000
+
+var ErrOperationNotFound = errors.New("operation not found")
+
+// Operation is an entry in the operation log of a DoltDB, recording an update of one or more of its refs and working
+// sets. Changes to branches, tags, remote refs, workspaces and stashes are always recorded. Changes to working sets
+// alone are only recorded when they are made by an OperationSession with a pending operation, so that writes to
+// tables don't fill the log.
+type Operation struct {
+	ID          uint64
+	Time        time.Time
+	User        string
+	Description string
+	// Transaction identifies the sql transaction that made the changes, if they were made by an OperationSession with
+	// a pending operation.
+	Transaction string
+	Changes     []RefChange
+}
+
+// RefChange is the change an Operation made to one ref or working set. An empty hash means the ref didn't exist.
+type RefChange struct {
+	Ref    string
+	Before hash.Hash
+	After  hash.Hash
+}
+
+// OperationSession is implemented by sql sessions that track which of their transactions run operations, like
+// `dolt_reset('--hard')`, whose changes to working sets belong in the operation log.
+type OperationSession interface {
+	// PendingOperation returns an ID and a description for the operations run in the session's current transaction, and
+	// whether there are any. All the changes made with the same ID are recorded as a single Operation.
+	PendingOperation() (id string, description string, ok bool)
+}
+
+type operationJSON struct {
+	Time        time.Time       `json:"time"`
+	User        string          `json:"user,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Transaction string          `json:"transaction,omitempty"`
+	Changes     []refChangeJSON `json:"changes"`
+}
+
+// operationLogJSON is the value stored under operationLogKey. The IDs of the operations in the log are consecutive, so
+// they're the |Count| IDs ending with |Last|.
+type operationLogJSON struct {
+	Last  uint64 `json:"last"`
+	Count int    `json:"count"`
+}
+
+type refChangeJSON struct {
+	Ref    string `json:"ref"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+func operationKey(id uint64) string {
+	return fmt.Sprintf("%s%020d", operationPrefix, id)
+}
+
+// isLoggedRef returns whether changes to the dataset |id| are recorded in the operation log on their own.
+func isLoggedRef(id string) bool {
+	if !ref.IsRef(id) {
+		return false
+	}
+	for _, t := range []ref.RefType{ref.BranchRefType, ref.RemoteRefType, ref.TagRefType, ref.WorkspaceRefType, ref.StashRefType} {
+		if strings.HasPrefix(id, ref.PrefixForType(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordOperation is the datas.DatasetsUpdateHook of a DoltDB. It adds an Operation for the changes between |before|
+// and |after| to |after|, dropping the oldest operations beyond maxOperations. Changes made by the same transaction as
+// the newest operation are added to it instead.
+func (ddb *DoltDB) recordOperation(ctx context.Context, before, after prolly.AddressMap) (prolly.AddressMap, error) {
+	op := operationJSON{Time: time.Now().UTC()}
+	if sqlCtx, ok := ctx.(*sql.Context); ok {
+		op.User = sqlCtx.Client().User
+		if sess, ok := sqlCtx.Session.(OperationSession); ok {
+			if id, description, ok := sess.PendingOperation(); ok {
+				op.Transaction = id
+				op.Description = description
+			}
+		}
+	}
+
+	var changes []RefChange
+	var refsChanged bool
+	err := prolly.DiffAddressMaps(ctx, before, after, func(ctx context.Context, name string, from, to hash.Hash) error {
+		if isLoggedRef(name) {
+			refsChanged = true
+		} else if !ref.IsWorkingSet(name) {
+			return nil
+		}
+		changes = append(changes, RefChange{Ref: name, Before: from, After: to})
+		return nil
+	})
+	if err != nil {
+		return prolly.AddressMap{}, err
+	}
+	if !refsChanged && (op.Transaction == "" || len(changes) == 0) {
+		return after, nil
+	}
+
+	log, err := ddb.readOperationLog(ctx, after)
+	if err != nil {
+		return prolly.AddressMap{}, err
+	}
+
+	id := log.Last + 1
+	if log.Count > 0 {
+		lastAddr, err := after.Get(ctx, ref.NewTupleRef(operationKey(log.Last)).String())
+		if err != nil {
+			return prolly.AddressMap{}, err
+		}
+		prev, err := ddb.readOperation(ctx, log.Last, lastAddr)
+		if err != nil {
+			return prolly.AddressMap{}, err
+		}
+		if op.Transaction != "" && prev.Transaction == op.Transaction {
+			op.Time = prev.Time
+			changes = mergeRefChanges(prev.Changes, changes)
+			id = log.Last
+		}
+	}
+
+	addr, err := ddb.writeOperation(ctx, op, changes)
+	if err != nil {
+		return prolly.AddressMap{}, err
+	}
+	ae := after.Editor()
+	if err = ae.Update(ctx, ref.NewTupleRef(operationKey(id)).String(), addr); err != nil {
+		return prolly.AddressMap{}, err
+	}
+	if id > log.Last {
+		log.Last = id
+		log.Count++
+	}
+	for ; log.Count > maxOperations; log.Count-- {
+		oldest := log.Last - uint64(log.Count) + 1
+		if err = ae.Delete(ctx, ref.NewTupleRef(operationKey(oldest)).String()); err != nil {
+			return prolly.AddressMap{}, err
+		}
+	}
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		return prolly.AddressMap{}, err
+	}
+	logAddr, err := ddb.writeTupleValue(ctx, data)
+	if err != nil {
+		return prolly.AddressMap{}, err
+	}
+	if err = ae.Update(ctx, ref.NewTupleRef(operationLogKey).String(), logAddr); err != nil {
+		return prolly.AddressMap{}, err
+	}
+	return ae.Flush(ctx)
+}
+
+// readOperationLog returns the newest ID and the number of operations in the operation log of |datasets|. Logs
+// recorded before the operation log key was stored are read key by key.
+func (ddb *DoltDB) readOperationLog(ctx context.Context, datasets prolly.AddressMap) (operationLogJSON, error) {
+	addr, err := datasets.Get(ctx, ref.NewTupleRef(operationLogKey).String())
+	if err != nil {
+		return operationLogJSON{}, err
+	}
+	if !addr.IsEmpty() {
+		data, ok, err := ddb.readTupleValue(ctx, addr)
+		if err != nil {
+			return operationLogJSON{}, fmt.Errorf("invalid operation log: %w", err)
+		} else if !ok {
+			return operationLogJSON{}, fmt.Errorf("invalid operation log: missing value %s", addr.String())
+		}
+		var log operationLogJSON
+		if err = json.Unmarshal(data, &log); err != nil {
+			return operationLogJSON{}, fmt.Errorf("invalid operation log: %w", err)
+		}
+		return log, nil
+	}
+
+	// '0' sorts right after '/', so this range holds exactly the keys of the operation log
+	start := ref.NewTupleRef(operationPrefix).String()
+	stop := strings.TrimSuffix(start, "/") + "0"
+	var log operationLogJSON
+	err = datasets.IterRange(ctx, start, stop, func(name string, _ hash.Hash) error {
+		id, err := strconv.ParseUint(name[len(start):], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid operation '%s': %w", name, err)
+		}
+		log.Last = id
+		log.Count++
+		return nil
+	})
+	return log, err
+}
+
+// mergeRefChanges returns the changes of |first| followed by |then| as a single set of changes, sorted by ref.
+func mergeRefChanges(first []RefChange, then []RefChange) []RefChange {
+	merged := make(map[string]RefChange, len(first)+len(then))
+	for _, c := range first {
+		merged[c.Ref] = c
+	}
+	for _, c := range then {
+		if prev, ok := merged[c.Ref]; ok {
+			c.Before = prev.Before
+		}
+		merged[c.Ref] = c
+	}
+
+	changes := make([]RefChange, 0, len(merged))
+	for _, c := range merged {
+		if c.Before != c.After {
+			changes = append(changes, c)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Ref < changes[j].Ref
+	})
+	return changes
+}
+
+// writeOperation writes |op| with |changes| as a tuple value and returns its address.
+func (ddb *DoltDB) writeOperation(ctx context.Context, op operationJSON, changes []RefChange) (hash.Hash, error) {
+	op.Changes = make([]refChangeJSON, len(changes))
+	for i, c := range changes {
+		op.Changes[i].Ref = c.Ref
+		if !c.Before.IsEmpty() {
+			op.Changes[i].Before = c.Before.String()
+		}
+		if !c.After.IsEmpty() {
+			op.Changes[i].After = c.After.String()
+		}
+	}
+	data, err := json.Marshal(op)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return ddb.writeTupleValue(ctx, data)
+}
+
+// writeTupleValue writes |data| as a tuple value and returns its address.
+func (ddb *DoltDB) writeTupleValue(ctx context.Context, data []byte) (hash.Hash, error) {
+	r, err := ddb.vrw.WriteValue(ctx, types.SerialMessage(datas.Tuple_flatbuffer(data)))
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return r.TargetHash(), nil
+}
+
+// readTupleValue reads the data of the tuple value at |addr|. Returns false if there's no value at |addr|.
+func (ddb *DoltDB) readTupleValue(ctx context.Context, addr hash.Hash) ([]byte, bool, error) {
+	val, err := ddb.vrw.ReadValue(ctx, addr)
+	if err != nil || val == nil {
+		return nil, false, err
+	}
+	msg, ok := val.(types.SerialMessage)
+	if !ok || serial.GetFileID(msg) != serial.TupleFileID {
+		return nil, false, fmt.Errorf("value at %s is not a tuple", addr.String())
+	}
+	tup, err := serial.TryGetRootAsTuple(msg, serial.MessagePrefixSz)
+	if err != nil {
+		return nil, false, err
+	}
+	return tup.ValueBytes(), true, nil
+}
+
+// readOperation reads the operation with ID |id| from the tuple value at |addr|.
+func (ddb *DoltDB) readOperation(ctx context.Context, id uint64, addr hash.Hash) (Operation, error) {
+	if addr.IsEmpty() {
+		return Operation{}, fmt.Errorf("%w: %d", ErrOperationNotFound, id)
+	}
+	data, ok, err := ddb.readTupleValue(ctx, addr)
+	if err != nil {
+		return Operation{}, fmt.Errorf("invalid operation %d: %w", id, err)
+	} else if !ok {
+		return Operation{}, fmt.Errorf("%w: %d", ErrOperationNotFound, id)
+	}
+
+	var op operationJSON
+	if err = json.Unmarshal(data, &op); err != nil {
+		return Operation{}, fmt.Errorf("invalid operation %d: %w", id, err)
+	}
+
+	ret := Operation{ID: id, Time: op.Time, User: op.User, Description: op.Description, Transaction: op.Transaction}
+	for _, c := range op.Changes {
+		rc := RefChange{Ref: c.Ref}
+		if c.Before != "" {
+			if rc.Before, ok = hash.MaybeParse(c.Before); !ok {
+				return Operation{}, fmt.Errorf("invalid operation %d: invalid hash '%s'", id, c.Before)
+			}
+		}
+		if c.After != "" {
+			if rc.After, ok = hash.MaybeParse(c.After); !ok {
+				return Operation{}, fmt.Errorf("invalid operation %d: invalid hash '%s'", id, c.After)
+			}
+		}
+		ret.Changes = append(ret.Changes, rc)
+	}
+	return ret, nil
+}
+
+// RecordOperations turns on the operation log of this DoltDB, recording its updates as Operations. It's turned on for
+// the local database of an environment, and not for remotes, backups and the other databases it's copied to.
+func (ddb *DoltDB) RecordOperations() {
+	ddb.db.SetDatasetsUpdateHook(ddb.recordOperation)
+}
+
+// Operations returns the operation log of this DoltDB, newest first.
+func (ddb *DoltDB) Operations(ctx context.Context) ([]Operation, error) {
+	refs, err := ddb.GetRefsOfType(ctx, tuplesRefFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	for _, r := range refs {
+		key := r.GetPath()
+		if !strings.HasPrefix(key, operationPrefix) {
+			continue
+		}
+		id, err := strconv.ParseUint(key[len(operationPrefix):], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation '%s': %w", key, err)
+		}
+		op, err := ddb.Operation(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].ID > ops[j].ID
+	})
+	return ops, nil
+}
+
+// Operation returns the operation with ID |id|, or ErrOperationNotFound.
+func (ddb *DoltDB) Operation(ctx context.Context, id uint64) (Operation, error) {
+	ds, err := ddb.db.GetDataset(ctx, ref.NewTupleRef(operationKey(id)).String())
+	if err != nil {
+		return Operation{}, err
+	}
+	addr, ok := ds.MaybeHeadAddr()
+	if !ok {
+		return Operation{}, fmt.Errorf("%w: %d", ErrOperationNotFound, id)
+	}
+	return ddb.readOperation(ctx, id, addr)
+}
+
+// RestoreOperation restores every ref and working set changed by the operations recorded after operation |id| to the
+// value it had after operation |id|, undoing those operations. The restore is itself recorded as an operation, so it
+// can be undone in turn. Values that were garbage collected since they were recorded can't be restored. Returns the
+// changes made.
+//
+// The working set |sessionWs|, which belongs to the caller's session, isn't restored here: if it was changed, the
+// working set it had after operation |id| is returned for the caller to set through its session, so that its
+// transaction sees the change.
+func (ddb *DoltDB) RestoreOperation(ctx context.Context, id uint64, sessionWs ref.WorkingSetRef) (*WorkingSet, []RefChange, error) {
+	ops, err := ddb.Operations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var found bool
+	targets := make(map[string]hash.Hash)
+	var names []string
+	for i := len(ops) - 1; i >= 0; i-- {
+		if ops[i].ID == id {
+			found = true
+		}
+		if ops[i].ID <= id {
+			continue
+		}
+		// The value a ref had before the first operation after |id| that changed it is its value after |id|
+		for _, c := range ops[i].Changes {
+			if _, ok := targets[c.Ref]; !ok {
+				targets[c.Ref] = c.Before
+				names = append(names, c.Ref)
+			}
+		}
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("%w: %d", ErrOperationNotFound, id)
+	}
+
+	var changes []RefChange
+	var ws *WorkingSet
+	heads := make(map[string]hash.Hash)
+	for _, name := range names {
+		ds, err := ddb.db.GetDataset(ctx, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		current, _ := ds.MaybeHeadAddr()
+		target := targets[name]
+		if current == target {
+			continue
+		}
+		if !target.IsEmpty() {
+			val, err := ddb.vrw.ReadValue(ctx, target)
+			if err != nil {
+				return nil, nil, err
+			}
+			if val == nil {
+				return nil, nil, fmt.Errorf("cannot restore %s to %s, it was removed by garbage collection", name, target.String())
+			}
+		}
+		changes = append(changes, RefChange{Ref: name, Before: current, After: target})
+
+		if name != sessionWs.String() {
+			heads[name] = target
+			continue
+		}
+		if target.IsEmpty() {
+			return nil, nil, fmt.Errorf("cannot restore operation %d: %s didn't exist after it and is in use by this session", id, name)
+		}
+		if ws, err = ddb.ResolveWorkingSetAtAddr(ctx, sessionWs, target); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(heads) > 0 {
+		if err = ddb.db.SetHeads(ctx, heads); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ws, changes, nil
+}
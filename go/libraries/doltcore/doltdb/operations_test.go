@@ -0,0 +1,158 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestOperationLog(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestOperationsDoltDB(t)
+
+	ops, err := ddb.Operations(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, ops)
+	created := ops[0]
+	assert.Contains(t, created.Changes, RefChange{Ref: "refs/heads/main", After: mustHeadAddr(t, ddb, "refs/heads/main")})
+
+	main, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	mainAddr, err := main.HashOf()
+	require.NoError(t, err)
+
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), main, nil))
+	require.NoError(t, ddb.DeleteBranch(ctx, ref.NewBranchRef("feature"), nil))
+
+	ops, err = ddb.Operations(ctx)
+	require.NoError(t, err)
+	deleted, branched := ops[0], ops[1]
+	assert.Equal(t, created.ID+1, branched.ID)
+	assert.Equal(t, branched.ID+1, deleted.ID)
+	assert.Equal(t, []RefChange{{Ref: "refs/heads/feature", After: mainAddr}}, branched.Changes)
+	assert.Equal(t, []RefChange{{Ref: "refs/heads/feature", Before: mainAddr}}, deleted.Changes)
+
+	// Tuples, like the operation log itself, aren't recorded
+	require.NoError(t, ddb.SetTuple(ctx, "some_key", []byte("some value")))
+	ops, err = ddb.Operations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, deleted.ID, ops[0].ID)
+
+	// Restoring the operation that created the branch brings it back, and is recorded in turn
+	ws, changes, err := ddb.RestoreOperation(ctx, branched.ID, ref.WorkingSetRef{})
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+	assert.Equal(t, []RefChange{{Ref: "refs/heads/feature", After: mainAddr}}, changes)
+	restored, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("feature"))
+	require.NoError(t, err)
+	restoredAddr, err := restored.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, mainAddr, restoredAddr)
+
+	ops, err = ddb.Operations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, deleted.ID+1, ops[0].ID)
+	assert.Equal(t, changes, ops[0].Changes)
+
+	// Restoring the operation before the restore undoes it
+	_, changes, err = ddb.RestoreOperation(ctx, deleted.ID, ref.WorkingSetRef{})
+	require.NoError(t, err)
+	assert.Equal(t, []RefChange{{Ref: "refs/heads/feature", Before: mainAddr}}, changes)
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ref.DoltRef{ref.NewBranchRef("main")}, branches)
+
+	// Restoring the newest operation changes nothing
+	ops, err = ddb.Operations(ctx)
+	require.NoError(t, err)
+	_, changes, err = ddb.RestoreOperation(ctx, ops[0].ID, ref.WorkingSetRef{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, _, err = ddb.RestoreOperation(ctx, ops[0].ID+100, ref.WorkingSetRef{})
+	assert.True(t, errors.Is(err, ErrOperationNotFound))
+	_, err = ddb.Operation(ctx, ops[0].ID+100)
+	assert.True(t, errors.Is(err, ErrOperationNotFound))
+}
+
+func TestOperationLogPrunesOldestOperations(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestOperationsDoltDB(t)
+	main, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+
+	for i := 0; i < maxOperations; i++ {
+		require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef(fmt.Sprintf("b%d", i)), main, nil))
+	}
+
+	ops, err := ddb.Operations(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, maxOperations)
+	for i, op := range ops {
+		assert.Equal(t, ops[0].ID-uint64(i), op.ID)
+	}
+	assert.Equal(t, []RefChange{{Ref: fmt.Sprintf("refs/heads/b%d", maxOperations-1), After: mustHeadAddr(t, ddb, "refs/heads/main")}}, ops[0].Changes)
+
+	data, ok, err := ddb.GetTuple(ctx, operationLogKey)
+	require.NoError(t, err)
+	require.True(t, ok)
+	var log operationLogJSON
+	require.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, operationLogJSON{Last: ops[0].ID, Count: maxOperations}, log)
+}
+
+func TestOperationLogIsOffByDefault(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestFileDoltDB(t)
+	main, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("feature"), main, nil))
+
+	ops, err := ddb.Operations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+// loadTestOperationsDoltDB returns a new DoltDB that records its operation log
+func loadTestOperationsDoltDB(t *testing.T) *DoltDB {
+	ddb, err := LoadDoltDB(context.Background(), types.Format_DOLT, "file://"+t.TempDir(), filesys.LocalFS)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ddb.Close()
+	})
+	ddb.RecordOperations()
+	require.NoError(t, ddb.WriteEmptyRepo(context.Background(), "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	return ddb
+}
+
+func mustHeadAddr(t *testing.T, ddb *DoltDB, id string) hash.Hash {
+	ds, err := ddb.db.GetDataset(context.Background(), id)
+	require.NoError(t, err)
+	addr, ok := ds.MaybeHeadAddr()
+	require.True(t, ok)
+	return addr
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// restorePointPrefix is the prefix of the IDs of the datasets in which restore points are stored. Like working sets,
+// restore points are datasets rather than refs.
+const restorePointPrefix = "restorePoints/"
+
+// restorePointNameLayout is the layout of restore point names. It sorts lexically in time order.
+const restorePointNameLayout = "20060102T150405.000000000Z"
+
+var ErrRestorePointNotFound = errors.New("restore point not found")
+
+// RestorePoint is a root of a database recorded in one of its backups. Restore points are stored in the backup as
+// datasets named by the time they were recorded, and let a backup be restored to an earlier sync than the latest. The
+// head of a restore point's dataset is its root, so the chunks of the root stay reachable from the backup's own root
+// and aren't collected by garbage collection.
+type RestorePoint struct {
+	Name string
+	Time time.Time
+	Root hash.Hash
+}
+
+// NewRestorePoint returns a RestorePoint for |root|, recorded at |t|.
+func NewRestorePoint(t time.Time, root hash.Hash) RestorePoint {
+	t = t.UTC()
+	return RestorePoint{Name: t.Format(restorePointNameLayout), Time: t, Root: root}
+}
+
+// isRestorePoint returns whether the dataset |id| is a restore point.
+func isRestorePoint(id string) bool {
+	return strings.HasPrefix(id, restorePointPrefix)
+}
+
+// RestorePoints returns the restore points stored in this DoltDB, oldest first.
+func (ddb *DoltDB) RestorePoints(ctx context.Context) ([]RestorePoint, error) {
+	dss, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return restorePointsOf(ctx, dss)
+}
+
+// restorePointsOf returns the restore points in |dss|, oldest first.
+func restorePointsOf(ctx context.Context, dss datas.DatasetsMap) ([]RestorePoint, error) {
+	var points []RestorePoint
+	err := dss.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if !isRestorePoint(id) {
+			return nil
+		}
+		name := id[len(restorePointPrefix):]
+		t, err := time.Parse(restorePointNameLayout, name)
+		if err != nil {
+			return fmt.Errorf("invalid restore point '%s': %w", name, err)
+		}
+		points = append(points, RestorePoint{Name: name, Time: t, Root: addr})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Name < points[j].Name
+	})
+	return points, nil
+}
+
+// RestorePoint returns the restore point named |name|, or ErrRestorePointNotFound.
+func (ddb *DoltDB) RestorePoint(ctx context.Context, name string) (RestorePoint, error) {
+	points, err := ddb.RestorePoints(ctx)
+	if err != nil {
+		return RestorePoint{}, err
+	}
+	for _, p := range points {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return RestorePoint{}, fmt.Errorf("%w: '%s'", ErrRestorePointNotFound, name)
+}
+
+// SetRestorePoints replaces the restore points stored in this DoltDB with |points|, in a single update of its root.
+// The chunks of each restore point's root must already be present in this DoltDB.
+func (ddb *DoltDB) SetRestorePoints(ctx context.Context, points []RestorePoint) error {
+	existing, err := ddb.RestorePoints(ctx)
+	if err != nil {
+		return err
+	}
+	heads := restorePointHeads(existing, points)
+	if len(heads) == 0 {
+		return nil
+	}
+	// Restore points don't fire commit hooks
+	return ddb.db.Database.SetHeads(ctx, heads)
+}
+
+// CommitRootWithRestorePoints sets the root of this DoltDB to |root|, with |points| in place of the restore points of
+// |root|, if its root is still |last|. Returns false if it isn't. The chunks of |root| and of the roots of |points| must
+// already be present in this DoltDB. It's used to sync a backup to the root of its database without dropping the
+// restore points the backup keeps, not even for a moment.
+func (ddb *DoltDB) CommitRootWithRestorePoints(ctx context.Context, root, last hash.Hash, points []RestorePoint) (bool, error) {
+	dss, err := ddb.db.DatasetsByRootHash(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	existing, err := restorePointsOf(ctx, dss)
+	if err != nil {
+		return false, err
+	}
+	return ddb.db.CommitRootWithHeads(ctx, root, last, restorePointHeads(existing, points))
+}
+
+// restorePointHeads returns the dataset heads that replace the restore points |existing| with |points|, for
+// datas.Database.SetHeads
+func restorePointHeads(existing, points []RestorePoint) map[string]hash.Hash {
+	heads := make(map[string]hash.Hash)
+	for _, p := range existing {
+		heads[restorePointPrefix+p.Name] = hash.Hash{}
+	}
+	for _, p := range points {
+		heads[restorePointPrefix+p.Name] = p.Root
+	}
+	for _, p := range existing {
+		if heads[restorePointPrefix+p.Name] == p.Root {
+			delete(heads, restorePointPrefix+p.Name)
+		}
+	}
+	return heads
+}
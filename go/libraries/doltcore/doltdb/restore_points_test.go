@@ -0,0 +1,166 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestRestorePoints(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestFileDoltDB(t)
+
+	points, err := ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, points)
+
+	root, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	p1 := NewRestorePoint(start, root)
+	p2 := NewRestorePoint(start.Add(time.Hour), root)
+	p3 := NewRestorePoint(start.Add(2*time.Hour), root)
+	assert.Equal(t, "20261001T120000.000000000Z", p1.Name)
+
+	require.NoError(t, ddb.SetRestorePoints(ctx, []RestorePoint{p2, p1}))
+	points, err = ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RestorePoint{p1, p2}, points)
+
+	require.NoError(t, ddb.SetRestorePoints(ctx, []RestorePoint{p2, p3}))
+	points, err = ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RestorePoint{p2, p3}, points)
+
+	p, err := ddb.RestorePoint(ctx, p3.Name)
+	require.NoError(t, err)
+	assert.Equal(t, p3, p)
+	_, err = ddb.RestorePoint(ctx, p1.Name)
+	assert.True(t, errors.Is(err, ErrRestorePointNotFound))
+	_, err = ddb.RestorePoint(ctx, "not a restore point")
+	assert.True(t, errors.Is(err, ErrRestorePointNotFound))
+
+	// Restore points are not branches or other refs users see
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Len(t, branches, 1)
+
+	require.NoError(t, ddb.SetRestorePoints(ctx, nil))
+	points, err = ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, points)
+}
+
+func TestCommitRootWithRestorePoints(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestFileDoltDB(t)
+
+	old, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+	point := NewRestorePoint(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), old)
+	require.NoError(t, ddb.SetRestorePoints(ctx, []RestorePoint{point}))
+
+	// A root without the restore point, as synced from a database that never had it
+	require.NoError(t, ddb.SetRestorePoints(ctx, nil))
+	head, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("other"), head, nil))
+	synced, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+	require.NoError(t, ddb.SetRestorePoints(ctx, []RestorePoint{point}))
+	last, err := ddb.NomsRoot(ctx)
+	require.NoError(t, err)
+
+	ok, err := ddb.CommitRootWithRestorePoints(ctx, synced, old, []RestorePoint{point})
+	require.NoError(t, err)
+	assert.False(t, ok, "the root is no longer |old|")
+
+	ok, err = ddb.CommitRootWithRestorePoints(ctx, synced, last, []RestorePoint{point})
+	require.NoError(t, err)
+	require.True(t, ok)
+	points, err := ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RestorePoint{point}, points)
+	branches, err := ddb.GetBranches(ctx)
+	require.NoError(t, err)
+	assert.Len(t, branches, 2)
+
+	// Restore points are reachable, so garbage collection keeps them and their roots
+	require.NoError(t, ddb.pruneUnreferencedDatasets(ctx))
+	points, err = ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RestorePoint{point}, points)
+	verification, err := ddb.VerifyChunks(ctx)
+	require.NoError(t, err)
+	assert.True(t, verification.OK(), verification.String())
+
+	require.NoError(t, ddb.DeleteAllRefs(ctx))
+	points, err = ddb.RestorePoints(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, points)
+}
+
+func TestVerifyChunks(t *testing.T) {
+	ctx := context.Background()
+	ddb := loadTestFileDoltDB(t)
+
+	verification, err := ddb.VerifyChunks(ctx)
+	require.NoError(t, err)
+	assert.True(t, verification.OK(), verification.String())
+	assert.Greater(t, verification.Reachable, 0)
+	assert.GreaterOrEqual(t, verification.Chunks, verification.Reachable)
+
+	// A root whose chunks were never written is reported as missing
+	absent := hash.Of([]byte("not a chunk"))
+	verification, err = ddb.VerifyChunks(ctx, absent)
+	require.NoError(t, err)
+	assert.False(t, verification.OK())
+	assert.Equal(t, []hash.Hash{absent}, verification.Missing)
+	assert.Contains(t, verification.String(), "1 missing chunks")
+}
+
+func TestChunkMatchesAddress(t *testing.T) {
+	c := chunks.NewChunk([]byte("some chunk data"))
+	assert.True(t, chunkMatchesAddress(c))
+
+	truncated := c.Hash()
+	copy(truncated[hash.ByteLen-4:], []byte{0, 0, 0, 0})
+	assert.True(t, chunkMatchesAddress(chunks.NewChunkWithHash(truncated, c.Data())))
+
+	assert.False(t, chunkMatchesAddress(chunks.NewChunkWithHash(hash.Of([]byte("other data")), c.Data())))
+}
+
+func loadTestFileDoltDB(t *testing.T) *DoltDB {
+	dir := t.TempDir()
+	ddb, err := LoadDoltDB(context.Background(), types.Format_DOLT, "file://"+dir, filesys.LocalFS)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ddb.Close()
+	})
+	require.NoError(t, ddb.WriteEmptyRepo(context.Background(), "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	return ddb
+}
@@ -127,6 +127,9 @@ var getWriteableSystemTables = func() []string {
 		GetRebaseTableName(),
 		GetQueryCatalogTableName(),
 		GetTestsTableName(),
+		GetMergeStrategiesTableName(),
+		GetMergeResolversTableName(),
+		GetMaterializedViewsTableName(),
 
 		// TODO: find way to make these writable by the dolt process
 		// TODO: but not by user
@@ -160,6 +163,10 @@ func GeneratedSystemTableNames() []string {
 		GetBackupsTableName(),
 		GetStashesTableName(),
 		GetBranchActivityTableName(),
+		GetOperationsTableName(),
+		GetDeletedRefsTableName(),
+		GetLocksTableName(),
+		GetQueryCacheTableName(),
 		// [dtables.StatusTable] now uses [adapters.DoltTableAdapterRegistry] in its constructor for Doltgres.
 		StatusTableName,
 		StatusIgnoredTableName,
@@ -395,10 +402,38 @@ var GetTestsTableName = func() string {
 	return TestsTableName
 }
 
+var GetMergeStrategiesTableName = func() string {
+	return MergeStrategiesTableName
+}
+
+var GetMergeResolversTableName = func() string {
+	return MergeResolversTableName
+}
+
+var GetMaterializedViewsTableName = func() string {
+	return MaterializedViewsTableName
+}
+
 var GetBranchActivityTableName = func() string {
 	return BranchActivityTableName
 }
 
+var GetOperationsTableName = func() string {
+	return OperationsTableName
+}
+
+var GetDeletedRefsTableName = func() string {
+	return DeletedRefsTableName
+}
+
+var GetLocksTableName = func() string {
+	return LocksTableName
+}
+
+var GetQueryCacheTableName = func() string {
+	return QueryCacheTableName
+}
+
 const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
@@ -460,8 +495,29 @@ const (
 	// TestsTableName is the tests system table name
 	TestsTableName = "dolt_tests"
 
+	// MergeStrategiesTableName is the merge strategies system table name
+	MergeStrategiesTableName = "dolt_merge_strategies"
+
+	// MergeResolversTableName is the merge resolvers system table name
+	MergeResolversTableName = "dolt_merge_resolvers"
+
+	// MaterializedViewsTableName is the materialized views system table name
+	MaterializedViewsTableName = "dolt_materialized_views"
+
 	// BranchActivityTableName is the branch activity system table name
 	BranchActivityTableName = "dolt_branch_activity"
+
+	// OperationsTableName is the operation log system table name
+	OperationsTableName = "dolt_operations"
+
+	// DeletedRefsTableName is the deleted branches and tags system table name
+	DeletedRefsTableName = "dolt_deleted_refs"
+
+	// LocksTableName is the row locks system table name
+	LocksTableName = "dolt_locks"
+
+	// QueryCacheTableName is the query result cache system table name
+	QueryCacheTableName = "dolt_query_cache"
 )
 
 // DoltGeneratedTableNames is a list of all the generated dolt system tables that are not specific to a user table.
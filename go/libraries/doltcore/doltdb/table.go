@@ -560,7 +560,7 @@ func getMaxAutoIncrementValue(ctx *sql.Context, table *Table) (uint64, error) {
 
 	var indexData durable.Index
 	aiIndex, ok := sch.Indexes().GetIndexByColumnNames(aiCol.Name)
-	if ok {
+	if ok && !aiIndex.IsColumnStore() {
 		indexes, err := table.GetIndexSet(ctx)
 		if err != nil {
 			return 0, err
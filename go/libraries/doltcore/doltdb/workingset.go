@@ -148,6 +148,8 @@ type MergeState struct {
 	// is resolved in a multi-commit revert or cherry-pick series. When --continue is called,
 	// these commits are applied, in order.
 	pendingCommitsToProcess []string
+	// baseCommit is the commit given as the common ancestor of a merge of unrelated histories, if there was one.
+	baseCommit *Commit
 }
 
 // todo(andy): this might make more sense in pkg merge
@@ -237,6 +239,12 @@ func (m MergeState) PendingRevertCommitHashes() []string {
 	return m.pendingCommitsToProcess
 }
 
+// BaseCommit returns the commit given as the common ancestor of a merge of unrelated histories, or nil if the merge
+// didn't have one.
+func (m MergeState) BaseCommit() *Commit {
+	return m.baseCommit
+}
+
 type SchemaConflictFn func(table TableName, conflict SchemaConflict) error
 
 func (m MergeState) HasSchemaConflicts() bool {
@@ -352,6 +360,13 @@ func (ws WorkingSet) WithRebaseState(rebaseState *RebaseState) *WorkingSet {
 	return &ws
 }
 
+// WithRef returns a copy of this working set stored under the working set ref given
+func (ws WorkingSet) WithRef(workingSetRef ref.WorkingSetRef) *WorkingSet {
+	ws.Name = workingSetRef.GetPath()
+	ws.addr = nil
+	return &ws
+}
+
 func (ws WorkingSet) WithUnmergableTables(tables []TableName) *WorkingSet {
 	ws.mergeState.unmergableTables = tables
 	return &ws
@@ -362,6 +377,13 @@ func (ws WorkingSet) WithMergedTables(tables []TableName) *WorkingSet {
 	return &ws
 }
 
+// WithMergeBase records |base| as the commit used as the common ancestor of the merge in progress, for merges of
+// unrelated histories that were given one.
+func (ws WorkingSet) WithMergeBase(base *Commit) *WorkingSet {
+	ws.mergeState.baseCommit = base
+	return &ws
+}
+
 // StartMerge returns a new, modified WorkingSet, that has MergeState metadata tracking an
 // active merge. |preMergeHeadCommit| is the HEAD commit before the merge is started, and is used
 // to cleanly reset the branch head for merge operations that create multiple commits if processing
@@ -511,7 +533,12 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 	if err != nil {
 		return nil, err
 	}
+	addr, _ := ds.MaybeHeadAddr()
+	return newWorkingSetFromHead(ctx, name, vrw, ns, dsws, addr)
+}
 
+// newWorkingSetFromHead creates a new WorkingSet object from the working set head stored at |addr|.
+func newWorkingSetFromHead(ctx context.Context, name string, vrw types.ValueReadWriter, ns tree.NodeStore, dsws *datas.WorkingSetHead, addr hash.Hash) (*WorkingSet, error) {
 	meta := dsws.Meta
 	if meta == nil {
 		meta = &datas.WorkingSetMeta{
@@ -606,6 +633,19 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 			}
 		}
 
+		var baseCommit *Commit
+		if baseDCommit, err := dsws.MergeState.MergeBaseCommit(ctx, vrw); err != nil {
+			return nil, err
+		} else if baseDCommit != nil {
+			if baseDCommit.IsGhost() {
+				return nil, ErrGhostCommitEncountered
+			}
+			baseCommit, err = NewCommit(ctx, vrw, ns, baseDCommit)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		mergeState = &MergeState{
 			commit:                  commit,
 			commitSpecStr:           commitSpec,
@@ -615,6 +655,7 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 			isRevert:                isRevert,
 			preMergeHeadCommit:      preMergeHeadCommit,
 			pendingCommitsToProcess: dsws.MergeState.PendingRevertHashes(),
+			baseCommit:              baseCommit,
 		}
 	}
 
@@ -657,8 +698,6 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		}
 	}
 
-	addr, _ := ds.MaybeHeadAddr()
-
 	return &WorkingSet{
 		Name:        name,
 		meta:        meta,
@@ -741,8 +780,20 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 			}
 		}
 
+		var baseDCommit *datas.Commit
+		if ws.mergeState.baseCommit != nil {
+			baseH, err := ws.mergeState.baseCommit.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			baseDCommit, err = datas.LoadCommitAddr(ctx, db.vrw, baseH)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// TODO: Serialize the full TableName
-		mergeState, err = datas.NewMergeState(preMergeWorking, dCommit, ws.mergeState.commitSpecStr, FlattenTableNames(ws.mergeState.unmergableTables), ws.mergeState.isCherryPick, ws.mergeState.isRevert, headDCommit, ws.mergeState.pendingCommitsToProcess)
+		mergeState, err = datas.NewMergeState(preMergeWorking, dCommit, ws.mergeState.commitSpecStr, FlattenTableNames(ws.mergeState.unmergableTables), ws.mergeState.isCherryPick, ws.mergeState.isRevert, headDCommit, ws.mergeState.pendingCommitsToProcess, baseDCommit)
 		if err != nil {
 			return nil, err
 		}
@@ -78,7 +78,7 @@ func RenameBranch[C doltdb.Context](ctx C, dbData env.DbData[C], oldBranch, newB
 
 	// todo: update default branch variable
 
-	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true, AllowDeletingCurrentBranch: true}, rsc)
+	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true, AllowDeletingCurrentBranch: true, Renamed: true}, rsc)
 }
 
 func CopyBranch(ctx context.Context, dEnv *env.DoltEnv, oldBranch, newBranch string, force bool) error {
@@ -141,6 +141,8 @@ type DeleteOptions struct {
 	Force                      bool
 	Remote                     bool
 	AllowDeletingCurrentBranch bool
+	// Renamed is set when the branch is deleted because it was renamed, in which case it isn't kept as a deleted ref
+	Renamed bool
 }
 
 func DeleteBranch[C doltdb.Context](ctx C, dbData env.DbData[C], brName string, opts DeleteOptions, rsc *doltdb.ReplicationStatusController) error {
@@ -193,6 +195,9 @@ func DeleteBranchOnDB[C doltdb.Context](ctx C, dbdata env.DbData[C], branchRef r
 		}
 	}
 
+	if opts.Renamed {
+		return ddb.DeleteRenamedBranch(ctx, branchRef, rsc)
+	}
 	return ddb.DeleteBranch(ctx, branchRef, rsc)
 }
 
@@ -282,6 +287,18 @@ func CreateBranchWithStartPt[C doltdb.Context](ctx C, dbData env.DbData[C], newB
 	return nil
 }
 
+// RestoreBranch restores the deleted branch |brName| as it was when it was most recently deleted.
+func RestoreBranch[C doltdb.Context](ctx C, dbData env.DbData[C], brName string, rsc *doltdb.ReplicationStatusController) error {
+	err := dbData.Ddb.RestoreDeletedRef(ctx, ref.NewBranchRef(brName), rsc)
+	if existsErr := BranchExistsError(err); existsErr != nil {
+		return existsErr
+	} else if err != nil {
+		return err
+	}
+
+	return branch_control.AddAdminForContext(ctx, brName)
+}
+
 func CreateBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, newBranch, startingPoint string, force bool, headRef ref.DoltRef, rsc *doltdb.ReplicationStatusController) error {
 	branchRef := ref.NewBranchRef(newBranch)
 	hasRef, err := ddb.HasRef(ctx, branchRef)
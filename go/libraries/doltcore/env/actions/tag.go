@@ -77,6 +77,17 @@ func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint
 	return ddb.NewTagAtCommit(ctx, tagRef, cm, meta)
 }
 
+// RestoreTagsOnDB restores the deleted tags |tagNames| as they were when they were most recently deleted.
+func RestoreTagsOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagNames ...string) error {
+	for _, tagName := range tagNames {
+		err := ddb.RestoreDeletedRef(ctx, ref.NewTagRef(tagName), nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func DeleteTagsOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagNames ...string) error {
 	for _, tn := range tagNames {
 		dref := ref.NewTagRef(tn)
@@ -265,6 +265,9 @@ func LoadDoltDB(ctx context.Context, dEnv *DoltEnv) {
 			}
 		}
 		ddb, dbLoadErr := doltdb.LoadDoltDBWithParams(ctx, types.Format_DOLT, dEnv.urlStr, dEnv.FS, params)
+		if dbLoadErr == nil {
+			ddb.RecordOperations()
+		}
 		dEnv.doltDB = ddb
 		dEnv.DBLoadError = dbLoadErr
 
@@ -43,6 +43,8 @@ type MergeSpec struct {
 	HeadC           *doltdb.Commit
 	MergeC          *doltdb.Commit
 	MergeCSpecStr   string
+	BaseC           *doltdb.Commit
+	BaseCSpecStr    string
 	StompedTblNames []doltdb.TableName
 	WorkingDiffs    map[doltdb.TableName]hash.Hash
 	Squash          bool
@@ -50,6 +52,8 @@ type MergeSpec struct {
 	NoCommit        bool
 	NoEdit          bool
 	Force           bool
+	AllowUnrelated  bool
+	DetectRenames   bool
 	Email           string
 	Name            string
 	Date            *datas.CommitDate
@@ -87,6 +91,23 @@ func WithSquash(squash bool) MergeSpecOpt {
 	}
 }
 
+// WithAllowUnrelatedHistories allows merging a commit that has no common ancestor with HEAD. The commit
+// |baseSpecStr| is used as their ancestor if it's not empty, otherwise an empty root is.
+func WithAllowUnrelatedHistories(allow bool, baseSpecStr string) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.AllowUnrelated = allow
+		ms.BaseCSpecStr = baseSpecStr
+	}
+}
+
+// WithDetectRenames detects columns that a side dropped and re-added under a new name by their values, and merges them
+// as renames, see MergeOpts.DetectRenames.
+func WithDetectRenames(detect bool) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.DetectRenames = detect
+	}
+}
+
 // NewMergeSpec returns a MergeSpec with the arguments provided. Pass |date| as nil when --date
 // was not explicitly specified; the merge commit will then derive the author date from the
 // dolt_author_date session variable.
@@ -166,6 +187,20 @@ func NewMergeSpec[C doltdb.Context](
 		opt(spec)
 	}
 
+	if spec.BaseCSpecStr != "" {
+		baseCS, err := doltdb.NewCommitSpec(spec.BaseCSpecStr)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err = ddb.Resolve(ctx, baseCS, headRef)
+		if err != nil {
+			return nil, err
+		}
+		if spec.BaseC, ok = optCmt.ToCommit(); !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+	}
+
 	return spec, nil
 }
 
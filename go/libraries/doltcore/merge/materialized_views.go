@@ -0,0 +1,45 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func materializedViewsTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMaterializedViewsTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMaterializedViewsTableName()}
+}
+
+// loadMaterializedViews returns the lowercase names of the materialized views defined in the dolt_materialized_views
+// table of |root|. The rows of their tables aren't merged: our side is kept, and the views are refreshed from the
+// merged tables they select from when the merge is committed.
+func loadMaterializedViews(ctx context.Context, root doltdb.RootValue) (map[string]struct{}, error) {
+	rows, err := readSystemTableRows(ctx, root, materializedViewsTableName(), 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	views := make(map[string]struct{}, len(rows))
+	for _, fields := range rows {
+		views[strings.ToLower(fields[0])] = struct{}{}
+	}
+	return views, nil
+}
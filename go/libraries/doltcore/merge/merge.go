@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	goerrors "gopkg.in/src-d/go-errors.v1"
@@ -53,7 +54,9 @@ const (
 	ConflictDiffTypeRemoved  = "removed"
 )
 
-func MergeCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+// MergeCommits merges |mergeCommit| into |commit| from their common ancestor. Column renames are detected by value
+// when |detectRenames| is set, see MergeOpts.DetectRenames.
+func MergeCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit, mergeCommit *doltdb.Commit, opts editor.Options, detectRenames bool) (*Result, error) {
 	optCmt, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 	if err != nil {
 		return nil, err
@@ -82,6 +85,7 @@ func MergeCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit,
 	mo := MergeOpts{
 		IsCherryPick:        false,
 		KeepSchemaConflicts: true,
+		DetectRenames:       detectRenames,
 	}
 	return MergeRoots(ctx, tableResolver, ourRoot, theirRoot, ancRoot, mergeCommit, ancCommit, opts, mo)
 }
@@ -236,6 +240,11 @@ func MergeRoots(
 		return nil, err
 	}
 
+	materializedViews, err := loadMaterializedViews(ctx, ourRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	// visitedTables holds all tables that were added, removed, or modified (basically not "unmodified")
 	visitedTables := make(map[string]struct{})
 	var schConflicts []SchemaConflict
@@ -263,6 +272,13 @@ func MergeRoots(
 		} else if err != nil {
 			return nil, err
 		}
+		if _, ok := materializedViews[strings.ToLower(tblName.Name)]; ok && mergedTable.table != nil {
+			if ours, ok, err := ourRoot.GetTable(ctx, tblName); err != nil {
+				return nil, err
+			} else if ok {
+				mergedTable, stats = &MergedResult{table: ours}, &MergeStats{Operation: TableUnmodified}
+			}
+		}
 		// If this table was visited during the merge, then we'll add it to the set
 		if stats.Operation != TableUnmodified {
 			visitedTables[tblName.Name] = struct{}{}
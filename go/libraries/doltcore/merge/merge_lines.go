@@ -0,0 +1,289 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// maxLineDiffEdits is the number of line edits after which the lines between the first and last change of a side are
+// diffed as a single hunk, rather than searching for the lines they share.
+const maxLineDiffEdits = 2000
+
+// mergeLines merges the changes both sides made to the lines of a text or blob cell, for the LinesStrategy of
+// dolt_merge_strategies. The cell is read from the rows of each side by the column index of that side. When both
+// sides changed the same lines, the cell conflicts, and the row is recorded as a conflict with our row left in place.
+func (m *valueMerger) mergeLines(ctx *sql.Context, sqlType sql.Type, base, left, right val.Tuple, baseIdx, leftIdx, rightIdx int) (interface{}, bool, error) {
+	if !types.IsText(sqlType) {
+		return nil, true, nil
+	}
+	baseCell, err := m.adaptiveCell(ctx, sqlType, m.baseVD, baseIdx, base)
+	if err != nil {
+		return nil, true, err
+	}
+	leftCell, err := m.adaptiveCell(ctx, sqlType, m.leftVD, leftIdx, left)
+	if err != nil {
+		return nil, true, err
+	}
+	rightCell, err := m.adaptiveCell(ctx, sqlType, m.rightVD, rightIdx, right)
+	if err != nil {
+		return nil, true, err
+	}
+	if baseCell.IsNull() || leftCell.IsNull() || rightCell.IsNull() {
+		return nil, true, nil
+	}
+
+	baseText, _, err := tree.DivergentBlobSuffixes(ctx, m.ns, baseCell, nil)
+	if err != nil {
+		return nil, true, err
+	}
+	// Only the chunks of each side after the ones it shares with the base are read
+	leftText, err := m.textFromBase(ctx, baseText, baseCell, leftCell)
+	if err != nil {
+		return nil, true, err
+	}
+	rightText, err := m.textFromBase(ctx, baseText, baseCell, rightCell)
+	if err != nil {
+		return nil, true, err
+	}
+
+	merged, ok := mergeTextLines(baseText, leftText, rightText)
+	if !ok {
+		return nil, true, nil
+	}
+	if types.IsBinaryType(sqlType) {
+		return merged, false, nil
+	}
+	return string(merged), false, nil
+}
+
+// adaptiveCell returns column |idx| of |tup| as an adaptive value, so that it can be diffed by its chunks. Cells
+// stored in another encoding are returned inline.
+func (m *valueMerger) adaptiveCell(ctx *sql.Context, sqlType sql.Type, vd *val.TupleDesc, idx int, tup val.Tuple) (val.AdaptiveValue, error) {
+	if idx == -1 {
+		return nil, nil
+	}
+	if enc := vd.Types[idx].Enc; enc == val.StringAdaptiveEnc || enc == val.BytesAdaptiveEnc {
+		return val.AdaptiveValue(vd.GetField(idx, tup)), nil
+	}
+	v, err := convert(ctx, vd, sqlType, idx, tup, m.ns)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	v, err = sql.UnwrapAny(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case string:
+		return val.AdaptiveValueInlineBytes([]byte(v)), nil
+	case []byte:
+		return val.AdaptiveValueInlineBytes(v), nil
+	default:
+		return nil, fmt.Errorf("unexpected type for a line merge: %T", v)
+	}
+}
+
+// textFromBase returns the bytes of |cell|, reading only the chunks that follow the ones it shares with |baseCell|.
+// The shared bytes are taken from |baseText|, the bytes of |baseCell|.
+func (m *valueMerger) textFromBase(ctx *sql.Context, baseText []byte, baseCell, cell val.AdaptiveValue) ([]byte, error) {
+	baseSuffix, suffix, err := tree.DivergentBlobSuffixes(ctx, m.ns, baseCell, cell)
+	if err != nil {
+		return nil, err
+	}
+	shared := baseText[:len(baseText)-len(baseSuffix)]
+	return append(append([]byte(nil), shared...), suffix...), nil
+}
+
+// lineHunk is a change to a range of lines of the base text.
+type lineHunk struct {
+	// start and end are the range of base lines replaced by the hunk
+	start, end int
+	lines      [][]byte
+}
+
+// mergeTextLines performs a three-way merge of the lines of |left| and |right|, the way a version control system
+// merges files. Hunks changed on only one side are applied. Returns false if both sides changed hunks that overlap or
+// are adjacent, unless both made the same change.
+func mergeTextLines(base, left, right []byte) ([]byte, bool) {
+	baseLines := splitLines(base)
+	hunks := [2][]lineHunk{
+		diffLines(baseLines, splitLines(left)),
+		diffLines(baseLines, splitLines(right)),
+	}
+
+	var merged []byte
+	pos := 0
+	next := [2]int{}
+	for next[0] < len(hunks[0]) || next[1] < len(hunks[1]) {
+		// A group starts at the first remaining hunk of either side, and takes in the hunks that touch it
+		first := 0
+		if next[0] == len(hunks[0]) || (next[1] < len(hunks[1]) && hunks[1][next[1]].start < hunks[0][next[0]].start) {
+			first = 1
+		}
+		start, end := hunks[first][next[first]].start, hunks[first][next[first]].end
+		var group [2][]lineHunk
+		for extended := true; extended; {
+			extended = false
+			for side := range hunks {
+				for next[side] < len(hunks[side]) && hunks[side][next[side]].start <= end {
+					h := hunks[side][next[side]]
+					group[side] = append(group[side], h)
+					end = max(end, h.end)
+					next[side]++
+					extended = true
+				}
+			}
+		}
+
+		merged = appendLines(merged, baseLines[pos:start])
+		ours := applyHunks(baseLines, start, end, group[0])
+		theirs := applyHunks(baseLines, start, end, group[1])
+		switch {
+		case len(group[1]) == 0:
+			merged = append(merged, ours...)
+		case len(group[0]) == 0, bytes.Equal(ours, theirs):
+			merged = append(merged, theirs...)
+		default:
+			return nil, false
+		}
+		pos = end
+	}
+	return appendLines(merged, baseLines[pos:]), true
+}
+
+// applyHunks returns the base lines from |start| to |end| with |hunks|, which are within that range, applied.
+func applyHunks(baseLines [][]byte, start, end int, hunks []lineHunk) []byte {
+	var text []byte
+	for _, h := range hunks {
+		text = appendLines(text, baseLines[start:h.start])
+		text = appendLines(text, h.lines)
+		start = h.end
+	}
+	return appendLines(text, baseLines[start:end])
+}
+
+func appendLines(text []byte, lines [][]byte) []byte {
+	for _, l := range lines {
+		text = append(text, l...)
+	}
+	return text
+}
+
+// splitLines splits |text| into lines, each including its newline.
+func splitLines(text []byte) [][]byte {
+	var lines [][]byte
+	for len(text) > 0 {
+		i := bytes.IndexByte(text, '\n')
+		if i == -1 {
+			return append(lines, text)
+		}
+		lines = append(lines, text[:i+1])
+		text = text[i+1:]
+	}
+	return lines
+}
+
+// diffLines returns the hunks that change |base| into |other|, in order.
+func diffLines(base, other [][]byte) []lineHunk {
+	prefix := 0
+	for prefix < len(base) && prefix < len(other) && bytes.Equal(base[prefix], other[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(other)-prefix && bytes.Equal(base[len(base)-1-suffix], other[len(other)-1-suffix]) {
+		suffix++
+	}
+	a, b := base[prefix:len(base)-suffix], other[prefix:len(other)-suffix]
+
+	var hunks []lineHunk
+	i, j := 0, 0
+	for _, match := range append(matchLines(a, b), [2]int{len(a), len(b)}) {
+		if match[0] > i || match[1] > j {
+			hunks = append(hunks, lineHunk{start: prefix + i, end: prefix + match[0], lines: b[j:match[1]]})
+		}
+		i, j = match[0]+1, match[1]+1
+	}
+	return hunks
+}
+
+// matchLines returns the pairs of indexes of the lines of |a| and |b| in their longest common subsequence, found
+// with Myers' diff algorithm. If the lines differ by more than maxLineDiffEdits, no lines are matched.
+func matchLines(a, b [][]byte) [][2]int {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return nil
+	}
+	// v holds the furthest x reached on each diagonal k = x - y, offset by maxD+1. The diagonals read by step d,
+	// -d-1 through d+1, are kept in trace[d] to walk the path back from the end.
+	maxD := min(n+m, maxLineDiffEdits)
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v[maxD-d:maxD+d+3]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k+maxD] < v[k+maxD+2]) {
+				x = v[k+maxD+2]
+			} else {
+				x = v[k+maxD] + 1
+			}
+			y := x - k
+			for x < n && y < m && bytes.Equal(a[x], b[y]) {
+				x, y = x+1, y+1
+			}
+			v[k+maxD+1] = x
+			if x >= n && y >= m {
+				return backtrackMatches(trace, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrackMatches walks the path found by matchLines back from (|n|, |m|), returning the matched lines in order.
+func backtrackMatches(trace [][]int, n, m int) [][2]int {
+	var matches [][2]int
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		// trace[d] starts at diagonal -d-1
+		v, k := trace[d], x-y
+		furthest := func(k int) int { return v[k+d+1] }
+		var prevK int
+		if k == -d || (k != d && furthest(k-1) < furthest(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := furthest(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY && x > 0 && y > 0 {
+			x, y = x-1, y-1
+			matches = append(matches, [2]int{x, y})
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches
+}
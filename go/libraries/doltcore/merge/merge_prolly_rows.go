@@ -265,6 +265,14 @@ func computeProllyTreePatches(
 		!needsSchemaMigration &&
 		!diffInfo.RightSchemaChange &&
 		!diffInfo.LeftSchemaChange
+	if canFastMergeProllyTrees {
+		// merging the trees takes rows changed the same way on both sides once, which the sum strategy adds up instead
+		needsSum, err := valueMerger.changesSums(ctx, ancRows, leftRows)
+		if err != nil {
+			return nil, nil, err
+		}
+		canFastMergeProllyTrees = !needsSum
+	}
 	if canFastMergeProllyTrees {
 		lDiff, err := tree.PatchGeneratorFromRoots(ctx, ns, ns, ancRows.Node(), leftRows.Node(), leftRows.Tuples().Order)
 		if err != nil {
@@ -425,7 +433,28 @@ func computeProllyTreePatches(
 					return nil, nil, err
 				}
 			case tree.DiffOpConvergentAdd, tree.DiffOpConvergentModify, tree.DiffOpConvergentDelete:
-				// In this case, both sides of the merge have made the same change, so no additional changes are needed.
+				// In this case, both sides of the merge have made the same change, so no additional changes are needed,
+				// unless a merge strategy adds up the changes of both sides. Rows added the same way on both sides are
+				// taken once.
+				if diff.Op == tree.DiffOpConvergentModify && !keyless && valueMerger.hasSumStrategy() {
+					merged, ok, err := valueMerger.TryMerge(ctx, diff.Left, diff.Left, diff.Base)
+					if err != nil {
+						return nil, nil, err
+					}
+					if ok && !bytes.Equal(merged, diff.Left) {
+						s.Modifications++
+						diff.Op, diff.Right, diff.Merged = tree.DiffOpDivergentModifyResolved, diff.Left, merged
+						err = pri.merge(ctx, diff, nil)
+						if err != nil {
+							return nil, nil, err
+						}
+						err = sec.merge(ctx, diff, tm.leftSch, tm.rightSch, tm, finalSch)
+						if err != nil {
+							return nil, nil, err
+						}
+					}
+					continue
+				}
 				if keyless {
 					s.DataConflicts++
 					err = conflicts.merge(ctx, diff, nil)
@@ -1909,6 +1938,7 @@ type valueMerger struct {
 	keyless                                bool
 	ns                                     tree.NodeStore
 	valueBuilder                           *val.TupleBuilder
+	strategies                             columnStrategies
 }
 
 func NewValueMerger(ctx context.Context, merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -2190,6 +2220,11 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return nil, false, err
 		}
 
+		// equal inserts are still added up by the sum strategy
+		if s, ok := m.strategyForColumn(resultColumn); ok && s.strategy == SumStrategy && !generatedColumn {
+			return m.resolveWithStrategy(ctx, s, sqlType, left, right, nil, leftVal, rightVal)
+		}
+
 		if cmp == 0 {
 			// Columns are equal, returning either would be correct.
 			// However, for certain types the two columns may have different bytes.
@@ -2206,6 +2241,10 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftVal, false, err
 		}
 
+		if s, ok := m.strategyForColumn(resultColumn); ok {
+			return m.resolveWithStrategy(ctx, s, sqlType, left, right, nil, leftVal, rightVal)
+		}
+
 		// conflicting inserts
 		return nil, true, nil
 	}
@@ -2259,6 +2298,19 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		return nil, true, err
 	}
 
+	leftCmp, err := sqlType.Compare(ctx, leftVal, baseVal)
+	if err != nil {
+		return nil, true, err
+	}
+	leftModified = leftCmp != 0
+
+	// the sum strategy applies both changes even when they're equal, so it comes before the check for equal values
+	if leftModified && rightModified && !generatedColumn {
+		if s, ok := m.strategyForColumn(resultColumn); ok && s.strategy == SumStrategy {
+			return m.resolveWithStrategy(ctx, s, sqlType, left, right, baseVal, leftVal, rightVal)
+		}
+	}
+
 	cmp, err := sqlType.Compare(ctx, leftVal, rightVal)
 	if err != nil {
 		return nil, true, err
@@ -2274,12 +2326,6 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		return rightVal, false, nil
 	}
 
-	leftCmp, err := sqlType.Compare(ctx, leftVal, baseVal)
-	if err != nil {
-		return nil, true, err
-	}
-	leftModified = leftCmp != 0
-
 	switch {
 	case leftModified && rightModified:
 		// generated columns will be updated as part of the merge later on, so choose either value for now
@@ -2287,6 +2333,13 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftVal, false, nil
 		}
 		// concurrent modification
+		// a rule in dolt_merge_strategies takes precedence over any other way of resolving it
+		if s, ok := m.strategyForColumn(resultColumn); ok {
+			if s.strategy == LinesStrategy {
+				return m.mergeLines(ctx, sqlType, base, left, right, baseColIdx, leftColIdx, rightColIdx)
+			}
+			return m.resolveWithStrategy(ctx, s, sqlType, left, right, baseVal, leftVal, rightVal)
+		}
 		// if the result type is JSON, we can attempt to merge the JSON changes.
 		dontMergeJsonVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_dont_merge_json")
 		if err != nil {
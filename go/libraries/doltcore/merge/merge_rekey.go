@@ -0,0 +1,481 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	errorkinds "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typecompatibility"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrMergeRekeyCollision is returned when the rows of one side of a merge can't be re-keyed under the primary key the
+// other side changed to, because two of them have the same new key.
+var ErrMergeRekeyCollision = errorkinds.NewKind("error: cannot merge because the primary key of table %s changed, and rows of the other side collide under the new key %s")
+
+// errNotRekeyable is returned by rekeyTable when the rows of a table can't be mapped onto the new primary key.
+var errNotRekeyable = errors.New("not rekeyable")
+
+// rekeyForPrimaryKeyChange handles a merge of a table whose primary key was changed on one side, by re-keying the rows
+// of the other side and the ancestor under the new primary key, so that the three can be diffed and merged row by
+// row. The mapping is derivable when every column of the new key exists in the rows being re-keyed or has a default
+// value, such as a column added to the key, a key column whose type was widened, or a reordered key. When both sides
+// changed the key differently, or the mapping isn't derivable, the tables are left as they are and the schema merge
+// reports the different primary keys.
+func (tm *TableMerger) rekeyForPrimaryKeyChange(ctx *sql.Context) error {
+	if tm.leftTbl == nil || tm.rightTbl == nil || tm.ancTbl == nil {
+		return nil
+	}
+	if schema.IsKeyless(tm.leftSch) || schema.IsKeyless(tm.rightSch) || schema.IsKeyless(tm.ancSch) {
+		return nil
+	}
+
+	ancLeft := schema.ArePrimaryKeySetsDiffable(tm.ancSch, tm.leftSch)
+	ancRight := schema.ArePrimaryKeySetsDiffable(tm.ancSch, tm.rightSch)
+	leftRight := schema.ArePrimaryKeySetsDiffable(tm.leftSch, tm.rightSch)
+	if ancLeft && leftRight {
+		return nil
+	}
+
+	var target schema.Schema
+	switch {
+	case ancLeft && !ancRight:
+		target = tm.rightSch
+	case ancRight && !ancLeft:
+		target = tm.leftSch
+	case leftRight:
+		target = tm.leftSch
+	default:
+		return nil
+	}
+
+	if !tm.canRewriteSources() {
+		return nil
+	}
+
+	left, leftSch, err := tm.rekeySide(ctx, tm.leftTbl, tm.leftSch, target)
+	if err != nil {
+		return handleNotRekeyable(err)
+	}
+	right, rightSch, err := tm.rekeySide(ctx, tm.rightTbl, tm.rightSch, target)
+	if err != nil {
+		return handleNotRekeyable(err)
+	}
+	anc, ancSch, err := tm.rekeySide(ctx, tm.ancTbl, tm.ancSch, target)
+	if err != nil {
+		return handleNotRekeyable(err)
+	}
+	tm.rightRewritten = tm.rightRewritten || right != tm.rightTbl
+	tm.ancRewritten = tm.ancRewritten || anc != tm.ancTbl
+	tm.leftTbl, tm.leftSch = left, leftSch
+	tm.rightTbl, tm.rightSch = right, rightSch
+	tm.ancTbl, tm.ancSch = anc, ancSch
+	return nil
+}
+
+// canRewriteSources returns whether the tables of the right side and the ancestor can be rewritten for the merge,
+// which requires both to have a source to record them in place of, see writeRewrittenSources.
+func (tm *TableMerger) canRewriteSources() bool {
+	return tm.rightSrc != nil && tm.ancestorSrc != nil
+}
+
+// writeRewrittenSources records the tables of the right side and the ancestor that were re-keyed, re-tagged or
+// aligned for the merge. Conflicts and violations reference the rows of their side and the ancestor through the
+// roots of their sources, so each rewritten root is written as a commit that isn't referenced by any ref, and
+// replaces its source. The artifacts of the merge reference the right side by an address that garbage collection
+// follows, but the ancestor only by the metadata of conflicts, so the ancestor's commit is a parent of the right
+// side's commit to keep it.
+func (tm *TableMerger) writeRewrittenSources(ctx context.Context) error {
+	if !tm.rightRewritten && !tm.ancRewritten {
+		return nil
+	}
+	meta, err := rewrittenSourceMeta(ctx, tm.rightSrc)
+	if err != nil {
+		return err
+	}
+
+	var parents []*doltdb.Commit
+	if right, ok := tm.rightSrc.(*doltdb.Commit); ok {
+		parents = append(parents, right)
+	}
+	if tm.ancRewritten {
+		root, err := tm.ancestorSrc.ResolveRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		if root, err = root.PutTable(ctx, tm.name, tm.ancTbl); err != nil {
+			return err
+		}
+		var ancParents []*doltdb.Commit
+		if anc, ok := tm.ancestorSrc.(*doltdb.Commit); ok {
+			ancParents = append(ancParents, anc)
+		}
+		anc, err := doltdb.NewDanglingCommit(ctx, root, ancParents, meta)
+		if err != nil {
+			return err
+		}
+		tm.ancestorSrc = anc
+		parents = append(parents, anc)
+	}
+
+	root, err := tm.rightSrc.ResolveRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	if tm.rightRewritten {
+		if root, err = root.PutTable(ctx, tm.name, tm.rightTbl); err != nil {
+			return err
+		}
+	}
+	tm.rightSrc, err = doltdb.NewDanglingCommit(ctx, root, parents, meta)
+	tm.rightRewritten, tm.ancRewritten = false, false
+	return err
+}
+
+// rewrittenSourceMeta returns the metadata of the commits that record rewritten tables for |src|, which is that of
+// |src| when it's a commit.
+func rewrittenSourceMeta(ctx context.Context, src doltdb.Rootish) (*datas.CommitMeta, error) {
+	if cm, ok := src.(*doltdb.Commit); ok {
+		return cm.GetCommitMeta(ctx)
+	}
+	return datas.NewCommitMeta("dolt", "dolt@localhost", "tables rewritten for a merge")
+}
+
+func handleNotRekeyable(err error) error {
+	if errors.Is(err, errNotRekeyable) {
+		return nil
+	}
+	return err
+}
+
+// rekeySide re-keys |tbl| under the primary key of |target|, unless it already has that key.
+func (tm *TableMerger) rekeySide(ctx *sql.Context, tbl *doltdb.Table, sch, target schema.Schema) (*doltdb.Table, schema.Schema, error) {
+	if schema.ArePrimaryKeySetsDiffable(sch, target) {
+		return tbl, sch, nil
+	}
+	return rekeyTable(ctx, tm.name, tbl, sch, tm.ancSch, target)
+}
+
+// rekeyTable rewrites the rows of |tbl| under the primary key of |target|. Key columns of |target| take their
+// definition from |target|, and are filled with their default value for rows that don't have them. A key column that
+// |sch| changed from its definition in |ancSch| can't be re-keyed, since that would discard the change.
+func rekeyTable(ctx *sql.Context, tblName doltdb.TableName, tbl *doltdb.Table, sch, ancSch, target schema.Schema) (*doltdb.Table, schema.Schema, error) {
+	for _, idx := range sch.Indexes().AllIndexes() {
+		if idx.IsFullText() || idx.IsVector() {
+			return nil, nil, errNotRekeyable
+		}
+	}
+
+	newSch, err := rekeyedSchema(sch, ancSch, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var defaults []sql.Expression
+	for _, col := range newSch.GetAllCols().GetColumns() {
+		expr := sql.Expression(nil)
+		if _, ok := sch.GetAllCols().GetByTag(col.Tag); !ok {
+			if expr, err = expranalysis.ResolveDefaultExpression(ctx, tblName.Name, newSch, col); err != nil {
+				return nil, nil, err
+			}
+		}
+		defaults = append(defaults, expr)
+	}
+
+	rows, err := rowsFromTable(ctx, tbl)
+	if err != nil {
+		return nil, nil, err
+	}
+	ns := rows.NodeStore()
+	empty, err := durable.NewEmptyPrimaryIndex(ctx, tbl.ValueReadWriter(), ns, newSch)
+	if err != nil {
+		return nil, nil, err
+	}
+	emptyRows, err := durable.ProllyMapFromIndex(empty)
+	if err != nil {
+		return nil, nil, err
+	}
+	mut := emptyRows.Mutate()
+
+	oldKd, oldVd := sch.GetMapDescriptors(ns)
+	kd, vd := newSch.GetMapDescriptors(ns)
+	kb, vb := val.NewTupleBuilder(kd, ns), val.NewTupleBuilder(vd, ns)
+	oldFields := storedFieldsByTag(sch)
+	allCols := newSch.GetAllCols().GetColumns()
+
+	iter, err := rows.IterAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		row := make(sql.Row, len(allCols))
+		for i, col := range allCols {
+			f, ok := oldFields[col.Tag]
+			if !ok {
+				continue
+			}
+			desc, tup := oldVd, v
+			if f.key {
+				desc, tup = oldKd, k
+			}
+			if row[i], err = tree.GetField(ctx, desc, f.idx, tup, ns); err != nil {
+				return nil, nil, err
+			}
+			oldCol, _ := sch.GetAllCols().GetByTag(col.Tag)
+			if row[i] != nil && !oldCol.TypeInfo.ToSqlType().Equals(col.TypeInfo.ToSqlType()) {
+				converted, inRange, err := col.TypeInfo.ToSqlType().Convert(ctx, row[i])
+				if err != nil || inRange != sql.InRange {
+					return nil, nil, errNotRekeyable
+				}
+				row[i] = converted
+			}
+		}
+		for i, expr := range defaults {
+			if expr != nil {
+				if row[i], err = expr.Eval(ctx, row); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		for i, tag := range newSch.GetPKCols().Tags {
+			idx := newSch.GetAllCols().TagToIdx[tag]
+			if row[idx] == nil {
+				return nil, nil, errNotRekeyable
+			}
+			if err = tree.PutField(ctx, ns, kb, i, row[idx]); err != nil {
+				return nil, nil, err
+			}
+		}
+		i := 0
+		for _, col := range newSch.GetNonPKCols().GetColumns() {
+			if col.Virtual {
+				continue
+			}
+			if err = tree.PutField(ctx, ns, vb, i, row[newSch.GetAllCols().TagToIdx[col.Tag]]); err != nil {
+				return nil, nil, err
+			}
+			i++
+		}
+		newKey, err := kb.Build(ctx, ns.Pool())
+		if err != nil {
+			return nil, nil, err
+		}
+		newVal, err := vb.Build(ctx, ns.Pool())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if ok, err := mut.Has(ctx, newKey); err != nil {
+			return nil, nil, err
+		} else if ok {
+			return nil, nil, ErrMergeRekeyCollision.New(tblName.Name, kd.Format(ctx, newKey))
+		}
+		if err = mut.Put(ctx, newKey, newVal); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	newRows, err := mut.Map(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tbl, err = tbl.UpdateSchema(ctx, newSch); err != nil {
+		return nil, nil, err
+	}
+	if tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(newRows)); err != nil {
+		return nil, nil, err
+	}
+	for _, idx := range newSch.Indexes().AllIndexes() {
+		idxRows, err := creation.BuildSecondaryProllyIndex(ctx, tbl.ValueReadWriter(), ns, newSch, tblName.Name, idx, newRows, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tbl, err = tbl.SetIndexRows(ctx, idx.Name(), idxRows); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tbl, newSch, nil
+}
+
+// rekeyedSchema returns |sch| with the primary key of |target|. Columns whose membership in the key changed take
+// their definition from |target|, and key columns of |target| that |sch| doesn't have are added after the column
+// they follow in |target|.
+func rekeyedSchema(sch, ancSch, target schema.Schema) (schema.Schema, error) {
+	targetCols := target.GetAllCols()
+	var cols []schema.Column
+	for _, col := range sch.GetAllCols().GetColumns() {
+		targetCol, ok := targetCols.GetByTag(col.Tag)
+		if !ok {
+			if col.IsPartOfPK {
+				col.IsPartOfPK = false
+			}
+			cols = append(cols, col)
+			continue
+		}
+		if targetCol.IsPartOfPK || col.IsPartOfPK {
+			if targetCol.IsPartOfPK && !col.Equals(targetCol) {
+				// a key column that this side changed itself can't take the other side's definition
+				ancCol, inAnc := ancSch.GetAllCols().GetByTag(col.Tag)
+				if !inAnc || !ancCol.Equals(col) {
+					return nil, errNotRekeyable
+				}
+			}
+			if !isWideningTypeChange(col.TypeInfo, targetCol.TypeInfo) {
+				return nil, errNotRekeyable
+			}
+			col = targetCol
+		}
+		cols = append(cols, col)
+	}
+
+	for i, targetCol := range targetCols.GetColumns() {
+		if !targetCol.IsPartOfPK {
+			continue
+		}
+		if _, ok := sch.GetAllCols().GetByTag(targetCol.Tag); ok {
+			continue
+		}
+		if targetCol.Default == "" || targetCol.Virtual || targetCol.Generated != "" {
+			return nil, errNotRekeyable
+		}
+		pos := 0
+		for j := i - 1; j >= 0; j-- {
+			if k := indexOfTag(cols, targetCols.GetByIndex(j).Tag); k >= 0 {
+				pos = k + 1
+				break
+			}
+		}
+		cols = append(cols[:pos], append([]schema.Column{targetCol}, cols[pos:]...)...)
+	}
+
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+	pkOrdinals := make([]int, 0, target.GetPKCols().Size())
+	for _, tag := range target.GetPKCols().Tags {
+		pkOrdinals = append(pkOrdinals, indexOfTag(cols, tag))
+	}
+	if err = newSch.SetPkOrdinals(pkOrdinals); err != nil {
+		return nil, err
+	}
+	newSch.SetCollation(sch.GetCollation())
+	newSch.Indexes().AddIndex(sch.Indexes().AllIndexes()...)
+	for _, chk := range sch.Checks().AllChecks() {
+		if _, err = newSch.Checks().AddCheck(chk.Name(), chk.Expression(), chk.Enforced(), chk.IsNotValid()); err != nil {
+			return nil, err
+		}
+	}
+	return newSch, nil
+}
+
+// isWideningTypeChange returns whether every value of |from| is also a value of |to|, such as for INT to BIGINT or
+// VARCHAR(10) to VARCHAR(20).
+func isWideningTypeChange(from, to typeinfo.TypeInfo) bool {
+	if typecompatibility.NewTypeCompatabilityChecker().IsTypeChangeCompatible(from, to).Compatible {
+		return true
+	}
+	fromBits, fromSigned, ok := integerBits(from.ToSqlType())
+	if !ok {
+		return false
+	}
+	toBits, toSigned, ok := integerBits(to.ToSqlType())
+	if !ok || (fromSigned && !toSigned) {
+		return false
+	}
+	if fromSigned == toSigned {
+		return toBits >= fromBits
+	}
+	return toBits > fromBits
+}
+
+func integerBits(t sql.Type) (bits int, signed bool, ok bool) {
+	switch t.Type() {
+	case sqltypes.Int8:
+		return 8, true, true
+	case sqltypes.Int16:
+		return 16, true, true
+	case sqltypes.Int24:
+		return 24, true, true
+	case sqltypes.Int32:
+		return 32, true, true
+	case sqltypes.Int64:
+		return 64, true, true
+	case sqltypes.Uint8:
+		return 8, false, true
+	case sqltypes.Uint16:
+		return 16, false, true
+	case sqltypes.Uint24:
+		return 24, false, true
+	case sqltypes.Uint32:
+		return 32, false, true
+	case sqltypes.Uint64:
+		return 64, false, true
+	}
+	return 0, false, false
+}
+
+func indexOfTag(cols []schema.Column, tag uint64) int {
+	for i, col := range cols {
+		if col.Tag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+type storedField struct {
+	key bool
+	idx int
+}
+
+// storedFieldsByTag returns where each stored column of |sch| is in its key and value tuples.
+func storedFieldsByTag(sch schema.Schema) map[uint64]storedField {
+	fields := make(map[uint64]storedField)
+	for i, tag := range sch.GetPKCols().Tags {
+		fields[tag] = storedField{key: true, idx: i}
+	}
+	i := 0
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		fields[col.Tag] = storedField{idx: i}
+		i++
+	}
+	return fields
+}
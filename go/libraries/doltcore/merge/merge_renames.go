@@ -0,0 +1,131 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// retagRenamedColumns handles columns that one side of the merge renamed under a new tag, such as when a table was
+// recreated by an import. Columns are merged by tag, so such a rename would otherwise merge as the ancestor column
+// being dropped and an unrelated column being added, losing the other side's changes to the column. With
+// MergeOpts.DetectRenames, the columns that diff.DetectColumnRenames matches by value are given the tag of their
+// ancestor column, so that they merge as any other rename.
+func (tm *TableMerger) retagRenamedColumns(ctx *sql.Context) error {
+	if !tm.detectRenames || tm.leftTbl == nil || tm.rightTbl == nil || tm.ancTbl == nil || !tm.canRewriteSources() {
+		return nil
+	}
+
+	left, leftSch, err := tm.retagRenames(ctx, tm.leftTbl, tm.leftSch)
+	if err != nil {
+		return err
+	}
+	right, rightSch, err := tm.retagRenames(ctx, tm.rightTbl, tm.rightSch)
+	if err != nil {
+		return err
+	}
+	tm.rightRewritten = tm.rightRewritten || right != tm.rightTbl
+	tm.leftTbl, tm.leftSch = left, leftSch
+	tm.rightTbl, tm.rightSch = right, rightSch
+	return nil
+}
+
+// retagRenames gives the columns of |tbl| that were renamed from a column of the ancestor with a different tag the
+// tag of the ancestor column. The rows of the table are unchanged, since they don't depend on the column tags.
+func (tm *TableMerger) retagRenames(ctx *sql.Context, tbl *doltdb.Table, sch schema.Schema) (*doltdb.Table, schema.Schema, error) {
+	renames, err := diff.DetectColumnRenames(ctx, tm.ancTbl, tbl, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	tags := make(map[uint64]uint64)
+	for _, rename := range renames {
+		if rename.IsTagMatch() {
+			continue
+		}
+		if _, ok := sch.GetAllCols().GetByTag(rename.From.Tag); ok {
+			continue
+		}
+		tags[rename.To.Tag] = rename.From.Tag
+	}
+	if len(tags) == 0 {
+		return tbl, sch, nil
+	}
+
+	newSch, err := retaggedSchema(sch, tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	tbl, err = tbl.UpdateSchema(ctx, newSch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tbl, newSch, nil
+}
+
+// retaggedSchema returns a copy of |sch| with the column tags that are keys of |tags| replaced by their values.
+func retaggedSchema(sch schema.Schema, tags map[uint64]uint64) (schema.Schema, error) {
+	retag := func(tag uint64) uint64 {
+		if newTag, ok := tags[tag]; ok {
+			return newTag
+		}
+		return tag
+	}
+
+	cols := sch.GetAllCols().GetColumns()
+	for i := range cols {
+		cols[i].Tag = retag(cols[i].Tag)
+	}
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+	if err = newSch.SetPkOrdinals(sch.GetPkOrdinals()); err != nil {
+		return nil, err
+	}
+	newSch.SetCollation(sch.GetCollation())
+	newSch.SetTargetRowSize(sch.GetTargetRowSize())
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		idxTags := make([]uint64, len(idx.IndexedColumnTags()))
+		for i, tag := range idx.IndexedColumnTags() {
+			idxTags[i] = retag(tag)
+		}
+		_, err = newSch.Indexes().AddIndexByColTags(idx.Name(), idxTags, idx.PrefixLengths(), schema.IndexProperties{
+			IsUnique:           idx.IsUnique(),
+			IsSpatial:          idx.IsSpatial(),
+			IsFullText:         idx.IsFullText(),
+			IsUserDefined:      idx.IsUserDefined(),
+			Comment:            idx.Comment(),
+			Predicate:          idx.Predicate(),
+			FullTextProperties: idx.FullTextProperties(),
+			IsVector:           idx.IsVector(),
+			VectorProperties:   idx.VectorProperties(),
+			IsColumnStore:      idx.IsColumnStore(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, chk := range sch.Checks().AllChecks() {
+		if _, err = newSch.Checks().AddCheck(chk.Name(), chk.Expression(), chk.Enforced(), chk.IsNotValid()); err != nil {
+			return nil, err
+		}
+	}
+	return newSch, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+func mergeResolversTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMergeResolversTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMergeResolversTableName()}
+}
+
+// LoadMergeResolvers reads the dolt_merge_resolvers table of |root|, returning the stored procedure registered for
+// each table, keyed by lowercase table name. Like dolt_merge_strategies, the resolvers of our side of a merge are the
+// ones used.
+func LoadMergeResolvers(ctx context.Context, root doltdb.RootValue) (map[string]string, error) {
+	rows, err := readSystemTableRows(ctx, root, mergeResolversTableName(), 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make(map[string]string, len(rows))
+	for _, fields := range rows {
+		if fields[1] != "" {
+			resolvers[strings.ToLower(fields[0])] = fields[1]
+		}
+	}
+	return resolvers, nil
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -55,6 +56,13 @@ type MergeOpts struct {
 	// dolt_verify_constraints() stored procedure to allow callers to verify constraints for a
 	// subset of tables.
 	RecordViolationsForTables map[doltdb.TableName]struct{}
+	// UnrelatedHistories is set when the roots being merged don't share history, so that tables both sides added
+	// are merged by primary key rather than rejected as added twice.
+	UnrelatedHistories bool
+	// DetectRenames is set to merge a column that a side dropped and re-added under a new name, such as when an
+	// import recreated the table, as a rename of the ancestor column when their values match, see
+	// retagRenamedColumns.
+	DetectRenames bool
 }
 
 type TableMerger struct {
@@ -75,6 +83,11 @@ type TableMerger struct {
 	rightSrc    doltdb.Rootish
 	ancestorSrc doltdb.Rootish
 
+	// rightRewritten and ancRewritten are set when the right or ancestor table was rewritten for the merge, and have
+	// to be recorded in place of their sources, see writeRewrittenSources.
+	rightRewritten bool
+	ancRewritten   bool
+
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
 
@@ -83,10 +96,18 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// detectRenames is set when columns re-added under a new name are matched to ancestor columns by their values.
+	detectRenames bool
+
+	// strategies are the dolt_merge_strategies rules for this table.
+	strategies columnStrategies
 }
 
 func (tm TableMerger) GetNewValueMerger(ctx context.Context, mergeSch schema.Schema, leftRows prolly.Map) *valueMerger {
-	return NewValueMerger(ctx, mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm := NewValueMerger(ctx, mergeSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), leftRows.NodeStore())
+	vm.strategies = tm.strategies
+	return vm
 }
 
 func rowsFromTable(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
@@ -148,7 +169,19 @@ func (tm TableMerger) tableHashes(ctx context.Context) (left, right, anc hash.Ha
 	return
 }
 
-func (tm TableMerger) SchemaMerge(ctx *sql.Context, tblName doltdb.TableName) (schema.Schema, SchemaConflict, MergeInfo, tree.ThreeWayDiffInfo, error) {
+// SchemaMerge merges the schemas of the table. Columns that a side renamed under a new tag are first given the tag of
+// their ancestor column, see retagRenamedColumns. When one side changed the primary key, the tables of the other side
+// and the ancestor are then re-keyed under the new primary key where possible, see rekeyForPrimaryKeyChange.
+func (tm *TableMerger) SchemaMerge(ctx *sql.Context, tblName doltdb.TableName) (schema.Schema, SchemaConflict, MergeInfo, tree.ThreeWayDiffInfo, error) {
+	if err := tm.retagRenamedColumns(ctx); err != nil {
+		return nil, SchemaConflict{}, MergeInfo{}, tree.ThreeWayDiffInfo{}, err
+	}
+	if err := tm.rekeyForPrimaryKeyChange(ctx); err != nil {
+		return nil, SchemaConflict{}, MergeInfo{}, tree.ThreeWayDiffInfo{}, err
+	}
+	if err := tm.writeRewrittenSources(ctx); err != nil {
+		return nil, SchemaConflict{}, MergeInfo{}, tree.ThreeWayDiffInfo{}, err
+	}
 	return SchemaMerge(ctx, tm.vrw.Format(), tm.leftSch, tm.rightSch, tm.ancSch, tblName)
 }
 
@@ -162,6 +195,9 @@ type RootMerger struct {
 
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
+
+	// strategies are the dolt_merge_strategies rules of |left|, loaded by the first table merged.
+	strategies tableStrategies
 }
 
 // NewMerger creates a new merger utility object.
@@ -274,6 +310,16 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
+	var err error
+	if rm.strategies == nil {
+		if rm.strategies, err = loadMergeStrategies(ctx, rm.left); err != nil {
+			return nil, err
+		}
+		if rm.strategies == nil {
+			rm.strategies = tableStrategies{}
+		}
+	}
+
 	tm := TableMerger{
 		name:             tblName,
 		rightSrc:         rm.rightSrc,
@@ -281,9 +327,10 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		vrw:              rm.vrw,
 		ns:               rm.ns,
 		recordViolations: recordViolations,
+		detectRenames:    mergeOpts.DetectRenames,
+		strategies:       rm.strategies[strings.ToLower(tblName.Name)],
 	}
 
-	var err error
 	var leftSideTableExists, rightSideTableExists, ancTableExists bool
 
 	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, tblName)
@@ -353,6 +400,10 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		if err != nil {
 			return nil, err
 		}
+	} else if mergeOpts.UnrelatedHistories && tm.leftTbl != nil && tm.rightTbl != nil {
+		if err = tm.alignUnrelatedTables(ctx); err != nil {
+			return nil, err
+		}
 	} else {
 		tm.ancRootObj, _, err = rm.anc.GetRootObject(ctx, tblName)
 		if err != nil {
@@ -360,6 +411,12 @@ func (rm *RootMerger) MakeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
+	if tm.leftSch != nil {
+		if err = tm.strategies.validate(tblName.Name, tm.leftSch); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: need to determine what to do if we have a mix of both tables and root objects (we'll error for now)
 	if tm.HasTable() && tm.HasRootObject() {
 		return nil, errors.New("Attempting to merge fundamentally different objects, which has not yet been implemented\n" +
@@ -0,0 +1,437 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeStrategy is a rule from dolt_merge_strategies for resolving a cell that was modified on both sides of a merge.
+type MergeStrategy string
+
+const (
+	// LastWriterWins takes the value from the side whose order column is greater, e.g. the newer updated_at.
+	LastWriterWins MergeStrategy = "last_writer_wins"
+	// MaxStrategy takes the greater of the two values.
+	MaxStrategy MergeStrategy = "max"
+	// MinStrategy takes the lesser of the two values.
+	MinStrategy MergeStrategy = "min"
+	// SumStrategy applies the changes of both sides to the base value, like an additive counter. A row inserted on
+	// both sides counts from zero, so the values inserted on each side are added up, unless the same row was inserted
+	// on both sides, which is taken once like any other change made the same way on both sides. Only numeric columns
+	// can be summed.
+	SumStrategy MergeStrategy = "sum"
+	// UnionStrategy merges two JSON arrays as sets, keeping the elements added on either side.
+	UnionStrategy MergeStrategy = "union"
+	// LinesStrategy merges the lines changed on either side of a text or blob value, like a merge of files. Changes
+	// to the same or adjacent lines conflict, leaving our row as it is.
+	LinesStrategy MergeStrategy = "lines"
+)
+
+// AllColumns is the column_name of a dolt_merge_strategies rule that applies to every column of a table without a
+// rule of its own.
+const AllColumns = "*"
+
+// MergeStrategies returns the strategies that can be used in dolt_merge_strategies.
+func MergeStrategies() []MergeStrategy {
+	return []MergeStrategy{LastWriterWins, MaxStrategy, MinStrategy, SumStrategy, UnionStrategy, LinesStrategy}
+}
+
+type columnStrategy struct {
+	strategy    MergeStrategy
+	orderColumn string
+}
+
+// columnStrategies are the dolt_merge_strategies rules of a single table, keyed by lowercase column name.
+type columnStrategies map[string]columnStrategy
+
+func (cs columnStrategies) forColumn(name string) (columnStrategy, bool) {
+	if s, ok := cs[strings.ToLower(name)]; ok {
+		return s, true
+	}
+	s, ok := cs[AllColumns]
+	return s, ok
+}
+
+// tableStrategies are all the dolt_merge_strategies rules of a root, keyed by lowercase table name.
+type tableStrategies map[string]columnStrategies
+
+func mergeStrategiesTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMergeStrategiesTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMergeStrategiesTableName()}
+}
+
+// loadMergeStrategies reads the dolt_merge_strategies table of |root|. The strategies of our side of a merge are the
+// ones used, the same way the rest of our working set is.
+func loadMergeStrategies(ctx context.Context, root doltdb.RootValue) (tableStrategies, error) {
+	rows, err := readSystemTableRows(ctx, root, mergeStrategiesTableName(), 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	return parseMergeStrategies(rows)
+}
+
+// parseMergeStrategies parses the |rows| of a dolt_merge_strategies table, as read by readSystemTableRows. Rows can
+// get past the checks of the table, e.g. when written by a version of Dolt that had other strategies, so each one is
+// checked again here.
+func parseMergeStrategies(rows [][]string) (tableStrategies, error) {
+	strategies := make(tableStrategies)
+	for _, fields := range rows {
+		table, column, strategy, orderColumn := strings.ToLower(fields[0]), strings.ToLower(fields[1]), MergeStrategy(strings.ToLower(fields[2])), fields[3]
+		if !slices.Contains(MergeStrategies(), strategy) {
+			return nil, fmt.Errorf("%s row ('%s', '%s'): unknown merge strategy '%s'", doltdb.GetMergeStrategiesTableName(), fields[0], fields[1], fields[2])
+		}
+		if strategy == LastWriterWins && orderColumn == "" {
+			return nil, fmt.Errorf("%s row ('%s', '%s'): merge strategy %s needs an order_column", doltdb.GetMergeStrategiesTableName(), fields[0], fields[1], strategy)
+		}
+		if strategies[table] == nil {
+			strategies[table] = make(columnStrategies)
+		}
+		strategies[table][column] = columnStrategy{strategy: strategy, orderColumn: orderColumn}
+	}
+	return strategies, nil
+}
+
+// validate returns an error naming the rule of these strategies, the rules of the table |table|, that can't apply to
+// the column it's for in |sch|. Only numeric columns can be summed.
+func (cs columnStrategies) validate(table string, sch schema.Schema) error {
+	for column, s := range cs {
+		if s.strategy != SumStrategy {
+			continue
+		}
+		var cols []schema.Column
+		if column == AllColumns {
+			for _, col := range sch.GetNonPKCols().GetColumns() {
+				if rule, ok := cs.forColumn(col.Name); ok && rule.strategy == SumStrategy {
+					cols = append(cols, col)
+				}
+			}
+		} else if col, ok := sch.GetNonPKCols().GetByNameCaseInsensitive(column); ok {
+			cols = append(cols, col)
+		}
+		for _, col := range cols {
+			if typ := col.TypeInfo.ToSqlType(); !types.IsNumber(typ) {
+				return fmt.Errorf("%s row ('%s', '%s'): merge strategy %s needs a numeric column, but %s.%s is %s",
+					doltdb.GetMergeStrategiesTableName(), table, column, s.strategy, table, col.Name, typ.String())
+			}
+		}
+	}
+	return nil
+}
+
+// readSystemTableRows reads every row of the user-space system table |name| of |root| as strings, key fields first.
+// NULL fields are read as the empty string. The table is expected to have |keyCount| key and |valCount| value fields.
+func readSystemTableRows(ctx context.Context, root doltdb.RootValue, name doltdb.TableName, keyCount, valCount int) ([][]string, error) {
+	tbl, ok, err := root.GetTable(ctx, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := durable.ProllyMapFromIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	kd, vd := sch.GetMapDescriptors(m.NodeStore())
+	if kd.Count() != keyCount || vd.Count() != valCount {
+		return nil, fmt.Errorf("%s had an unexpected schema", name.Name)
+	}
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]string
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		fields := make([]string, keyCount+valCount)
+		for i := range fields {
+			desc, tup, j := kd, k, i
+			if i >= keyCount {
+				desc, tup, j = vd, v, i-keyCount
+			}
+			f, err := tree.GetField(ctx, desc, j, tup, m.NodeStore())
+			if err != nil {
+				return nil, err
+			}
+			if f != nil {
+				str, _, err := types.LongText.Convert(ctx, f)
+				if err != nil {
+					return nil, err
+				}
+				fields[i], _ = str.(string)
+			}
+		}
+		rows = append(rows, fields)
+	}
+	return rows, nil
+}
+
+// resolveWithStrategy resolves a cell of column |i| of the merged schema that was modified on both sides, using the
+// dolt_merge_strategies rule |s|. |baseVal| is nil when the row was inserted on both sides. It returns a conflict
+// when the rule doesn't apply to the values.
+func (m *valueMerger) resolveWithStrategy(ctx *sql.Context, s columnStrategy, sqlType sql.Type, left, right val.Tuple, baseVal, leftVal, rightVal interface{}) (result interface{}, conflict bool, err error) {
+	switch s.strategy {
+	case LastWriterWins:
+		cmp, err := m.compareOrderColumn(ctx, s.orderColumn, left, right)
+		if err != nil || cmp == 0 {
+			return nil, true, err
+		} else if cmp > 0 {
+			return leftVal, false, nil
+		}
+		return rightVal, false, nil
+	case MaxStrategy, MinStrategy:
+		if leftVal == nil || rightVal == nil {
+			return nil, true, nil
+		}
+		cmp, err := sqlType.Compare(ctx, leftVal, rightVal)
+		if err != nil {
+			return nil, true, err
+		}
+		if (cmp > 0) == (s.strategy == MaxStrategy) {
+			return leftVal, false, nil
+		}
+		return rightVal, false, nil
+	case SumStrategy:
+		return sumDeltas(ctx, sqlType, baseVal, leftVal, rightVal)
+	case UnionStrategy:
+		return unionJSONArrays(ctx, sqlType, baseVal, leftVal, rightVal)
+	case LinesStrategy:
+		// cells with a base value are merged by mergeLines, without one there are no changes to merge
+		return nil, true, nil
+	default:
+		return nil, true, nil
+	}
+}
+
+// compareOrderColumn compares the values of the column named |name| in the left and right rows.
+func (m *valueMerger) compareOrderColumn(ctx *sql.Context, name string, left, right val.Tuple) (int, error) {
+	leftIdx := findNonPKColumnMappingByName(m.leftSchema, name)
+	rightIdx := findNonPKColumnMappingByName(m.rightSchema, name)
+	if leftIdx == -1 || rightIdx == -1 {
+		return 0, nil
+	}
+	sqlType := m.leftSchema.GetNonPKCols().GetByIndex(leftIdx).TypeInfo.ToSqlType()
+	leftVal, err := tree.GetField(ctx, m.leftVD, leftIdx, left, m.ns)
+	if err != nil {
+		return 0, err
+	}
+	rightVal, err := convert(ctx, m.rightVD, sqlType, rightIdx, right, m.ns)
+	if err != nil {
+		return 0, err
+	}
+	if leftVal == nil || rightVal == nil {
+		return 0, nil
+	}
+	return sqlType.Compare(ctx, leftVal, rightVal)
+}
+
+// sumDeltas adds the changes made to a numeric value on both sides to its base value. A missing base value counts
+// as zero.
+func sumDeltas(ctx *sql.Context, sqlType sql.Type, baseVal, leftVal, rightVal interface{}) (interface{}, bool, error) {
+	if !types.IsNumber(sqlType) || leftVal == nil || rightVal == nil {
+		return nil, true, nil
+	}
+	if baseVal == nil {
+		baseVal = 0
+	}
+	var vals [3]*apd.Decimal
+	for i, v := range []interface{}{baseVal, leftVal, rightVal} {
+		d, err := types.InternalDecimalType.ConvertToDecimal(v)
+		if err != nil {
+			return nil, true, err
+		}
+		vals[i] = d
+	}
+
+	sum := new(apd.Decimal)
+	if _, err := apd.BaseContext.Add(sum, vals[1], vals[2]); err != nil {
+		return nil, true, err
+	}
+	if _, err := apd.BaseContext.Sub(sum, sum, vals[0]); err != nil {
+		return nil, true, err
+	}
+	var result interface{} = sum
+	if types.IsFloat(sqlType) {
+		f, err := sum.Float64()
+		if err != nil {
+			return nil, true, err
+		}
+		result = f
+	}
+	result, inRange, err := sqlType.Convert(ctx, result)
+	if err != nil || inRange != sql.InRange {
+		// The sum doesn't fit in the column, so leave it for the user to resolve
+		return nil, true, nil
+	}
+	return result, false, nil
+}
+
+// unionJSONArrays merges two JSON arrays as sets: elements removed from the base on either side are removed, and
+// elements added on either side are kept, in the order they appear on the left side and then the right.
+func unionJSONArrays(ctx *sql.Context, sqlType sql.Type, baseVal, leftVal, rightVal interface{}) (interface{}, bool, error) {
+	if !types.IsJSON(sqlType) {
+		return nil, true, nil
+	}
+	var arrays [3]types.JsonArray
+	for i, v := range []interface{}{baseVal, leftVal, rightVal} {
+		if v == nil {
+			if i == 0 {
+				continue
+			}
+			return nil, true, nil
+		}
+		wrapper, ok := v.(sql.JSONWrapper)
+		if !ok {
+			return nil, true, nil
+		}
+		doc, err := wrapper.ToInterface(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		arr, ok := doc.(types.JsonArray)
+		if !ok {
+			return nil, true, nil
+		}
+		arrays[i] = arr
+	}
+	base, left, right := arrays[0], arrays[1], arrays[2]
+
+	contains := func(arr types.JsonArray, el interface{}) (bool, error) {
+		for _, v := range arr {
+			cmp, err := types.CompareJSON(ctx, v, el)
+			if err != nil {
+				return false, err
+			}
+			if cmp == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	merged := make(types.JsonArray, 0, len(left)+len(right))
+	for _, side := range []struct{ arr, other types.JsonArray }{{left, right}, {right, left}} {
+		for _, el := range side.arr {
+			inBase, err := contains(base, el)
+			if err != nil {
+				return nil, true, err
+			}
+			inOther, err := contains(side.other, el)
+			if err != nil {
+				return nil, true, err
+			}
+			if inBase && !inOther {
+				// removed by the other side
+				continue
+			}
+			seen, err := contains(merged, el)
+			if err != nil {
+				return nil, true, err
+			}
+			if !seen {
+				merged = append(merged, el)
+			}
+		}
+	}
+	return types.JSONDocument{Val: merged}, false, nil
+}
+
+// hasSumStrategy returns whether any column of the table is merged with the sum strategy.
+func (m *valueMerger) hasSumStrategy() bool {
+	for _, s := range m.strategies {
+		if s.strategy == SumStrategy {
+			return true
+		}
+	}
+	return false
+}
+
+// changesSums returns whether any row changed between |base| and |left| has a different value in a column merged with
+// the sum strategy. Only such rows can have a summed column changed the same way on both sides, which merging the trees
+// takes once instead of adding up. |base| and |left| must have the same schema, that of our side of the merge.
+func (m *valueMerger) changesSums(ctx context.Context, base, left prolly.Map) (bool, error) {
+	if !m.hasSumStrategy() {
+		return false, nil
+	}
+	var sumCols []int
+	for i, col := range m.leftSchema.GetNonPKCols().GetColumns() {
+		if s, ok := m.strategyForColumn(col); ok && s.strategy == SumStrategy {
+			sumCols = append(sumCols, i)
+		}
+	}
+	if len(sumCols) == 0 {
+		return false, nil
+	}
+
+	var changes bool
+	err := prolly.DiffMaps(ctx, base, left, false, func(ctx context.Context, diff tree.Diff) error {
+		if diff.Type != tree.ModifiedDiff {
+			// rows added the same way on both sides are taken once, and deleted rows have nothing to add up
+			return nil
+		}
+		from, to := val.Tuple(diff.From), val.Tuple(diff.To)
+		for _, i := range sumCols {
+			if !bytes.Equal(m.leftVD.GetField(i, from), m.leftVD.GetField(i, to)) {
+				changes = true
+				return io.EOF
+			}
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return changes, nil
+}
+
+// strategyForColumn returns the dolt_merge_strategies rule for |col|, if it has one.
+func (m *valueMerger) strategyForColumn(col schema.Column) (columnStrategy, bool) {
+	if m.strategies == nil {
+		return columnStrategy{}, false
+	}
+	return m.strategies.forColumn(col.Name)
+}
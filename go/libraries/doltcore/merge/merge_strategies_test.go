@@ -0,0 +1,154 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+)
+
+func TestSumDeltas(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	tests := []struct {
+		name              string
+		typ               sql.Type
+		base, left, right interface{}
+		expected          interface{}
+		conflict          bool
+	}{
+		{"ints", types.Int32, int32(100), int32(90), int32(120), int32(110), false},
+		{"no base", types.Int32, nil, int32(3), int32(4), int32(7), false},
+		{"unsigned below zero", types.Uint32, uint32(10), uint32(0), uint32(5), nil, true},
+		{"overflow", types.Int8, int8(0), int8(100), int8(100), nil, true},
+		{"floats", types.Float64, 1.5, 2.5, 1.0, 2.0, false},
+		{"null side", types.Int32, int32(1), nil, int32(2), nil, true},
+		{"not a number", types.Text, "a", "b", "c", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, conflict, err := sumDeltas(ctx, test.typ, test.base, test.left, test.right)
+			require.NoError(t, err)
+			assert.Equal(t, test.conflict, conflict)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestUnionJSONArrays(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	tests := []struct {
+		name              string
+		base, left, right interface{}
+		expected          string
+		conflict          bool
+	}{
+		{"adds on both sides", types.MustJSON(`[1]`), types.MustJSON(`[1, 2]`), types.MustJSON(`[1, 3]`), `[1, 2, 3]`, false},
+		{"removes on both sides", types.MustJSON(`[1, 2, 3]`), types.MustJSON(`[1, 3]`), types.MustJSON(`[1, 2]`), `[1]`, false},
+		{"no base", nil, types.MustJSON(`["a"]`), types.MustJSON(`["a", {"b": 1}]`), `["a", {"b": 1}]`, false},
+		{"not arrays", types.MustJSON(`{}`), types.MustJSON(`{"a": 1}`), types.MustJSON(`[1]`), "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, conflict, err := unionJSONArrays(ctx, types.JSON, test.base, test.left, test.right)
+			require.NoError(t, err)
+			require.Equal(t, test.conflict, conflict)
+			if !conflict {
+				cmp, err := types.CompareJSON(ctx, types.MustJSON(test.expected), res)
+				require.NoError(t, err)
+				assert.Zero(t, cmp, "%v", res)
+			}
+		})
+	}
+}
+
+func TestMergeTextLines(t *testing.T) {
+	base := "a\nb\nc\nd\ne\nf\n"
+	tests := []struct {
+		name              string
+		base, left, right string
+		expected          string
+		conflict          bool
+	}{
+		{"different lines", base, "A\nb\nc\nd\ne\nf\n", "a\nb\nc\nd\ne\nF\n", "A\nb\nc\nd\ne\nF\n", false},
+		{"insertions", base, "a\nb\nx\nc\nd\ne\nf\n", "a\nb\nc\nd\ne\nf\ny\n", "a\nb\nx\nc\nd\ne\nf\ny\n", false},
+		{"deletions", base, "a\nc\nd\ne\nf\n", "a\nb\nc\nd\nf\n", "a\nc\nd\nf\n", false},
+		{"same change", base, "a\nB\nc\nd\ne\nf\n", "a\nB\nc\nd\ne\nf\n", "a\nB\nc\nd\ne\nf\n", false},
+		{"same line", base, "a\nb\nleft\nd\ne\nf\n", "a\nb\nright\nd\ne\nf\n", "", true},
+		{"adjacent lines", base, "a\nB\nc\nd\ne\nf\n", "a\nb\nC\nd\ne\nf\n", "", true},
+		{"conflict and clean change", base, "A\nb\nleft\nd\ne\nf\n", "a\nb\nright\nd\ne\nF\n", "", true},
+		{"no trailing newline", "a\nb\nc", "A\nb\nc", "a\nb\nC", "A\nb\nC", false},
+		{"conflict without trailing newline", "a\nb", "a\nl", "a\nr", "", true},
+		{"empty base", "", "l\n", "r\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, ok := mergeTextLines([]byte(tt.base), []byte(tt.left), []byte(tt.right))
+			assert.Equal(t, tt.expected, string(merged))
+			assert.Equal(t, tt.conflict, !ok)
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	lines := func(s string) [][]byte { return splitLines([]byte(s)) }
+	base := lines("a\nb\nc\nd\ne\n")
+	hunks := diffLines(base, lines("a\nx\nc\ne\ny\n"))
+	require.Len(t, hunks, 3)
+	assert.Equal(t, lineHunk{start: 1, end: 2, lines: lines("x\n")}, hunks[0])
+	assert.Equal(t, lineHunk{start: 3, end: 4, lines: [][]byte{}}, hunks[1])
+	assert.Equal(t, lineHunk{start: 5, end: 5, lines: lines("y\n")}, hunks[2])
+	assert.Empty(t, diffLines(base, base))
+}
+
+func TestParseMergeStrategies(t *testing.T) {
+	strategies, err := parseMergeStrategies([][]string{
+		{"T", "C1", "SUM", ""},
+		{"t", "c2", "last_writer_wins", "updated_at"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tableStrategies{"t": columnStrategies{
+		"c1": {strategy: SumStrategy},
+		"c2": {strategy: LastWriterWins, orderColumn: "updated_at"},
+	}}, strategies)
+
+	_, err = parseMergeStrategies([][]string{{"t", "c", "average", ""}})
+	require.EqualError(t, err, "dolt_merge_strategies row ('t', 'c'): unknown merge strategy 'average'")
+	_, err = parseMergeStrategies([][]string{{"t", "c", "last_writer_wins", ""}})
+	require.EqualError(t, err, "dolt_merge_strategies row ('t', 'c'): merge strategy last_writer_wins needs an order_column")
+}
+
+func TestValidateMergeStrategies(t *testing.T) {
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, storetypes.IntKind, true),
+		schema.NewColumn("n", 1, storetypes.IntKind, false),
+		schema.NewColumn("label", 2, storetypes.StringKind, false),
+	))
+
+	require.NoError(t, columnStrategies{"n": {strategy: SumStrategy}}.validate("t", sch))
+	require.NoError(t, columnStrategies{"*": {strategy: SumStrategy}, "label": {strategy: MaxStrategy}}.validate("t", sch))
+	require.NoError(t, columnStrategies{"label": {strategy: MaxStrategy}}.validate("t", sch))
+
+	err := columnStrategies{"label": {strategy: SumStrategy}}.validate("t", sch)
+	require.ErrorContains(t, err, "dolt_merge_strategies row ('t', 'label'): merge strategy sum needs a numeric column")
+	err = columnStrategies{"*": {strategy: SumStrategy}}.validate("t", sch)
+	require.ErrorContains(t, err, "dolt_merge_strategies row ('t', '*'): merge strategy sum needs a numeric column, but t.label")
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// ErrUnrelatedHistories is returned when merging commits that have no common ancestor, unless the merge allows
+// unrelated histories.
+var ErrUnrelatedHistories = errors.New("refusing to merge unrelated histories; use --allow-unrelated-histories to merge them")
+
+// MergeUnrelatedCommits merges |mergeCommit| into |commit| when the two have no common ancestor, such as when they
+// come from databases that were created independently. The root of |base| is used as their ancestor if it's given,
+// otherwise an empty root is, so that every row that differs between the two is a conflict. Tables that both commits
+// added are aligned by primary key and column name, see alignUnrelatedTables. Column renames since |base| are
+// detected by value when |detectRenames| is set, see MergeOpts.DetectRenames.
+func MergeUnrelatedCommits(ctx *sql.Context, tableResolver doltdb.TableResolver, commit, mergeCommit, base *doltdb.Commit, opts editor.Options, detectRenames bool) (*Result, error) {
+	ourRoot, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	theirRoot, err := mergeCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ancRoot doltdb.RootValue
+	ancestor, theirs := base, mergeCommit
+	if base != nil {
+		if ancRoot, err = base.GetRootValue(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		if ancRoot, err = doltdb.EmptyRootValue(ctx, ourRoot.VRW(), ourRoot.NodeStore()); err != nil {
+			return nil, err
+		}
+		// Conflicts reference their ancestor by the hash of a commit, which is kept as a parent of the commit they
+		// reference their side by, see TableMerger.writeRewrittenSources
+		meta, err := mergeCommit.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ancestor, err = doltdb.NewDanglingCommit(ctx, ancRoot, []*doltdb.Commit{mergeCommit}, meta); err != nil {
+			return nil, err
+		}
+		if theirs, err = doltdb.NewDanglingCommit(ctx, theirRoot, []*doltdb.Commit{mergeCommit, ancestor}, meta); err != nil {
+			return nil, err
+		}
+	}
+
+	mo := MergeOpts{
+		IsCherryPick:        false,
+		KeepSchemaConflicts: true,
+		UnrelatedHistories:  true,
+		DetectRenames:       detectRenames,
+	}
+	return MergeRoots(ctx, tableResolver, ourRoot, theirRoot, ancRoot, theirs, ancestor, opts, mo)
+}
+
+// alignUnrelatedTables prepares the merge of a table that both sides of a merge of unrelated histories added, which
+// otherwise can't be merged unless both added it with the same schema. The right side is re-tagged and the ancestor is
+// an empty table, as given by AlignUnrelatedSchemas. Rows are then matched by their primary key, columns that only one
+// side has merge as added by that side, and columns that the sides define differently are schema conflicts. When the
+// sides have different primary keys, the tables are left as they are.
+func (tm *TableMerger) alignUnrelatedTables(ctx context.Context) error {
+	rightSch, ancSch, ok, err := AlignUnrelatedSchemas(tm.leftSch, tm.rightSch)
+	if err != nil || !ok || !tm.canRewriteSources() {
+		return err
+	}
+
+	// The table doesn't exist in the ancestor's root, so conflicts read it as empty without it being recorded
+	anc, err := doltdb.NewEmptyTable(ctx, tm.vrw, tm.ns, ancSch)
+	if err != nil {
+		return err
+	}
+	if !schema.SchemasAreEqual(rightSch, tm.rightSch) {
+		right, err := tm.rightTbl.UpdateSchema(ctx, rightSch)
+		if err != nil {
+			return err
+		}
+		tm.rightTbl, tm.rightSch = right, rightSch
+		tm.rightRewritten = true
+	}
+	tm.ancTbl, tm.ancSch = anc, ancSch
+	return nil
+}
+
+// AlignUnrelatedSchemas returns the schemas a merge of unrelated histories uses for a table that both sides added
+// with the schemas |left| and |right|. The columns of |right| are given the tag of the left column of the same name,
+// and the ancestor schema has the columns that both sides define the same way. Returns false if the sides have
+// different primary keys and can't be aligned.
+func AlignUnrelatedSchemas(left, right schema.Schema) (alignedRight, ancestor schema.Schema, ok bool, err error) {
+	leftCols, rightCols := left.GetAllCols(), right.GetAllCols()
+
+	tags := make(map[uint64]uint64)
+	err = rightCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		leftCol, ok := leftCols.GetByNameCaseInsensitive(col.Name)
+		if !ok || leftCol.Tag == tag {
+			return false, nil
+		}
+		if other, ok := rightCols.GetByTag(leftCol.Tag); ok && !strings.EqualFold(other.Name, col.Name) {
+			return false, nil
+		}
+		tags[tag] = leftCol.Tag
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	alignedRight = right
+	if len(tags) > 0 {
+		if alignedRight, err = retaggedSchema(right, tags); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if !sameUnrelatedPrimaryKey(left, alignedRight) {
+		return nil, nil, false, nil
+	}
+
+	var ancCols []schema.Column
+	ancTags := make(map[uint64]int)
+	for _, col := range leftCols.GetColumns() {
+		if rightCol, ok := alignedRight.GetAllCols().GetByTag(col.Tag); ok && col.Equals(rightCol) {
+			ancTags[col.Tag] = len(ancCols)
+			ancCols = append(ancCols, col)
+		}
+	}
+	ancestor, err = schema.SchemaFromCols(schema.NewColCollection(ancCols...))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	pkOrdinals := make([]int, 0, len(left.GetPkOrdinals()))
+	for _, col := range left.GetPKCols().GetColumns() {
+		pkOrdinals = append(pkOrdinals, ancTags[col.Tag])
+	}
+	if err = ancestor.SetPkOrdinals(pkOrdinals); err != nil {
+		return nil, nil, false, err
+	}
+	ancestor.SetCollation(left.GetCollation())
+	return alignedRight, ancestor, true, nil
+}
+
+// sameUnrelatedPrimaryKey returns whether |left| and |right| have the same primary key columns, in the same order and
+// with the same definitions.
+func sameUnrelatedPrimaryKey(left, right schema.Schema) bool {
+	leftPks, rightPks := left.GetPKCols().GetColumns(), right.GetPKCols().GetColumns()
+	if len(leftPks) == 0 || len(leftPks) != len(rightPks) {
+		return false
+	}
+	for i := range leftPks {
+		if !leftPks[i].Equals(rightPks[i]) {
+			return false
+		}
+	}
+	return true
+}
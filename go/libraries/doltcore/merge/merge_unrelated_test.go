@@ -0,0 +1,89 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func TestMergeUnrelatedHistories(t *testing.T) {
+	ctx := context.Background()
+	denv := dtestutils.CreateTestEnv()
+	ddb := denv.DoltDB(ctx)
+	var eo editor.Options
+
+	// the same table, imported with its columns in a different order, so that columns a and b have different tags
+	left := makeRootWithTable(t, ddb, eo, *tbl(sch("CREATE TABLE t (pk int PRIMARY KEY, a int, b int)"),
+		row(1, 1, 1), row(2, 2, 2), row(3, 3, 3)))
+	right := makeRootWithTable(t, ddb, eo, *tbl(sch("CREATE TABLE t (pk int PRIMARY KEY, b int, a int, c int)"),
+		row(1, 1, 1, 10), row(2, 20, 2, 20), row(4, 4, 4, 40)))
+	anc := makeEmptyRoot(t, ddb, eo)
+
+	t.Run("requires unrelated histories", func(t *testing.T) {
+		_, err := merge.MergeRoots(sql.NewContext(ctx), doltdb.SimpleTableResolver{}, left, right, anc, rootish{right}, rootish{anc}, eo, merge.MergeOpts{})
+		require.Error(t, err)
+		assert.True(t, merge.ErrSameTblAddedTwice.Is(err))
+	})
+
+	t.Run("aligns rows by primary key and columns by name", func(t *testing.T) {
+		mo := merge.MergeOpts{UnrelatedHistories: true}
+		result, err := merge.MergeRoots(sql.NewContext(ctx), doltdb.SimpleTableResolver{}, left, right, anc, rootish{right}, rootish{anc}, eo, mo)
+		require.NoError(t, err)
+		require.False(t, result.HasSchemaConflicts())
+
+		stats := result.Stats[doltdb.TableName{Name: "t"}]
+		require.NotNil(t, stats)
+		assert.Equal(t, 1, stats.DataConflicts)
+		assert.Equal(t, 1, stats.Adds)
+
+		merged, ok, err := result.Root.GetTable(ctx, doltdb.TableName{Name: "t"})
+		require.NoError(t, err)
+		require.True(t, ok)
+		mergedSch, err := merged.GetSchema(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pk", "a", "b", "c"}, columnNames(mergedSch))
+		cnt, err := merged.GetRowData(ctx)
+		require.NoError(t, err)
+		n, err := cnt.Count()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), n)
+	})
+
+	t.Run("different primary keys", func(t *testing.T) {
+		other := makeRootWithTable(t, ddb, eo, *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int, b int)")))
+		mo := merge.MergeOpts{UnrelatedHistories: true}
+		_, err := merge.MergeRoots(sql.NewContext(ctx), doltdb.SimpleTableResolver{}, left, other, anc, rootish{other}, rootish{anc}, eo, mo)
+		require.Error(t, err)
+	})
+}
+
+func columnNames(sch schema.Schema) []string {
+	var names []string
+	for _, col := range sch.GetAllCols().GetColumns() {
+		names = append(names, col.Name)
+	}
+	return names
+}
@@ -127,10 +127,32 @@ type MutableSecondaryIdx struct {
 	Name                       string
 	mut                        *prolly.MutableMap
 	leftBuilder, mergedBuilder index.SecondaryKeyBuilder
+	// leftColumns and mergedColumns build the entries of columnstore indexes, which are used in place of the key
+	// builders when |columnStore| is set
+	columnStore                bool
+	leftColumns, mergedColumns index.ColumnStoreBuilder
 }
 
 // NewMutableSecondaryIdx returns a MutableSecondaryIdx. |m| is the secondary idx data.
 func NewMutableSecondaryIdx(ctx *sql.Context, idx prolly.Map, ourSch, mergedSch schema.Schema, tableName string, def schema.Index) (MutableSecondaryIdx, error) {
+	if def.IsColumnStore() {
+		leftColumns, err := index.NewColumnStoreBuilder(ourSch, def, idx.NodeStore())
+		if err != nil {
+			return MutableSecondaryIdx{}, err
+		}
+		mergedColumns, err := index.NewColumnStoreBuilder(mergedSch, def, idx.NodeStore())
+		if err != nil {
+			return MutableSecondaryIdx{}, err
+		}
+		return MutableSecondaryIdx{
+			Name:          def.Name(),
+			mut:           idx.Mutate(),
+			columnStore:   true,
+			leftColumns:   leftColumns,
+			mergedColumns: mergedColumns,
+		}, nil
+	}
+
 	leftBuilder, err := index.NewSecondaryKeyBuilder(ctx, tableName, ourSch, def, idx.KeyDesc(), idx.Pool(), idx.NodeStore())
 	mergedBuilder, err := index.NewSecondaryKeyBuilder(ctx, tableName, mergedSch, def, idx.KeyDesc(), idx.Pool(), idx.NodeStore())
 	if err != nil {
@@ -148,6 +170,10 @@ func NewMutableSecondaryIdx(ctx *sql.Context, idx prolly.Map, ourSch, mergedSch
 // InsertEntry inserts a secondary index entry given the key and new value
 // of the primary row.
 func (m MutableSecondaryIdx) InsertEntry(ctx *sql.Context, key, newValue val.Tuple) error {
+	if m.columnStore {
+		return m.putColumnStoreEntries(ctx, key, newValue)
+	}
+
 	newKey, err := m.mergedBuilder.SecondaryKeyFromRow(ctx, key, newValue)
 	if err != nil {
 		return err
@@ -164,6 +190,11 @@ func (m MutableSecondaryIdx) InsertEntry(ctx *sql.Context, key, newValue val.Tup
 // UpdateEntry modifies the corresponding secondary index entry given the key
 // and curr/new values of the primary row.
 func (m MutableSecondaryIdx) UpdateEntry(ctx *sql.Context, key, currValue, newValue val.Tuple) error {
+	if m.columnStore {
+		// the entries of a row are keyed by its primary key, which doesn't change
+		return m.putColumnStoreEntries(ctx, key, newValue)
+	}
+
 	currKey, err := m.leftBuilder.SecondaryKeyFromRow(ctx, key, currValue)
 	if err != nil {
 		return err
@@ -183,6 +214,19 @@ func (m MutableSecondaryIdx) UpdateEntry(ctx *sql.Context, key, currValue, newVa
 
 // DeleteEntry deletes a secondary index entry given they key and value of the primary row.
 func (m MutableSecondaryIdx) DeleteEntry(ctx *sql.Context, key val.Tuple, value val.Tuple) error {
+	if m.columnStore {
+		for col := 0; col < m.leftColumns.Columns(); col++ {
+			k, err := m.leftColumns.KeyFromRow(ctx, col, key)
+			if err != nil {
+				return err
+			}
+			if err = m.mut.Delete(ctx, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	currKey, err := m.leftBuilder.SecondaryKeyFromRow(ctx, key, value)
 	if err != nil {
 		return err
@@ -191,6 +235,20 @@ func (m MutableSecondaryIdx) DeleteEntry(ctx *sql.Context, key val.Tuple, value
 	return m.mut.Delete(ctx, currKey)
 }
 
+// putColumnStoreEntries puts the entries of a columnstore index for each of its columns of the merged row |key|, |value|
+func (m MutableSecondaryIdx) putColumnStoreEntries(ctx *sql.Context, key, value val.Tuple) error {
+	for col := 0; col < m.mergedColumns.Columns(); col++ {
+		k, v, err := m.mergedColumns.EntryFromRow(ctx, col, key, value)
+		if err != nil {
+			return err
+		}
+		if err = m.mut.Put(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Map returns the finalized prolly.Map of the underlying prolly.MutableMap.
 func (m MutableSecondaryIdx) Map(ctx context.Context) (prolly.Map, error) {
 	return m.mut.Map(ctx)
@@ -0,0 +1,86 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeletedRef is a reference to a branch or tag that has been deleted, kept so that it can be restored. Its path is the
+// type and path of the deleted ref followed by the time it was deleted, in milliseconds since the epoch, so that every
+// deletion of a ref with the same name is kept, e.g. refs/deleted/heads/feature/1760000000000.
+type DeletedRef struct {
+	ref       DoltRef
+	deletedAt time.Time
+}
+
+var _ DoltRef = DeletedRef{}
+
+// NewDeletedRef returns the DeletedRef for |r| deleted at |deletedAt|. |r| must be a BranchRef or a TagRef.
+func NewDeletedRef(r DoltRef, deletedAt time.Time) DeletedRef {
+	if t := r.GetType(); t != BranchRefType && t != TagRefType {
+		panic("deleted refs can only be created for branches and tags, not " + t)
+	}
+	return DeletedRef{ref: r, deletedAt: time.UnixMilli(deletedAt.UnixMilli()).UTC()}
+}
+
+// NewDeletedRefFromPath parses the path of a DeletedRef, e.g. heads/feature/1760000000000.
+func NewDeletedRefFromPath(path string) (DeletedRef, error) {
+	typeEnd := strings.Index(path, "/")
+	timeStart := strings.LastIndex(path, "/")
+	if typeEnd == -1 || typeEnd == timeStart {
+		return DeletedRef{}, fmt.Errorf("invalid deleted ref path '%s'", path)
+	}
+
+	millis, err := strconv.ParseInt(path[timeStart+1:], 10, 64)
+	if err != nil {
+		return DeletedRef{}, fmt.Errorf("invalid deleted ref path '%s'", path)
+	}
+
+	name := path[typeEnd+1 : timeStart]
+	switch RefType(path[:typeEnd]) {
+	case BranchRefType:
+		return DeletedRef{ref: NewBranchRef(name), deletedAt: time.UnixMilli(millis).UTC()}, nil
+	case TagRefType:
+		return DeletedRef{ref: NewTagRef(name), deletedAt: time.UnixMilli(millis).UTC()}, nil
+	default:
+		return DeletedRef{}, fmt.Errorf("invalid deleted ref path '%s'", path)
+	}
+}
+
+// Ref returns the branch or tag that was deleted
+func (r DeletedRef) Ref() DoltRef {
+	return r.ref
+}
+
+// DeletedAt returns when the ref was deleted
+func (r DeletedRef) DeletedAt() time.Time {
+	return r.deletedAt
+}
+
+func (r DeletedRef) GetType() RefType {
+	return DeletedRefType
+}
+
+func (r DeletedRef) GetPath() string {
+	return string(r.ref.GetType()) + "/" + r.ref.GetPath() + "/" + strconv.FormatInt(r.deletedAt.UnixMilli(), 10)
+}
+
+func (r DeletedRef) String() string {
+	return String(r)
+}
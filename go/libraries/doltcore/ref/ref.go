@@ -60,6 +60,9 @@ const (
 
 	// TupleRefType is a reference to a statistics table
 	TupleRefType RefType = "tuples"
+
+	// DeletedRefType is a reference to a deleted branch or tag
+	DeletedRefType RefType = "deleted"
 )
 
 // HeadRefTypes are the ref types that point to a HEAD and contain a Commit struct. These are the types that are
@@ -212,5 +215,9 @@ func Parse(str string) (DoltRef, error) {
 		return NewTupleRef(str[len(prefix):]), nil
 	}
 
+	if prefix := PrefixForType(DeletedRefType); strings.HasPrefix(str, prefix) {
+		return NewDeletedRefFromPath(str[len(prefix):])
+	}
+
 	return nil, ErrUnknownRefType
 }
@@ -17,6 +17,10 @@ package ref
 import (
 	"encoding/json"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const defaultBranch = "main"
@@ -160,6 +164,16 @@ func TestEqualsStr(t *testing.T) {
 			"refs/remotes/origin/newworkspace",
 			false,
 		},
+		{
+			NewDeletedRef(NewBranchRef("feature/x"), time.UnixMilli(1760000000000)),
+			"refs/deleted/heads/feature/x/1760000000000",
+			true,
+		},
+		{
+			NewDeletedRef(NewTagRef("v1"), time.UnixMilli(1760000000000)),
+			"refs/deleted/heads/v1/1760000000000",
+			false,
+		},
 	}
 
 	for _, test := range tests {
@@ -170,3 +184,24 @@ func TestEqualsStr(t *testing.T) {
 		}
 	}
 }
+
+func TestDeletedRef(t *testing.T) {
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	dr := NewDeletedRef(NewBranchRef("feature/x"), deletedAt)
+	assert.Equal(t, "refs/deleted/heads/feature/x/1790856000000", dr.String())
+
+	parsed, err := Parse(dr.String())
+	require.NoError(t, err)
+	require.IsType(t, DeletedRef{}, parsed)
+	assert.Equal(t, NewBranchRef("feature/x"), parsed.(DeletedRef).Ref())
+	assert.True(t, deletedAt.Equal(parsed.(DeletedRef).DeletedAt()))
+
+	parsed, err = Parse(NewDeletedRef(NewTagRef("v1"), deletedAt).String())
+	require.NoError(t, err)
+	assert.Equal(t, NewTagRef("v1"), parsed.(DeletedRef).Ref())
+
+	for _, invalid := range []string{"refs/deleted/heads", "refs/deleted/heads/1790856000000", "refs/deleted/heads/feature/x", "refs/deleted/remotes/origin/main/1790856000000"} {
+		_, err = Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -483,7 +483,28 @@ func applySingleRevert(ctx *sql.Context, dbName string, doltSession *dsess.DoltS
 		return "", nil, err
 	}
 
-	if mergeResult.HasMergeArtifacts() {
+	resolved := false
+	if mergeResult.HasMergeArtifacts() && !mergeResult.HasSchemaConflicts() {
+		var resolvedTables []doltdb.TableName
+		resolvedTables, resolved, err = doltSession.ResolveMergeConflicts(ctx, dbName)
+		if err != nil {
+			return "", nil, err
+		}
+		if resolved {
+			roots, ok = doltSession.GetRoots(ctx, dbName)
+			if !ok {
+				return "", nil, fmt.Errorf("failed to get roots for current session")
+			}
+			if roots, err = actions.StageTables(ctx, roots, resolvedTables, true); err != nil {
+				return "", nil, err
+			}
+			if err = doltSession.SetRoots(ctx, dbName, roots); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	if mergeResult.HasMergeArtifacts() && !resolved {
 		// Get the working set after staging, then rebuild it using the pre-revert
 		// base so that preMergeWorking correctly captures the clean pre-revert root.
 		ws, err := doltSession.WorkingSet(ctx, dbName)
@@ -480,6 +480,9 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 		if idx.Predicate() != "" {
 			serial.IndexAddPredicate(b, predOffset)
 		}
+		if idx.IsColumnStore() {
+			serial.IndexAddColumnstoreKey(b, true)
+		}
 		offs[i] = serial.IndexEnd(b)
 	}
 
@@ -516,6 +519,7 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			IsSpatial:          idx.SpatialKey(),
 			IsFullText:         idx.FulltextKey(),
 			IsVector:           idx.VectorKey(),
+			IsColumnStore:      idx.ColumnstoreKey(),
 			IsUserDefined:      !idx.SystemDefined(),
 			Comment:            string(idx.Comment()),
 			Predicate:          string(idx.Predicate()),
@@ -17,6 +17,7 @@ package schema
 import (
 	"slices"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -48,6 +49,9 @@ type Index interface {
 	IsFullText() bool
 	// IsVector returns whether the given index has the VECTOR constraint.
 	IsVector() bool
+	// IsColumnStore returns whether the given index is a columnstore index, which stores each of its columns
+	// separately for analytic scans rather than ordering rows by the indexed columns.
+	IsColumnStore() bool
 	// IsUserDefined returns whether the given index was created by a user or automatically generated.
 	IsUserDefined() bool
 	// Name returns the name of the index.
@@ -135,6 +139,7 @@ type indexImpl struct {
 	isSpatial        bool
 	isFullText       bool
 	isVector         bool
+	isColumnStore    bool
 	isUserDefined    bool
 	comment          string
 	predicate        string
@@ -158,6 +163,7 @@ func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, pr
 		isSpatial:        props.IsSpatial,
 		isFullText:       props.IsFullText,
 		isVector:         props.IsVector,
+		isColumnStore:    props.IsColumnStore,
 		isUserDefined:    props.IsUserDefined,
 		comment:          props.Comment,
 		predicate:        props.Predicate,
@@ -212,6 +218,7 @@ func (ix *indexImpl) Equals(other Index) bool {
 
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
+		ix.IsColumnStore() == other.IsColumnStore() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
 		ix.Comment() == other.Comment() &&
 		ix.Predicate() == other.Predicate() &&
@@ -235,6 +242,7 @@ func (ix *indexImpl) DeepEquals(other Index) bool {
 
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
+		ix.IsColumnStore() == other.IsColumnStore() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
 		ix.Comment() == other.Comment() &&
 		ix.Predicate() == other.Predicate() &&
@@ -287,6 +295,11 @@ func (ix *indexImpl) IsVector() bool {
 	return ix.isVector
 }
 
+// IsColumnStore implements Index.
+func (ix *indexImpl) IsColumnStore() bool {
+	return ix.isColumnStore
+}
+
 // IsUserDefined implements Index.
 func (ix *indexImpl) IsUserDefined() bool {
 	return ix.isUserDefined
@@ -304,6 +317,10 @@ func (ix *indexImpl) PrimaryKeyTags() []uint64 {
 
 // Schema implements Index.
 func (ix *indexImpl) Schema() Schema {
+	if ix.isColumnStore {
+		return ix.columnStoreSchema()
+	}
+
 	contentHashedFields := make([]uint64, 0)
 	cols := make([]Column, len(ix.allTags))
 	for i, tag := range ix.allTags {
@@ -341,6 +358,58 @@ func (ix *indexImpl) Schema() Schema {
 	}
 }
 
+// ColumnStoreColumnName is the name of the key column of a columnstore index that holds the position, among the value
+// columns of the index, of the column an entry belongs to
+const ColumnStoreColumnName = "dolt_column"
+
+// columnStoreSchema returns the schema of the map of a columnstore index. The columns of the index are stored one after
+// another in the map: its key is the position of a column in the index followed by the primary key of the table, and
+// its value holds the value of that column alone, with the other value columns left null, so a scan of some of the
+// columns only reads their part of the map. Columns of the primary key are read from the key of any entry, so they're
+// not stored as values.
+func (ix *indexImpl) columnStoreSchema() Schema {
+	pkCols := []Column{{
+		Name:       ColumnStoreColumnName,
+		Tag:        ColumnStoreColumnTag,
+		Kind:       typeinfo.Uint16Type.NomsKind(),
+		IsPartOfPK: true,
+		TypeInfo:   typeinfo.Uint16Type,
+	}}
+	for _, tag := range ix.indexColl.pks {
+		col := ix.indexColl.colColl.TagToCol[tag]
+		pkCols = append(pkCols, Column{
+			Name:       col.Name,
+			Tag:        tag,
+			Kind:       col.Kind,
+			IsPartOfPK: true,
+			TypeInfo:   col.TypeInfo,
+		})
+	}
+
+	var valCols []Column
+	for _, tag := range ix.tags {
+		if slices.Contains(ix.indexColl.pks, tag) {
+			continue
+		}
+		col := ix.indexColl.colColl.TagToCol[tag]
+		valCols = append(valCols, Column{
+			Name:     col.Name,
+			Tag:      tag,
+			Kind:     col.Kind,
+			TypeInfo: col.TypeInfo,
+		})
+	}
+
+	pkColl, nonPkColl := NewColCollection(pkCols...), NewColCollection(valCols...)
+	return &schemaImpl{
+		pkCols:          pkColl,
+		nonPKCols:       nonPkColl,
+		allCols:         NewColCollection(append(pkCols, valCols...)...),
+		indexCollection: NewIndexCollection(nil, nil),
+		checkCollection: NewCheckCollection(),
+	}
+}
+
 // PrefixLengths implements Index.
 func (ix *indexImpl) PrefixLengths() []uint16 {
 	return ix.prefixLengths
@@ -90,6 +90,7 @@ type IndexProperties struct {
 	FullTextProperties
 	IsVector bool
 	VectorProperties
+	IsColumnStore bool
 }
 
 type FullTextProperties struct {
@@ -231,6 +232,7 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 		isSpatial:        props.IsSpatial,
 		isFullText:       props.IsFullText,
 		isVector:         props.IsVector,
+		isColumnStore:    props.IsColumnStore,
 		isUserDefined:    props.IsUserDefined,
 		comment:          props.Comment,
 		predicate:        props.Predicate,
@@ -260,6 +262,7 @@ func (ixc *indexCollectionImpl) UnsafeAddIndexByColTags(indexName string, tags [
 		isSpatial:     props.IsSpatial,
 		isFullText:    props.IsFullText,
 		isVector:      props.IsVector,
+		isColumnStore: props.IsColumnStore,
 		isUserDefined: props.IsUserDefined,
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
@@ -447,6 +450,7 @@ func (ixc *indexCollectionImpl) Merge(indexes ...Index) {
 				isSpatial:     index.IsSpatial(),
 				isFullText:    index.IsFullText(),
 				isVector:      index.IsVector(),
+				isColumnStore: index.IsColumnStore(),
 				isUserDefined: index.IsUserDefined(),
 				comment:       index.Comment(),
 				prefixLengths: index.PrefixLengths(),
@@ -88,6 +88,13 @@ const (
 	KeylessRowCardinalityTag
 )
 
+// Tags for hidden columns in columnstore indexes
+const (
+	// ColumnStoreColumnTag is the tag of the key column of a columnstore index that holds the position of the column an
+	// entry belongs to, see ColumnStoreColumnName
+	ColumnStoreColumnTag = iota + SystemTableReservedMin + uint64(5100)
+)
+
 // Tags for the dolt_procedures table
 const (
 	DoltProceduresNameTag = iota + SystemTableReservedMin + uint64(6000)
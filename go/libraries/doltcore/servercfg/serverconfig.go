@@ -67,6 +67,7 @@ const (
 	DefaultMaxConnections            = 1000
 	DefaultMaxWaitConnections        = 50
 	DefaultMaxWaitConnectionsTimeout = 60 * time.Second
+	DefaultQueryParallelism          = 1
 	DefaultDataDir                   = "."
 	DefaultCfgDir                    = ".doltcfg"
 	DefaultPrivilegeFilePath         = "privileges.db"
@@ -74,6 +75,10 @@ const (
 	DefaultMetricsHost               = ""
 	DefaultMetricsPort               = -1
 	DefaultMCPPort                   = 7007
+	DefaultPostgresReplicationPort   = 5432
+	DefaultBackupSyncInterval        = time.Hour
+	DefaultBackupRestorePoints       = 24
+	DefaultBackupVerifyInterval      = 24 * time.Hour
 	DefaultAllowCleartextPasswords   = false
 	DefaultMySQLUnixSocketFilePath   = "/tmp/mysql.sock"
 	DefaultMaxLoggedQueryLen         = 0
@@ -129,6 +134,35 @@ type ClusterStandbyRemoteConfig interface {
 	RemoteURLTemplate() string
 }
 
+// PostgresReplicationConfig configures a replica that streams changes from a Postgres logical replication slot.
+type PostgresReplicationConfig interface {
+	Host() string
+	Port() int
+	User() string
+	Password() string
+	Database() string
+	SSL() bool
+	SlotName() string
+	Publication() string
+	TargetDatabase() string
+	CommitPerTransaction() bool
+}
+
+// BackupScheduleConfig configures a named backup of a database that sql-server syncs on a schedule.
+type BackupScheduleConfig interface {
+	// Database is the name of the database to back up.
+	Database() string
+	// Backup is the name of the backup to sync to, as configured with dolt_backup('add', ...).
+	Backup() string
+	// SyncInterval is the time between syncs.
+	SyncInterval() time.Duration
+	// RestorePoints is the number of restore points kept in the backup.
+	RestorePoints() int
+	// VerifyInterval is the time between verifications of the chunks in the backup, or 0 if the backup is not
+	// verified.
+	VerifyInterval() time.Duration
+}
+
 type JwksConfig struct {
 	Name        string            `yaml:"name"`
 	LocationUrl string            `yaml:"location_url"`
@@ -243,8 +277,16 @@ type ServerConfig interface {
 	MCPDatabase() *string
 	// ClusterConfig is the configuration for clustering in this sql-server.
 	ClusterConfig() ClusterConfig
+	// PostgresReplicationConfig is the configuration for replicating from a Postgres source, or nil if this
+	// sql-server does not replicate from Postgres.
+	PostgresReplicationConfig() PostgresReplicationConfig
+	// BackupSchedules are the backups this sql-server syncs on a schedule.
+	BackupSchedules() []BackupScheduleConfig
 	// EventSchedulerStatus is the configuration for enabling or disabling the event scheduler in this server.
 	EventSchedulerStatus() string
+	// QueryParallelism is the number of goroutines that table scans are split across, or 0 for the number of CPUs. Scans
+	// are serial by default.
+	QueryParallelism() int
 	// ValueSet returns whether the value string provided was explicitly set in the config
 	ValueSet(value string) bool
 	// AutoGCBehavior defines parameters around how auto-GC works for the running server.
@@ -332,9 +374,39 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateBackupSchedules(config.BackupSchedules()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
+func ValidateBackupSchedules(schedules []BackupScheduleConfig) error {
+	seen := make(map[[2]string]struct{}, len(schedules))
+	for i, schedule := range schedules {
+		if schedule.Database() == "" {
+			return fmt.Errorf("backup_schedules[%d]: database: Cannot be empty", i)
+		}
+		if schedule.Backup() == "" {
+			return fmt.Errorf("backup_schedules[%d]: backup: Cannot be empty", i)
+		}
+		key := [2]string{strings.ToLower(schedule.Database()), schedule.Backup()}
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("backup_schedules[%d]: backup %s of database %s is scheduled more than once", i, schedule.Backup(), schedule.Database())
+		}
+		seen[key] = struct{}{}
+		if schedule.SyncInterval() <= 0 {
+			return fmt.Errorf("backup_schedules[%d]: sync_interval: must be a positive duration, such as 15m or 1h", i)
+		}
+		if schedule.RestorePoints() < 1 {
+			return fmt.Errorf("backup_schedules[%d]: restore_points: is %d but must be >= 1", i, schedule.RestorePoints())
+		}
+		if schedule.VerifyInterval() < 0 {
+			return fmt.Errorf("backup_schedules[%d]: verify_interval: must be a duration, such as 24h, or 0 to disable verification", i)
+		}
+	}
+	return nil
+}
+
 const (
 	HostKey                           = "host"
 	PortKey                           = "port"
@@ -378,6 +450,7 @@ const (
 	RemotesapiReadOnlyKey             = "remotesapi_read_only"
 	ClusterConfigKey                  = "cluster_config"
 	EventSchedulerKey                 = "event_scheduler"
+	QueryParallelismKey               = "query_parallelism"
 )
 
 type SystemVariableTarget interface {
@@ -414,6 +487,13 @@ func ApplySystemVariables(ctx *sql.Context, cfg ServerConfig, sysVarTarget Syste
 		}
 	}
 
+	if cfg.ValueSet(QueryParallelismKey) {
+		err := sysVarTarget.SetGlobal(ctx, "dolt_query_parallelism", cfg.QueryParallelism())
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
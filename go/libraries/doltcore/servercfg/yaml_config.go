@@ -107,7 +107,7 @@ type ListenerYAMLConfig struct {
 
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
 type PerformanceYAMLConfig struct {
-	// QueryParallelism is deprecated but still present to prevent breaking YAML config that still uses it
+	// QueryParallelism is the number of goroutines that table scans are split across, or 0 for the number of CPUs
 	QueryParallelism *int `yaml:"query_parallelism,omitempty"`
 }
 
@@ -171,6 +171,9 @@ type YAMLConfig struct {
 	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
 	MetricsConfig   MetricsYAMLConfig      `yaml:"metrics,omitempty"`
 	ClusterCfg      *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+
+	PostgresReplication *PostgresReplicationYAMLConfig `yaml:"postgres_replication,omitempty" minver:"TBD"`
+	BackupSchedules_    []BackupScheduleYAMLConfig     `yaml:"backup_schedules,omitempty" minver:"TBD"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -254,6 +257,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Port_:     cfg.RemotesapiPort(),
 			ReadOnly_: cfg.RemotesapiReadOnly(),
 		},
+		PerformanceConfig: performanceConfigAsYAMLConfig(cfg),
 		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
 		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
 		BranchControlFile: ptr(cfg.BranchControlFilePath()),
@@ -263,6 +267,14 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 	}
 }
 
+// performanceConfigAsYAMLConfig returns the performance section of |cfg|, which is only written when it's set
+func performanceConfigAsYAMLConfig(cfg ServerConfig) *PerformanceYAMLConfig {
+	if !cfg.ValueSet(QueryParallelismKey) {
+		return nil
+	}
+	return &PerformanceYAMLConfig{QueryParallelism: ptr(cfg.QueryParallelism())}
+}
+
 func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
 	if config == nil {
 		return nil
@@ -316,8 +328,9 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			AllowCleartextPasswords: zeroIf(ptr(cfg.AllowCleartextPasswords()), !cfg.ValueSet(AllowCleartextPasswordsKey)),
 			Socket:                  zeroIf(ptr(cfg.Socket()), !cfg.ValueSet(SocketKey)),
 		},
-		DataDirStr: zeroIf(ptr(cfg.DataDir()), !cfg.ValueSet(DataDirKey)),
-		CfgDirStr:  zeroIf(ptr(cfg.CfgDir()), !cfg.ValueSet(CfgDirKey)),
+		PerformanceConfig: performanceConfigAsYAMLConfig(cfg),
+		DataDirStr:        zeroIf(ptr(cfg.DataDir()), !cfg.ValueSet(DataDirKey)),
+		CfgDirStr:         zeroIf(ptr(cfg.CfgDir()), !cfg.ValueSet(CfgDirKey)),
 		MetricsConfig: MetricsYAMLConfig{
 			Labels:                  zeroIf(cfg.MetricsLabels(), !cfg.ValueSet(MetricsLabelsKey)),
 			Host:                    zeroIf(ptr(cfg.MetricsHost()), !cfg.ValueSet(MetricsHostKey)),
@@ -418,6 +431,10 @@ func (cfg YAMLConfig) withPlaceholdersFilledIn() YAMLConfig {
 		withPlaceholders.ListenerConfig.Socket = ptr(DefaultUnixSocketFilePath)
 	}
 
+	if withPlaceholders.PerformanceConfig == nil {
+		withPlaceholders.PerformanceConfig = &PerformanceYAMLConfig{QueryParallelism: ptr(DefaultQueryParallelism)}
+	}
+
 	if withPlaceholders.MetricsConfig.Labels == nil {
 		withPlaceholders.MetricsConfig.Labels = map[string]string{}
 	}
@@ -1019,6 +1036,24 @@ func (cfg YAMLConfig) ClusterConfig() ClusterConfig {
 	return cfg.ClusterCfg
 }
 
+func (cfg YAMLConfig) PostgresReplicationConfig() PostgresReplicationConfig {
+	if cfg.PostgresReplication == nil {
+		return nil
+	}
+	return cfg.PostgresReplication
+}
+
+func (cfg YAMLConfig) BackupSchedules() []BackupScheduleConfig {
+	if len(cfg.BackupSchedules_) == 0 {
+		return nil
+	}
+	schedules := make([]BackupScheduleConfig, len(cfg.BackupSchedules_))
+	for i := range cfg.BackupSchedules_ {
+		schedules[i] = &cfg.BackupSchedules_[i]
+	}
+	return schedules
+}
+
 func (cfg YAMLConfig) AutoGCBehavior() AutoGCBehavior {
 	if cfg.BehaviorConfig.AutoGCBehavior == nil {
 		return nil
@@ -1040,10 +1075,107 @@ func (cfg YAMLConfig) EventSchedulerStatus() string {
 	}
 }
 
+func (cfg YAMLConfig) QueryParallelism() int {
+	if cfg.PerformanceConfig == nil || cfg.PerformanceConfig.QueryParallelism == nil {
+		return DefaultQueryParallelism
+	}
+	return *cfg.PerformanceConfig.QueryParallelism
+}
+
 func (cfg YAMLConfig) Overrides() sql.EngineOverrides {
 	return sql.EngineOverrides{}
 }
 
+// PostgresReplicationYAMLConfig contains configuration for replicating from a Postgres logical replication slot
+// using the pgoutput plugin.
+type PostgresReplicationYAMLConfig struct {
+	Host_                 *string `yaml:"host,omitempty"`
+	Port_                 *int    `yaml:"port,omitempty"`
+	User_                 *string `yaml:"user,omitempty"`
+	Password_             *string `yaml:"password,omitempty"`
+	Database_             *string `yaml:"database,omitempty"`
+	SSL_                  *bool   `yaml:"ssl,omitempty"`
+	SlotName_             *string `yaml:"slot_name,omitempty"`
+	Publication_          *string `yaml:"publication,omitempty"`
+	TargetDatabase_       *string `yaml:"target_database,omitempty"`
+	CommitPerTransaction_ *bool   `yaml:"commit_per_transaction,omitempty"`
+}
+
+var _ PostgresReplicationConfig = (*PostgresReplicationYAMLConfig)(nil)
+
+func (c *PostgresReplicationYAMLConfig) Host() string {
+	if c.Host_ == nil {
+		return "localhost"
+	}
+	return *c.Host_
+}
+
+func (c *PostgresReplicationYAMLConfig) Port() int {
+	if c.Port_ == nil {
+		return DefaultPostgresReplicationPort
+	}
+	return *c.Port_
+}
+
+func (c *PostgresReplicationYAMLConfig) User() string {
+	if c.User_ == nil {
+		return ""
+	}
+	return *c.User_
+}
+
+func (c *PostgresReplicationYAMLConfig) Password() string {
+	if c.Password_ == nil {
+		return ""
+	}
+	return *c.Password_
+}
+
+// Database returns the name of the source Postgres database, which defaults to the user name, as it does in Postgres.
+func (c *PostgresReplicationYAMLConfig) Database() string {
+	if c.Database_ == nil {
+		return c.User()
+	}
+	return *c.Database_
+}
+
+func (c *PostgresReplicationYAMLConfig) SSL() bool {
+	if c.SSL_ == nil {
+		return false
+	}
+	return *c.SSL_
+}
+
+func (c *PostgresReplicationYAMLConfig) SlotName() string {
+	if c.SlotName_ == nil {
+		return ""
+	}
+	return *c.SlotName_
+}
+
+func (c *PostgresReplicationYAMLConfig) Publication() string {
+	if c.Publication_ == nil {
+		return ""
+	}
+	return *c.Publication_
+}
+
+// TargetDatabase returns the name of the Dolt database that changes are applied to, which defaults to the name of
+// the source database.
+func (c *PostgresReplicationYAMLConfig) TargetDatabase() string {
+	if c.TargetDatabase_ == nil {
+		return c.Database()
+	}
+	return *c.TargetDatabase_
+}
+
+func (c *PostgresReplicationYAMLConfig) CommitPerTransaction() bool {
+	if c.CommitPerTransaction_ == nil {
+		return true
+	}
+	return *c.CommitPerTransaction_
+}
+
 type ClusterYAMLConfig struct {
 	StandbyRemotes_ []StandbyRemoteYAMLConfig   `yaml:"standby_remotes"`
 	BootstrapRole_  string                      `yaml:"bootstrap_role"`
@@ -1136,6 +1268,8 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 		return cfg.ListenerConfig.MaxConnectionsTimeoutMs != nil
 	case EventSchedulerKey:
 		return cfg.BehaviorConfig.EventSchedulerStatus != nil
+	case QueryParallelismKey:
+		return cfg.PerformanceConfig != nil && cfg.PerformanceConfig.QueryParallelism != nil
 	}
 	return false
 }
@@ -1173,3 +1307,59 @@ func toAutoGCBehaviorYAML(a AutoGCBehavior) *AutoGCBehaviorYAMLConfig {
 		ArchiveLevel_: ptr(a.ArchiveLevel()),
 	}
 }
+
+// BackupScheduleYAMLConfig contains configuration for syncing a named backup of a database on a schedule
+type BackupScheduleYAMLConfig struct {
+	Database_       *string `yaml:"database,omitempty"`
+	Backup_         *string `yaml:"backup,omitempty"`
+	SyncInterval_   *string `yaml:"sync_interval,omitempty"`
+	RestorePoints_  *int    `yaml:"restore_points,omitempty"`
+	VerifyInterval_ *string `yaml:"verify_interval,omitempty"`
+}
+
+var _ BackupScheduleConfig = (*BackupScheduleYAMLConfig)(nil)
+
+func (c *BackupScheduleYAMLConfig) Database() string {
+	if c.Database_ == nil {
+		return ""
+	}
+	return *c.Database_
+}
+
+func (c *BackupScheduleYAMLConfig) Backup() string {
+	if c.Backup_ == nil {
+		return ""
+	}
+	return *c.Backup_
+}
+
+// SyncInterval returns the configured sync interval, or 0 if it is not a valid duration.
+func (c *BackupScheduleYAMLConfig) SyncInterval() time.Duration {
+	if c.SyncInterval_ == nil {
+		return DefaultBackupSyncInterval
+	}
+	d, err := time.ParseDuration(*c.SyncInterval_)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+func (c *BackupScheduleYAMLConfig) RestorePoints() int {
+	if c.RestorePoints_ == nil {
+		return DefaultBackupRestorePoints
+	}
+	return *c.RestorePoints_
+}
+
+// VerifyInterval returns the configured verification interval, or -1 if it is not a valid duration.
+func (c *BackupScheduleYAMLConfig) VerifyInterval() time.Duration {
+	if c.VerifyInterval_ == nil {
+		return DefaultBackupVerifyInterval
+	}
+	d, err := time.ParseDuration(*c.VerifyInterval_)
+	if err != nil {
+		return -1
+	}
+	return d
+}
@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -185,6 +186,17 @@ remotesapi:
 	require.Equal(t, 8000, *config.RemotesapiPort())
 }
 
+func TestUnmarshallQueryParallelism(t *testing.T) {
+	testStr := `
+performance:
+  query_parallelism: 4
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(QueryParallelismKey))
+	require.Equal(t, 4, config.QueryParallelism())
+}
+
 func TestUnmarshallCluster(t *testing.T) {
 	testStr := `
 cluster:
@@ -419,6 +431,98 @@ cluster:
 	}
 }
 
+func TestUnmarshallBackupSchedules(t *testing.T) {
+	testStr := `
+backup_schedules:
+- database: mydb
+  backup: nightly
+  sync_interval: 15m
+  restore_points: 4
+  verify_interval: 0
+- database: otherdb
+  backup: offsite
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	schedules := config.BackupSchedules()
+	require.Len(t, schedules, 2)
+	require.Equal(t, "mydb", schedules[0].Database())
+	require.Equal(t, "nightly", schedules[0].Backup())
+	require.Equal(t, 15*time.Minute, schedules[0].SyncInterval())
+	require.Equal(t, 4, schedules[0].RestorePoints())
+	require.Equal(t, time.Duration(0), schedules[0].VerifyInterval())
+	require.Equal(t, DefaultBackupSyncInterval, schedules[1].SyncInterval())
+	require.Equal(t, DefaultBackupRestorePoints, schedules[1].RestorePoints())
+	require.Equal(t, DefaultBackupVerifyInterval, schedules[1].VerifyInterval())
+	require.NoError(t, ValidateBackupSchedules(schedules))
+}
+
+func TestValidateBackupSchedules(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+	}{
+		{
+			Name: "no database",
+			Config: `
+backup_schedules:
+- backup: nightly
+`,
+		},
+		{
+			Name: "no backup",
+			Config: `
+backup_schedules:
+- database: mydb
+`,
+		},
+		{
+			Name: "invalid sync_interval",
+			Config: `
+backup_schedules:
+- database: mydb
+  backup: nightly
+  sync_interval: often
+`,
+		},
+		{
+			Name: "no restore points",
+			Config: `
+backup_schedules:
+- database: mydb
+  backup: nightly
+  restore_points: 0
+`,
+		},
+		{
+			Name: "invalid verify_interval",
+			Config: `
+backup_schedules:
+- database: mydb
+  backup: nightly
+  verify_interval: daily
+`,
+		},
+		{
+			Name: "duplicate schedule",
+			Config: `
+backup_schedules:
+- database: mydb
+  backup: nightly
+- database: MyDB
+  backup: nightly
+`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			cfg, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			require.Error(t, ValidateBackupSchedules(cfg.BackupSchedules()))
+		})
+	}
+}
+
 // Tests that a common YAML error (incorrect indentation) throws an error
 func TestUnmarshallError(t *testing.T) {
 	testStr := `
@@ -480,6 +584,8 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, "", cfg.MetricsTLSCA())
 	assert.Equal(t, DefaultAllowCleartextPasswords, cfg.AllowCleartextPasswords())
 	assert.Nil(t, cfg.RemotesapiPort())
+	assert.Equal(t, DefaultQueryParallelism, cfg.QueryParallelism())
+	assert.False(t, cfg.ValueSet(QueryParallelismKey))
 
 	c, err := LoadTLSConfig(cfg)
 	assert.NoError(t, err)
@@ -257,6 +257,7 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 				IsSpatial:          index.IsSpatial(),
 				IsFullText:         index.IsFullText(),
 				IsVector:           index.IsVector(),
+				IsColumnStore:      index.IsColumnStore(),
 				IsUserDefined:      index.IsUserDefined(),
 				Comment:            index.Comment(),
 				Predicate:          index.Predicate(),
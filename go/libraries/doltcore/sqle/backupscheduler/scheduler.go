@@ -0,0 +1,227 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backupscheduler syncs named backups of the databases of a running sql-server on a schedule, keeping a
+// number of restore points in each backup and periodically verifying the chunks stored in it.
+package backupscheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+)
+
+// Schedule is the schedule for syncing one named backup of a database.
+type Schedule struct {
+	// Database is the name of the database to back up.
+	Database string
+	// Backup is the name of the backup to sync to.
+	Backup string
+	// SyncInterval is the time between syncs. The first sync runs as soon as the scheduler starts.
+	SyncInterval time.Duration
+	// RestorePoints is the number of restore points kept in the backup.
+	RestorePoints int
+	// VerifyInterval is the time between verifications of the backup, or 0 to never verify it.
+	VerifyInterval time.Duration
+}
+
+// NewContextFunc returns a new session to run a scheduled operation in. The scheduler ends the session when the
+// operation completes.
+type NewContextFunc func(context.Context) (*sql.Context, error)
+
+// Scheduler runs the syncs and verifications of a set of Schedules. While it runs, the status of each scheduled backup
+// is published to the running SQL server with sqlserver.SetBackupStatuses.
+type Scheduler struct {
+	schedules []Schedule
+	newCtx    NewContextFunc
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	statusMu sync.Mutex
+	statuses map[statusKey]sqlserver.BackupStatus
+}
+
+type statusKey struct {
+	db, backup string
+}
+
+var _ sqlserver.BackupStatuses = (*Scheduler)(nil)
+
+// NewScheduler returns a Scheduler for |schedules|, which runs each operation in a session returned by |newCtx|.
+func NewScheduler(schedules []Schedule, newCtx NewContextFunc) *Scheduler {
+	return &Scheduler{schedules: schedules, newCtx: newCtx, statuses: make(map[statusKey]sqlserver.BackupStatus)}
+}
+
+// Start starts running the schedules in the background, until Stop is called.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.statusMu.Lock()
+	s.statuses = make(map[statusKey]sqlserver.BackupStatus)
+	s.statusMu.Unlock()
+	sqlserver.SetBackupStatuses(s)
+	for _, schedule := range s.schedules {
+		// Publish a status right away, so the backup shows up as scheduled before its first sync completes
+		s.updateStatus(schedule, func(*sqlserver.BackupStatus) {})
+		s.wg.Go(func() {
+			s.run(ctx, schedule)
+		})
+	}
+}
+
+// Stop stops running the schedules, waiting for any sync or verification in progress to be canceled. The statuses of
+// the scheduled backups are no longer published to the running SQL server, but remain available from BackupStatus
+// until the scheduler is started again.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel != nil {
+		sqlserver.UnsetBackupStatuses(s)
+		cancel()
+		s.wg.Wait()
+	}
+}
+
+// BackupStatus implements sqlserver.BackupStatuses.
+func (s *Scheduler) BackupStatus(db, backup string) (sqlserver.BackupStatus, bool) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	status, ok := s.statuses[statusKey{strings.ToLower(db), backup}]
+	return status, ok
+}
+
+// updateStatus calls |update| with the status of the backup of |schedule|, and stores the result.
+func (s *Scheduler) updateStatus(schedule Schedule, update func(*sqlserver.BackupStatus)) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	key := statusKey{strings.ToLower(schedule.Database), schedule.Backup}
+	status := s.statuses[key]
+	update(&status)
+	s.statuses[key] = status
+}
+
+// run syncs and verifies the backup of |schedule| until |ctx| is canceled. Syncs and verifications of the same backup
+// never run concurrently.
+func (s *Scheduler) run(ctx context.Context, schedule Schedule) {
+	syncTicker := time.NewTicker(schedule.SyncInterval)
+	defer syncTicker.Stop()
+
+	var verifyC <-chan time.Time
+	if schedule.VerifyInterval > 0 {
+		verifyTicker := time.NewTicker(schedule.VerifyInterval)
+		defer verifyTicker.Stop()
+		verifyC = verifyTicker.C
+	}
+
+	s.sync(ctx, schedule)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-syncTicker.C:
+			s.sync(ctx, schedule)
+		case <-verifyC:
+			s.verify(ctx, schedule)
+		}
+	}
+}
+
+// sync syncs the backup of |schedule| and records a restore point for it.
+func (s *Scheduler) sync(ctx context.Context, schedule Schedule) {
+	var restorePoints []string
+	err := s.withSession(ctx, schedule, func(sqlCtx *sql.Context) error {
+		points, err := dprocedures.SyncBackup(sqlCtx, schedule.Database, schedule.Backup, schedule.RestorePoints)
+		if err != nil {
+			return err
+		}
+		for _, p := range points {
+			restorePoints = append(restorePoints, p.Name)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.updateStatus(schedule, func(status *sqlserver.BackupStatus) {
+		status.LastSync = time.Now()
+		if err != nil {
+			status.LastSyncError = err.Error()
+			return
+		}
+		status.LastSyncError = ""
+		status.RestorePoints = restorePoints
+	})
+	if err != nil {
+		logrus.Errorf("scheduled sync of backup %s of database %s failed: %s", schedule.Backup, schedule.Database, err.Error())
+	}
+}
+
+// verify verifies the chunks of the backup of |schedule|.
+func (s *Scheduler) verify(ctx context.Context, schedule Schedule) {
+	var result string
+	err := s.withSession(ctx, schedule, func(sqlCtx *sql.Context) error {
+		verification, err := dprocedures.VerifyBackup(sqlCtx, schedule.Database, schedule.Backup)
+		if err != nil {
+			return err
+		}
+		result = verification.String()
+		if !verification.OK() {
+			sqlCtx.GetLogger().Errorf("verification of backup %s of database %s %s", schedule.Backup, schedule.Database, result)
+		}
+		return nil
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		result = fmt.Sprintf("error: %s", err.Error())
+		logrus.Errorf("scheduled verification of backup %s of database %s failed: %s", schedule.Backup, schedule.Database, err.Error())
+	}
+
+	s.updateStatus(schedule, func(status *sqlserver.BackupStatus) {
+		status.LastVerify = time.Now()
+		status.LastVerifyResult = result
+	})
+}
+
+// withSession calls |f| with a new session for the database of |schedule|.
+func (s *Scheduler) withSession(ctx context.Context, schedule Schedule, f func(*sql.Context) error) error {
+	sqlCtx, err := s.newCtx(ctx)
+	if err != nil {
+		return err
+	}
+	defer sql.SessionEnd(sqlCtx.Session)
+	sqlCtx.SetCurrentDatabase(schedule.Database)
+	return f(sqlCtx)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupscheduler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/gcctx"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func TestSchedulerSyncsAndVerifies(t *testing.T) {
+	ctx := context.Background()
+	// Syncs stage chunks in the temp dir of the database's filesystem, so it can't be in memory
+	dEnv := dtestutils.CreateTestEnvForLocalFilesystem()
+	defer dEnv.Close()
+
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), editor.Options{})
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+
+	backupUrl := "file://" + filepath.ToSlash(filepath.Join(t.TempDir(), "backup"))
+	runQuery(t, sqlCtx, engine, fmt.Sprintf("call dolt_backup('add', 'bak', '%s')", backupUrl))
+	runQuery(t, sqlCtx, engine, "create table t (pk int primary key)")
+	runQuery(t, sqlCtx, engine, "call dolt_commit('-Am', 'create t')")
+
+	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+	provider := dsess.DSessFromSess(sqlCtx.Session).Provider()
+	scheduler := NewScheduler([]Schedule{{
+		Database:       "dolt",
+		Backup:         "bak",
+		SyncInterval:   20 * time.Millisecond,
+		RestorePoints:  2,
+		VerifyInterval: 50 * time.Millisecond,
+	}}, func(ctx context.Context) (*sql.Context, error) {
+		return sqle.NewTestSQLCtxWithProvider(ctx, provider, config, nil, gcctx.NewGCSafepointController()), nil
+	})
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	// Every change to the database gets a restore point, and only the newest two are kept
+	newest := ""
+	for i := 0; i < 3; i++ {
+		runQuery(t, sqlCtx, engine, fmt.Sprintf("insert into t values (%d)", i))
+		runQuery(t, sqlCtx, engine, fmt.Sprintf("call dolt_commit('-am', 'insert %d')", i))
+		require.Eventually(t, func() bool {
+			status, _ := sqlserver.GetBackupStatus("dolt", "bak")
+			if status.LastSyncError != "" || len(status.RestorePoints) == 0 {
+				return false
+			}
+			last := status.RestorePoints[len(status.RestorePoints)-1]
+			if last == newest {
+				return false
+			}
+			newest = last
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+		// Syncs that find nothing new must not add restore points
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		status, _ := sqlserver.GetBackupStatus("dolt", "bak")
+		return strings.HasPrefix(status.LastVerifyResult, "ok: ")
+	}, 10*time.Second, 10*time.Millisecond)
+
+	rows := queryRows(t, sqlCtx, engine, "select name, last_sync is not null, last_sync_error, json_length(restore_points), last_verify_result like 'ok: %' from dolt_backups")
+	require.Equal(t, []sql.Row{{"bak", true, nil, 2, true}}, rows)
+
+	// Once stopped, the scheduler's statuses are no longer those of the server
+	scheduler.Stop()
+	_, ok := sqlserver.GetBackupStatus("dolt", "bak")
+	require.False(t, ok)
+	rows = queryRows(t, sqlCtx, engine, "select name, last_sync from dolt_backups")
+	require.Equal(t, []sql.Row{{"bak", nil}}, rows)
+	status, ok := scheduler.BackupStatus("dolt", "bak")
+	require.True(t, ok)
+	require.Empty(t, status.LastSyncError)
+	require.Len(t, status.RestorePoints, 2)
+
+	// The oldest kept restore point can be restored
+	runQuery(t, sqlCtx, engine, fmt.Sprintf("call dolt_backup('restore', '--restore-point', '%s', '%s', 'restored')", status.RestorePoints[0], backupUrl))
+	rows = queryRows(t, sqlCtx, engine, "select count(*) from restored.t")
+	require.Equal(t, []sql.Row{{int64(2)}}, rows)
+}
+
+func TestSchedulerReportsSyncErrors(t *testing.T) {
+
+	scheduler := NewScheduler([]Schedule{{
+		Database:      "dolt",
+		Backup:        "bak",
+		SyncInterval:  time.Hour,
+		RestorePoints: 1,
+	}}, func(ctx context.Context) (*sql.Context, error) {
+		return nil, fmt.Errorf("no sessions available")
+	})
+	scheduler.Start()
+	require.Eventually(t, func() bool {
+		status, _ := sqlserver.GetBackupStatus("dolt", "bak")
+		return !status.LastSync.IsZero()
+	}, 10*time.Second, 10*time.Millisecond)
+	scheduler.Stop()
+
+	status, ok := scheduler.BackupStatus("dolt", "bak")
+	require.True(t, ok)
+	require.Equal(t, "no sessions available", status.LastSyncError)
+	require.Empty(t, status.RestorePoints)
+	require.True(t, status.LastVerify.IsZero())
+}
+
+func runQuery(t *testing.T, ctx *sql.Context, engine *gms.Engine, query string) {
+	queryRows(t, ctx, engine, query)
+}
+
+func queryRows(t *testing.T, ctx *sql.Context, engine *gms.Engine, query string) []sql.Row {
+	_, iter, _, err := engine.Query(ctx, query)
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+	return rows
+}
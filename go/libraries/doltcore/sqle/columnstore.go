@@ -0,0 +1,171 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+)
+
+// CreateColumnStoreIndex creates the columnstore index |name| on the columns |columns| of this table, for
+// CREATE INDEX ... USING COLUMNSTORE. See schema.Index.IsColumnStore.
+func (t *AlterableDoltTable) CreateColumnStoreIndex(ctx *sql.Context, name string, columns []string, comment string) error {
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	if schema.IsKeyless(t.sch) {
+		return index.ErrColumnStoreKeyless
+	}
+
+	hasValueColumn := false
+	for _, colName := range columns {
+		if col, ok := t.sch.GetAllCols().GetByNameCaseInsensitive(colName); ok && !col.IsPartOfPK {
+			hasValueColumn = true
+		}
+	}
+	if !hasValueColumn {
+		return fmt.Errorf("columnstore index %s must include a column that's not part of the primary key", name)
+	}
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+	ret, err := creation.CreateIndex(ctx, table, t.Name(), name, columns, nil, schema.IndexProperties{
+		IsColumnStore: true,
+		IsUserDefined: true,
+		Comment:       comment,
+	}, t.opts, nil)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), ret.NewTable)
+	if err != nil {
+		return err
+	}
+	if err = t.setRoot(ctx, newRoot); err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
+// columnStoreScan reads some of the columns of a table from one of its columnstore indexes instead of from its rows
+type columnStoreScan struct {
+	sch         schema.Schema
+	def         schema.Index
+	idx         prolly.Map
+	projections []uint64
+	// rowData is the hash of the rows of the table the index was read with, and rowCount their number
+	rowData  hash.Hash
+	rowCount int
+}
+
+// newColumnStoreScan returns a scan of the columns |projections| of |tbl| from the columnstore index that covers them
+// with the fewest segments. Returns nil if no index covers them, or if reading them from the rows of the table is
+// as cheap.
+func newColumnStoreScan(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, projections []uint64) (*columnStoreScan, error) {
+	if len(projections) == 0 || schema.IsKeyless(sch) {
+		return nil, nil
+	}
+
+	var def schema.Index
+	segments := sch.GetNonPKCols().StoredSize()
+	for _, idx := range sch.Indexes().AllIndexes() {
+		if !idx.IsColumnStore() {
+			continue
+		}
+		if ok, n := index.ColumnStoreCovers(sch, idx, projections); ok && n < segments {
+			def, segments = idx, n
+		}
+	}
+	if def == nil {
+		return nil, nil
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rowHash, err := rowData.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	rowCount, err := rowData.Count()
+	if err != nil {
+		return nil, err
+	}
+	idxData, err := tbl.GetIndexRowData(ctx, def.Name())
+	if err != nil {
+		return nil, err
+	}
+	idx, err := durable.ProllyMapFromIndex(idxData)
+	if err != nil {
+		return nil, err
+	}
+
+	// every row must have an entry in each segment for them to line up, otherwise the rows are read from the table
+	idxCount, err := idx.Count()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(idxCount) != rowCount*uint64(def.Schema().GetNonPKCols().Size()) {
+		return nil, nil
+	}
+
+	return &columnStoreScan{
+		sch:         sch,
+		def:         def,
+		idx:         idx,
+		projections: projections,
+		rowData:     rowHash,
+		rowCount:    int(rowCount),
+	}, nil
+}
+
+// partitionRows returns the rows of |partition| read from the index. Returns false if |partition| isn't a partition of
+// the rows the index was read with.
+func (s *columnStoreScan) partitionRows(ctx context.Context, partition doltTablePartition) (sql.RowIter, bool, error) {
+	rowHash, err := partition.rowData.HashOf()
+	if err != nil {
+		return nil, false, err
+	}
+	if rowHash != s.rowData {
+		return nil, false, nil
+	}
+
+	end := min(partition.end, uint64(s.rowCount))
+	iter, err := index.NewColumnStoreRowIter(ctx, s.sch, s.def, s.idx, s.rowCount, partition.start, end, s.projections)
+	if err != nil {
+		return nil, false, err
+	}
+	return iter, true, nil
+}
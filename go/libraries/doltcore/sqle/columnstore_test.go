@@ -0,0 +1,154 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+)
+
+func TestColumnStoreScan(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.Close()
+
+	defer func(max uint64) {
+		MaxRowsPerPartition = max
+	}(MaxRowsPerPartition)
+	MaxRowsPerPartition = 64
+
+	values := make([]string, 500)
+	for i := range values {
+		values[i] = fmt.Sprintf("(%d, %d, 'b%d', %d)", i, i%7, i, i*2)
+	}
+	_, err := ExecuteSql(ctx, dEnv, strings.Join([]string{
+		"create table t (id int primary key, a int, b varchar(10), c int);",
+		"insert into t values " + strings.Join(values, ", ") + ";",
+	}, "\n"))
+	require.NoError(t, err)
+
+	// CREATE INDEX ... USING COLUMNSTORE is executed by the kvexec builder, which this package can't use
+	createColumnStoreIndex := func(name string, columns ...string) (doltdb.RootValue, *doltdb.Table) {
+		root, err := dEnv.WorkingRoot(ctx)
+		require.NoError(t, err)
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: "t"})
+		require.NoError(t, err)
+		require.True(t, ok)
+		ret, err := creation.CreateIndex(sql.NewEmptyContext(), tbl, "t", name, columns, nil, schema.IndexProperties{
+			IsColumnStore: true,
+			IsUserDefined: true,
+		}, editor.Options{}, nil)
+		require.NoError(t, err)
+		root, err = root.PutTable(ctx, doltdb.TableName{Name: "t"}, ret.NewTable)
+		require.NoError(t, err)
+		require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+		return root, ret.NewTable
+	}
+
+	root, tbl := createColumnStoreIndex("cs", "a", "b")
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	tags := func(names ...string) []uint64 {
+		var tags []uint64
+		for _, name := range names {
+			col, ok := sch.GetAllCols().GetByName(name)
+			require.True(t, ok)
+			tags = append(tags, col.Tag)
+		}
+		return tags
+	}
+
+	t.Run("columns outside of the index are read from the table", func(t *testing.T) {
+		scan, err := newColumnStoreScan(ctx, tbl, sch, tags("a", "c"))
+		require.NoError(t, err)
+		assert.Nil(t, scan)
+	})
+
+	t.Run("all the columns of the table are read from the table", func(t *testing.T) {
+		_, tbl := createColumnStoreIndex("cs2", "a", "b", "c")
+		sch, err := tbl.GetSchema(ctx)
+		require.NoError(t, err)
+		scan, err := newColumnStoreScan(ctx, tbl, sch, sch.GetAllCols().Tags)
+		require.NoError(t, err)
+		assert.Nil(t, scan)
+	})
+
+	for _, cols := range [][]string{{"id", "b"}, {"a"}, {"b", "id", "a"}, {"id"}} {
+		t.Run(strings.Join(cols, ","), func(t *testing.T) {
+			scan, err := newColumnStoreScan(ctx, tbl, sch, tags(cols...))
+			require.NoError(t, err)
+			require.NotNil(t, scan)
+			assert.Equal(t, "cs", scan.def.Name())
+
+			rowData, err := tbl.GetRowData(ctx)
+			require.NoError(t, err)
+			partitions, err := partitionsFromTableRows(ctx, rowData, false)
+			require.NoError(t, err)
+			require.Greater(t, len(partitions), 1)
+
+			sqlCtx := sql.NewEmptyContext()
+			var actual []sql.Row
+			for _, p := range partitions {
+				iter, ok, err := scan.partitionRows(ctx, p)
+				require.NoError(t, err)
+				require.True(t, ok)
+				for {
+					r, err := iter.Next(sqlCtx)
+					if err == io.EOF {
+						break
+					}
+					require.NoError(t, err)
+					actual = append(actual, r)
+				}
+				require.NoError(t, iter.Close(sqlCtx))
+			}
+
+			expected, err := ExecuteSelect(ctx, dEnv, root, fmt.Sprintf("select %s from t order by id", strings.Join(cols, ", ")))
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("partitions of other rows are read from the table", func(t *testing.T) {
+		scan, err := newColumnStoreScan(ctx, tbl, sch, tags("a"))
+		require.NoError(t, err)
+		require.NotNil(t, scan)
+
+		root, err := ExecuteSql(ctx, dEnv, "insert into t values (1000, 1, 'x', 1);")
+		require.NoError(t, err)
+		tbl, _, err := root.GetTable(ctx, doltdb.TableName{Name: "t"})
+		require.NoError(t, err)
+		rowData, err := tbl.GetRowData(ctx)
+		require.NoError(t, err)
+		partitions, err := partitionsFromTableRows(ctx, rowData, false)
+		require.NoError(t, err)
+		_, ok, err := scan.partitionRows(ctx, partitions[0])
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
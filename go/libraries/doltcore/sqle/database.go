@@ -738,6 +738,38 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewRemotesTable(ctx, db.Name(), db.ddb, lwrName), true
 		}
+	case doltdb.OperationsTableName, doltdb.GetOperationsTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewOperationsTable(ctx, db.ddb, lwrName), true
+		}
+	case doltdb.DeletedRefsTableName, doltdb.GetDeletedRefsTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewDeletedRefsTable(ctx, db.ddb, lwrName), true
+		}
+	case doltdb.LocksTableName, doltdb.GetLocksTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewLocksTable(ctx, db.Name(), lwrName), true
+		}
+	case doltdb.QueryCacheTableName, doltdb.GetQueryCacheTableName():
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewQueryCacheTable(ctx, db.Name(), lwrName), true
+		}
 	case doltdb.StashesTableName, doltdb.GetStashesTableName():
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -947,6 +979,39 @@ func (db Database) getTableInsensitiveWithRoot(ctx *sql.Context, head *doltdb.Co
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewTestsTable(ctx, versionableTable), true
 		}
+	case doltdb.GetMergeStrategiesTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetMergeStrategiesTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeStrategiesTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeStrategiesTable(ctx, versionableTable), true
+		}
+	case doltdb.GetMergeResolversTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetMergeResolversTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeResolversTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeResolversTable(ctx, versionableTable), true
+		}
+	case doltdb.GetMaterializedViewsTableName():
+		backingTable, _, err := db.getTable(ctx, root, doltdb.GetMaterializedViewsTableName())
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMaterializedViewsTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMaterializedViewsTable(ctx, versionableTable), true
+		}
 	}
 
 	if found {
@@ -39,6 +39,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+	// matview implements the materialized view procedures and refreshes materialized views on commit
+	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
@@ -90,7 +92,11 @@ type DoltDatabaseProvider struct {
 	// clone's on-disk directory is simply invisible until it registers.
 	creatingDatabases map[string]struct{}
 
-	txLocks keymutex.Keymutex
+	txLocks    keymutex.Keymutex
+	rowLocks   *dsess.RowLockManager
+	queryCache *dsess.QueryCache
+	xa         *dsess.XATransactions
+	runner     sql.StatementRunner
 
 	defaultBranch     string
 	dbFactoryUrl      string
@@ -231,6 +237,9 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		droppedDatabaseManager: newDroppedDatabaseManager(fs),
 		overrides:              overrides,
 		txLocks:                keymutex.NewMapped(),
+		rowLocks:               dsess.NewRowLockManager(),
+		queryCache:             dsess.NewQueryCache(),
+		xa:                     dsess.NewXATransactions(),
 		gitRemotes:             map[string]*doltdb.DoltDB{},
 		gitRemotesMu:           &sync.Mutex{},
 	}, nil
@@ -1874,6 +1883,28 @@ func (p *DoltDatabaseProvider) TxLocks() keymutex.Keymutex {
 	return p.txLocks
 }
 
+func (p *DoltDatabaseProvider) RowLocks() *dsess.RowLockManager {
+	return p.rowLocks
+}
+
+func (p *DoltDatabaseProvider) QueryCache() *dsess.QueryCache {
+	return p.queryCache
+}
+
+func (p *DoltDatabaseProvider) XATransactions() *dsess.XATransactions {
+	return p.xa
+}
+
+func (p *DoltDatabaseProvider) StatementRunner() sql.StatementRunner {
+	return p.runner
+}
+
+// SetStatementRunner sets the engine that runs the statements of this provider's databases, which procedures use to
+// run statements of their own with the privileges of the session.
+func (p *DoltDatabaseProvider) SetStatementRunner(runner sql.StatementRunner) {
+	p.runner = runner
+}
+
 // isBranch returns whether a branch with the given name is in scope for the database given
 func isBranch(ctx context.Context, db dsess.SqlDatabase, branchName string) (string, bool, error) {
 	ddbs := db.DoltDatabases()
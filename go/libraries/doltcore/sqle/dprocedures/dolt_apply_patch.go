@@ -0,0 +1,676 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	querypb "github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+// ErrApplyPatchUncommittedChanges is returned when a patch is applied without a clean working set.
+var ErrApplyPatchUncommittedChanges = errors.New("cannot apply a patch with uncommitted changes")
+
+// ErrApplyPatchUnsupportedStatement is returned for a statement of a patch that dolt_patch() doesn't write.
+var ErrApplyPatchUnsupportedStatement = errors.New("only INSERT, UPDATE and DELETE statements and changes to the " +
+	"tables of the current database can be applied as a patch")
+
+var doltApplyPatchSchema = int64Schema("statements_applied", "statements_skipped", "data_conflicts")
+
+// doltApplyPatch is the stored procedure version for the CLI command `dolt apply`.
+func doltApplyPatch(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	applied, skipped, conflicts, err := doDoltApplyPatch(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(applied), int64(skipped), int64(conflicts)), nil
+}
+
+// doDoltApplyPatch applies the statements of a SQL patch, such as the output of dolt_patch() or dolt diff -r sql, to
+// the working set, and stages the tables it changed. Only the statements dolt_patch() writes are applied, see
+// patchApplier.checkStatement, and they run with the privileges of the session's user. Each UPDATE and DELETE only
+// changes a row that still has the values its WHERE clause gives, which patches created with --preimage match by all
+// of its old values. A statement that changes no rows is skipped if the row already has its new value. Otherwise the
+// row's old value in the patch, the row in the working set and the statement's new value are merged, and if they
+// can't be, the row is recorded as a conflict in dolt_conflicts_<table>, whose table is left unstaged. An INSERT of a
+// row that already exists with other values is merged the same way.
+//
+// Returns the number of statements applied, the number skipped because they had already been applied, and the
+// number of rows recorded as conflicts.
+func doDoltApplyPatch(ctx *sql.Context, args []string) (int, int, int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 0, 0, 0, fmt.Errorf("error: empty database name")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 0, 0, 0, err
+	}
+	if len(args) != 1 {
+		return 0, 0, 0, fmt.Errorf("error: dolt_apply_patch takes the text of the patch to apply")
+	}
+
+	stmts, err := sqlparser.SplitStatementToPieces(args[0])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("Could not load database %s", dbName)
+	}
+	clean, err := diff.WorkingSetContainsOnlyIgnoredTables(ctx, roots)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if !clean {
+		return 0, 0, 0, ErrApplyPatchUncommittedChanges
+	}
+	ws, err := sess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if ws.MergeActive() {
+		return 0, 0, 0, fmt.Errorf("cannot apply a patch while a %s is in progress", ws.MergeState().OperationName())
+	}
+
+	runner := sess.Provider().StatementRunner()
+	if runner == nil {
+		return 0, 0, 0, fmt.Errorf("cannot apply a patch: no engine is registered with the database provider")
+	}
+
+	// The statements run in this session and transaction, like the ones of the patch would if they were run directly,
+	// so they're checked against the privileges of the session's user
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	ctx.SetIgnoreAutoCommit(true)
+	defer ctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+
+	pa := &patchApplier{sess: sess, dbName: dbName, runner: runner}
+	applied, skipped, conflicts, err := pa.apply(ctx, stmts)
+	if err != nil {
+		// leave the working set as it was, rather than with part of the patch applied
+		if rerr := sess.SetWorkingSet(ctx, dbName, ws); rerr != nil {
+			return 0, 0, 0, rerr
+		}
+		return 0, 0, 0, err
+	}
+	return applied, skipped, conflicts, nil
+}
+
+// patchApplier applies the statements of a patch to the working set of a database.
+type patchApplier struct {
+	sess   *dsess.DoltSession
+	dbName string
+	runner sql.StatementRunner
+}
+
+// patchRowChange is a statement of a patch that didn't apply to the row it changes, which is merged with the row.
+type patchRowChange struct {
+	stmt  string
+	table string
+	// setup is the statement that gives the row its old value in the patch
+	setup string
+	// isUpdate is set for a statement whose number of rows affected shows whether it applied
+	isUpdate bool
+}
+
+// patchColumnValue is the value a statement gives a column, as an expression.
+type patchColumnValue struct {
+	col  string
+	expr string
+}
+
+func (pa *patchApplier) apply(ctx *sql.Context, stmts []string) (applied, skipped, conflicts int, err error) {
+	var pending []patchRowChange
+	for _, stmt := range stmts {
+		parsed, err := sqlparser.Parse(stmt)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if err = pa.checkStatement(parsed); err != nil {
+			return 0, 0, 0, fmt.Errorf("error applying statement %s: %w", stmt, err)
+		}
+
+		var change *patchRowChange
+		var ok bool
+		switch s := parsed.(type) {
+		case *sqlparser.Insert:
+			change, ok, err = pa.applyInsert(ctx, stmt, s)
+		case *sqlparser.Update:
+			change, ok, err = pa.applyUpdate(ctx, stmt, s)
+		case *sqlparser.Delete:
+			change, ok, err = pa.applyDelete(ctx, stmt, s)
+		default:
+			// the exec builder reads clauses the engine doesn't keep, like USING COLUMNSTORE, from the query
+			_, err = dsess.QueryRows(ctx.WithQuery(stmt), pa.runner, stmt, nil)
+			ok = true
+		}
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("error applying statement %s: %w", stmt, err)
+		}
+
+		switch {
+		case change != nil:
+			pending = append(pending, *change)
+		case ok:
+			applied++
+		default:
+			skipped++
+		}
+	}
+
+	if len(pending) > 0 {
+		conflicts, err = pa.mergeRowChanges(ctx, pending)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		// every pending statement changes a row of its own, so each conflict is the row of one statement
+		applied += len(pending) - conflicts
+	}
+
+	if err = pa.stageTablesWithoutConflicts(ctx); err != nil {
+		return 0, 0, 0, err
+	}
+	return applied, skipped, conflicts, nil
+}
+
+// checkStatement returns ErrApplyPatchUnsupportedStatement unless |parsed| is one of the statements dolt_patch()
+// writes: an INSERT of values, an UPDATE or DELETE, a CREATE, ALTER, RENAME or DROP of a table, or an ALTER DATABASE,
+// of the tables or the database the patch is applied to.
+func (pa *patchApplier) checkStatement(parsed sqlparser.Statement) error {
+	var tables []sqlparser.TableName
+	switch s := parsed.(type) {
+	case *sqlparser.Insert:
+		if _, ok := s.Rows.(*sqlparser.AliasedValues); !ok {
+			return ErrApplyPatchUnsupportedStatement
+		}
+		tables = append(tables, s.Table)
+	case *sqlparser.Update:
+		tn, err := singleTable(s.TableExprs)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, tn)
+	case *sqlparser.Delete:
+		tn, err := singleTable(s.TableExprs)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, tn)
+	case *sqlparser.AlterTable:
+		tables = append(tables, s.Table)
+	case *sqlparser.DDL:
+		if !isTableDDL(s) {
+			return ErrApplyPatchUnsupportedStatement
+		}
+		tables = append(append(append(tables, s.Table), s.FromTables...), s.ToTables...)
+	case *sqlparser.DBDDL:
+		if s.Action != sqlparser.AlterStr || (s.DBName != "" && !strings.EqualFold(s.DBName, pa.dbName)) {
+			return ErrApplyPatchUnsupportedStatement
+		}
+	default:
+		return ErrApplyPatchUnsupportedStatement
+	}
+	for _, tn := range tables {
+		if !tn.DbQualifier.IsEmpty() && !strings.EqualFold(tn.DbQualifier.String(), pa.dbName) {
+			return ErrApplyPatchUnsupportedStatement
+		}
+	}
+	return nil
+}
+
+// isTableDDL returns whether |ddl| creates, alters, renames or drops tables, rather than views, triggers, procedures,
+// events or users.
+func isTableDDL(ddl *sqlparser.DDL) bool {
+	if ddl.ViewSpec != nil || ddl.TriggerSpec != nil || ddl.ProcedureSpec != nil || ddl.EventSpec != nil || len(ddl.FromViews) > 0 {
+		return false
+	}
+	if ddl.AccountLimits != nil || ddl.User.Name != "" {
+		return false
+	}
+	switch ddl.Action {
+	case sqlparser.CreateStr:
+		return ddl.TableSpec != nil
+	case sqlparser.AlterStr, sqlparser.RenameStr, sqlparser.DropStr:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyInsert runs the INSERT |stmt|. If its row's primary key already exists, it returns whether the existing row
+// has the values inserted, or the change to merge if it doesn't.
+func (pa *patchApplier) applyInsert(ctx *sql.Context, stmt string, ins *sqlparser.Insert) (*patchRowChange, bool, error) {
+	_, err := dsess.QueryRows(ctx, pa.runner, stmt, nil)
+	if wie, ok := err.(sql.WrappedInsertError); ok {
+		err = wie.Cause
+	}
+	if err == nil || !sql.ErrPrimaryKeyViolation.Is(err) {
+		return nil, true, err
+	}
+
+	values, ok := ins.Rows.(*sqlparser.AliasedValues)
+	if !ok || len(values.Values) != 1 || len(values.Values[0]) != len(ins.Columns) {
+		return nil, false, err
+	}
+	tableName := ins.Table.Name.String()
+	sch, err := pa.tableSchema(ctx, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	row := make([]patchColumnValue, len(ins.Columns))
+	for i, col := range ins.Columns {
+		row[i] = patchColumnValue{col: col.String(), expr: sqlparser.String(values.Values[0][i])}
+	}
+
+	exists, err := pa.rowExists(ctx, tableName, sch, row)
+	if err != nil || exists {
+		return nil, false, err
+	}
+	// the row didn't exist before the patch inserted it
+	setup := fmt.Sprintf("DELETE FROM %s WHERE %s", quoteIdent(tableName), rowCondition(sch, primaryKeyValues(sch, row)))
+	return &patchRowChange{stmt: stmt, table: tableName, setup: setup}, false, nil
+}
+
+// applyUpdate runs the UPDATE |stmt|. If it changes no rows, it returns whether the row already has the values the
+// statement sets, or the change to merge if it doesn't.
+func (pa *patchApplier) applyUpdate(ctx *sql.Context, stmt string, upd *sqlparser.Update) (*patchRowChange, bool, error) {
+	affected, err := rowsAffected(ctx, pa.runner, stmt)
+	if err != nil || affected > 0 {
+		return nil, true, err
+	}
+
+	tableName, err := singleTableName(upd.TableExprs)
+	if err != nil {
+		return nil, false, err
+	}
+	sch, err := pa.tableSchema(ctx, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	preimage := whereValues(upd.Where)
+	if preimage == nil {
+		return nil, false, fmt.Errorf("unable to read the rows changed from the WHERE clause")
+	}
+
+	postimage := append([]patchColumnValue(nil), preimage...)
+	for _, set := range upd.Exprs {
+		postimage = withValue(postimage, set.Name.Name.String(), sqlparser.String(set.Expr))
+	}
+	exists, err := pa.rowExists(ctx, tableName, sch, postimage)
+	if err != nil || exists {
+		return nil, false, err
+	}
+
+	setup, err := preimageSetup(tableName, sch, preimage)
+	if err != nil {
+		return nil, false, err
+	}
+	return &patchRowChange{stmt: stmt, table: tableName, setup: setup, isUpdate: true}, false, nil
+}
+
+// applyDelete runs the DELETE |stmt|. If it deletes no rows, it returns whether the row has already been deleted, or
+// the change to merge if it hasn't.
+func (pa *patchApplier) applyDelete(ctx *sql.Context, stmt string, del *sqlparser.Delete) (*patchRowChange, bool, error) {
+	affected, err := rowsAffected(ctx, pa.runner, stmt)
+	if err != nil || affected > 0 {
+		return nil, true, err
+	}
+
+	tableName, err := singleTableName(del.TableExprs)
+	if err != nil {
+		return nil, false, err
+	}
+	sch, err := pa.tableSchema(ctx, tableName)
+	if err != nil {
+		return nil, false, err
+	}
+	// a keyless row is only identified by its values, so it's been deleted if no row has them
+	if schema.IsKeyless(sch) {
+		return nil, false, nil
+	}
+	preimage := whereValues(del.Where)
+	if preimage == nil {
+		return nil, false, fmt.Errorf("unable to read the rows deleted from the WHERE clause")
+	}
+
+	exists, err := pa.rowExists(ctx, tableName, sch, primaryKeyValues(sch, preimage))
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	setup, err := preimageSetup(tableName, sch, preimage)
+	if err != nil {
+		return nil, false, err
+	}
+	return &patchRowChange{stmt: stmt, table: tableName, setup: setup, isUpdate: true}, false, nil
+}
+
+// mergeRowChanges merges the statements of |pending| with the rows they change, as a merge of the working set with a
+// root where the statements applied, from an ancestor root where their rows have the old values in the patch. Returns
+// the number of rows that conflict.
+func (pa *patchApplier) mergeRowChanges(ctx *sql.Context, pending []patchRowChange) (int, error) {
+	roots, ok := pa.sess.GetRoots(ctx, pa.dbName)
+	if !ok {
+		return 0, fmt.Errorf("Could not load database %s", pa.dbName)
+	}
+	ours := roots.Working
+
+	for _, change := range pending {
+		if _, err := dsess.QueryRows(ctx, pa.runner, change.setup, nil); err != nil {
+			return 0, fmt.Errorf("error recording conflict for statement %s: %w", change.stmt, err)
+		}
+	}
+	if roots, ok = pa.sess.GetRoots(ctx, pa.dbName); !ok {
+		return 0, fmt.Errorf("Could not load database %s", pa.dbName)
+	}
+	base := roots.Working
+
+	for _, change := range pending {
+		affected, err := rowsAffected(ctx, pa.runner, change.stmt)
+		if err == nil && change.isUpdate && affected == 0 {
+			err = fmt.Errorf("the row doesn't have the values in the WHERE clause")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error recording conflict for statement %s: %w", change.stmt, err)
+		}
+	}
+	if roots, ok = pa.sess.GetRoots(ctx, pa.dbName); !ok {
+		return 0, fmt.Errorf("Could not load database %s", pa.dbName)
+	}
+	theirs := roots.Working
+
+	if err := pa.sess.SetWorkingRoot(ctx, pa.dbName, ours); err != nil {
+		return 0, err
+	}
+
+	// Conflicts refer to their base and their rows by the hash of a commit, so both are written as commits that aren't
+	// referenced by any ref. The base is a parent of the commit of their rows, which the conflicts keep.
+	head, err := pa.sess.GetHeadCommit(ctx, pa.dbName)
+	if err != nil {
+		return 0, err
+	}
+	meta, err := head.GetCommitMeta(ctx)
+	if err != nil {
+		return 0, err
+	}
+	baseCommit, err := doltdb.NewDanglingCommit(ctx, base, []*doltdb.Commit{head}, meta)
+	if err != nil {
+		return 0, err
+	}
+	theirCommit, err := doltdb.NewDanglingCommit(ctx, theirs, []*doltdb.Commit{head, baseCommit}, meta)
+	if err != nil {
+		return 0, err
+	}
+
+	tableResolver, err := dsess.GetTableResolver(ctx, pa.dbName)
+	if err != nil {
+		return 0, err
+	}
+	dbState, ok, err := pa.sess.LookupDbState(ctx, pa.dbName)
+	if err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, sql.ErrDatabaseNotFound.New(pa.dbName)
+	}
+	result, err := merge.MergeRoots(ctx, tableResolver, ours, theirs, base, theirCommit, baseCommit, dbState.EditOpts(), merge.MergeOpts{})
+	if err != nil {
+		return 0, err
+	}
+	if err = pa.sess.SetWorkingRoot(ctx, pa.dbName, result.Root); err != nil {
+		return 0, err
+	}
+
+	conflicts := 0
+	for _, stats := range result.Stats {
+		conflicts += stats.DataConflicts
+	}
+	return conflicts, nil
+}
+
+// stageTablesWithoutConflicts stages the tables changed in the working set that have no conflicts.
+func (pa *patchApplier) stageTablesWithoutConflicts(ctx *sql.Context) error {
+	roots, ok := pa.sess.GetRoots(ctx, pa.dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", pa.dbName)
+	}
+	deltas, err := diff.GetTableDeltas(ctx, roots.Staged, roots.Working)
+	if err != nil {
+		return err
+	}
+	withConflicts, err := doltdb.TablesWithDataConflicts(ctx, roots.Working)
+	if err != nil {
+		return err
+	}
+	conflicted := make(map[doltdb.TableName]bool, len(withConflicts))
+	for _, tblName := range withConflicts {
+		conflicted[tblName] = true
+	}
+
+	var tables []doltdb.TableName
+	for _, td := range deltas {
+		changed, err := td.HasChanges()
+		if err != nil {
+			return err
+		}
+		tblName := td.ToName
+		if td.IsDrop() {
+			tblName = td.FromName
+		}
+		if changed && !conflicted[tblName] {
+			tables = append(tables, tblName)
+		}
+	}
+
+	roots, err = actions.StageTables(ctx, roots, tables, true)
+	if err != nil {
+		return err
+	}
+	return pa.sess.SetRoots(ctx, pa.dbName, roots)
+}
+
+// tableSchema returns the schema of |tableName| in the working set.
+func (pa *patchApplier) tableSchema(ctx *sql.Context, tableName string) (schema.Schema, error) {
+	roots, ok := pa.sess.GetRoots(ctx, pa.dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", pa.dbName)
+	}
+	_, tbl, ok, err := resolve.Table(ctx, roots.Working, tableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(tableName)
+	}
+	return tbl.GetSchema(ctx)
+}
+
+// rowExists returns whether |tableName| has a row with the values of |row|.
+func (pa *patchApplier) rowExists(ctx *sql.Context, tableName string, sch schema.Schema, row []patchColumnValue) (bool, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", quoteIdent(tableName), rowCondition(sch, row))
+	rows, err := dsess.QueryRows(ctx, pa.runner, query, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) != 1 {
+		return false, fmt.Errorf("unexpected result of %s", query)
+	}
+	n, _, err := types.Int64.Convert(ctx, rows[0][0])
+	if err != nil {
+		return false, err
+	}
+	return n.(int64) > 0, nil
+}
+
+// rowsAffected runs the UPDATE or DELETE statement |stmt| and returns the number of rows it changed.
+func rowsAffected(ctx *sql.Context, runner sql.StatementRunner, stmt string) (uint64, error) {
+	rows, err := dsess.QueryRows(ctx, runner, stmt, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 1 && len(rows[0]) == 1 {
+		if res, ok := rows[0][0].(types.OkResult); ok {
+			return res.RowsAffected, nil
+		}
+	}
+	return 0, fmt.Errorf("unexpected result of %s", stmt)
+}
+
+// preimageSetup returns the statement that gives the row of |tableName| matched by |preimage| the values in it. The
+// values have to include every column of the table that a patch created with --preimage gives the old value of.
+func preimageSetup(tableName string, sch schema.Schema, preimage []patchColumnValue) (string, error) {
+	var cols, exprs []string
+	err := sch.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
+		if col.IsGenerated() {
+			return false, nil
+		}
+		expr, ok := valueOf(preimage, col.Name)
+		if !ok {
+			if col.TypeInfo.ToSqlType().Type() == querypb.Type_GEOMETRY {
+				return false, nil
+			}
+			return true, fmt.Errorf("the row it changes no longer matches, and the WHERE clause has no old value of "+
+				"column %s to record a conflict with; create the patch with --preimage", col.Name)
+		}
+		cols = append(cols, quoteIdent(col.Name))
+		exprs = append(exprs, expr)
+		return false, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("REPLACE INTO %s (%s) VALUES (%s)", quoteIdent(tableName), strings.Join(cols, ", "), strings.Join(exprs, ", ")), nil
+}
+
+// rowCondition returns a condition matching rows of |sch| with the values of |row|. JSON and FLOAT values are cast to
+// their type so they compare equal to the values they're written as, and spatial values are left out.
+func rowCondition(sch schema.Schema, row []patchColumnValue) string {
+	conds := make([]string, 0, len(row))
+	for _, v := range row {
+		expr := v.expr
+		if col, ok := sch.GetAllCols().GetByNameCaseInsensitive(v.col); ok {
+			switch col.TypeInfo.ToSqlType().Type() {
+			case querypb.Type_JSON:
+				expr = "CAST(" + expr + " AS JSON)"
+			case querypb.Type_FLOAT32:
+				expr = "CAST(" + expr + " AS FLOAT)"
+			case querypb.Type_GEOMETRY:
+				continue
+			}
+		}
+		conds = append(conds, quoteIdent(v.col)+" <=> "+expr)
+	}
+	if len(conds) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// whereValues returns the values the conjunction of column comparisons |where| matches, or nil if it isn't one.
+func whereValues(where *sqlparser.Where) []patchColumnValue {
+	if where == nil {
+		return nil
+	}
+	var values []patchColumnValue
+	var walk func(expr sqlparser.Expr) bool
+	walk = func(expr sqlparser.Expr) bool {
+		switch e := expr.(type) {
+		case *sqlparser.AndExpr:
+			return walk(e.Left) && walk(e.Right)
+		case *sqlparser.ParenExpr:
+			return walk(e.Expr)
+		case *sqlparser.ComparisonExpr:
+			col, ok := e.Left.(*sqlparser.ColName)
+			if !ok || (e.Operator != sqlparser.EqualStr && e.Operator != sqlparser.NullSafeEqualStr) {
+				return false
+			}
+			values = append(values, patchColumnValue{col: col.Name.String(), expr: sqlparser.String(e.Right)})
+			return true
+		default:
+			return false
+		}
+	}
+	if !walk(where.Expr) {
+		return nil
+	}
+	return values
+}
+
+// primaryKeyValues returns the values of |row| for the primary key columns of |sch|.
+func primaryKeyValues(sch schema.Schema, row []patchColumnValue) []patchColumnValue {
+	var pk []patchColumnValue
+	for _, v := range row {
+		if col, ok := sch.GetPKCols().GetByNameCaseInsensitive(v.col); ok && col.IsPartOfPK {
+			pk = append(pk, v)
+		}
+	}
+	return pk
+}
+
+func valueOf(row []patchColumnValue, col string) (string, bool) {
+	for _, v := range row {
+		if strings.EqualFold(v.col, col) {
+			return v.expr, true
+		}
+	}
+	return "", false
+}
+
+func withValue(row []patchColumnValue, col, expr string) []patchColumnValue {
+	for i, v := range row {
+		if strings.EqualFold(v.col, col) {
+			row[i].expr = expr
+			return row
+		}
+	}
+	return append(row, patchColumnValue{col: col, expr: expr})
+}
+
+// singleTableName returns the name of the table of an UPDATE or DELETE statement of one table.
+func singleTableName(exprs sqlparser.TableExprs) (string, error) {
+	tn, err := singleTable(exprs)
+	if err != nil {
+		return "", err
+	}
+	return tn.Name.String(), nil
+}
+
+func singleTable(exprs sqlparser.TableExprs) (sqlparser.TableName, error) {
+	if len(exprs) == 1 {
+		if aliased, ok := exprs[0].(*sqlparser.AliasedTableExpr); ok {
+			if tn, ok := aliased.Expr.(sqlparser.TableName); ok {
+				return tn, nil
+			}
+		}
+	}
+	return sqlparser.TableName{}, fmt.Errorf("only statements of a single table are supported")
+}
+
+func quoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
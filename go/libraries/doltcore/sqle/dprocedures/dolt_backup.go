@@ -17,6 +17,7 @@ package dprocedures
 import (
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -25,13 +26,17 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -45,6 +50,11 @@ const (
 	DoltBackupParamSync    = "sync"
 	DoltBackupParamSyncUrl = "sync-url"
 	DoltBackupParamRestore = "restore"
+
+	DoltBackupParamShipJournal = "ship-journal"
+
+	// journalArchiveDir is the path under the URL of a backup that holds its journal archive.
+	journalArchiveDir = "journal_archive"
 )
 
 var awsParamsUsage = []string{
@@ -56,7 +66,7 @@ var awsParamsUsage = []string{
 
 // doltBackup implements backup operations for Dolt databases. It routes |args| to the appropriate operation handler
 // based on the first argument. The procedure requires superuser privileges and write access to the current database.
-// Supported operations are: add, remove/rm, sync, sync-url, and restore.
+// Supported operations are: add, remove/rm, sync, sync-url, ship-journal, and restore.
 func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	apr, err := cli.CreateBackupArgParser().Parse(args)
 	if err != nil {
@@ -95,6 +105,13 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if apr.Contains(cli.PruneWithGracePeriod) && funcParam != DoltBackupParamSync && funcParam != DoltBackupParamSyncUrl {
 		return nil, fmt.Errorf("--%s is only supported with '%s' and '%s'", cli.PruneWithGracePeriod, DoltBackupParamSync, DoltBackupParamSyncUrl)
 	}
+	if apr.ContainsAny(cli.ToTimeFlag, cli.ToWorkingSetFlag, cli.RestorePointFlag) && funcParam != DoltBackupParamRestore {
+		return nil, fmt.Errorf("--%s, --%s and --%s are only supported with '%s'", cli.ToTimeFlag, cli.ToWorkingSetFlag, cli.RestorePointFlag, DoltBackupParamRestore)
+	}
+	// Shipping continuously is implemented by the dolt backup command, which calls this procedure repeatedly.
+	if apr.Contains(cli.IntervalFlag) {
+		return nil, fmt.Errorf("--%s is not supported by %s, call '%s' periodically instead", cli.IntervalFlag, DoltBackupProcedureName, DoltBackupParamShipJournal)
+	}
 
 	switch funcParam {
 	case DoltBackupParamAdd:
@@ -118,10 +135,20 @@ func doltBackup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 			return nil, errDoltBackupUsage(funcParam, []string{"remote_url"}, awsParamsUsage)
 		}
 		err = doltBackupSyncUrl(ctx, dbData, doltSess, apr)
+	case DoltBackupParamShipJournal:
+		if apr.NArg() != 2 {
+			return nil, errDoltBackupUsage(funcParam, []string{"name"}, nil)
+		}
+		err = doltBackupShipJournal(ctx, dbData, apr)
 	case DoltBackupParamRestore:
 		if apr.NArg() != 3 {
-			forceParamUsage := []string{fmt.Sprintf("--%s", cli.ForceFlag)}
-			return nil, errDoltBackupUsage(funcParam, []string{"remote_url", "new_db_name"}, append(forceParamUsage, awsParamsUsage...))
+			restoreParamUsage := []string{
+				fmt.Sprintf("--%s", cli.ForceFlag),
+				fmt.Sprintf("--%s=<time>", cli.ToTimeFlag),
+				fmt.Sprintf("--%s=<hash>", cli.ToWorkingSetFlag),
+				fmt.Sprintf("--%s=<name>", cli.RestorePointFlag),
+			}
+			return nil, errDoltBackupUsage(funcParam, []string{"remote_url", "new_db_name"}, append(restoreParamUsage, awsParamsUsage...))
 		}
 		err = doltBackupRestore(ctx, dbData, doltSess, apr)
 	default:
@@ -169,17 +196,75 @@ func doltBackupSync(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *ds
 		return err
 	}
 
-	backups, err := dbData.Rsr.GetBackups()
+	backupRemote, err := getBackup(dbData, backupName)
 	if err != nil {
 		return err
 	}
 
-	backupRemote, ok := backups.Get(backupName)
+	_, err = syncRemote(ctx, dbData, dsess, backupRemote, pruneGrace, 0)
+	return err
+}
+
+// SyncBackup syncs the database |dbName| to its backup |backupName| like dolt_backup('sync', ...), and records a
+// restore point for the synced root in the backup unless the newest restore point already has that root. Only the
+// newest |keepRestorePoints| restore points are kept. Returns the restore points in the backup, oldest first.
+func SyncBackup(ctx *sql.Context, dbName, backupName string, keepRestorePoints int) ([]doltdb.RestorePoint, error) {
+	if keepRestorePoints < 1 {
+		return nil, fmt.Errorf("at least one restore point must be kept, got %d", keepRestorePoints)
+	}
+	doltSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := doltSess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	backupRemote, err := getBackup(dbData, backupName)
+	if err != nil {
+		return nil, err
+	}
+	return syncRemote(ctx, dbData, doltSess, backupRemote, 0, keepRestorePoints)
+}
+
+// VerifyBackup checks the integrity of the chunks in the backup |backupName| of the database |dbName|, including the
+// chunks of every restore point kept in the backup. See [doltdb.DoltDB.VerifyChunks].
+func VerifyBackup(ctx *sql.Context, dbName, backupName string) (doltdb.ChunkVerification, error) {
+	doltSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := doltSess.GetDbData(ctx, dbName)
 	if !ok {
-		return env.ErrBackupNotFound.New(backupName)
+		return doltdb.ChunkVerification{}, sql.ErrDatabaseNotFound.New(dbName)
 	}
+	backupRemote, err := getBackup(dbData, backupName)
+	if err != nil {
+		return doltdb.ChunkVerification{}, err
+	}
+
+	destDb, err := doltSess.Provider().GetRemoteDB(ctx, dbData.Ddb.Format(), backupRemote)
+	if err != nil {
+		return doltdb.ChunkVerification{}, err
+	}
+	defer destDb.Close()
 
-	return syncRemote(ctx, dbData, dsess, backupRemote, pruneGrace)
+	points, err := destDb.RestorePoints(ctx)
+	if err != nil {
+		return doltdb.ChunkVerification{}, err
+	}
+	roots := make([]hash.Hash, len(points))
+	for i, p := range points {
+		roots[i] = p.Root
+	}
+	return destDb.VerifyChunks(ctx, roots...)
+}
+
+// getBackup returns the backup named |backupName| in the repository state of |dbData|.
+func getBackup(dbData env.DbData[*sql.Context], backupName string) (env.Remote, error) {
+	backups, err := dbData.Rsr.GetBackups()
+	if err != nil {
+		return env.Remote{}, err
+	}
+	backupRemote, ok := backups.Get(backupName)
+	if !ok {
+		return env.Remote{}, env.ErrBackupNotFound.New(backupName)
+	}
+	return backupRemote, nil
 }
 
 // backupPruneGracePeriod returns the grace period requested by --prune-with-grace-period, or 0 if the option was not
@@ -220,7 +305,8 @@ func doltBackupSyncUrl(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 	}
 
 	remote := env.NewRemote(DoltBackupParamSyncUrl, remoteUrl, remoteParams)
-	return syncRemote(ctx, dbData, dsess, remote, pruneGrace)
+	_, err = syncRemote(ctx, dbData, dsess, remote, pruneGrace, 0)
+	return err
 }
 
 // doltBackupRestore clones a database from the remote URL specified in |apr| into a new database with the name
@@ -230,12 +316,24 @@ func doltBackupSyncUrl(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 //
 // If the target database already exists, the restore operation fails unless the --force flag is provided, in which case
 // the existing database is dropped before cloning.
+//
+// If --to-time or --to-working-set is provided, the journal archive shipped alongside the backup by ship-journal is
+// replayed on top of the restored backup, up to the requested root. If --restore-point is provided, the database is
+// restored to that restore point of the backup rather than to its latest sync.
 func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, apr *argparser.ArgParseResults) error {
 	remoteUrlScheme, remoteUrl, err := newAbsRemoteUrl(dsess, apr.Arg(1))
 	if err != nil {
 		return err
 	}
 
+	archiveTarget, replayArchive, err := journalArchiveTarget(apr)
+	if err != nil {
+		return err
+	}
+	restorePointName, toRestorePoint := apr.GetValue(cli.RestorePointFlag)
+	if toRestorePoint && replayArchive {
+		return fmt.Errorf("--%s cannot be used with --%s or --%s", cli.RestorePointFlag, cli.ToTimeFlag, cli.ToWorkingSetFlag)
+	}
 	remoteParams, err := newParams(apr, remoteUrl, remoteUrlScheme)
 	if err != nil {
 		return err
@@ -250,6 +348,14 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 
 	remote := env.NewRemote(DoltBackupParamRestore, remoteUrl, remoteParams)
 
+	var archive blobstore.Blobstore
+	if replayArchive {
+		// Check for the archive before doing any work, so that a failed restore leaves nothing behind.
+		if archive, err = journalArchiveBlobstore(ctx, remote, false); err != nil {
+			return err
+		}
+	}
+
 	remoteDb, err := dsess.Provider().GetRemoteDB(ctx, types.Format_DOLT, remote)
 	if err != nil {
 		return err
@@ -260,6 +366,13 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 	// and follows the normal caching path.
 	defer remoteDb.Close()
 
+	var restorePoint doltdb.RestorePoint
+	if toRestorePoint {
+		if restorePoint, err = remoteDb.RestorePoint(ctx, restorePointName); err != nil {
+			return err
+		}
+	}
+
 	lookupDbName := apr.Arg(2)
 	hasLookupDb := dsess.Provider().HasDatabase(ctx, lookupDbName)
 	// We can't only check the databases from memory since this command can be run from subdirectories.
@@ -298,11 +411,159 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
 		err = actions.SyncRoots(ctx, remoteDb, newDb.DbData().Ddb, fileSys.TempDir(), actions.SyncRootsDBRelationshipUnrelated, statsCh)
 	})
-	if err == nil {
-		// XXX: Old SyncRoots ProgStarter behavior.
-		cli.Println()
+	if err != nil {
+		return err
 	}
-	return err
+	// XXX: Old SyncRoots ProgStarter behavior.
+	cli.Println()
+
+	// The restore points of the backup describe the backup, not the restored database.
+	newDdb := newDb.DbData().Ddb
+	if err = newDdb.SetRestorePoints(ctx, nil); err != nil {
+		return err
+	}
+	if toRestorePoint {
+		return restoreToRestorePoint(ctx, remoteDb, newDdb, fileSys.TempDir(), restorePoint)
+	}
+
+	if replayArchive {
+		restored, err := newDdb.RestoreFromJournalArchive(ctx, archive, archiveTarget)
+		if err != nil {
+			// Don't leave behind a database restored to a different point than the one requested.
+			if dropErr := dsess.Provider().DropDatabase(ctx, lookupDbName); dropErr != nil {
+				ctx.GetLogger().Warnf("dolt_backup: unable to drop %s after a failed restore: %v", lookupDbName, dropErr)
+			}
+			return err
+		}
+		ctx.GetLogger().Infof("dolt_backup: restored %s to root %s from its journal archive", lookupDbName, restored.String())
+	}
+	return nil
+}
+
+// doltBackupShipJournal ships the chunk journal records written since the last shipment to the journal archive of the
+// backup named in |apr|. Together with the backup itself, the archive allows restore to recover any root the
+// database was committed to, including uncommitted working set changes. Backups whose storage is not a blobstore, such
+// as aws:// backups, are not supported.
+func doltBackupShipJournal(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argparser.ArgParseResults) error {
+	backupRemote, err := getBackup(dbData, apr.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	journalPath, ok, err := dbData.Ddb.ChunkJournalPath(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("cannot ship the journal of database '%s': its storage does not use a chunk journal", ctx.GetCurrentDatabase())
+	}
+
+	archive, err := journalArchiveBlobstore(ctx, backupRemote, true)
+	if err != nil {
+		return err
+	}
+
+	n, err := nbs.NewJournalShipper(journalPath, archive).Ship(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		ctx.GetLogger().Debugf("dolt_backup: shipped %d journal bytes to %s", n, backupRemote.Url)
+	}
+	return nil
+}
+
+// journalArchiveTarget returns the root requested by --to-time or --to-working-set in |apr|, and false if neither
+// was supplied.
+func journalArchiveTarget(apr *argparser.ArgParseResults) (doltdb.JournalArchiveTarget, bool, error) {
+	toTime, hasTime := apr.GetValue(cli.ToTimeFlag)
+	toWorkingSet, hasWorkingSet := apr.GetValue(cli.ToWorkingSetFlag)
+	switch {
+	case hasTime && hasWorkingSet:
+		return doltdb.JournalArchiveTarget{}, false, fmt.Errorf("--%s and --%s cannot be used together", cli.ToTimeFlag, cli.ToWorkingSetFlag)
+	case hasTime:
+		t, err := dconfig.ParseDate(toTime)
+		if err != nil {
+			return doltdb.JournalArchiveTarget{}, false, fmt.Errorf("invalid --%s: %w", cli.ToTimeFlag, err)
+		}
+		return doltdb.JournalArchiveTarget{Time: t}, true, nil
+	case hasWorkingSet:
+		h, ok := hash.MaybeParse(strings.TrimSpace(toWorkingSet))
+		if !ok || h.IsEmpty() {
+			return doltdb.JournalArchiveTarget{}, false, fmt.Errorf("invalid --%s: '%s' is not a valid hash", cli.ToWorkingSetFlag, toWorkingSet)
+		}
+		return doltdb.JournalArchiveTarget{WorkingSet: h}, true, nil
+	default:
+		return doltdb.JournalArchiveTarget{}, false, nil
+	}
+}
+
+// journalArchiveBlobstore returns the blobstore holding the journal archive of |backup|, which is stored under the
+// backup's URL. Archives are supported for backups whose storage is a blobstore, such as file://, s3:// and gs://
+// backups. If |create| is true, the archive is prepared for shipping; otherwise a missing archive is an error.
+func journalArchiveBlobstore(ctx *sql.Context, backup env.Remote, create bool) (blobstore.Blobstore, error) {
+	u, err := earl.Parse(backup.Url)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, journalArchiveDir)
+	archiveUrl := u.String()
+
+	params := make(map[string]any, len(backup.Params))
+	for k, v := range backup.Params {
+		params[k] = v
+	}
+
+	if create {
+		// This fails for schemes that don't need preparing, as in syncRemote
+		_ = dbfactory.PrepareDB(ctx, types.Format_DOLT, archiveUrl, params)
+	}
+	archive, err := dbfactory.CreateBlobstore(ctx, archiveUrl, params)
+	if errors.Is(err, dbfactory.ErrBlobstoreUnsupported) {
+		return nil, fmt.Errorf("journal archives are not supported for backup '%s': its storage is not a blobstore", backup.Url)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !create {
+		ok, err := nbs.JournalArchiveExists(ctx, archive)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("backup '%s' does not have a journal archive; use '%s' to create one", backup.Url, DoltBackupParamShipJournal)
+		}
+	}
+	return archive, nil
+}
+
+// maxBackupCommitAttempts is the number of times a sync tries to set the root of a backup that is being modified
+// concurrently, as in actions.SyncRoots.
+const maxBackupCommitAttempts = 10
+
+// restoreToRestorePoint sets the root of |destDb|, which has just been restored from |backupDb|, to the root of
+// |restorePoint|, copying any chunks of that root it is missing from |backupDb|.
+func restoreToRestorePoint(ctx *sql.Context, backupDb, destDb *doltdb.DoltDB, tempDir string, restorePoint doltdb.RestorePoint) error {
+	var err error
+	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+		err = destDb.PullChunks(ctx, tempDir, backupDb, []hash.Hash{restorePoint.Root}, statsCh, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	last, err := destDb.NomsRoot(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := destDb.CommitRoot(ctx, restorePoint.Root, last)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("unable to restore to restore point '%s': the database was modified concurrently", restorePoint.Name)
+	}
+	return nil
 }
 
 // syncRemote syncs the roots from |dbData| to the remote specified by |remote|. It prepares the remote database
@@ -311,7 +572,11 @@ func doltBackupRestore(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess
 // to match the source.
 //
 // If |pruneGrace| is non-zero, prune stale table files in the destination before running the sync.
-func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, remote env.Remote, pruneGrace time.Duration) error {
+//
+// Restore points in the destination are kept across the sync. If |keepRestorePoints| is non-zero, a restore point is
+// recorded for the synced root, and only the newest |keepRestorePoints| are kept. Returns the restore points in the
+// destination after the sync, oldest first.
+func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.DoltSession, remote env.Remote, pruneGrace time.Duration, keepRestorePoints int) ([]doltdb.RestorePoint, error) {
 	params := make(map[string]any, len(remote.Params))
 	for k, v := range remote.Params {
 		params[k] = v
@@ -323,7 +588,7 @@ func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.
 
 	destDb, err := dsess.Provider().GetRemoteDB(ctx, dbData.Ddb.Format(), remote)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Close the backup database after the sync to release all file descriptors it holds. Without this,
 	// the process retains open file descriptors on the backup directory until exit. On network filesystems
@@ -335,18 +600,72 @@ func syncRemote(ctx *sql.Context, dbData env.DbData[*sql.Context], dsess *dsess.
 		pruneBackupDestination(ctx, destDb, remote, pruneGrace)
 	}
 
+	restorePoints, err := destDb.RestorePoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tempDir := dsess.GetFileSystem().TempDir()
+
+	if len(restorePoints) == 0 {
+		pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
+			err = actions.SyncRoots(ctx, dbData.Ddb, destDb, tempDir, actions.SyncRootsDBRelationshipUnknown, statsCh)
+		})
+		if err == nil {
+			// XXX: Old SyncRoots ProgStarter behavior.
+			cli.Println()
+		}
+		if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
+			return nil, err
+		}
+		if keepRestorePoints > 0 {
+			synced, err := destDb.NomsRoot(ctx)
+			if err != nil {
+				return nil, err
+			}
+			restorePoints = []doltdb.RestorePoint{doltdb.NewRestorePoint(time.Now(), synced)}
+			if err = destDb.SetRestorePoints(ctx, restorePoints); err != nil {
+				return nil, err
+			}
+		}
+		return restorePoints, nil
+	}
+
+	// The destination has restore points, which a sync by clone would replace. Pull the chunks of the source root
+	// instead, and set the destination's root to it together with the restore points, in a single update.
+	srcRoot, err := dbData.Ddb.NomsRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
 	pull.WithDiscardingStatsCh(func(statsCh chan pull.Stats) {
-		err = actions.SyncRoots(ctx, dbData.Ddb, destDb, dsess.GetFileSystem().TempDir(), actions.SyncRootsDBRelationshipUnknown, statsCh)
+		err = destDb.PullChunks(ctx, tempDir, dbData.Ddb, []hash.Hash{srcRoot}, statsCh, nil)
 	})
-	if err == nil {
-		// XXX: Old SyncRoots ProgStarter behavior.
-		cli.Println()
+	if err != nil {
+		return nil, err
 	}
-	if err != nil && !errors.Is(err, pull.ErrDBUpToDate) {
-		return err
+
+	if keepRestorePoints > 0 {
+		if restorePoints[len(restorePoints)-1].Root != srcRoot {
+			restorePoints = append(restorePoints, doltdb.NewRestorePoint(time.Now(), srcRoot))
+		}
+		if len(restorePoints) > keepRestorePoints {
+			restorePoints = restorePoints[len(restorePoints)-keepRestorePoints:]
+		}
 	}
 
-	return nil
+	for i := 0; i < maxBackupCommitAttempts; i++ {
+		destRoot, err := destDb.NomsRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := destDb.CommitRootWithRestorePoints(ctx, srcRoot, destRoot, restorePoints)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return restorePoints, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to sync backup %s: its root was modified concurrently", remote.Name)
 }
 
 // pruneBackupDestination reclaims unreferenced table files in |destDb|. Nothing is removed if
@@ -81,6 +81,8 @@ func doDoltBranch(ctx *sql.Context, args []string) (int, error) {
 		err = deleteBranches(ctx, dbData, apr, dSess, dbName, &rsc)
 	case apr.Contains(cli.SetUpstreamToFlag):
 		err = setBranchUpstream(ctx, dbData, apr, &rsc)
+	case apr.Contains(cli.RestoreFlag):
+		err = restoreBranches(ctx, dbData, apr, &rsc)
 	default:
 		err = createNewBranch(ctx, dbData, apr, &rsc)
 	}
@@ -297,6 +299,27 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argp
 	return nil
 }
 
+// restoreBranches restores each deleted branch named in |apr| as it was when it was most recently deleted.
+func restoreBranches(ctx *sql.Context, dbData env.DbData[*sql.Context], apr *argparser.ArgParseResults, rsc *doltdb.ReplicationStatusController) error {
+	if apr.NArg() == 0 {
+		return InvalidArgErr
+	}
+
+	for _, branchName := range apr.Args {
+		if len(branchName) == 0 {
+			return EmptyBranchNameErr
+		}
+		if err := branch_control.CanCreateBranch(ctx, branchName); err != nil {
+			return err
+		}
+		if err := actions.RestoreBranch(ctx, dbData, branchName, rsc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // shouldAllowDefaultBranchDeletion returns true if the default branch deletion check should be
 // bypassed for testing. This should only ever be true for tests that need to invalidate a databases
 // default branch to test recovery from a bad state. We determine if the check should be bypassed by
@@ -0,0 +1,116 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+)
+
+const materializedViewFullFlag = "--full"
+
+// MaterializedViews creates, refreshes and drops the materialized views of a database's working set
+type MaterializedViews interface {
+	Create(ctx *sql.Context, dbName, name, query string) error
+	Refresh(ctx *sql.Context, dbName string, names []string, full bool) error
+	Drop(ctx *sql.Context, dbName, name string) error
+}
+
+// MaterializedViewsImpl implements the materialized view procedures. It's set by the matview package, which can't be
+// imported here due to import cycles.
+var MaterializedViewsImpl MaterializedViews
+
+// doltCreateMaterializedView creates a materialized view of a query and fills its table with the query's result:
+//
+//	CALL dolt_create_materialized_view(name, query)
+func doltCreateMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("dolt_create_materialized_view expects a view name and a query")
+	}
+	dbName, err := materializedViewDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = MaterializedViewsImpl.Create(ctx, dbName, args[0], args[1]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltRefreshMaterializedView refreshes the materialized views named, or all of them if none are, in the working set.
+// With --full, their tables are built from scratch rather than from their tables in HEAD:
+//
+//	CALL dolt_refresh_materialized_view(['--full',] [name, ...])
+func doltRefreshMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	full := false
+	var names []string
+	for _, arg := range args {
+		if arg == materializedViewFullFlag {
+			full = true
+		} else {
+			names = append(names, arg)
+		}
+	}
+	dbName, err := materializedViewDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = MaterializedViewsImpl.Refresh(ctx, dbName, names, full); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltDropMaterializedView drops a materialized view and its table:
+//
+//	CALL dolt_drop_materialized_view(name)
+func doltDropMaterializedView(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("dolt_drop_materialized_view expects a view name")
+	}
+	dbName, err := materializedViewDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = MaterializedViewsImpl.Drop(ctx, dbName, args[0]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// materializedViewDatabase returns the current database, whose working set the materialized view procedures change
+func materializedViewDatabase(ctx *sql.Context) (string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return "", fmt.Errorf("Empty database name.")
+	}
+	if MaterializedViewsImpl == nil {
+		return "", fmt.Errorf("materialized views are not supported")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return "", err
+	}
+	isReadOnly, err := isReadOnlyDatabase(ctx, dbName)
+	if err != nil {
+		return "", err
+	}
+	if isReadOnly {
+		return "", fmt.Errorf("unable to change materialized views in read-only databases")
+	}
+	return dbName, nil
+}
@@ -223,6 +223,12 @@ func performMerge(
 	}
 
 	canFF, err := spec.HeadC.CanFastForwardTo(ctx, spec.MergeC)
+	if err == doltdb.ErrNoCommonAncestor {
+		if !spec.AllowUnrelated {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", merge.ErrUnrelatedHistories
+		}
+		canFF, err = false, nil
+	}
 	if err != nil {
 		switch err {
 		case doltdb.ErrIsAhead, doltdb.ErrUpToDate:
@@ -287,7 +293,7 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.AllowUnrelated, spec.DetectRenames, spec.HeadC, spec.MergeC, spec.BaseC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -338,7 +344,9 @@ func executeMerge(
 	dbName string,
 	squash bool,
 	force bool,
-	head, cm *doltdb.Commit,
+	allowUnrelated bool,
+	detectRenames bool,
+	head, cm, base *doltdb.Commit,
 	cmSpec string,
 	ws *doltdb.WorkingSet,
 	opts editor.Options,
@@ -348,7 +356,10 @@ func executeMerge(
 	if err != nil {
 		return nil, err
 	}
-	result, err := merge.MergeCommits(ctx, sqlDB, head, cm, opts)
+	result, err := merge.MergeCommits(ctx, sqlDB, head, cm, opts, detectRenames)
+	if err == doltdb.ErrNoCommonAncestor && allowUnrelated {
+		result, err = merge.MergeUnrelatedCommits(ctx, sqlDB, head, cm, base, opts, detectRenames)
+	}
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
@@ -359,7 +370,11 @@ func executeMerge(
 			return nil, err
 		}
 	}
-	return mergeRootToWorking(ctx, sess, dbName, squash, force, ws, result, workingDiffs, cm, cmSpec, head)
+	ws, err = mergeRootToWorking(ctx, sess, dbName, squash, force, ws, result, workingDiffs, cm, cmSpec, head, base)
+	if err == doltdb.ErrUnresolvedConflictsOrViolations {
+		return resolveConflictsWithProcedures(ctx, sess, dbName, ws, result)
+	}
+	return ws, err
 }
 
 func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.WorkingSet, dbData env.DbData[*sql.Context], cm2 *doltdb.Commit, spec *merge.MergeSpec) (*doltdb.WorkingSet, error) {
@@ -428,7 +443,7 @@ func executeNoFFMerge(
 	}
 	result := &merge.Result{Root: mergeRoot, Stats: make(map[doltdb.TableName]*merge.MergeStats)}
 
-	ws, err = mergeRootToWorking(ctx, dSess, dbName, false, spec.Force, ws, result, spec.WorkingDiffs, spec.MergeC, spec.MergeCSpecStr, spec.HeadC)
+	ws, err = mergeRootToWorking(ctx, dSess, dbName, false, spec.Force, ws, result, spec.WorkingDiffs, spec.MergeC, spec.MergeCSpecStr, spec.HeadC, nil)
 	if err != nil {
 		// This error is recoverable, so we return a working set value along with the error
 		return ws, nil, err
@@ -534,6 +549,11 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		return nil, errors.New("cannot define both 'commit' and 'no-commit' flags at the same time")
 	}
 
+	base, _ := apr.GetValue(cli.BaseParam)
+	if base != "" && !apr.Contains(cli.AllowUnrelatedFlag) {
+		return nil, fmt.Errorf("--%s can only be used with --%s", cli.BaseParam, cli.AllowUnrelatedFlag)
+	}
+
 	// Determine FastForwardMode based on flags. validation of mutually exclusive flags done earlier
 	var ffMode merge.FastForwardMode = merge.FastForwardDefault
 	if apr.Contains(cli.NoFFParam) {
@@ -556,6 +576,8 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithAllowUnrelatedHistories(apr.Contains(cli.AllowUnrelatedFlag), base),
+		merge.WithDetectRenames(apr.Contains(cli.DetectRenamesFlag)),
 	)
 }
 
@@ -569,7 +591,7 @@ func mergeRootToWorking(
 	workingDiffs map[doltdb.TableName]hash.Hash,
 	cm2 *doltdb.Commit,
 	cm2Spec string,
-	headCommit *doltdb.Commit,
+	headCommit, baseCommit *doltdb.Commit,
 ) (*doltdb.WorkingSet, error) {
 	var err error
 	staged, working := merged.Root, merged.Root
@@ -583,7 +605,7 @@ func mergeRootToWorking(
 	if !squash || merged.HasSchemaConflicts() {
 		ws = ws.StartMerge(headCommit, cm2, cm2Spec)
 		tt := merge.SchemaConflictTableNames(merged.SchemaConflicts)
-		ws = ws.WithUnmergableTables(tt)
+		ws = ws.WithUnmergableTables(tt).WithMergeBase(baseCommit)
 	}
 
 	ws = ws.WithWorkingRoot(working)
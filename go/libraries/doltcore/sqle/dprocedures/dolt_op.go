@@ -0,0 +1,111 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltOp is the stored procedure version of the CLI command `dolt op restore`, which restores the refs and working
+// sets of the current database to their state after an operation in its operation log.
+func doltOp(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 2 || args[0] != "restore" {
+		return nil, fmt.Errorf("error: invalid arguments. Usage: dolt_op('restore', <operation id>)")
+	}
+	id, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid operation id '%s'", args[1])
+	}
+
+	dbName, _ := doltdb.SplitRevisionDbName(ctx.GetCurrentDatabase())
+	if dbName == "" {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	current, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session's own working set is restored through the session, like a hard reset, so that its transaction
+	// commits the restored working set rather than overwriting it
+	ws, _, err := dbData.Ddb.RestoreOperation(ctx, id, current.Ref())
+	if err != nil {
+		return nil, err
+	}
+	if ws != nil {
+		if err = dSess.SetWorkingSet(ctx, dbName, ws); err != nil {
+			return nil, err
+		}
+		if err = dSess.ResetGlobals(ctx, dbName, ws.WorkingRoot()); err != nil {
+			return nil, err
+		}
+	}
+	return rowToIter(int64(cmdSuccess)), nil
+}
+
+// sensitiveOperationFlags are the flags whose values are redacted from the descriptions of operations.
+var sensitiveOperationFlags = []string{"--password", "--token", "--secret"}
+
+// operationDescription returns the description of a call of the procedure |name| with |args| recorded in the operation
+// log. The query text itself isn't recorded, since it can hold anything, and credentials are redacted from the args.
+func operationDescription(name string, args []string) string {
+	quoted := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			arg = "xxxxx"
+			redactNext = false
+		case strings.HasPrefix(arg, "--"):
+			for _, flag := range sensitiveOperationFlags {
+				if strings.EqualFold(arg, flag) {
+					redactNext = true
+				} else if len(arg) > len(flag) && strings.EqualFold(arg[:len(flag)+1], flag+"=") {
+					arg = arg[:len(flag)+1] + "xxxxx"
+				}
+			}
+		default:
+			if u, err := url.Parse(arg); err == nil && u.User != nil {
+				arg = u.Redacted()
+			}
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+	}
+	return fmt.Sprintf("call %s(%s)", name, strings.Join(quoted, ", "))
+}
+
+// operation returns the procedure |name| implemented by |fn| as an operation, whose changes to working sets are
+// recorded in the operation log along with its changes to refs.
+func operation(name string, fn func(*sql.Context, ...string) (sql.RowIter, error)) func(*sql.Context, ...string) (sql.RowIter, error) {
+	return func(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+		if sess, ok := ctx.Session.(*dsess.DoltSession); ok {
+			sess.MarkOperation(operationDescription(name, args))
+		}
+		return fn(ctx, args...)
+	}
+}
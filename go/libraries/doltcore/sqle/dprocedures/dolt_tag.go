@@ -56,6 +56,20 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("error: invalid argument, use 'dolt_tags' system table to list tags")
 	}
 
+	// restore deleted tags
+	if apr.Contains(cli.RestoreFlag) {
+		if apr.Contains(cli.DeleteFlag) {
+			return 1, fmt.Errorf("delete and restore options are incompatible")
+		} else if apr.Contains(cli.MessageArg) {
+			return 1, fmt.Errorf("restore and tag message options are incompatible")
+		}
+		err = actions.RestoreTagsOnDB(ctx, dbData.Ddb, apr.Args...)
+		if err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
 	// delete tag
 	if apr.Contains(cli.DeleteFlag) {
 		if apr.Contains(cli.MessageArg) {
@@ -0,0 +1,128 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	xaOnePhaseFlag   = "--one-phase"
+	xaConvertXIDFlag = "--convert-xid"
+)
+
+var doltXARecoverSchema = []*sql.Column{
+	{Name: "formatID", Type: types.Int64, Nullable: false},
+	{Name: "gtrid_length", Type: types.Int64, Nullable: false},
+	{Name: "bqual_length", Type: types.Int64, Nullable: false},
+	{Name: "data", Type: types.LongText, Nullable: false},
+}
+
+// doltXA runs the XA statement named by its first argument, which sql-server clients can also run as an XA statement:
+//
+//	XA {START|BEGIN} xid            CALL dolt_xa('start', gtrid[, bqual[, formatID]])
+//	XA END xid                      CALL dolt_xa('end', gtrid[, bqual[, formatID]])
+//	XA PREPARE xid                  CALL dolt_xa('prepare', gtrid[, bqual[, formatID]])
+//	XA COMMIT xid [ONE PHASE]       CALL dolt_xa('commit'[, '--one-phase'], gtrid[, bqual[, formatID]])
+//	XA ROLLBACK xid                 CALL dolt_xa('rollback', gtrid[, bqual[, formatID]])
+func doltXA(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 2 {
+		return nil, dsess.ErrXAInvalid
+	}
+	command, args := strings.ToLower(args[0]), args[1:]
+
+	onePhase := false
+	if command == "commit" && args[0] == xaOnePhaseFlag {
+		onePhase, args = true, args[1:]
+	}
+	xid, err := parseXID(args)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	switch command {
+	case "start", "begin":
+		err = sess.XAStart(ctx, xid)
+	case "end":
+		err = sess.XAEnd(ctx, xid)
+	case "prepare":
+		err = sess.XAPrepare(ctx, xid)
+	case "commit":
+		err = sess.XACommit(ctx, xid, onePhase)
+	case "rollback":
+		err = sess.XARollback(ctx, xid)
+	default:
+		return nil, fmt.Errorf("unknown XA command: %s", command)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// parseXID returns the XID with the gtrid, bqual and formatID in |args|, of which only gtrid is required
+func parseXID(args []string) (dsess.XID, error) {
+	if len(args) == 0 || len(args) > 3 {
+		return dsess.XID{}, dsess.ErrXAInvalid
+	}
+	gtrid, bqual, formatID := args[0], "", int64(1)
+	if len(args) > 1 {
+		bqual = args[1]
+	}
+	if len(args) > 2 {
+		var err error
+		formatID, err = strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return dsess.XID{}, dsess.ErrXAInvalid
+		}
+	}
+	return dsess.NewXID(gtrid, bqual, formatID)
+}
+
+// doltXARecover lists the prepared XA transactions, as XA RECOVER [CONVERT XID] does. With --convert-xid, the data
+// column is hex encoded.
+func doltXARecover(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	convertXID := false
+	for _, arg := range args {
+		if arg != xaConvertXIDFlag {
+			return nil, fmt.Errorf("unknown argument: %s", arg)
+		}
+		convertXID = true
+	}
+
+	xids, err := dsess.DSessFromSess(ctx.Session).XARecover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(xids))
+	for i, xid := range xids {
+		data := xid.Gtrid + xid.Bqual
+		if convertXID {
+			data = "0x" + hex.EncodeToString([]byte(data))
+		}
+		rows[i] = sql.Row{xid.FormatID, int64(len(xid.Gtrid)), int64(len(xid.Bqual)), data}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -20,37 +20,44 @@ import (
 )
 
 var DoltProcedures = []sql.ExternalStoredProcedureDetails{
-	{Name: "dolt_add", Schema: int64Schema("status"), Function: doltAdd},
+	{Name: "dolt_add", Schema: int64Schema("status"), Function: operation("dolt_add", doltAdd)},
+	{Name: "dolt_apply_patch", Schema: doltApplyPatchSchema, Function: operation("dolt_apply_patch", doltApplyPatch)},
 	{Name: "dolt_backup", Schema: int64Schema("status"), Function: doltBackup, ReadOnly: true, AdminOnly: true},
-	{Name: "dolt_branch", Schema: int64Schema("status"), Function: doltBranch},
-	{Name: "dolt_checkout", Schema: doltCheckoutSchema, Function: doltCheckout, ReadOnly: true},
-	{Name: "dolt_cherry_pick", Schema: cherryPickSchema, Function: doltCherryPick},
-	{Name: "dolt_clean", Schema: int64Schema("status"), Function: doltClean},
+	{Name: "dolt_branch", Schema: int64Schema("status"), Function: operation("dolt_branch", doltBranch)},
+	{Name: "dolt_checkout", Schema: doltCheckoutSchema, Function: operation("dolt_checkout", doltCheckout), ReadOnly: true},
+	{Name: "dolt_cherry_pick", Schema: cherryPickSchema, Function: operation("dolt_cherry_pick", doltCherryPick)},
+	{Name: "dolt_clean", Schema: int64Schema("status"), Function: operation("dolt_clean", doltClean)},
 	{Name: "dolt_clone", Schema: int64Schema("status"), Function: doltClone, AdminOnly: true},
-	{Name: "dolt_commit", Schema: stringSchema("hash"), Function: doltCommit},
+	{Name: "dolt_commit", Schema: stringSchema("hash"), Function: operation("dolt_commit", doltCommit)},
 	{Name: "dolt_commit_hash_out", Schema: stringSchema("hash"), Function: doltCommitHashOut},
-	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: doltConflictsResolve},
+	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: operation("dolt_conflicts_resolve", doltConflictsResolve)},
 	{Name: "dolt_count_commits", Schema: int64Schema("ahead", "behind"), Function: doltCountCommits, ReadOnly: true},
+	{Name: "dolt_create_materialized_view", Schema: int64Schema("status"), Function: doltCreateMaterializedView},
+	{Name: "dolt_drop_materialized_view", Schema: int64Schema("status"), Function: doltDropMaterializedView},
 	{Name: "dolt_fetch", Schema: int64Schema("status"), Function: doltFetch, AdminOnly: true},
 	{Name: "dolt_undrop", Schema: int64Schema("status"), Function: doltUndrop, AdminOnly: true},
 	{Name: "dolt_update_column_tag", Schema: int64Schema("status"), Function: doltUpdateColumnTag, AdminOnly: true},
 	{Name: "dolt_purge_dropped_databases", Schema: int64Schema("status"), Function: doltPurgeDroppedDatabases, AdminOnly: true},
-	{Name: "dolt_rebase", Schema: doltRebaseProcedureSchema, Function: doltRebase},
-	{Name: "dolt_rm", Schema: int64Schema("status"), Function: doltRm},
+	{Name: "dolt_op", Schema: int64Schema("status"), Function: operation("dolt_op", doltOp), AdminOnly: true},
+	{Name: "dolt_rebase", Schema: doltRebaseProcedureSchema, Function: operation("dolt_rebase", doltRebase)},
+	{Name: "dolt_rm", Schema: int64Schema("status"), Function: operation("dolt_rm", doltRm)},
 
 	{Name: "dolt_gc", Schema: int64Schema("status"), Function: doltGC, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_thread_dump", Schema: stringSchema("thread_dump"), Function: doltThreadDump, ReadOnly: true, AdminOnly: true},
 
-	{Name: "dolt_merge", Schema: doltMergeSchema, Function: doltMerge},
-	{Name: "dolt_pull", Schema: doltPullSchema, Function: doltPull, AdminOnly: true},
+	{Name: "dolt_merge", Schema: doltMergeSchema, Function: operation("dolt_merge", doltMerge)},
+	{Name: "dolt_pull", Schema: doltPullSchema, Function: operation("dolt_pull", doltPull), AdminOnly: true},
 	{Name: "dolt_push", Schema: doltPushSchema, Function: doltPush, AdminOnly: true},
 	{Name: "dolt_remote", Schema: int64Schema("status"), Function: doltRemote, AdminOnly: true},
-	{Name: "dolt_reset", Schema: int64Schema("status"), Function: doltReset},
-	{Name: "dolt_revert", Schema: doltRevertSchema, Function: doltRevert},
-	{Name: "dolt_squash_history", Schema: stringSchema("hash"), Function: doltSquashHistory},
-	{Name: "dolt_stash", Schema: int64Schema("status"), Function: doltStash},
-	{Name: "dolt_tag", Schema: int64Schema("status"), Function: doltTag},
+	{Name: "dolt_refresh_materialized_view", Schema: int64Schema("status"), Function: doltRefreshMaterializedView},
+	{Name: "dolt_reset", Schema: int64Schema("status"), Function: operation("dolt_reset", doltReset)},
+	{Name: "dolt_revert", Schema: doltRevertSchema, Function: operation("dolt_revert", doltRevert)},
+	{Name: "dolt_squash_history", Schema: stringSchema("hash"), Function: operation("dolt_squash_history", doltSquashHistory)},
+	{Name: "dolt_stash", Schema: int64Schema("status"), Function: operation("dolt_stash", doltStash)},
+	{Name: "dolt_tag", Schema: int64Schema("status"), Function: operation("dolt_tag", doltTag)},
 	{Name: "dolt_verify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
+	{Name: "dolt_xa", Schema: int64Schema("status"), Function: doltXA},
+	{Name: "dolt_xa_recover", Schema: doltXARecoverSchema, Function: doltXARecover, ReadOnly: true},
 
 	{Name: "dolt_stats_restart", Schema: statsFuncSchema, Function: statsFunc(statsRestart)},
 	{Name: "dolt_stats_stop", Schema: statsFuncSchema, Function: statsFunc(statsStop)},
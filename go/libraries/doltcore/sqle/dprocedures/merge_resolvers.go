@@ -0,0 +1,66 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// resolveConflictsWithProcedures resolves the data conflicts of a merge with the session's merge resolvers, see
+// dsess.DoltSession.ResolveMergeConflicts.
+//
+// If every conflict and constraint violation was resolved, the merged tables are staged and nil is returned, so the
+// merge can be committed. Otherwise ErrUnresolvedConflictsOrViolations is returned along with the working set.
+func resolveConflictsWithProcedures(ctx *sql.Context, sess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, merged *merge.Result) (*doltdb.WorkingSet, error) {
+	if merged.HasSchemaConflicts() {
+		return ws, doltdb.ErrUnresolvedConflictsOrViolations
+	}
+	resolved, ok, err := sess.ResolveMergeConflicts(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	ws, err = sess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return ws, doltdb.ErrUnresolvedConflictsOrViolations
+	}
+
+	// Only the resolved tables are staged, any other changes to the working set aren't part of the merge
+	working := ws.WorkingRoot()
+	staged := merged.Root
+	for _, tblName := range resolved {
+		tbl, ok, err := working.GetTable(ctx, tblName)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		staged, err = staged.PutTable(ctx, tblName, tbl)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ws = ws.WithStagedRoot(staged)
+	if err = sess.SetWorkingSet(ctx, dbName, ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
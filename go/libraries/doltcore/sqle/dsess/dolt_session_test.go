@@ -406,3 +406,19 @@ func (e emptyRevisionDatabaseProvider) EngineOverrides() sql.EngineOverrides {
 func (e emptyRevisionDatabaseProvider) TxLocks() keymutex.Keymutex {
 	return keymutex.NewMapped()
 }
+
+func (e emptyRevisionDatabaseProvider) RowLocks() *RowLockManager {
+	return NewRowLockManager()
+}
+
+func (e emptyRevisionDatabaseProvider) XATransactions() *XATransactions {
+	return NewXATransactions()
+}
+
+func (e emptyRevisionDatabaseProvider) StatementRunner() sql.StatementRunner {
+	return nil
+}
+
+func (e emptyRevisionDatabaseProvider) QueryCache() *QueryCache {
+	return NewQueryCache()
+}
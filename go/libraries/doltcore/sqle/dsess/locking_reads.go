@@ -0,0 +1,291 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+// LockingRead is the locking clause of a SELECT statement, such as FOR UPDATE SKIP LOCKED
+type LockingRead struct {
+	Mode   RowLockMode
+	Policy RowLockPolicy
+	// tables are the lower case names of the tables named by FOR UPDATE OF, nil when every table read is locked
+	tables map[string]struct{}
+}
+
+// LocksTable returns whether rows read from the table named |name| are locked
+func (r *LockingRead) LocksTable(name string) bool {
+	if r.tables == nil {
+		return true
+	}
+	_, ok := r.tables[strings.ToLower(name)]
+	return ok
+}
+
+// lockingReadStatement caches the locking clause of the statement a session is running, so that the statement is
+// parsed once rather than once for every table it reads
+type lockingReadStatement struct {
+	query     string
+	queryTime time.Time
+	read      *LockingRead
+}
+
+// LockingReadForTable returns the locking clause of the current statement of |ctx| if it locks the rows read from
+// the table named |table|, or nil when it doesn't.
+func LockingReadForTable(ctx *sql.Context, table string) *LockingRead {
+	read := currentLockingRead(ctx)
+	if read == nil || !read.LocksTable(table) {
+		return nil
+	}
+	return read
+}
+
+// IsLockingRead returns whether the current statement of |ctx| is a locking read
+func IsLockingRead(ctx *sql.Context) bool {
+	return currentLockingRead(ctx) != nil
+}
+
+func currentLockingRead(ctx *sql.Context) *LockingRead {
+	sess, ok := ctx.Session.(*DoltSession)
+	if !ok {
+		return nil
+	}
+	query, queryTime := ctx.Query(), ctx.QueryTime()
+	stmt := sess.lockingRead.Load()
+	if stmt == nil || stmt.query != query || !stmt.queryTime.Equal(queryTime) {
+		stmt = &lockingReadStatement{query: query, queryTime: queryTime, read: parseLockingRead(query)}
+		sess.lockingRead.Store(stmt)
+	}
+	return stmt.read
+}
+
+// parseLockingRead returns the locking clause of |query|, or nil if it isn't a SELECT with one. Only the clause of the
+// outermost query block is honored.
+func parseLockingRead(query string) *LockingRead {
+	lower := strings.ToLower(query)
+	if !strings.Contains(lower, "for update") && !strings.Contains(lower, "share mode") {
+		return nil
+	}
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Lock == nil {
+		return nil
+	}
+
+	read := &LockingRead{Mode: RowLockExclusive}
+	lockType := sel.Lock.Type
+	switch {
+	case lockType == sqlparser.ShareModeStr:
+		read.Mode = RowLockShared
+	case !strings.HasPrefix(lockType, sqlparser.ForUpdateStr):
+		return nil
+	}
+	if strings.HasSuffix(lockType, " nowait") {
+		read.Policy = RowLockNoWait
+	} else if strings.HasSuffix(lockType, " skip locked") {
+		read.Policy = RowLockSkipLocked
+	}
+
+	if len(sel.Lock.Tables) > 0 {
+		// FOR UPDATE OF names tables by their alias when they have one
+		aliases := make(map[string]string)
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if ate, ok := node.(*sqlparser.AliasedTableExpr); ok && !ate.As.IsEmpty() {
+				if tn, ok := ate.Expr.(sqlparser.TableName); ok {
+					aliases[strings.ToLower(ate.As.String())] = strings.ToLower(tn.Name.String())
+				}
+			}
+			return true, nil
+		}, sel.From)
+
+		read.tables = make(map[string]struct{})
+		for _, tn := range sel.Lock.Tables {
+			name := strings.ToLower(tn.Name.String())
+			if table, ok := aliases[name]; ok {
+				name = table
+			}
+			read.tables[name] = struct{}{}
+		}
+	}
+	return read
+}
+
+// lockWaitTimeout returns the longest a locking read in the session of |ctx| waits for a row lock
+func lockWaitTimeout(ctx *sql.Context) time.Duration {
+	timeout, err := ctx.GetSessionVariable(ctx, DoltLockWaitTimeout)
+	if err != nil {
+		return 0
+	}
+	secs, ok := timeout.(int64)
+	if !ok {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// RowLockTarget is the branch of a database that a locking read takes its row locks on
+type RowLockTarget struct {
+	Database string
+	Branch   string
+	ddb      *doltdb.DoltDB
+	wsRef    ref.WorkingSetRef
+}
+
+// RowLockTarget returns the branch of the database named |dbName| whose rows are locked by locking reads. Returns false
+// for databases that aren't on a branch, whose rows can't be changed by other transactions.
+func (d *DoltSession) RowLockTarget(ctx *sql.Context, dbName string) (RowLockTarget, bool, error) {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil || !ok {
+		return RowLockTarget{}, false, err
+	}
+	ws := bs.WorkingSet()
+	if ws == nil || bs.dbData.Ddb == nil {
+		return RowLockTarget{}, false, nil
+	}
+	return RowLockTarget{
+		Database: strings.ToLower(bs.dbState.dbName),
+		Branch:   bs.head,
+		ddb:      bs.dbData.Ddb,
+		wsRef:    ws.Ref(),
+	}, true, nil
+}
+
+// LockRow takes the lock on the row |key| for the current transaction, in the mode and with the policy of |read|. A
+// lock wait that would deadlock rolls the transaction back, as MySQL does.
+func (d *DoltSession) LockRow(ctx *sql.Context, key RowLockKey, data string, read *LockingRead) (acquired bool, waited bool, err error) {
+	acquired, waited, err = d.provider.RowLocks().Lock(ctx, key, data, read.Mode, read.Policy, lockWaitTimeout(ctx))
+	if err != nil && sql.ErrLockDeadlock.Is(err) {
+		if tx, ok := ctx.GetTransaction().(*DoltTransaction); ok {
+			return false, waited, tx.rollbackAndErr(ctx, err)
+		}
+	}
+	return acquired, waited, err
+}
+
+// RowLockReleases returns the number of times any session released its row locks
+func (d *DoltSession) RowLockReleases() uint64 {
+	return d.provider.RowLocks().Releases()
+}
+
+// releaseRowLocks releases the row locks held by this session, at the end of its transaction
+func (d *DoltSession) releaseRowLocks() {
+	if d.provider != nil {
+		if locks := d.provider.RowLocks(); locks != nil {
+			locks.ReleaseAll(d)
+		}
+	}
+}
+
+// TransactionStartRoot returns the working root of |target| when the current transaction began, or false when the
+// branch didn't exist then.
+func (d *DoltSession) TransactionStartRoot(ctx *sql.Context, target RowLockTarget) (doltdb.RootValue, bool, error) {
+	tx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return nil, false, nil
+	}
+	startPoint, ok := tx.dbStartPoints[target.Database]
+	if !ok {
+		return nil, false, nil
+	}
+	ws, err := target.ddb.ResolveWorkingSetAtRoot(ctx, target.wsRef, startPoint.rootHash)
+	if err == doltdb.ErrWorkingSetNotFound {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return ws.WorkingRoot(), true, nil
+}
+
+// LatestRoot returns the working root most recently committed to |target| by any transaction
+func (target RowLockTarget) LatestRoot(ctx *sql.Context) (doltdb.RootValue, error) {
+	ws, err := target.ddb.ResolveWorkingSet(ctx, target.wsRef)
+	if err != nil {
+		return nil, err
+	}
+	return ws.WorkingRoot(), nil
+}
+
+// AdvanceTransaction moves the start of the current transaction on the database of |target| to the latest committed
+// root, so that its next statements see the changes committed since it began. A locking read does this when another
+// transaction changed a row of |table| that it locked, so that the transaction goes on to update the row it locked
+// rather than the version in its snapshot. A transaction that can't be advanced, because it has changes to the
+// database, savepoints, or is SERIALIZABLE, is rolled back with a retryable error instead.
+func (d *DoltSession) AdvanceTransaction(ctx *sql.Context, target RowLockTarget, table string) error {
+	tx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return nil
+	}
+	advanced, err := d.advanceTransaction(ctx, tx, target)
+	if err != nil || advanced {
+		return err
+	}
+	return AbortLockingRead(ctx, table)
+}
+
+// AbortLockingRead rolls back the current transaction with a retryable error, for a locking read that locked a row of
+// |table| which was changed by a concurrent transaction in a way the locking read can't return.
+func AbortLockingRead(ctx *sql.Context, table string) error {
+	detail := fmt.Sprintf("a row of table %s locked by this transaction was changed by a concurrent transaction", table)
+	if tx, ok := ctx.GetTransaction().(*DoltTransaction); ok {
+		return tx.rollbackAndErr(ctx, retryTransactionError(detail))
+	}
+	return retryTransactionError(detail)
+}
+
+func (d *DoltSession) advanceTransaction(ctx *sql.Context, tx *DoltTransaction, target RowLockTarget) (bool, error) {
+	if tx.IsSerializable() || len(tx.savepoints) > 0 {
+		return false, nil
+	}
+	startPoint, ok := tx.dbStartPoints[target.Database]
+	if !ok {
+		return false, nil
+	}
+	nomsRoot, err := startPoint.db.NomsRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dbState, ok := d.dbStates[target.Database]
+	if !ok {
+		return false, nil
+	}
+	for _, bs := range dbState.heads {
+		if bs.dirty {
+			return false, nil
+		}
+	}
+
+	startPoint.rootHash = nomsRoot
+	tx.dbStartPoints[target.Database] = startPoint
+	// the branch states are loaded again from the new start point when they're next used
+	for head := range dbState.heads {
+		delete(dbState.heads, head)
+	}
+	return true, nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+var errNoStatementRunner = errors.New("merge resolvers can't be run: no engine is registered with the database provider")
+
+// ResolveMergeConflicts resolves the data conflicts in the working set of |dbName|, left there by a merge,
+// cherry-pick, revert or rebase, with the stored procedures registered in the dolt_merge_resolvers table of the
+// session's HEAD. For every conflict of a table with a resolver, the procedure is called with the base, our and their
+// rows as JSON objects keyed by column name, NULL for a row that doesn't exist on that side (a procedure parameter
+// declared as JSON rather than TEXT receives that as a JSON null).
+// The first row of the result set the procedure returns, with the table's columns in order, is written to our side
+// and the conflict is removed. A procedure that returns no rows leaves the conflict in dolt_conflicts_<table>.
+//
+// The procedures and the statements that apply their results run through the provider's engine, in this session and
+// transaction, so they're checked against the privileges of the session's user.
+//
+// Returns the tables whose conflicts were resolved, for the caller to stage, and whether the working set is left
+// without any conflicts or constraint violations.
+func (d *DoltSession) ResolveMergeConflicts(ctx *sql.Context, dbName string) ([]doltdb.TableName, bool, error) {
+	head, err := d.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return nil, false, err
+	}
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	resolvers, err := merge.LoadMergeResolvers(ctx, headRoot)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(resolvers) == 0 {
+		return nil, false, nil
+	}
+	runner := d.provider.StatementRunner()
+	if runner == nil {
+		return nil, false, errNoStatementRunner
+	}
+
+	ws, err := d.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, false, err
+	}
+	tables, err := doltdb.TablesWithDataConflicts(ctx, ws.WorkingRoot())
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The resolvers run in this session and transaction, which is committed along with the merge
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	ctx.SetIgnoreAutoCommit(true)
+	defer ctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+
+	var resolved []doltdb.TableName
+	for _, tblName := range tables {
+		proc, ok := resolvers[strings.ToLower(tblName.Name)]
+		if !ok {
+			continue
+		}
+		tbl, ok, err := ws.WorkingRoot().GetTable(ctx, tblName)
+		if err != nil {
+			return nil, false, err
+		} else if !ok {
+			continue
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		// Keyless rows are identified by their values, so there's no row for a resolver to return in their place
+		if schema.IsKeyless(sch) {
+			continue
+		}
+		if err = resolveTableConflicts(ctx, runner, dbName, tblName, sch, proc); err != nil {
+			return nil, false, fmt.Errorf("merge resolver %s for table %s failed: %w", proc, tblName.Name, err)
+		}
+		resolved = append(resolved, tblName)
+	}
+
+	ws, err = d.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, false, err
+	}
+	hasConflicts, err := doltdb.HasConflicts(ctx, ws.WorkingRoot())
+	if err != nil {
+		return nil, false, err
+	}
+	hasViolations, err := doltdb.HasConstraintViolations(ctx, ws.WorkingRoot())
+	if err != nil {
+		return nil, false, err
+	}
+	return resolved, !hasConflicts && !hasViolations, nil
+}
+
+// resolveTableConflicts calls |proc| for each conflict of |tblName| and applies the rows it returns.
+func resolveTableConflicts(ctx *sql.Context, runner sql.StatementRunner, dbName string, tblName doltdb.TableName, sch schema.Schema, proc string) error {
+	cols := sch.GetAllCols().GetColumns()
+	conflictsTable := quoteIdent(dbName) + "." + quoteIdent(doltdb.DoltConfTablePrefix+tblName.Name)
+	basePk := quoteIdent("base_" + sch.GetPKCols().GetColumns()[0].Name)
+
+	query := fmt.Sprintf("SELECT dolt_conflict_id, "+
+		"CASE WHEN %s IS NULL THEN NULL ELSE %s END, "+
+		"CASE WHEN our_diff_type = 'removed' THEN NULL ELSE %s END, "+
+		"CASE WHEN their_diff_type = 'removed' THEN NULL ELSE %s END "+
+		"FROM %s",
+		basePk, jsonObjectOf(cols, "base_"), jsonObjectOf(cols, "our_"), jsonObjectOf(cols, "their_"), conflictsTable)
+	conflicts, err := QueryRows(ctx, runner, query, nil)
+	if err != nil {
+		return err
+	}
+
+	call := fmt.Sprintf("CALL %s.%s(?, ?, ?)", quoteIdent(dbName), quoteIdent(proc))
+	assignments := make([]string, len(cols))
+	for i, col := range cols {
+		assignments[i] = fmt.Sprintf("%s = ?", quoteIdent("our_"+col.Name))
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE dolt_conflict_id = ?", conflictsTable, strings.Join(assignments, ", "))
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	replace := fmt.Sprintf("REPLACE INTO %s.%s VALUES (%s)", quoteIdent(dbName), quoteIdent(tblName.Name), placeholders)
+	remove := fmt.Sprintf("DELETE FROM %s WHERE dolt_conflict_id = ?", conflictsTable)
+
+	for _, conflict := range conflicts {
+		id := sqlparser.NewStrVal([]byte(fmt.Sprint(conflict[0])))
+		args := make([]sqlparser.Expr, 3)
+		for i, doc := range conflict[1:] {
+			if doc == nil {
+				args[i] = &sqlparser.NullVal{}
+			} else {
+				args[i] = sqlparser.NewStrVal([]byte(doc.(string)))
+			}
+		}
+		results, err := callResolver(ctx, runner, call, args)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 || types.IsOkResult(results[0]) {
+			continue
+		}
+		row := results[0]
+		if len(row) != len(cols) {
+			return fmt.Errorf("returned %d columns, expected %d", len(row), len(cols))
+		}
+
+		values := make([]sqlparser.Expr, len(row))
+		for i, col := range cols {
+			values[i], err = bindingForValue(ctx, col.TypeInfo.ToSqlType(), row[i])
+			if err != nil {
+				return err
+			}
+		}
+		// A row removed on our side can't be updated through the conflicts table, so it's written to the table itself
+		if conflict[2] == nil {
+			_, err = QueryRows(ctx, runner, replace, values)
+		} else {
+			_, err = QueryRows(ctx, runner, update, append(values, id))
+		}
+		if err != nil {
+			return err
+		}
+		if _, err = QueryRows(ctx, runner, remove, []sqlparser.Expr{id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callResolver runs the CALL statement of a resolver. A procedure runs in a transaction of its own, which would
+// discard the uncommitted merge from the session, so transactions are disabled for the call and the procedure sees
+// the merge in progress instead.
+func callResolver(ctx *sql.Context, runner sql.StatementRunner, call string, args []sqlparser.Expr) ([]sql.Row, error) {
+	disabled, err := ctx.GetSessionVariable(ctx, TransactionsDisabledSysVar)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.SetSessionVariable(ctx, TransactionsDisabledSysVar, true); err != nil {
+		return nil, err
+	}
+	defer ctx.SetSessionVariable(ctx, TransactionsDisabledSysVar, disabled)
+	return QueryRows(ctx, runner, call, args)
+}
+
+// jsonObjectOf returns an expression of the columns of a dolt_conflicts_<table> row with |prefix| as a JSON object
+// keyed by column name.
+func jsonObjectOf(cols []schema.Column, prefix string) string {
+	pairs := make([]string, len(cols))
+	for i, col := range cols {
+		pairs[i] = fmt.Sprintf("'%s', %s", strings.ReplaceAll(col.Name, "'", "''"), quoteIdent(prefix+col.Name))
+	}
+	return fmt.Sprintf("CAST(JSON_OBJECT(%s) AS CHAR)", strings.Join(pairs, ", "))
+}
+
+func bindingForValue(ctx *sql.Context, typ sql.Type, v interface{}) (sqlparser.Expr, error) {
+	v, _, err := typ.Convert(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	sqlVal, err := typ.SQL(ctx, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return sqlparser.ExprFromValue(sqlVal)
+}
+
+// QueryRows runs |query| through |runner| with |args| bound to its placeholders in order, returning the rows of its
+// result.
+func QueryRows(ctx *sql.Context, runner sql.StatementRunner, query string, args []sqlparser.Expr) ([]sql.Row, error) {
+	bindings := make(map[string]sqlparser.Expr, len(args))
+	for i, arg := range args {
+		bindings[fmt.Sprintf("v%d", i+1)] = arg
+	}
+	_, iter, _, err := runner.QueryWithBindings(ctx, query, nil, bindings, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, iter)
+}
+
+func quoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
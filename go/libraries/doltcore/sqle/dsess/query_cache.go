@@ -0,0 +1,168 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// QueryCache caches the results of read-only queries for an engine. Results are keyed by the query and the hashes of
+// the tables it read, which identify their content exactly, so a result is served until a table it read changes
+// without ever being invalidated. Caching is disabled until @@dolt_query_cache_size is set, and the least recently
+// used results are evicted to stay within it.
+type QueryCache struct {
+	mu      sync.Mutex
+	entries map[hash.Hash]*list.Element
+	lru     *list.List
+	size    int64
+}
+
+// QueryCacheEntryInfo describes a cached result, as shown in the dolt_query_cache system table
+type QueryCacheEntryInfo struct {
+	Query string
+	// Database is the current database of the session that ran the query
+	Database string
+	// Databases are the databases of the tables the query read
+	Databases []string
+	Rows      int
+	Size      int64
+	Hits      uint64
+	Created   time.Time
+	// LastHit is the last time the result was served from the cache, zero if it never was
+	LastHit time.Time
+}
+
+type queryCacheEntry struct {
+	key  hash.Hash
+	rows []sql.Row
+	info QueryCacheEntryInfo
+}
+
+func NewQueryCache() *QueryCache {
+	return &QueryCache{
+		entries: make(map[hash.Hash]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// queryCacheLimits returns the size of the query cache and the size of the largest result it caches, in bytes
+func queryCacheLimits() (size int64, maxResultSize int64) {
+	if _, v, ok := sql.SystemVariables.GetGlobal(DoltQueryCacheSize); ok {
+		size, _ = v.(int64)
+	}
+	if _, v, ok := sql.SystemVariables.GetGlobal(DoltQueryCacheMaxResultSize); ok {
+		maxResultSize, _ = v.(int64)
+	}
+	return size, maxResultSize
+}
+
+// Enabled returns whether query results are cached
+func (c *QueryCache) Enabled() bool {
+	size, _ := queryCacheLimits()
+	return size > 0
+}
+
+// MaxResultSize returns the size of the largest result that is cached, in bytes. Queries stop collecting their results
+// for the cache once they're larger than this.
+func (c *QueryCache) MaxResultSize() int64 {
+	size, maxResultSize := queryCacheLimits()
+	return min(size, maxResultSize)
+}
+
+// Get returns the cached result for |key|, if there is one
+func (c *QueryCache) Get(key hash.Hash) ([]sql.Row, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	entry := elem.Value.(*queryCacheEntry)
+	entry.info.Hits++
+	entry.info.LastHit = time.Now()
+	return entry.rows, true
+}
+
+// Put caches the result |rows| of the query described by |info| under |key|, unless it's larger than the limits of
+// the cache. |info.Size| is the size of |rows| in bytes.
+func (c *QueryCache) Put(key hash.Hash, info QueryCacheEntryInfo, rows []sql.Row) {
+	size, maxResultSize := queryCacheLimits()
+	if info.Size > size || info.Size > maxResultSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	info.Rows, info.Created = len(rows), time.Now()
+	c.entries[key] = c.lru.PushFront(&queryCacheEntry{key: key, rows: rows, info: info})
+	c.size += info.Size
+	c.evict(size)
+}
+
+// evict removes the least recently used results until the cache is no larger than |size|. Must be called with |mu| held.
+func (c *QueryCache) evict(size int64) {
+	for c.size > size {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		entry := c.lru.Remove(elem).(*queryCacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= entry.info.Size
+	}
+}
+
+// Entries returns the cached results, most recently used first. Results that no longer fit in the cache, because its
+// size was reduced, are evicted first.
+func (c *QueryCache) Entries() []QueryCacheEntryInfo {
+	size, _ := queryCacheLimits()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evict(size)
+	infos := make([]QueryCacheEntryInfo, 0, c.lru.Len())
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		infos = append(infos, elem.Value.(*queryCacheEntry).info)
+	}
+	return infos
+}
+
+// queryCacheStatement identifies the statement a session is running, see StartQueryCacheStatement
+type queryCacheStatement struct {
+	query     string
+	queryTime time.Time
+}
+
+// StartQueryCacheStatement returns true the first time it's called for the statement that |ctx| is running, and false
+// after that. The root node of the plan of a statement is built before any of its other nodes, so the query cache uses
+// this to tell it apart from them.
+func (d *DoltSession) StartQueryCacheStatement(ctx *sql.Context) bool {
+	query, queryTime := ctx.Query(), ctx.QueryTime()
+	if cur := d.queryCacheStmt.Load(); cur != nil && cur.query == query && cur.queryTime.Equal(queryTime) {
+		return false
+	}
+	d.queryCacheStmt.Store(&queryCacheStatement{query: query, queryTime: queryTime})
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess_test
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	// the sqle package defines the system variables that limit the cache
+	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func setQueryCacheLimits(t *testing.T, size, maxResultSize int64) {
+	require.NoError(t, sql.SystemVariables.AssignValues(map[string]interface{}{
+		dsess.DoltQueryCacheSize:          size,
+		dsess.DoltQueryCacheMaxResultSize: maxResultSize,
+	}))
+}
+
+func TestQueryCache(t *testing.T) {
+	defer setQueryCacheLimits(t, 0, 1<<20)
+
+	key := func(s string) hash.Hash {
+		return hash.Of([]byte(s))
+	}
+	put := func(c *dsess.QueryCache, query string, size int64) {
+		c.Put(key(query), dsess.QueryCacheEntryInfo{Query: query, Size: size}, []sql.Row{{query}})
+	}
+	queries := func(c *dsess.QueryCache) []string {
+		var queries []string
+		for _, entry := range c.Entries() {
+			queries = append(queries, entry.Query)
+		}
+		return queries
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		setQueryCacheLimits(t, 0, 1<<20)
+		c := dsess.NewQueryCache()
+		assert.False(t, c.Enabled())
+		put(c, "a", 10)
+		_, ok := c.Get(key("a"))
+		assert.False(t, ok)
+	})
+
+	t.Run("hits", func(t *testing.T) {
+		setQueryCacheLimits(t, 100, 100)
+		c := dsess.NewQueryCache()
+		assert.True(t, c.Enabled())
+		put(c, "a", 10)
+		rows, ok := c.Get(key("a"))
+		require.True(t, ok)
+		assert.Equal(t, []sql.Row{{"a"}}, rows)
+		_, ok = c.Get(key("b"))
+		assert.False(t, ok)
+
+		entries := c.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, 1, entries[0].Rows)
+		assert.Equal(t, uint64(1), entries[0].Hits)
+		assert.False(t, entries[0].LastHit.IsZero())
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		setQueryCacheLimits(t, 30, 100)
+		c := dsess.NewQueryCache()
+		put(c, "a", 10)
+		put(c, "b", 10)
+		put(c, "c", 10)
+		_, ok := c.Get(key("a"))
+		require.True(t, ok)
+		put(c, "d", 10)
+		assert.Equal(t, []string{"d", "a", "c"}, queries(c))
+
+		setQueryCacheLimits(t, 20, 100)
+		assert.Equal(t, []string{"d", "a"}, queries(c))
+	})
+
+	t.Run("result size limits", func(t *testing.T) {
+		setQueryCacheLimits(t, 30, 20)
+		c := dsess.NewQueryCache()
+		assert.Equal(t, int64(20), c.MaxResultSize())
+		put(c, "a", 25)
+		put(c, "b", 20)
+		assert.Equal(t, []string{"b"}, queries(c))
+
+		setQueryCacheLimits(t, 10, 20)
+		assert.Equal(t, int64(10), c.MaxResultSize())
+	})
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+)
+
+// RowLockMode is the mode a row lock is held in
+type RowLockMode uint8
+
+const (
+	// RowLockShared is the mode of locks taken by LOCK IN SHARE MODE, any number of sessions can hold them at once
+	RowLockShared RowLockMode = iota
+	// RowLockExclusive is the mode of locks taken by FOR UPDATE, only one session can hold them at once
+	RowLockExclusive
+)
+
+func (m RowLockMode) String() string {
+	if m == RowLockExclusive {
+		return "EXCLUSIVE"
+	}
+	return "SHARED"
+}
+
+// RowLockPolicy is what a locking read does when a row is locked by another session
+type RowLockPolicy uint8
+
+const (
+	// RowLockWait waits for the other session to release the lock, up to the lock wait timeout
+	RowLockWait RowLockPolicy = iota
+	// RowLockNoWait fails the statement, as for FOR UPDATE NOWAIT
+	RowLockNoWait
+	// RowLockSkipLocked leaves the row out of the result, as for FOR UPDATE SKIP LOCKED
+	RowLockSkipLocked
+)
+
+// ErrLockNoWait is returned by a NOWAIT locking read when a row is locked by another session
+var ErrLockNoWait = mysql.NewSQLError(3572, mysql.SSUnknownSQLState,
+	"Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.")
+
+// ErrLockWaitTimeout is returned by a locking read that waited longer than the lock wait timeout for a row
+var ErrLockWaitTimeout = mysql.NewSQLError(mysql.ERLockWaitTimeout, mysql.SSUnknownSQLState,
+	"Lock wait timeout exceeded; try restarting transaction")
+
+// ErrLockDeadlock is the detail of the error returned to a session whose lock wait would deadlock
+const ErrLockDeadlock = "deadlock found when trying to get lock"
+
+// RowLockKey identifies a locked row: its primary key in a table on a branch of a database
+type RowLockKey struct {
+	Database string
+	Branch   string
+	Table    string
+	Key      string
+}
+
+// RowLockInfo describes a lock held or waited for by a session, as shown in the dolt_locks system table
+type RowLockInfo struct {
+	RowLockKey
+	// Data is the primary key of the locked row, formatted for display
+	Data         string
+	Mode         RowLockMode
+	ConnectionID uint32
+	Granted      bool
+	Since        time.Time
+}
+
+// RowLockManager holds the row locks taken by locking reads, such as SELECT ... FOR UPDATE, for all sessions of a
+// server. Locks are held until the transaction of the session that took them ends.
+type RowLockManager struct {
+	mu    sync.Mutex
+	locks map[RowLockKey]*rowLock
+	// held are the keys of the locks held by each session. Sessions are keyed by identity rather than connection id,
+	// which isn't unique for sessions that aren't connected to a server.
+	held map[sql.Session][]RowLockKey
+	// waits are the locks each session is waiting for
+	waits map[sql.Session]*rowLockWait
+	// releases counts the times that a session released its locks
+	releases uint64
+}
+
+type rowLock struct {
+	data    string
+	holders map[sql.Session]rowLockHolder
+	// released is closed when the holders of the lock change, to wake up waiting sessions
+	released chan struct{}
+}
+
+type rowLockHolder struct {
+	mode  RowLockMode
+	since time.Time
+}
+
+type rowLockWait struct {
+	key   RowLockKey
+	mode  RowLockMode
+	since time.Time
+}
+
+// NewRowLockManager returns a RowLockManager without any locks
+func NewRowLockManager() *RowLockManager {
+	return &RowLockManager{
+		locks: make(map[RowLockKey]*rowLock),
+		held:  make(map[sql.Session][]RowLockKey),
+		waits: make(map[sql.Session]*rowLockWait),
+	}
+}
+
+// Lock takes the lock on the row |key| for the session of |ctx| in |mode|, using |policy| when another session holds
+// a conflicting lock. Waits are limited to |timeout|. |data| describes the row in dolt_locks. Returns whether the
+// lock was acquired, which is false only for RowLockSkipLocked, and whether the session had to wait for it.
+func (m *RowLockManager) Lock(ctx *sql.Context, key RowLockKey, data string, mode RowLockMode, policy RowLockPolicy, timeout time.Duration) (acquired bool, waited bool, err error) {
+	sess := ctx.Session
+	deadline := time.Now().Add(timeout)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer delete(m.waits, sess)
+
+	for {
+		lock, ok := m.locks[key]
+		if !ok {
+			lock = &rowLock{data: data, holders: make(map[sql.Session]rowLockHolder), released: make(chan struct{})}
+			m.locks[key] = lock
+		}
+		if lock.grantable(sess, mode) {
+			m.grant(sess, key, lock, mode)
+			return true, waited, nil
+		}
+
+		switch policy {
+		case RowLockNoWait:
+			return false, waited, ErrLockNoWait
+		case RowLockSkipLocked:
+			return false, waited, nil
+		}
+
+		if _, ok := m.waits[sess]; !ok {
+			m.waits[sess] = &rowLockWait{key: key, mode: mode, since: time.Now()}
+		}
+		if m.deadlocks(sess) {
+			return false, waited, sql.ErrLockDeadlock.New(ErrLockDeadlock)
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, waited, ErrLockWaitTimeout
+		}
+
+		released := lock.released
+		waited = true
+		m.mu.Unlock()
+		timer := time.NewTimer(remaining)
+		select {
+		case <-released:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+		m.mu.Lock()
+		if ctx.Err() != nil {
+			return false, waited, ctx.Err()
+		}
+	}
+}
+
+// grantable returns whether |sess| can take |lock| in |mode|
+func (l *rowLock) grantable(sess sql.Session, mode RowLockMode) bool {
+	for holder, h := range l.holders {
+		if holder == sess {
+			continue
+		}
+		if mode == RowLockExclusive || h.mode == RowLockExclusive {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *RowLockManager) grant(sess sql.Session, key RowLockKey, lock *rowLock, mode RowLockMode) {
+	h, ok := lock.holders[sess]
+	if !ok {
+		m.held[sess] = append(m.held[sess], key)
+		h.since = time.Now()
+	}
+	if !ok || mode > h.mode {
+		h.mode = mode
+	}
+	lock.holders[sess] = h
+}
+
+// deadlocks returns whether the wait of |sess| closes a cycle of sessions that each wait for a lock held by the next
+func (m *RowLockManager) deadlocks(sess sql.Session) bool {
+	visited := make(map[sql.Session]bool)
+	var waitsOn func(waiter sql.Session) bool
+	waitsOn = func(waiter sql.Session) bool {
+		if visited[waiter] {
+			return false
+		}
+		visited[waiter] = true
+		wait, ok := m.waits[waiter]
+		if !ok {
+			return false
+		}
+		lock, ok := m.locks[wait.key]
+		if !ok {
+			return false
+		}
+		for holder, h := range lock.holders {
+			if holder == waiter || (wait.mode == RowLockShared && h.mode == RowLockShared) {
+				continue
+			}
+			if holder == sess || waitsOn(holder) {
+				return true
+			}
+		}
+		return false
+	}
+	return waitsOn(sess)
+}
+
+// ReleaseAll releases every lock held by |sess|, waking up the sessions waiting for them
+func (m *RowLockManager) ReleaseAll(sess sql.Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys, ok := m.held[sess]
+	if !ok {
+		return
+	}
+	delete(m.held, sess)
+	for _, key := range keys {
+		lock, ok := m.locks[key]
+		if !ok {
+			continue
+		}
+		delete(lock.holders, sess)
+		if len(lock.holders) == 0 {
+			delete(m.locks, key)
+		}
+		close(lock.released)
+		lock.released = make(chan struct{})
+	}
+	m.releases++
+}
+
+// Releases returns the number of times a session has released its locks. Sessions that read rows before taking their
+// locks use it to tell whether the rows might have been changed by the transaction of a session that held them.
+func (m *RowLockManager) Releases() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.releases
+}
+
+// Locks returns the locks held by all sessions, followed by the locks sessions are waiting for
+func (m *RowLockManager) Locks() []RowLockInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var infos []RowLockInfo
+	for key, lock := range m.locks {
+		for sess, h := range lock.holders {
+			infos = append(infos, RowLockInfo{
+				RowLockKey:   key,
+				Data:         lock.data,
+				Mode:         h.mode,
+				ConnectionID: sess.ID(),
+				Granted:      true,
+				Since:        h.since,
+			})
+		}
+	}
+	for sess, wait := range m.waits {
+		var data string
+		if lock, ok := m.locks[wait.key]; ok {
+			data = lock.data
+		}
+		infos = append(infos, RowLockInfo{
+			RowLockKey:   wait.key,
+			Data:         data,
+			Mode:         wait.mode,
+			ConnectionID: sess.ID(),
+			Since:        wait.since,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Granted != infos[j].Granted {
+			return infos[i].Granted
+		}
+		if !infos[i].Since.Equal(infos[j].Since) {
+			return infos[i].Since.Before(infos[j].Since)
+		}
+		return infos[i].ConnectionID < infos[j].ConnectionID
+	})
+	return infos
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLockTestContext() *sql.Context {
+	return sql.NewContext(context.Background(), sql.WithSession(sql.NewBaseSession()))
+}
+
+func rowKey(key string) RowLockKey {
+	return RowLockKey{Database: "mydb", Branch: "main", Table: "jobs", Key: key}
+}
+
+func TestRowLockManager(t *testing.T) {
+	t.Run("exclusive locks conflict", func(t *testing.T) {
+		m := NewRowLockManager()
+		a, b := newLockTestContext(), newLockTestContext()
+
+		acquired, waited, err := m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockWait, time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+		assert.False(t, waited)
+
+		// taking a lock the session already holds succeeds
+		acquired, _, err = m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockNoWait, 0)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		_, _, err = m.Lock(b, rowKey("1"), "1", RowLockExclusive, RowLockNoWait, 0)
+		assert.Equal(t, ErrLockNoWait, err)
+
+		acquired, _, err = m.Lock(b, rowKey("1"), "1", RowLockExclusive, RowLockSkipLocked, 0)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+
+		acquired, _, err = m.Lock(b, rowKey("2"), "2", RowLockExclusive, RowLockSkipLocked, 0)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("shared locks", func(t *testing.T) {
+		m := NewRowLockManager()
+		a, b, c := newLockTestContext(), newLockTestContext(), newLockTestContext()
+
+		_, _, err := m.Lock(a, rowKey("1"), "1", RowLockShared, RowLockNoWait, 0)
+		require.NoError(t, err)
+		_, _, err = m.Lock(b, rowKey("1"), "1", RowLockShared, RowLockNoWait, 0)
+		require.NoError(t, err)
+
+		_, _, err = m.Lock(c, rowKey("1"), "1", RowLockExclusive, RowLockNoWait, 0)
+		assert.Equal(t, ErrLockNoWait, err)
+		// a shared lock can't be upgraded while another session shares it
+		_, _, err = m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockNoWait, 0)
+		assert.Equal(t, ErrLockNoWait, err)
+
+		m.ReleaseAll(b.Session)
+		acquired, _, err := m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockNoWait, 0)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+	})
+
+	t.Run("waits until released", func(t *testing.T) {
+		m := NewRowLockManager()
+		a, b := newLockTestContext(), newLockTestContext()
+
+		_, _, err := m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockWait, time.Second)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			acquired, waited, err := m.Lock(b, rowKey("1"), "1", RowLockExclusive, RowLockWait, 10*time.Second)
+			if err == nil && (!acquired || !waited) {
+				err = assert.AnError
+			}
+			done <- err
+		}()
+
+		require.Eventually(t, func() bool {
+			locks := m.Locks()
+			return len(locks) == 2 && !locks[1].Granted && locks[1].ConnectionID == b.Session.ID()
+		}, 5*time.Second, 10*time.Millisecond)
+
+		releases := m.Releases()
+		m.ReleaseAll(a.Session)
+		require.NoError(t, <-done)
+		assert.Equal(t, releases+1, m.Releases())
+
+		locks := m.Locks()
+		require.Len(t, locks, 1)
+		assert.Equal(t, b.Session.ID(), locks[0].ConnectionID)
+		assert.True(t, locks[0].Granted)
+		assert.Equal(t, RowLockExclusive, locks[0].Mode)
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		m := NewRowLockManager()
+		a, b := newLockTestContext(), newLockTestContext()
+
+		_, _, err := m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockWait, time.Second)
+		require.NoError(t, err)
+		_, waited, err := m.Lock(b, rowKey("1"), "1", RowLockExclusive, RowLockWait, 50*time.Millisecond)
+		assert.Equal(t, ErrLockWaitTimeout, err)
+		assert.True(t, waited)
+		assert.Len(t, m.Locks(), 1)
+	})
+
+	t.Run("deadlock", func(t *testing.T) {
+		m := NewRowLockManager()
+		a, b := newLockTestContext(), newLockTestContext()
+
+		_, _, err := m.Lock(a, rowKey("1"), "1", RowLockExclusive, RowLockWait, time.Second)
+		require.NoError(t, err)
+		_, _, err = m.Lock(b, rowKey("2"), "2", RowLockExclusive, RowLockWait, time.Second)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			_, _, err := m.Lock(a, rowKey("2"), "2", RowLockExclusive, RowLockWait, 10*time.Second)
+			done <- err
+		}()
+		require.Eventually(t, func() bool {
+			return len(m.Locks()) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, _, err = m.Lock(b, rowKey("1"), "1", RowLockExclusive, RowLockWait, 10*time.Second)
+		require.Error(t, err)
+		assert.True(t, sql.ErrLockDeadlock.Is(err))
+
+		m.ReleaseAll(b.Session)
+		require.NoError(t, <-done)
+	})
+}
@@ -0,0 +1,430 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// TransactionIsolationSysVar is the system variable that sets the isolation level of new transactions
+	TransactionIsolationSysVar = "transaction_isolation"
+
+	// serializableIsolation is the value of TransactionIsolationSysVar that enables read set validation
+	serializableIsolation = "SERIALIZABLE"
+
+	// maxReadSetRanges is the number of ranges recorded for a single index, after which reads of that index are
+	// tracked as a read of the entire index
+	maxReadSetRanges = 1024
+)
+
+var errReadSetConflict = errors.New("read set conflict")
+
+// readSet records the rows a SERIALIZABLE transaction read, so that its commit can be rejected when a transaction that
+// committed in the meantime changed any of them. Without this, transactions are merged with the changes committed
+// since they began, which lets write skew through: two transactions can each read a row that the other one updates.
+type readSet struct {
+	mu     sync.Mutex
+	tables map[readSetKey]*tableReads
+}
+
+// readSetKey identifies a table in the working set of a database that a transaction read from
+type readSetKey struct {
+	dbName string
+	wsRef  ref.WorkingSetRef
+	table  doltdb.TableName
+}
+
+// tableReads are the reads of a single table. |scanned| records a read of every row in the table, otherwise the reads
+// are the ranges of keys read from each index, by index name.
+type tableReads struct {
+	scanned bool
+	indexes map[string]*indexReads
+}
+
+// indexReads are the key ranges read from an index. |all| records a read of the entire index. |primaryRows| records
+// that the rows of the keys read were looked up in the primary index, as they are for reads of a secondary index that
+// doesn't have every column read, which makes the reads depend on the primary rows as well as on the index.
+type indexReads struct {
+	all         bool
+	primaryRows bool
+	ranges      []prolly.Range
+}
+
+func newReadSet() *readSet {
+	return &readSet{tables: make(map[readSetKey]*tableReads)}
+}
+
+// isSerializable returns whether new transactions in the session of |ctx| use the SERIALIZABLE isolation level
+func isSerializable(ctx *sql.Context) bool {
+	isolation, err := ctx.GetSessionVariable(ctx, TransactionIsolationSysVar)
+	if err != nil {
+		return false
+	}
+	level, ok := isolation.(string)
+	return ok && strings.EqualFold(level, serializableIsolation)
+}
+
+// IsSerializable returns whether this transaction uses the SERIALIZABLE isolation level, and so needs its reads
+// recorded with RecordTableScan and RecordIndexRead.
+func (tx *DoltTransaction) IsSerializable() bool {
+	return tx.reads != nil
+}
+
+// RecordTableScan records that the current transaction read every row of |table| in the database named |dbName|. Does
+// nothing unless the transaction is SERIALIZABLE.
+func RecordTableScan(ctx *sql.Context, dbName string, table doltdb.TableName) error {
+	reads, key, ok, err := transactionReads(ctx, dbName, table)
+	if err != nil || !ok {
+		return err
+	}
+	reads.mu.Lock()
+	defer reads.mu.Unlock()
+	reads.table(key).scanned = true
+	return nil
+}
+
+// RecordIndexRead records that the current transaction read the keys in |ranges| from the index named |index| of
+// |table| in the database named |dbName|. No ranges records a read of the entire index. |primaryRows| records that
+// the rows of the keys read were also read from the primary index. Does nothing unless the transaction is
+// SERIALIZABLE.
+func RecordIndexRead(ctx *sql.Context, dbName string, table doltdb.TableName, index string, ranges []prolly.Range, primaryRows bool) error {
+	reads, key, ok, err := transactionReads(ctx, dbName, table)
+	if err != nil || !ok {
+		return err
+	}
+	reads.mu.Lock()
+	defer reads.mu.Unlock()
+	tr := reads.table(key)
+	if tr.scanned {
+		return nil
+	}
+	ir, ok := tr.indexes[index]
+	if !ok {
+		ir = &indexReads{}
+		tr.indexes[index] = ir
+	}
+	ir.primaryRows = ir.primaryRows || primaryRows
+	if ir.all {
+		return nil
+	}
+	if len(ranges) == 0 || len(ir.ranges)+len(ranges) > maxReadSetRanges {
+		ir.all, ir.ranges = true, nil
+		return nil
+	}
+	ir.ranges = append(ir.ranges, ranges...)
+	return nil
+}
+
+// transactionReads returns the read set of the transaction of |ctx| and the key for |table| of the database named
+// |dbName| in it. Returns false when the reads don't need to be recorded: the transaction isn't SERIALIZABLE, or the
+// database isn't on a branch, where the data read can't be changed by another transaction.
+func transactionReads(ctx *sql.Context, dbName string, table doltdb.TableName) (*readSet, readSetKey, bool, error) {
+	tx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok || !tx.IsSerializable() {
+		return nil, readSetKey{}, false, nil
+	}
+	sess := DSessFromSess(ctx.Session)
+	branchState, ok, err := sess.lookupDbState(ctx, dbName)
+	if err != nil || !ok {
+		return nil, readSetKey{}, false, err
+	}
+	ws := branchState.WorkingSet()
+	if ws == nil {
+		return nil, readSetKey{}, false, nil
+	}
+	key := readSetKey{
+		dbName: strings.ToLower(branchState.dbState.dbName),
+		wsRef:  ws.Ref(),
+		table:  table,
+	}
+	return tx.reads, key, true, nil
+}
+
+func (rs *readSet) table(key readSetKey) *tableReads {
+	tr, ok := rs.tables[key]
+	if !ok {
+		tr = &tableReads{indexes: make(map[string]*indexReads)}
+		rs.tables[key] = tr
+	}
+	return tr
+}
+
+// validateReads returns a retryable error if a transaction that committed since this one began changed any of the
+// rows this transaction read. Always succeeds for transactions that aren't SERIALIZABLE.
+func (tx *DoltTransaction) validateReads(ctx *sql.Context) error {
+	if !tx.IsSerializable() {
+		return nil
+	}
+	tx.reads.mu.Lock()
+	defer tx.reads.mu.Unlock()
+
+	for key, reads := range tx.reads.tables {
+		startPoint, ok := tx.dbStartPoints[key.dbName]
+		if !ok {
+			continue
+		}
+		startWs, err := startPoint.db.ResolveWorkingSetAtRoot(ctx, key.wsRef, startPoint.rootHash)
+		if err == doltdb.ErrWorkingSetNotFound {
+			// the branch was created by this transaction
+			continue
+		} else if err != nil {
+			return err
+		}
+		currWs, err := startPoint.db.ResolveWorkingSet(ctx, key.wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		changed, err := readsChanged(ctx, startWs.WorkingRoot(), currWs.WorkingRoot(), key.table, reads)
+		if err != nil {
+			return err
+		}
+		if changed {
+			detail := fmt.Sprintf("serializable transaction read rows of table %s that were changed by a concurrent transaction", key.table)
+			return tx.rollbackAndErr(ctx, retryTransactionError(detail))
+		}
+	}
+	return nil
+}
+
+// readsChanged returns whether any of the rows in |reads| of |tblName| differ between |startRoot| and |currRoot|.
+func readsChanged(ctx context.Context, startRoot, currRoot doltdb.RootValue, tblName doltdb.TableName, reads *tableReads) (bool, error) {
+	if rootsEqual(startRoot, currRoot) {
+		return false, nil
+	}
+	startTbl, startOk, err := startRoot.GetTable(ctx, tblName)
+	if err != nil {
+		return false, err
+	}
+	currTbl, currOk, err := currRoot.GetTable(ctx, tblName)
+	if err != nil {
+		return false, err
+	}
+	if !startOk || !currOk {
+		// a table that was read only counts as changed when it was created or dropped
+		return startOk != currOk, nil
+	}
+
+	startSchHash, err := startTbl.GetSchemaHash(ctx)
+	if err != nil {
+		return false, err
+	}
+	currSchHash, err := currTbl.GetSchemaHash(ctx)
+	if err != nil {
+		return false, err
+	}
+	if startSchHash != currSchHash {
+		return true, nil
+	}
+
+	if reads.scanned {
+		startHash, err := startTbl.GetRowDataHash(ctx)
+		if err != nil {
+			return false, err
+		}
+		currHash, err := currTbl.GetRowDataHash(ctx)
+		if err != nil {
+			return false, err
+		}
+		return startHash != currHash, nil
+	}
+
+	for name, ir := range reads.indexes {
+		startIdx, err := indexRowData(ctx, startTbl, name)
+		if err != nil {
+			return false, err
+		}
+		currIdx, err := indexRowData(ctx, currTbl, name)
+		if err != nil {
+			return false, err
+		}
+		changed, err := indexReadsChanged(ctx, startIdx, currIdx, ir)
+		if err != nil || changed {
+			return changed, err
+		}
+		if ir.primaryRows {
+			changed, err = primaryRowsChanged(ctx, startTbl, currTbl, name, startIdx, ir)
+			if err != nil || changed {
+				return changed, err
+			}
+		}
+	}
+	return false, nil
+}
+
+func indexRowData(ctx context.Context, tbl *doltdb.Table, name string) (durable.Index, error) {
+	if strings.EqualFold(name, "PRIMARY") {
+		return tbl.GetRowData(ctx)
+	}
+	return tbl.GetIndexRowData(ctx, name)
+}
+
+// indexReadsChanged returns whether any key in the ranges of |reads| was added, removed or modified between |from| and
+// |to|, using a diff of the two indexes limited to each range.
+func indexReadsChanged(ctx context.Context, from, to durable.Index, reads *indexReads) (bool, error) {
+	fromHash, err := from.HashOf()
+	if err != nil {
+		return false, err
+	}
+	toHash, err := to.HashOf()
+	if err != nil {
+		return false, err
+	}
+	if fromHash == toHash {
+		return false, nil
+	} else if reads.all {
+		return true, nil
+	}
+
+	fromMap, err := durable.ProllyMapFromIndex(from)
+	if err != nil {
+		return false, err
+	}
+	toMap, err := durable.ProllyMapFromIndex(to)
+	if err != nil {
+		return false, err
+	}
+
+	for _, rng := range reads.ranges {
+		err = prolly.RangeDiffMaps(ctx, fromMap, toMap, rng, func(ctx context.Context, diff tree.Diff) error {
+			matches, err := rng.Matches(ctx, val.Tuple(diff.Key))
+			if err != nil {
+				return err
+			}
+			if matches {
+				return errReadSetConflict
+			}
+			return nil
+		})
+		if err == errReadSetConflict {
+			return true, nil
+		} else if err != nil && err != io.EOF {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// primaryRowsChanged returns whether the primary row of any key in the ranges of |reads| of the secondary index named
+// |name| was changed between |startTbl| and |currTbl|. The keys are those in the ranges of |startIdx|, the index at
+// the start of the transaction, which indexReadsChanged has already found to be unchanged.
+func primaryRowsChanged(ctx context.Context, startTbl, currTbl *doltdb.Table, name string, startIdx durable.Index, reads *indexReads) (bool, error) {
+	startRows, err := startTbl.GetRowData(ctx)
+	if err != nil {
+		return false, err
+	}
+	currRows, err := currTbl.GetRowData(ctx)
+	if err != nil {
+		return false, err
+	}
+	startHash, err := startRows.HashOf()
+	if err != nil {
+		return false, err
+	}
+	currHash, err := currRows.HashOf()
+	if err != nil {
+		return false, err
+	}
+	if startHash == currHash {
+		return false, nil
+	} else if reads.all {
+		return true, nil
+	}
+
+	sch, err := startTbl.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	def := sch.Indexes().GetByName(name)
+	if def == nil {
+		return true, nil
+	}
+	secMap, err := durable.ProllyMapFromIndex(startIdx)
+	if err != nil {
+		return false, err
+	}
+	startMap, err := durable.ProllyMapFromIndex(startRows)
+	if err != nil {
+		return false, err
+	}
+	currMap, err := durable.ProllyMapFromIndex(currRows)
+	if err != nil {
+		return false, err
+	}
+
+	pkMap := schema.PrimaryIndexOrdinalToSecondaryIndexOrdinal(def)
+	kd, _ := startMap.Descriptors()
+	pkBld := val.NewTupleBuilder(kd, startMap.NodeStore())
+	for _, rng := range reads.ranges {
+		iter, err := secMap.IterRange(ctx, rng)
+		if err != nil {
+			return false, err
+		}
+		for {
+			secKey, _, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return false, err
+			}
+			for to := range pkMap {
+				pkBld.PutRaw(to, secKey.GetField(pkMap.MapOrdinal(to)))
+			}
+			pk, err := pkBld.Build(ctx, startMap.Pool())
+			if err != nil {
+				return false, err
+			}
+			startVal, err := primaryRow(ctx, startMap, pk)
+			if err != nil {
+				return false, err
+			}
+			currVal, err := primaryRow(ctx, currMap, pk)
+			if err != nil {
+				return false, err
+			}
+			if (startVal == nil) != (currVal == nil) || !bytes.Equal(startVal, currVal) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func primaryRow(ctx context.Context, m prolly.Map, key val.Tuple) (value val.Tuple, err error) {
+	err = m.Get(ctx, key, func(_, v val.Tuple) error {
+		value = v
+		return nil
+	})
+	return value, err
+}
@@ -21,10 +21,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
@@ -72,6 +74,22 @@ type DoltSession struct {
 	username              string
 	notices               []any // This is used by Doltgres to store notices. This is not used by Dolt.
 	branchActivityTracker *doltdb.BranchActivityTracker
+
+	// operations are the queries run in the current transaction whose changes are recorded in the operation log, and
+	// operationID identifies them in it. They have their own lock because they're read while writing to the database,
+	// which can happen while |mu| is held.
+	opMu        *sync.Mutex
+	operations  []string
+	operationID string
+
+	// lockingRead is the locking clause of the statement the session is running, see LockingReadForTable
+	lockingRead *atomic.Pointer[lockingReadStatement]
+
+	// queryCacheStmt is the statement the session is running, see StartQueryCacheStatement
+	queryCacheStmt *atomic.Pointer[queryCacheStatement]
+
+	// xa is the XA transaction the session is running, see XAStart
+	xa *sessionXA
 }
 
 var _ sql.Session = (*DoltSession)(nil)
@@ -79,6 +97,7 @@ var _ sql.PersistableSession = (*DoltSession)(nil)
 var _ sql.TransactionSession = (*DoltSession)(nil)
 var _ expranalysis.SessionDbProvider = (*DoltSession)(nil)
 var _ branch_control.ContextConvertible = (*DoltSession)(nil)
+var _ doltdb.OperationSession = (*DoltSession)(nil)
 
 // DefaultSession creates a DoltSession with default values
 func DefaultSession(pro DoltDatabaseProvider, sessFunc WriteSessFunc) *DoltSession {
@@ -93,6 +112,10 @@ func DefaultSession(pro DoltDatabaseProvider, sessFunc WriteSessFunc) *DoltSessi
 		globalsConf:      config.NewMapConfig(make(map[string]string)),
 		branchController: branch_control.CreateDefaultController(context.TODO()), // Default sessions are fine with the default controller
 		mu:               &sync.Mutex{},
+		opMu:             &sync.Mutex{},
+		lockingRead:      &atomic.Pointer[lockingReadStatement]{},
+		queryCacheStmt:   &atomic.Pointer[queryCacheStatement]{},
+		xa:               &sessionXA{},
 		fs:               pro.FileSystem(),
 		writeSessProv:    sessFunc,
 	}
@@ -151,6 +174,10 @@ func NewDoltSession(
 		branchController:      branchController,
 		statsProv:             statsProvider,
 		mu:                    &sync.Mutex{},
+		opMu:                  &sync.Mutex{},
+		lockingRead:           &atomic.Pointer[lockingReadStatement]{},
+		queryCacheStmt:        &atomic.Pointer[queryCacheStatement]{},
+		xa:                    &sessionXA{},
 		fs:                    pro.FileSystem(),
 		writeSessProv:         writeSessProv,
 		gcSafepointController: gcSafepointController,
@@ -178,6 +205,9 @@ func (d *DoltSession) StatsProvider() sql.StatsProvider {
 
 // DSessFromSess retrieves a dolt session from a standard sql.Session
 func DSessFromSess(sess sql.Session) *DoltSession {
+	if buf, ok := sess.(*warningBuffer); ok {
+		return buf.Session.(*DoltSession)
+	}
 	return sess.(*DoltSession)
 }
 
@@ -464,6 +494,32 @@ func (d *DoltSession) clear() {
 			delete(dbState.heads, head)
 		}
 	}
+
+	d.opMu.Lock()
+	defer d.opMu.Unlock()
+	d.operations = nil
+}
+
+// MarkOperation records that the current transaction runs the operation |description|, a call like
+// `call dolt_reset('--hard')` whose changes to working sets are recorded in the operation log of the databases it changes,
+// not just its changes to refs.
+func (d *DoltSession) MarkOperation(description string) {
+	d.opMu.Lock()
+	defer d.opMu.Unlock()
+	if len(d.operations) == 0 {
+		d.operationID = uuid.NewString()
+	}
+	d.operations = append(d.operations, description)
+}
+
+// PendingOperation implements doltdb.OperationSession
+func (d *DoltSession) PendingOperation() (string, string, bool) {
+	d.opMu.Lock()
+	defer d.opMu.Unlock()
+	if len(d.operations) == 0 {
+		return "", "", false
+	}
+	return d.operationID, strings.Join(d.operations, "; "), true
 }
 
 // CommitTransaction commits the in-progress transaction. Depending on session settings, this may write only a new
@@ -477,22 +533,22 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 	defer func() {
 		if err == nil {
 			ctx.SetTransaction(nil)
+			d.releaseRowLocks()
 		}
 	}()
 
 	if TransactionsDisabled(ctx) {
 		return nil
 	}
+	if d.InXATransaction() {
+		return d.xaStateErr()
+	}
 
 	dirties := d.dirtyWorkingSets()
 	if len(dirties) == 0 {
 		return nil
 	}
 
-	if len(dirties) > 1 {
-		return ErrDirtyWorkingSets
-	}
-
 	performDoltCommitVar, err := d.Session.GetSessionVariable(ctx, DoltCommitOnTransactionCommit)
 	if err != nil {
 		return err
@@ -503,6 +559,15 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 		return fmt.Errorf("Unexpected type for var %s: %T", DoltCommitOnTransactionCommit, performDoltCommitVar)
 	}
 
+	if len(dirties) > 1 {
+		// The dolt commit is only created on the checked out branch, which would leave the changes to the others
+		// uncommitted
+		if peformDoltCommitInt == 1 {
+			return ErrDirtyWorkingSets
+		}
+		return d.commitWorkingSets(ctx, dirties, tx)
+	}
+
 	dirtyBranchState := dirties[0]
 	if peformDoltCommitInt == 1 {
 		// if the dirty working set doesn't belong to the currently checked out branch, that's an error
@@ -581,8 +646,15 @@ func (d *DoltSession) validateDoltCommit(ctx *sql.Context, dirtyBranchState *bra
 	return nil
 }
 
+// ErrDirtyWorkingSets is returned when @@dolt_transaction_commit is set and a transaction changed more than one branch
 var ErrDirtyWorkingSets = errors.New("Cannot commit changes on more than one branch / database")
 
+// RefreshMaterializedViews refreshes the materialized views of the staged root of |roots| before it's committed. It's
+// set by the matview package, which can't be imported here due to import cycles.
+var RefreshMaterializedViews = func(ctx *sql.Context, dbName string, roots doltdb.Roots) (doltdb.Roots, error) {
+	return roots, nil
+}
+
 // dirtyWorkingSets returns all dirty working sets for this session
 func (d *DoltSession) dirtyWorkingSets() []*branchState {
 	d.mu.Lock()
@@ -674,6 +746,31 @@ func (d *DoltSession) commitWorkingSet(ctx *sql.Context, branchState *branchStat
 	return err
 }
 
+// commitWorkingSets commits the working sets of several branches of one database atomically, see
+// DoltTransaction.CommitWorkingSets.
+func (d *DoltSession) commitWorkingSets(ctx *sql.Context, branchStates []*branchState, tx sql.Transaction) error {
+	dtx, ok := tx.(*DoltTransaction)
+	if !ok {
+		return fmt.Errorf("expected a DoltTransaction")
+	}
+
+	workingSets := make([]*doltdb.WorkingSet, len(branchStates))
+	dbNames := make([]string, len(branchStates))
+	for i, branchState := range branchStates {
+		workingSets[i] = branchState.WorkingSet()
+		dbNames[i] = branchState.RevisionDbName()
+	}
+
+	_, err := dtx.CommitWorkingSets(ctx, workingSets, dbNames)
+	if err != nil {
+		return err
+	}
+
+	// See the comment in |commitBranchState|
+	ctx.SetTransaction(nil)
+	return nil
+}
+
 // DoltCommit commits the working set and a new dolt commit with the properties given.
 // Clients should typically use CommitTransaction, which performs additional checks, instead of this method.
 func (d *DoltSession) DoltCommit(
@@ -721,6 +818,9 @@ func (d *DoltSession) commitBranchState(
 	if !ok {
 		return nil, fmt.Errorf("expected a DoltTransaction")
 	}
+	if d.InXATransaction() {
+		return nil, d.xaStateErr()
+	}
 
 	updatedWs, newCommit, err := commitFunc(ctx, dtx, branchState.WorkingSet())
 	if err != nil {
@@ -843,6 +943,11 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, dbName string, branchSt
 		}
 	}
 
+	roots, err := RefreshMaterializedViews(ctx, dbName, roots)
+	if err != nil {
+		return nil, err
+	}
+
 	tableResolver, err := GetTableResolver(ctx, dbName)
 	if err != nil {
 		return nil, err
@@ -862,6 +967,8 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, dbName string, branchSt
 func (d *DoltSession) Rollback(ctx *sql.Context, tx sql.Transaction) error {
 	// Nothing to do here, we just throw away all our work and let a new transaction begin next statement
 	d.clear()
+	d.releaseRowLocks()
+	d.endXA()
 	return nil
 }
 
@@ -1663,6 +1770,32 @@ func (d *DoltSession) WithGlobals(conf config.ReadWriteConfig) *DoltSession {
 	return &nd
 }
 
+// WithWarningBuffer returns a handle to the session whose warnings are collected instead of being added to the
+// session. Everything else is done by the session itself, which isn't copied. |flush| returns and clears the warnings
+// collected so far. It's used by goroutines that evaluate expressions of a query concurrently with the session, whose
+// warnings are then added to the session by the goroutine running the query.
+func (d *DoltSession) WithWarningBuffer() (sess sql.Session, flush func() []*sql.Warning) {
+	buf := &warningBuffer{Session: d}
+	return buf, buf.flush
+}
+
+// warningBuffer is a sql.Session that collects the warnings raised in it rather than adding them to the DoltSession it
+// wraps
+type warningBuffer struct {
+	sql.Session
+	warnings []*sql.Warning
+}
+
+func (b *warningBuffer) Warn(warn *sql.Warning) {
+	b.warnings = append(b.warnings, warn)
+}
+
+func (b *warningBuffer) flush() []*sql.Warning {
+	warnings := b.warnings
+	b.warnings = nil
+	return warnings
+}
+
 // PersistGlobal implements sql.PersistableSession
 func (d *DoltSession) PersistGlobal(ctx *sql.Context, sysVarName string, value interface{}) error {
 	if d.globalsConf == nil {
@@ -1791,6 +1924,8 @@ func (d *DoltSession) CommandEnd() {
 }
 
 func (d *DoltSession) SessionEnd() {
+	d.releaseRowLocks()
+	d.endXA()
 	if d.gcSafepointController != nil {
 		d.gcSafepointController.SessionEnd(d)
 	}
@@ -122,6 +122,15 @@ type DoltDatabaseProvider interface {
 	// TxLocks returns the per-engine keymutex used to serialize
 	// transaction commits by working-set reference.
 	TxLocks() keymutex.Keymutex
+	// RowLocks returns the per-engine manager of the row locks taken by locking reads, such as SELECT ... FOR UPDATE.
+	RowLocks() *RowLockManager
+	// QueryCache returns the per-engine cache of the results of read-only queries
+	QueryCache() *QueryCache
+	// XATransactions returns the per-engine tracker of the XA transactions that aren't stored in databases.
+	XATransactions() *XATransactions
+	// StatementRunner returns the engine that runs the statements of this provider's databases, or nil if none was
+	// set. Statements run through it are checked against the privileges of the session.
+	StatementRunner() sql.StatementRunner
 }
 
 type SessionDatabaseBranchSpec struct {
@@ -97,6 +97,7 @@ type IndexState struct {
 	IsFullText    bool
 	IsUnique      bool
 	IsSpatial     bool
+	IsColumnStore bool
 	Predicate     sql.Expression
 	// KeyVirtualExprs is parallel to KeyMapping. A non-nil entry at position i is the resolved
 	// generating expression for a virtual generated column that is key part i of this index.
@@ -43,6 +43,11 @@ const (
 
 var ErrRetryTransaction = errors.New("this transaction conflicts with a committed transaction from another client")
 
+// ErrMultipleDatabasesCommit is returned when a transaction changed more than one database. Databases don't share a
+// root, so their changes can't be committed atomically.
+var ErrMultipleDatabasesCommit = errors.New("Cannot commit changes on more than one database in a transaction, " +
+	"commit the changes to each database in a separate transaction")
+
 var ErrUnresolvedConflictsCommit = errors.New("Merge conflict detected, transaction rolled back. Merge conflicts must be resolved using the dolt_conflicts and dolt_schema_conflicts tables before committing a transaction. To commit transactions with merge conflicts, set @@dolt_allow_commit_conflicts = 1")
 
 var ErrUnresolvedConflictsAutoCommit = errors.New("Merge conflict detected, @autocommit transaction rolled back. @autocommit must be disabled so that merge conflicts can be resolved using the dolt_conflicts and dolt_schema_conflicts tables before manually committing the transaction. Alternatively, to commit transactions with merge conflicts, set @@dolt_allow_commit_conflicts = 1")
@@ -87,6 +92,8 @@ type DoltTransaction struct {
 	dbStartPoints   map[string]dbRoot
 	savepoints      []savepoint
 	tCharacteristic sql.TransactionCharacteristic
+	// reads are the rows read by a SERIALIZABLE transaction, nil for other isolation levels
+	reads *readSet
 }
 
 type dbRoot struct {
@@ -121,10 +128,14 @@ func NewDoltTransaction(
 		}
 	}
 
-	return &DoltTransaction{
+	tx := &DoltTransaction{
 		dbStartPoints:   startPoints,
 		tCharacteristic: tCharacteristic,
-	}, nil
+	}
+	if isSerializable(ctx) {
+		tx.reads = newReadSet()
+	}
+	return tx, nil
 }
 
 // AddDb adds the database named to the transaction, establishing a start-point root for it. Necessary when a database
@@ -178,6 +189,200 @@ func (tx *DoltTransaction) Commit(ctx *sql.Context, workingSet *doltdb.WorkingSe
 	return ws, err
 }
 
+// workingSetCommit is one of the working sets committed together by CommitWorkingSets
+type workingSetCommit struct {
+	dbName     string
+	lockID     string
+	startPoint dbRoot
+	startState *doltdb.WorkingSet
+	workingSet *doltdb.WorkingSet
+	mergeOpts  editor.Options
+	// removes are working sets deleted in the same write as this one
+	removes []*doltdb.WorkingSet
+	// xid is the prepared XA transaction being committed, which may write the working set while it holds it
+	xid *XID
+
+	merged   *doltdb.WorkingSet
+	existing *doltdb.WorkingSet
+}
+
+// CommitWorkingSets commits several working sets, of different branches of one database. Each working set is merged
+// into its current value as in Commit, and every one of them is merged and validated, under the transaction locks of
+// all of them, before any is written. They're written with a single update of the database's root, so they're
+// committed atomically: either all of them are written or none are. Returns ErrMultipleDatabasesCommit if the working
+// sets belong to more than one database, and otherwise the working sets written, in the order of |workingSets|.
+func (tx *DoltTransaction) CommitWorkingSets(ctx *sql.Context, workingSets []*doltdb.WorkingSet, dbNames []string) ([]*doltdb.WorkingSet, error) {
+	commits, err := tx.newWorkingSetCommits(ctx, workingSets, dbNames)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.commitWorkingSets(ctx, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([]*doltdb.WorkingSet, len(commits))
+	for i, c := range commits {
+		updated[i] = c.merged
+	}
+	return updated, nil
+}
+
+// newWorkingSetCommits returns the commits of |workingSets| of the databases named |dbNames| in this transaction
+func (tx *DoltTransaction) newWorkingSetCommits(ctx *sql.Context, workingSets []*doltdb.WorkingSet, dbNames []string) ([]*workingSetCommit, error) {
+	sess := DSessFromSess(ctx.Session)
+
+	commits := make([]*workingSetCommit, len(workingSets))
+	for i, workingSet := range workingSets {
+		branchState, ok, err := sess.lookupDbState(ctx, dbNames[i])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("database %s unknown to transaction, this is a bug", dbNames[i])
+		}
+		normalizedDbName := strings.ToLower(branchState.dbState.dbName)
+
+		startPoint, ok := tx.dbStartPoints[normalizedDbName]
+		if !ok {
+			return nil, fmt.Errorf("database %s unknown to transaction, this is a bug", dbNames[i])
+		}
+
+		startState, err := startPoint.db.ResolveWorkingSetAtRoot(ctx, workingSet.Ref(), startPoint.rootHash)
+		if err != nil {
+			return nil, err
+		}
+
+		commits[i] = &workingSetCommit{
+			dbName:     dbNames[i],
+			lockID:     normalizedDbName + "\u0000" + workingSet.Ref().String(),
+			startPoint: startPoint,
+			startState: startState,
+			workingSet: workingSet,
+			mergeOpts:  branchState.EditOpts(),
+		}
+	}
+	return commits, nil
+}
+
+// workingSetsWrite writes the merged working sets of |commits|, which all belong to one database. Returns false, with
+// no error, when the write lost a race with another one and should be retried.
+type workingSetsWrite func(ctx *sql.Context, commits []*workingSetCommit, meta *datas.WorkingSetMeta, rsc *doltdb.ReplicationStatusController) (bool, error)
+
+// commitWorkingSets merges and writes |commits|, see CommitWorkingSets
+func (tx *DoltTransaction) commitWorkingSets(ctx *sql.Context, commits []*workingSetCommit) error {
+	return tx.mergeWorkingSets(ctx, commits, writeWorkingSets)
+}
+
+// mergeWorkingSets merges each of |commits| with the changes committed to its working set since this transaction
+// started, and validates the results, under the transaction locks of all of them. The merged working sets are then
+// written with |write|, still under the locks. All of |commits| must belong to the same database.
+func (tx *DoltTransaction) mergeWorkingSets(ctx *sql.Context, commits []*workingSetCommit, write workingSetsWrite) error {
+	sess := DSessFromSess(ctx.Session)
+
+	for i := 1; i < len(commits); i++ {
+		if !strings.EqualFold(commits[i].startPoint.dbName, commits[0].startPoint.dbName) {
+			return ErrMultipleDatabasesCommit
+		}
+	}
+
+	// Transaction locks are taken in a fixed order, so that sessions committing overlapping sets of working sets can't
+	// deadlock
+	locking := make([]*workingSetCommit, len(commits))
+	copy(locking, commits)
+	sort.Slice(locking, func(i, j int) bool {
+		return locking[i].lockID < locking[j].lockID
+	})
+
+	for i := 0; i < maxTxCommitRetries; i++ {
+		committed, err := func() (bool, error) {
+			for j, c := range locking {
+				err := sess.Provider().TxLocks().Lock(ctx, c.lockID)
+				if err != nil {
+					for _, locked := range locking[:j] {
+						sess.Provider().TxLocks().Unlock(locked.lockID)
+					}
+					return false, err
+				}
+			}
+			defer func() {
+				for _, c := range locking {
+					sess.Provider().TxLocks().Unlock(c.lockID)
+				}
+			}()
+
+			if err := tx.validateReads(ctx); err != nil {
+				return false, err
+			}
+
+			// Phase one: merge and validate every working set
+			for _, c := range locking {
+				if err := tx.checkXAHold(ctx, c.startPoint, c.workingSet.Ref(), c.xid); err != nil {
+					return false, err
+				}
+				var err error
+				c.merged, c.existing, err = tx.mergeWorkingSet(ctx, c.dbName, c.startPoint.db, c.startState, c.workingSet, nil, c.mergeOpts)
+				if err != nil {
+					return false, err
+				}
+			}
+
+			// Phase two: write the merged working sets
+			name, email, _, _, err := ResolveNameEmail(ctx, DoltCommitterName, DoltCommitterEmail)
+			if err != nil {
+				return false, err
+			}
+			meta := tx.WorkingSetMeta(name, email)
+
+			var rsc doltdb.ReplicationStatusController
+			defer func() {
+				WaitForReplicationController(ctx, rsc)
+			}()
+
+			return write(ctx, locking, meta, &rsc)
+		}()
+
+		if err != nil {
+			return err
+		} else if committed {
+			return nil
+		}
+	}
+
+	// TODO: different error type for retries exhausted
+	return datas.ErrOptimisticLockFailed
+}
+
+// writeWorkingSets is the workingSetsWrite of commitWorkingSets. It writes the working sets in one update of the
+// database's root.
+func writeWorkingSets(ctx *sql.Context, commits []*workingSetCommit, meta *datas.WorkingSetMeta, rsc *doltdb.ReplicationStatusController) (bool, error) {
+	var updates []doltdb.WorkingSetUpdate
+	for _, c := range commits {
+		prevHash, err := c.existing.HashOf()
+		if err != nil {
+			return false, err
+		}
+		updates = append(updates, doltdb.WorkingSetUpdate{Ref: c.workingSet.Ref(), WorkingSet: c.merged, PrevHash: prevHash})
+		for _, removed := range c.removes {
+			removedHash, err := removed.HashOf()
+			if err != nil {
+				return false, err
+			}
+			updates = append(updates, doltdb.WorkingSetUpdate{Ref: removed.Ref(), PrevHash: removedHash})
+		}
+	}
+
+	err := commits[0].startPoint.db.UpdateWorkingSets(ctx, updates, meta, rsc)
+	if err == datas.ErrOptimisticLockFailed {
+		// this is effectively a `continue` in the loop
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // transactionWrite is the logic to write an updated working set (and optionally a commit) to the database
 type transactionWrite func(ctx *sql.Context,
 	dbName string,
@@ -435,61 +640,22 @@ func (tx *DoltTransaction) doCommit(
 			}
 			defer sess.Provider().TxLocks().Unlock(lockID)
 
-			newWorkingSet := false
-
-			existingWs, err := startPoint.db.ResolveWorkingSet(ctx, workingSet.Ref())
-			if err == doltdb.ErrWorkingSetNotFound {
-				// This is to handle the case where this is the first commit to a branch which
-				// does not have a working set. Typically Dolt creates a working set when it
-				// creates the branch. However, things like pushing a branch to a remote do not
-				// typically eagerly create a working set which does not exist. Since sql-server
-				// can run as a doltremoteapi remote endpoint and accept writes, this logic
-				// should anti-entropy the lack of a working set here.
-				existingWs = doltdb.EmptyWorkingSet(workingSet.Ref())
-				newWorkingSet = true
-			} else if err != nil {
+			// Under SERIALIZABLE isolation, merging with the changes committed since the transaction began is only
+			// safe if none of them touched the rows this transaction read.
+			if err := tx.validateReads(ctx); err != nil {
 				return nil, nil, err
 			}
 
-			existingWSHash, err := existingWs.HashOf()
-			if err != nil {
+			if err := tx.checkXAHold(ctx, startPoint, workingSet.Ref(), nil); err != nil {
 				return nil, nil, err
 			}
 
-			// Checked before the working set merge so that a stale amend reports the moved head rather
-			// than a data conflict.
-			if err := tx.validateAmendedHead(ctx, startPoint.db, workingSet, commit); err != nil {
-				return nil, nil, err
-			}
-
-			if newWorkingSet || workingAndStagedEqual(existingWs, startState) {
-				// ff merge
-				err = tx.validateWorkingSetForCommit(ctx, workingSet, isFfMerge)
-				if err != nil {
-					return nil, nil, err
-				}
-
-				var newCommit *doltdb.Commit
-				workingSet, newCommit, err = writeFn(ctx, dbName, tx, startPoint.db, startState, commit, workingSet, existingWSHash, mergeOpts)
-				if err == datas.ErrOptimisticLockFailed {
-					// this is effectively a `continue` in the loop
-					return nil, nil, nil
-				} else if err != nil {
-					return nil, nil, err
-				}
-
-				return workingSet, newCommit, nil
-			}
-
-			// otherwise (not a ff), merge the working sets together
-			start := time.Now()
-			mergedWorkingSet, err := tx.mergeRoots(ctx, dbName, startState, existingWs, workingSet, mergeOpts)
+			mergedWorkingSet, existingWs, err := tx.mergeWorkingSet(ctx, dbName, startPoint.db, startState, workingSet, commit, mergeOpts)
 			if err != nil {
 				return nil, nil, err
 			}
-			logrus.Tracef("working set merge took %s", time.Since(start))
 
-			err = tx.validateWorkingSetForCommit(ctx, mergedWorkingSet, notFfMerge)
+			existingWSHash, err := existingWs.HashOf()
 			if err != nil {
 				return nil, nil, err
 			}
@@ -517,6 +683,65 @@ func (tx *DoltTransaction) doCommit(
 	return nil, nil, datas.ErrOptimisticLockFailed
 }
 
+// mergeWorkingSet merges |workingSet| with the changes committed to it by other transactions since this transaction
+// started at |startState|, and validates the result for commit. Returns the merged working set along with the working
+// set currently stored in |doltDb|, which the merged working set is meant to replace. The caller must hold the
+// transaction lock for the working set.
+func (tx *DoltTransaction) mergeWorkingSet(
+	ctx *sql.Context,
+	dbName string,
+	doltDb *doltdb.DoltDB,
+	startState *doltdb.WorkingSet,
+	workingSet *doltdb.WorkingSet,
+	commit *doltdb.PendingCommit,
+	mergeOpts editor.Options,
+) (merged *doltdb.WorkingSet, existing *doltdb.WorkingSet, err error) {
+	newWorkingSet := false
+	existingWs, err := doltDb.ResolveWorkingSet(ctx, workingSet.Ref())
+	if err == doltdb.ErrWorkingSetNotFound {
+		// This is to handle the case where this is the first commit to a branch which
+		// does not have a working set. Typically Dolt creates a working set when it
+		// creates the branch. However, things like pushing a branch to a remote do not
+		// typically eagerly create a working set which does not exist. Since sql-server
+		// can run as a doltremoteapi remote endpoint and accept writes, this logic
+		// should anti-entropy the lack of a working set here.
+		existingWs = doltdb.EmptyWorkingSet(workingSet.Ref())
+		newWorkingSet = true
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	// Checked before the working set merge so that a stale amend reports the moved head rather
+	// than a data conflict.
+	if err := tx.validateAmendedHead(ctx, doltDb, workingSet, commit); err != nil {
+		return nil, nil, err
+	}
+
+	if newWorkingSet || workingAndStagedEqual(existingWs, startState) {
+		// ff merge
+		err = tx.validateWorkingSetForCommit(ctx, workingSet, isFfMerge)
+		if err != nil {
+			return nil, nil, err
+		}
+		return workingSet, existingWs, nil
+	}
+
+	// otherwise (not a ff), merge the working sets together
+	start := time.Now()
+	mergedWorkingSet, err := tx.mergeRoots(ctx, dbName, startState, existingWs, workingSet, mergeOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	logrus.Tracef("working set merge took %s", time.Since(start))
+
+	err = tx.validateWorkingSetForCommit(ctx, mergedWorkingSet, notFfMerge)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mergedWorkingSet, existingWs, nil
+}
+
 // mergeRoots merges the roots in the existing working set with the one being committed and returns the resulting
 // working set. Conflicts are automatically resolved with "accept ours" if the session settings dictate it.
 // Currently merges working and staged roots as necessary. HEAD root is only handled by the DoltCommit function.
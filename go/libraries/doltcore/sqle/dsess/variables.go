@@ -16,6 +16,7 @@ package dsess
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -59,6 +60,10 @@ const (
 	DoltLogLevel                         = "dolt_log_level"
 	ShowSystemTables                     = "dolt_show_system_tables"
 	AllowCICreation                      = "dolt_allow_ci_creation"
+	DoltLockWaitTimeout                  = "dolt_lock_wait_timeout"
+	DoltQueryCacheSize                   = "dolt_query_cache_size"
+	DoltQueryCacheMaxResultSize          = "dolt_query_cache_max_result_size"
+	DoltQueryParallelism                 = "dolt_query_parallelism"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
@@ -202,6 +207,23 @@ func GetBooleanSystemVar(ctx *sql.Context, varName string) (bool, error) {
 	return i8 == int8(1), nil
 }
 
+// QueryParallelism returns the number of goroutines that the session of |ctx| splits table scans across, set with
+// @@dolt_query_parallelism. Scans are serial unless it's set to more than 1, or to 0 for the number of CPUs.
+func QueryParallelism(ctx *sql.Context) int {
+	v, err := ctx.GetSessionVariable(ctx, DoltQueryParallelism)
+	if err != nil {
+		return 1
+	}
+	n, ok := v.(int64)
+	if !ok {
+		return 1
+	}
+	if n == 0 {
+		return runtime.NumCPU()
+	}
+	return int(n)
+}
+
 // IgnoreReplicationErrors returns true if the dolt_skip_replication_errors system variable is set to true, which means
 // that errors that occur during replication should be logged and ignored.
 func IgnoreReplicationErrors() bool {
@@ -0,0 +1,756 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// XA transactions are the branches of distributed transactions run by an external transaction manager, with the
+// statements XA START, END, PREPARE, COMMIT, ROLLBACK and RECOVER. A prepared XA transaction is stored in the database
+// rather than in the session, so that it survives the session ending and the server restarting.
+//
+// Preparing a transaction merges the working sets it changed with the changes committed to them since it began, as
+// committing it would, and fails if they conflict. Each merged working set is written to a working set ref of its
+// own, along with the working set of the branch it was merged with:
+//
+//   workingSets/xa/<xid>/prepared/heads/main  the working set of main as committing the transaction will leave it
+//   workingSets/xa/<xid>/start/heads/main     the working set of main when the transaction was prepared
+//   workingSets/xa/<xid>/readonly             written instead, when the transaction changed nothing
+//
+// A prepared transaction holds the branches it changed: other transactions can't commit to them until it's committed
+// or rolled back, so that committing it only has to write the working sets it merged, and can't fail on a conflict.
+// Committing it writes them, and deletes its refs in the same write. Like any other transaction, an XA transaction can
+// only change the branches of one database, so that it's prepared and committed with a single write.
+
+// ErrXANotFound is returned for an XID that isn't a known XA transaction
+var ErrXANotFound = mysql.NewSQLError(1397, "XAE04", "XAER_NOTA: Unknown XID")
+
+// ErrXAInvalid is returned for an XID that isn't valid
+var ErrXAInvalid = mysql.NewSQLError(1398, "XAE05", "XAER_INVAL: Invalid arguments (or unsupported command)")
+
+// ErrXAOutside is returned when an XA transaction is started while the session has a transaction of its own
+var ErrXAOutside = mysql.NewSQLError(1400, "XAE09", "XAER_OUTSIDE: Some work is done outside global transaction")
+
+// ErrXADuplicate is returned when an XA transaction is started with the XID of another
+var ErrXADuplicate = mysql.NewSQLError(1440, "XAE08", "XAER_DUPID: The XID already exists")
+
+// errXAState returns the error for an XA statement that isn't valid in the state of the session's XA transaction
+func errXAState(state string) error {
+	return mysql.NewSQLError(1399, "XAE07",
+		"XAER_RMFAIL: The command cannot be executed when global transaction is in the %s state", state)
+}
+
+const (
+	xaActive      = "ACTIVE"
+	xaIdle        = "IDLE"
+	xaPrepared    = "PREPARED"
+	xaNonExisting = "NON-EXISTING"
+
+	xaRefPrefix   = "xa/"
+	xaPreparedRef = "prepared"
+	xaStartRef    = "start"
+	xaReadOnlyRef = "readonly"
+)
+
+// maxXIDPartLength is the longest gtrid or bqual allowed in an XID
+const maxXIDPartLength = 64
+
+// XID identifies an XA transaction
+type XID struct {
+	Gtrid    string
+	Bqual    string
+	FormatID int64
+}
+
+// NewXID returns the XID with the parts given, or ErrXAInvalid if they don't make one
+func NewXID(gtrid, bqual string, formatID int64) (XID, error) {
+	if len(gtrid) == 0 || len(gtrid) > maxXIDPartLength || len(bqual) > maxXIDPartLength || formatID < 0 {
+		return XID{}, ErrXAInvalid
+	}
+	return XID{Gtrid: gtrid, Bqual: bqual, FormatID: formatID}, nil
+}
+
+func (x XID) String() string {
+	return fmt.Sprintf("'%s','%s',%d", x.Gtrid, x.Bqual, x.FormatID)
+}
+
+// key returns the identifier of the XID in working set refs, which only uses characters valid in them
+func (x XID) key() string {
+	return fmt.Sprintf("%s_%s_%d", hex.EncodeToString([]byte(x.Gtrid)), hex.EncodeToString([]byte(x.Bqual)), x.FormatID)
+}
+
+// parseXIDKey is the inverse of XID.key
+func parseXIDKey(key string) (XID, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 {
+		return XID{}, false
+	}
+	gtrid, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return XID{}, false
+	}
+	bqual, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return XID{}, false
+	}
+	formatID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return XID{}, false
+	}
+	return XID{Gtrid: string(gtrid), Bqual: string(bqual), FormatID: formatID}, true
+}
+
+// xaWorkingSetRef returns the ref the prepared XA transaction |xid| stores the working set |kind| of |target| in
+func xaWorkingSetRef(xid XID, kind string, target ref.WorkingSetRef) ref.WorkingSetRef {
+	return ref.NewWorkingSetRef(xaRefPrefix + xid.key() + "/" + kind + "/" + target.GetPath())
+}
+
+// XATransactions tracks the XA transactions of a server: the ones sessions are running, and the branches held by the
+// prepared ones, which are loaded from each database the first time one of its branches is committed to.
+type XATransactions struct {
+	mu     *sync.Mutex
+	active map[string]uint32
+	// readOnly are the prepared transactions that changed nothing and had no database to be stored in
+	readOnly map[string]XID
+	// held are the prepared transactions holding working sets, by the transaction lock ID of the working set
+	held map[string]XID
+	// loaded are the databases whose prepared transactions are in |held|
+	loaded map[*doltdb.DoltDB]struct{}
+	// finishMu serializes the commit and rollback of prepared transactions, so that only one session finishes each
+	finishMu *sync.Mutex
+}
+
+// NewXATransactions returns a new, empty XATransactions
+func NewXATransactions() *XATransactions {
+	return &XATransactions{
+		mu:       &sync.Mutex{},
+		active:   make(map[string]uint32),
+		readOnly: make(map[string]XID),
+		held:     make(map[string]XID),
+		loaded:   make(map[*doltdb.DoltDB]struct{}),
+		finishMu: &sync.Mutex{},
+	}
+}
+
+// begin records that the session |connectionID| runs the XA transaction |xid|
+func (x *XATransactions) begin(xid XID, connectionID uint32) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.active[xid.key()]; ok {
+		return ErrXADuplicate
+	}
+	if _, ok := x.readOnly[xid.key()]; ok {
+		return ErrXADuplicate
+	}
+	x.active[xid.key()] = connectionID
+	return nil
+}
+
+// end records that the XA transaction |xid| isn't run by a session anymore
+func (x *XATransactions) end(xid XID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.active, xid.key())
+}
+
+// prepareReadOnly records the prepared transaction |xid|, which didn't change anything
+func (x *XATransactions) prepareReadOnly(xid XID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.readOnly[xid.key()] = xid
+}
+
+// finishReadOnly forgets the prepared transaction |xid| if it didn't change anything, and returns whether it did so
+func (x *XATransactions) finishReadOnly(xid XID) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	_, ok := x.readOnly[xid.key()]
+	delete(x.readOnly, xid.key())
+	return ok
+}
+
+func (x *XATransactions) readOnlyXIDs() []XID {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	xids := make([]XID, 0, len(x.readOnly))
+	for _, xid := range x.readOnly {
+		xids = append(xids, xid)
+	}
+	return xids
+}
+
+// xaLockID returns the transaction lock ID of the working set |wsRef| of |db|, which identifies it in
+// XATransactions.held
+func xaLockID(db dbRoot, wsRef ref.WorkingSetRef) string {
+	return strings.ToLower(db.dbName) + "\u0000" + wsRef.String()
+}
+
+// holder returns the prepared transaction holding the working set |wsRef| of |db|, if there is one
+func (x *XATransactions) holder(ctx *sql.Context, db dbRoot, wsRef ref.WorkingSetRef) (XID, bool, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, ok := x.loaded[db.db]; !ok {
+		refs, err := db.db.GetWorkingSetRefs(ctx, xaRefPrefix)
+		if err != nil {
+			return XID{}, false, err
+		}
+		for _, r := range refs {
+			key, rest, ok := strings.Cut(strings.TrimPrefix(r.GetPath(), xaRefPrefix), "/")
+			if !ok || !strings.HasPrefix(rest, xaStartRef+"/") {
+				continue
+			}
+			if xid, ok := parseXIDKey(key); ok {
+				target := ref.NewWorkingSetRef(strings.TrimPrefix(rest, xaStartRef+"/"))
+				x.held[xaLockID(db, target)] = xid
+			}
+		}
+		x.loaded[db.db] = struct{}{}
+	}
+	xid, ok := x.held[xaLockID(db, wsRef)]
+	return xid, ok, nil
+}
+
+// hold records that the prepared transaction |xid| holds the working set |wsRef| of |db|
+func (x *XATransactions) hold(xid XID, db dbRoot, wsRef ref.WorkingSetRef) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.held[xaLockID(db, wsRef)] = xid
+}
+
+// release records that the prepared transaction |xid| doesn't hold any working sets anymore
+func (x *XATransactions) release(xid XID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for lockID, holder := range x.held {
+		if holder == xid {
+			delete(x.held, lockID)
+		}
+	}
+}
+
+// checkXAHold returns an error, after rolling back this transaction, if the working set |wsRef| of |db| is held by a
+// prepared XA transaction other than |xid|, which is nil for transactions that aren't committing one
+func (tx *DoltTransaction) checkXAHold(ctx *sql.Context, db dbRoot, wsRef ref.WorkingSetRef, xid *XID) error {
+	holder, held, err := DSessFromSess(ctx.Session).provider.XATransactions().holder(ctx, db, wsRef)
+	if err != nil || !held || (xid != nil && *xid == holder) {
+		return err
+	}
+	branch := wsRef.GetPath()
+	if headRef, err := wsRef.ToHeadRef(); err == nil {
+		branch = headRef.GetPath()
+	}
+	detail := fmt.Sprintf("branch %s of database %s is held by the prepared XA transaction %s until it's committed "+
+		"or rolled back", branch, db.dbName, holder)
+	return tx.rollbackAndErr(ctx, retryTransactionError(detail))
+}
+
+// sessionXA is the XA transaction a session is running
+type sessionXA struct {
+	xid *XID
+	// ended is true after XA END, when the transaction can be prepared
+	ended bool
+}
+
+// xaStateErr returns the error for an XA statement that isn't valid in the state of the session's XA transaction
+func (d *DoltSession) xaStateErr() error {
+	if d.xa.xid == nil {
+		return errXAState(xaNonExisting)
+	} else if d.xa.ended {
+		return errXAState(xaIdle)
+	}
+	return errXAState(xaActive)
+}
+
+// InXATransaction returns whether the session is running an XA transaction
+func (d *DoltSession) InXATransaction() bool {
+	return d.xa != nil && d.xa.xid != nil
+}
+
+// endXA forgets the session's XA transaction
+func (d *DoltSession) endXA() {
+	if d.xa != nil && d.xa.xid != nil {
+		d.provider.XATransactions().end(*d.xa.xid)
+		d.xa.xid = nil
+	}
+}
+
+// checkXA returns an error unless the session is running the XA transaction |xid| and it's ended or not, as given
+func (d *DoltSession) checkXA(xid XID, ended bool) error {
+	if d.xa.xid == nil {
+		return errXAState(xaNonExisting)
+	} else if *d.xa.xid != xid {
+		return ErrXANotFound
+	} else if d.xa.ended != ended {
+		return d.xaStateErr()
+	}
+	return nil
+}
+
+// XAStart starts the XA transaction |xid|, whose statements run in a new transaction until it's ended with XAEnd
+func (d *DoltSession) XAStart(ctx *sql.Context, xid XID) error {
+	if d.xa.xid != nil {
+		return d.xaStateErr()
+	}
+	if ctx.GetIgnoreAutoCommit() || len(d.dirtyWorkingSets()) > 0 {
+		return ErrXAOutside
+	}
+
+	prepared, err := d.loadPreparedXA(ctx, xid)
+	if err != nil {
+		return err
+	}
+	if prepared.exists() {
+		return ErrXADuplicate
+	}
+	if err = d.provider.XATransactions().begin(xid, d.ID()); err != nil {
+		return err
+	}
+
+	tx, err := d.StartTransaction(ctx, sql.ReadWrite)
+	if err != nil {
+		d.provider.XATransactions().end(xid)
+		return err
+	}
+	ctx.SetTransaction(tx)
+	ctx.SetIgnoreAutoCommit(true)
+	d.xa.xid, d.xa.ended = &xid, false
+	return nil
+}
+
+// XAEnd ends the statements of the XA transaction |xid|, which can then be prepared
+func (d *DoltSession) XAEnd(ctx *sql.Context, xid XID) error {
+	if err := d.checkXA(xid, false); err != nil {
+		return err
+	}
+	d.xa.ended = true
+	return nil
+}
+
+// XAPrepare prepares the XA transaction |xid|, storing its changes, merged with the ones committed since it began, so
+// that any session can commit or roll it back later, even after the server restarts. The session isn't running the
+// transaction afterward. If the transaction can't be prepared, because its changes conflict with the ones committed
+// since it began or for any other reason, it's rolled back.
+func (d *DoltSession) XAPrepare(ctx *sql.Context, xid XID) error {
+	if err := d.checkXA(xid, true); err != nil {
+		return err
+	}
+	dtx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return fmt.Errorf("expected a DoltTransaction")
+	}
+
+	var err error
+	if dirties := d.dirtyWorkingSets(); len(dirties) == 0 {
+		err = d.prepareReadOnlyXA(ctx, dtx, xid)
+	} else {
+		err = d.prepareXA(ctx, dtx, xid, dirties)
+	}
+
+	d.endXA()
+	if rerr := d.Rollback(ctx, dtx); err == nil {
+		err = rerr
+	}
+	ctx.SetTransaction(nil)
+	ctx.SetIgnoreAutoCommit(false)
+	return err
+}
+
+// prepareXA merges the working sets of |branchStates| in |dtx| with the changes committed to them since it began, and
+// writes the results and the working sets they were merged with to the refs of the prepared transaction |xid|, which
+// then holds their branches
+func (d *DoltSession) prepareXA(ctx *sql.Context, dtx *DoltTransaction, xid XID, branchStates []*branchState) error {
+	workingSets := make([]*doltdb.WorkingSet, len(branchStates))
+	dbNames := make([]string, len(branchStates))
+	for i, bs := range branchStates {
+		workingSets[i] = bs.WorkingSet()
+		dbNames[i] = bs.RevisionDbName()
+	}
+	commits, err := dtx.newWorkingSetCommits(ctx, workingSets, dbNames)
+	if err != nil {
+		return err
+	}
+
+	return dtx.mergeWorkingSets(ctx, commits, func(ctx *sql.Context, commits []*workingSetCommit, meta *datas.WorkingSetMeta, rsc *doltdb.ReplicationStatusController) (bool, error) {
+		var updates []doltdb.WorkingSetUpdate
+		for _, c := range commits {
+			start := c.existing
+			if h, err := start.HashOf(); err != nil {
+				return false, err
+			} else if h.IsEmpty() {
+				// the branch has no working set yet
+				start = c.startState
+			}
+			preparedRef := xaWorkingSetRef(xid, xaPreparedRef, c.workingSet.Ref())
+			startRef := xaWorkingSetRef(xid, xaStartRef, c.workingSet.Ref())
+			updates = append(updates,
+				doltdb.WorkingSetUpdate{Ref: preparedRef, WorkingSet: c.merged.WithRef(preparedRef)},
+				doltdb.WorkingSetUpdate{Ref: startRef, WorkingSet: start.WithRef(startRef)})
+		}
+
+		err := commits[0].startPoint.db.UpdateWorkingSets(ctx, updates, meta, rsc)
+		if err == datas.ErrOptimisticLockFailed {
+			// the refs of |xid| already exist
+			return false, ErrXADuplicate
+		} else if err != nil {
+			return false, err
+		}
+
+		xas := d.provider.XATransactions()
+		for _, c := range commits {
+			xas.hold(xid, c.startPoint, c.workingSet.Ref())
+		}
+		return true, nil
+	})
+}
+
+// prepareReadOnlyXA records the prepared transaction |xid|, which changed nothing, in the first database of |dtx| by
+// name that the session has a working set of. It's only remembered until the server stops if there's none.
+func (d *DoltSession) prepareReadOnlyXA(ctx *sql.Context, dtx *DoltTransaction, xid XID) error {
+	names := make([]string, 0, len(dtx.dbStartPoints))
+	for name := range dtx.dbStartPoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		startPoint := dtx.dbStartPoints[name]
+		bs, ok, err := d.lookupDbState(ctx, startPoint.dbName)
+		if err != nil {
+			return err
+		}
+		if !ok || bs.WorkingSet() == nil {
+			continue
+		}
+
+		userName, email, _, _, err := ResolveNameEmail(ctx, DoltCommitterName, DoltCommitterEmail)
+		if err != nil {
+			return err
+		}
+		markerRef := xaReadOnlyWorkingSetRef(xid)
+		update := doltdb.WorkingSetUpdate{Ref: markerRef, WorkingSet: bs.WorkingSet().WithRef(markerRef)}
+		err = startPoint.db.UpdateWorkingSets(ctx, []doltdb.WorkingSetUpdate{update}, dtx.WorkingSetMeta(userName, email), nil)
+		if err == datas.ErrOptimisticLockFailed {
+			return ErrXADuplicate
+		}
+		return err
+	}
+
+	d.provider.XATransactions().prepareReadOnly(xid)
+	return nil
+}
+
+// xaReadOnlyWorkingSetRef returns the ref that records the prepared transaction |xid| when it changed nothing
+func xaReadOnlyWorkingSetRef(xid XID) ref.WorkingSetRef {
+	return ref.NewWorkingSetRef(xaRefPrefix + xid.key() + "/" + xaReadOnlyRef)
+}
+
+// preparedXA is a prepared XA transaction, as stored in the databases
+type preparedXA struct {
+	branches []preparedXABranch
+	// readOnly are the refs that record the transaction when it changed nothing
+	readOnly []preparedXAMarker
+}
+
+func (p preparedXA) exists() bool {
+	return len(p.branches) > 0 || len(p.readOnly) > 0
+}
+
+// preparedXABranch is a branch changed by a prepared XA transaction
+type preparedXABranch struct {
+	dbName   string
+	db       *doltdb.DoltDB
+	target   ref.WorkingSetRef
+	prepared *doltdb.WorkingSet
+	start    *doltdb.WorkingSet
+}
+
+// preparedXAMarker is the ref that records a prepared XA transaction that changed nothing
+type preparedXAMarker struct {
+	db *doltdb.DoltDB
+	ws *doltdb.WorkingSet
+}
+
+// loadPreparedXA returns the prepared XA transaction |xid| as stored in all databases
+func (d *DoltSession) loadPreparedXA(ctx *sql.Context, xid XID) (preparedXA, error) {
+	var prepared preparedXA
+	prefix := xaRefPrefix + xid.key() + "/"
+	for _, db := range d.provider.DoltDatabases() {
+		ddb := db.DbData().Ddb
+		if ddb == nil {
+			continue
+		}
+
+		refs, err := ddb.GetWorkingSetRefs(ctx, prefix)
+		if err != nil {
+			return preparedXA{}, err
+		}
+		for _, r := range refs {
+			rest := strings.TrimPrefix(r.GetPath(), prefix)
+			if rest == xaReadOnlyRef {
+				ws, err := ddb.ResolveWorkingSet(ctx, r)
+				if err != nil {
+					return preparedXA{}, err
+				}
+				prepared.readOnly = append(prepared.readOnly, preparedXAMarker{db: ddb, ws: ws})
+				continue
+			} else if !strings.HasPrefix(rest, xaPreparedRef+"/") {
+				continue
+			}
+
+			target := ref.NewWorkingSetRef(strings.TrimPrefix(rest, xaPreparedRef+"/"))
+			preparedWs, err := ddb.ResolveWorkingSet(ctx, r)
+			if err != nil {
+				return preparedXA{}, err
+			}
+			start, err := ddb.ResolveWorkingSet(ctx, xaWorkingSetRef(xid, xaStartRef, target))
+			if err != nil {
+				return preparedXA{}, err
+			}
+			prepared.branches = append(prepared.branches, preparedXABranch{
+				dbName:   db.Name(),
+				db:       ddb,
+				target:   target,
+				prepared: preparedWs,
+				start:    start,
+			})
+		}
+	}
+	return prepared, nil
+}
+
+// XACommit commits the XA transaction |xid|. Unless |onePhase| is given, it must be a prepared transaction, which any
+// session can commit. The branches a prepared transaction changed are held for it, so committing it only writes the
+// working sets it merged when it was prepared.
+func (d *DoltSession) XACommit(ctx *sql.Context, xid XID, onePhase bool) error {
+	if d.xa.xid != nil {
+		if *d.xa.xid != xid {
+			return ErrXANotFound
+		} else if !onePhase || !d.xa.ended {
+			return d.xaStateErr()
+		}
+
+		tx := ctx.GetTransaction()
+		d.endXA()
+		ctx.SetIgnoreAutoCommit(false)
+		if err := d.CommitTransaction(ctx, tx); err != nil {
+			_ = d.Rollback(ctx, tx)
+			ctx.SetTransaction(nil)
+			return err
+		}
+		return nil
+	}
+
+	if len(d.dirtyWorkingSets()) > 0 {
+		return ErrXAOutside
+	}
+
+	xas := d.provider.XATransactions()
+	xas.finishMu.Lock()
+	defer xas.finishMu.Unlock()
+
+	prepared, err := d.loadPreparedXA(ctx, xid)
+	if err != nil {
+		return err
+	}
+	if !prepared.exists() {
+		if xas.finishReadOnly(xid) {
+			return nil
+		}
+		return ErrXANotFound
+	} else if onePhase {
+		return errXAState(xaPrepared)
+	} else if len(prepared.branches) == 0 {
+		return d.deletePreparedXA(ctx, prepared)
+	}
+
+	dtx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return fmt.Errorf("expected a DoltTransaction")
+	}
+
+	commits := make([]*workingSetCommit, len(prepared.branches))
+	for i, b := range prepared.branches {
+		headRef, err := b.target.ToHeadRef()
+		if err != nil {
+			return err
+		}
+		dbName := doltdb.RevisionDbName(b.dbName, headRef.GetPath())
+		startPoint := dbRoot{db: b.db, dbName: b.dbName}
+		commits[i] = &workingSetCommit{
+			dbName:     dbName,
+			lockID:     xaLockID(startPoint, b.target),
+			startPoint: startPoint,
+			startState: b.start.WithRef(b.target),
+			workingSet: b.prepared.WithRef(b.target),
+			mergeOpts:  editor.Options{},
+			removes:    []*doltdb.WorkingSet{b.prepared, b.start},
+			xid:        &xid,
+		}
+	}
+	for _, m := range prepared.readOnly {
+		// a transaction prepared again with the XID of one that was committed or rolled back without this database
+		commits[0].removes = append(commits[0].removes, m.ws)
+	}
+
+	if err = dtx.commitWorkingSets(ctx, commits); err != nil {
+		return err
+	}
+	xas.release(xid)
+
+	// See the comment in |commitBranchState|
+	ctx.SetTransaction(nil)
+	return nil
+}
+
+// XARollback rolls back the XA transaction |xid|, which is either the ended transaction of the session or a prepared
+// transaction
+func (d *DoltSession) XARollback(ctx *sql.Context, xid XID) error {
+	if d.xa.xid != nil {
+		if err := d.checkXA(xid, true); err != nil {
+			return err
+		}
+		if err := d.Rollback(ctx, ctx.GetTransaction()); err != nil {
+			return err
+		}
+		ctx.SetTransaction(nil)
+		ctx.SetIgnoreAutoCommit(false)
+		return nil
+	}
+
+	xas := d.provider.XATransactions()
+	xas.finishMu.Lock()
+	defer xas.finishMu.Unlock()
+
+	if xas.finishReadOnly(xid) {
+		return nil
+	}
+	prepared, err := d.loadPreparedXA(ctx, xid)
+	if err != nil {
+		return err
+	}
+	if !prepared.exists() {
+		return ErrXANotFound
+	}
+	if err = d.deletePreparedXA(ctx, prepared); err != nil {
+		return err
+	}
+	xas.release(xid)
+	return nil
+}
+
+// deletePreparedXA deletes the refs of the prepared transaction |prepared|. A failure part way leaves the rest of the
+// transaction prepared, to be rolled back again.
+func (d *DoltSession) deletePreparedXA(ctx *sql.Context, prepared preparedXA) error {
+	var dbs []*doltdb.DoltDB
+	updates := make(map[*doltdb.DoltDB][]doltdb.WorkingSetUpdate)
+	deleteWs := func(db *doltdb.DoltDB, ws *doltdb.WorkingSet) error {
+		h, err := ws.HashOf()
+		if err != nil {
+			return err
+		}
+		if _, ok := updates[db]; !ok {
+			dbs = append(dbs, db)
+		}
+		updates[db] = append(updates[db], doltdb.WorkingSetUpdate{Ref: ws.Ref(), PrevHash: h})
+		return nil
+	}
+	for _, b := range prepared.branches {
+		if err := deleteWs(b.db, b.prepared); err != nil {
+			return err
+		}
+		if err := deleteWs(b.db, b.start); err != nil {
+			return err
+		}
+	}
+	for _, m := range prepared.readOnly {
+		if err := deleteWs(m.db, m.ws); err != nil {
+			return err
+		}
+	}
+
+	dtx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return fmt.Errorf("expected a DoltTransaction")
+	}
+	name, email, _, _, err := ResolveNameEmail(ctx, DoltCommitterName, DoltCommitterEmail)
+	if err != nil {
+		return err
+	}
+	meta := dtx.WorkingSetMeta(name, email)
+	for _, db := range dbs {
+		if err = db.UpdateWorkingSets(ctx, updates[db], meta, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// XARecover returns the XIDs of the prepared XA transactions, ordered by their gtrid and bqual
+func (d *DoltSession) XARecover(ctx *sql.Context) ([]XID, error) {
+	seen := make(map[string]XID)
+	for _, xid := range d.provider.XATransactions().readOnlyXIDs() {
+		seen[xid.key()] = xid
+	}
+
+	for _, db := range d.provider.DoltDatabases() {
+		ddb := db.DbData().Ddb
+		if ddb == nil {
+			continue
+		}
+
+		refs, err := ddb.GetWorkingSetRefs(ctx, xaRefPrefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			key, rest, ok := strings.Cut(strings.TrimPrefix(r.GetPath(), xaRefPrefix), "/")
+			if !ok || (rest != xaReadOnlyRef && !strings.HasPrefix(rest, xaPreparedRef+"/")) {
+				continue
+			}
+			if xid, ok := parseXIDKey(key); ok {
+				seen[key] = xid
+			}
+		}
+	}
+
+	xids := make([]XID, 0, len(seen))
+	for _, xid := range seen {
+		xids = append(xids, xid)
+	}
+	sort.Slice(xids, func(i, j int) bool {
+		if xids[i].Gtrid != xids[j].Gtrid {
+			return xids[i].Gtrid < xids[j].Gtrid
+		}
+		if xids[i].Bqual != xids[j].Bqual {
+			return xids[i].Bqual < xids[j].Bqual
+		}
+		return xids[i].FormatID < xids[j].FormatID
+	})
+	return xids, nil
+}
@@ -42,6 +42,10 @@ import (
 
 const diffTableDefaultRowCount = 1000
 
+// cellDiffColName is the column added by the --cell-diff option, holding the line diff of each TEXT and JSON column
+// changed by a modified row.
+const cellDiffColName = "cell_diff"
+
 var ErrInvalidNonLiteralArgument = errors.NewKind("Invalid argument to %s: %s – only literal values supported")
 var ErrInvalidTableName = errors.NewKind("Invalid table name %s.")
 
@@ -65,6 +69,7 @@ type DiffTableFunction struct {
 	includeCols      map[string]struct{}
 	sqlSch           sql.Schema
 	showSkinny       bool
+	showCellDiff     bool
 }
 
 // CollationCoercibility implements the interface sql.CollationCoercible.
@@ -261,6 +266,10 @@ func (dtf *DiffTableFunction) WithExpressions(ctx *sql.Context, expressions ...s
 		newDtf.showSkinny = true
 	}
 
+	if apr.Contains(cli.CellDiffFlag) {
+		newDtf.showCellDiff = true
+	}
+
 	if cols, ok := apr.GetValueList(cli.IncludeCols); ok {
 		newDtf.includeCols = make(map[string]struct{})
 		for _, col := range cols {
@@ -347,7 +356,7 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 		toSchema, fromSchema,
 		nil)
 
-	return dtables.NewDiffPartitionRowIter(dp, ddb), nil
+	return dtf.withCellDiffs(dtables.NewDiffPartitionRowIter(dp, ddb)), nil
 }
 
 // findMatchingDelta returns the best matching table delta for the table name
@@ -684,14 +693,20 @@ func (dtf *DiffTableFunction) PartitionRows(ctx *sql.Context, part sql.Partition
 	if part == nil {
 		return dtf.RowIter(ctx, nil)
 	}
+	var iter sql.RowIter
+	var err error
 	switch p := part.(type) {
 	case *dtables.DiffPartition:
-		return p.GetRowIter(ctx)
+		iter, err = p.GetRowIter(ctx)
 	case *dtables.SecondaryDiffPartition:
-		return p.GetRowIter(ctx)
+		iter, err = p.GetRowIter(ctx)
 	default:
 		return nil, fmt.Errorf("unexpected partition type: %T", part)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return dtf.withCellDiffs(iter), nil
 }
 
 func (dtf *DiffTableFunction) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
@@ -877,6 +892,9 @@ func (dtf *DiffTableFunction) generateSchema(ctx *sql.Context, fromCommitVal, to
 	}
 
 	dtf.sqlSch = sqlSchema.Schema
+	if dtf.showCellDiff {
+		dtf.sqlSch = append(dtf.sqlSch, &sql.Column{Name: cellDiffColName, Type: gmstypes.JSON, Nullable: true})
+	}
 
 	return nil
 }
@@ -889,15 +907,29 @@ func (dtf *DiffTableFunction) cacheTableDelta(ctx *sql.Context, fromCommitVal, t
 		return diff.TableDelta{}, err
 	}
 
+	dtf.fromRefDetails = fromRefDetails
+	dtf.toRefDetails = toRefDetails
+
+	delta, err := tableDeltaForRefs(ctx, fromRefDetails, toRefDetails, tableName)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+
+	dtf.tableDelta = delta
+
+	return delta, nil
+}
+
+// tableDeltaForRefs returns the table delta for the table name given between the roots of |fromRefDetails| and
+// |toRefDetails|, taking renames into consideration. When the table has no diff, the delta holds the table as it is in
+// each revision. Returns a sql.ErrTableNotFound if the given table name cannot be found in either revision.
+func tableDeltaForRefs(ctx *sql.Context, fromRefDetails, toRefDetails *refDetails, tableName string) (diff.TableDelta, error) {
 	// TODO: it would be nice to limit this to just the table under consideration, not all tables with a diff
 	deltas, err := diff.GetTableDeltas(ctx, fromRefDetails.root, toRefDetails.root)
 	if err != nil {
 		return diff.TableDelta{}, err
 	}
 
-	dtf.fromRefDetails = fromRefDetails
-	dtf.toRefDetails = toRefDetails
-
 	delta := findMatchingDelta(deltas, tableName)
 
 	// We only get a delta if there's a diff. When there isn't one, construct a delta here with table and schema info
@@ -941,8 +973,6 @@ func (dtf *DiffTableFunction) cacheTableDelta(ctx *sql.Context, fromCommitVal, t
 		// TODO: There are other fields we could set here that we don't
 	}
 
-	dtf.tableDelta = delta
-
 	return delta, nil
 }
 
@@ -987,3 +1017,82 @@ func (dtf *DiffTableFunction) String() string {
 func (dtf *DiffTableFunction) Name() string {
 	return "dolt_diff"
 }
+
+// withCellDiffs returns |iter| with the cell_diff column appended to its rows when the --cell-diff option was given.
+func (dtf *DiffTableFunction) withCellDiffs(iter sql.RowIter) sql.RowIter {
+	if !dtf.showCellDiff {
+		return iter
+	}
+	// The rows of |iter| have every column of the schema but the last, and end with the diff_type column
+	cdi := &cellDiffIter{iter: iter, diffTypeIdx: len(dtf.sqlSch) - 2}
+	toCols := make(map[string]int)
+	for i, col := range dtf.sqlSch[:len(dtf.sqlSch)-1] {
+		toCols[strings.ToLower(col.Name)] = i
+	}
+	for i, col := range dtf.sqlSch[:len(dtf.sqlSch)-1] {
+		if !strings.HasPrefix(strings.ToLower(col.Name), "from_") {
+			continue
+		}
+		name := col.Name[len("from_"):]
+		if !gmstypes.IsJSON(col.Type) && !gmstypes.IsTextBlob(col.Type) {
+			continue
+		}
+		if toIdx, ok := toCols[strings.ToLower(diff.ToColNamer(name))]; ok {
+			cdi.cols = append(cdi.cols, cellDiffCol{name: name, typ: col.Type, fromIdx: i, toIdx: toIdx})
+		}
+	}
+	return cdi
+}
+
+// cellDiffCol is a column of the diffed table that cellDiffIter diffs the cells of.
+type cellDiffCol struct {
+	name           string
+	typ            sql.Type
+	fromIdx, toIdx int
+}
+
+// cellDiffIter appends the cell_diff column to the rows of a dolt_diff table function. For a modified row, it holds a
+// JSON object of the line diff of each TEXT and JSON column that changed, keyed by column name. It is NULL for other
+// rows.
+type cellDiffIter struct {
+	iter        sql.RowIter
+	cols        []cellDiffCol
+	diffTypeIdx int
+}
+
+var _ sql.RowIter = (*cellDiffIter)(nil)
+
+func (itr *cellDiffIter) Next(ctx *sql.Context) (sql.Row, error) {
+	row, err := itr.iter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if row[itr.diffTypeIdx] != diff.DiffTypeModified {
+		return append(row, nil), nil
+	}
+
+	cellDiffs := make(map[string]interface{})
+	for _, col := range itr.cols {
+		from, to := row[col.fromIdx], row[col.toIdx]
+		if cmp, err := col.typ.Compare(ctx, from, to); err != nil {
+			return nil, err
+		} else if cmp == 0 {
+			continue
+		}
+		cellDiff, ok, err := diff.CellDiff(ctx, col.typ, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cellDiffs[col.name] = cellDiff
+		}
+	}
+	if len(cellDiffs) == 0 {
+		return append(row, nil), nil
+	}
+	return append(row, gmstypes.JSONDocument{Val: cellDiffs}), nil
+}
+
+func (itr *cellDiffIter) Close(ctx *sql.Context) error {
+	return itr.iter.Close(ctx)
+}
@@ -0,0 +1,308 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const diffGroupByDefaultRowCount = 100
+
+var _ sql.TableFunction = (*DiffGroupByTableFunction)(nil)
+var _ sql.ExecSourceRel = (*DiffGroupByTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*DiffGroupByTableFunction)(nil)
+
+// DiffGroupByTableFunction implements the dolt_diff_group_by table function, which counts the rows added, modified
+// and deleted between two revisions of a table, grouped by the value of one of its columns. The counts are taken from
+// the diff of the table's row data, rather than by aggregating the rows of the dolt_diff table.
+type DiffGroupByTableFunction struct {
+	fromCommitExpr sql.Expression
+	toCommitExpr   sql.Expression
+	dotCommitExpr  sql.Expression
+	tableNameExpr  sql.Expression
+	columnExpr     sql.Expression
+	database       sql.Database
+
+	tableDelta diff.TableDelta
+	columnName string
+	sqlSch     sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dg *DiffGroupByTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &DiffGroupByTableFunction{
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(ctx, expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (dg *DiffGroupByTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(dg.Schema(ctx))
+	numRows, _, err := dg.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (dg *DiffGroupByTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return diffGroupByDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (dg *DiffGroupByTableFunction) Database() sql.Database {
+	return dg.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dg *DiffGroupByTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ndg := *dg
+	ndg.database = database
+	return &ndg, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (dg *DiffGroupByTableFunction) Name() string {
+	return "dolt_diff_group_by"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dg *DiffGroupByTableFunction) Resolved() bool {
+	for _, expr := range dg.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (dg *DiffGroupByTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (dg *DiffGroupByTableFunction) String() string {
+	args := make([]string, 0, 4)
+	for _, expr := range dg.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_DIFF_GROUP_BY(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface. The first column has the name and type of the column the diff is grouped
+// by.
+func (dg *DiffGroupByTableFunction) Schema(ctx *sql.Context) sql.Schema {
+	if !dg.Resolved() {
+		return nil
+	}
+
+	if dg.sqlSch == nil {
+		panic("schema hasn't been generated yet")
+	}
+
+	return dg.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (dg *DiffGroupByTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (dg *DiffGroupByTableFunction) WithChildren(ctx *sql.Context, children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dg, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (dg *DiffGroupByTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	_, _, _, tableName, _, err := dg.evaluateArguments(ctx)
+	if err != nil {
+		return ExpressionIsDeferred(ctx, dg.tableNameExpr)
+	}
+
+	baseDB, _ := doltdb.SplitRevisionDbName(dg.database.Name())
+	subject := sql.PrivilegeCheckSubject{Database: baseDB, Table: tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (dg *DiffGroupByTableFunction) Expressions() []sql.Expression {
+	if dg.dotCommitExpr != nil {
+		return []sql.Expression{dg.dotCommitExpr, dg.tableNameExpr, dg.columnExpr}
+	}
+	return []sql.Expression{dg.fromCommitExpr, dg.toCommitExpr, dg.tableNameExpr, dg.columnExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (dg *DiffGroupByTableFunction) WithExpressions(ctx *sql.Context, exprs ...sql.Expression) (sql.Node, error) {
+	// The schema depends on the column grouped by, so like dolt_diff, only literal arguments are supported
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(dg.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(dg.Name(), expr.String())
+		}
+		if containsColumnReference(ctx, expr) {
+			return nil, ErrInvalidNonLiteralArgument.New(dg.Name(), expr.String())
+		}
+	}
+
+	newDg := *dg
+	if len(exprs) > 0 && strings.Contains(exprs[0].String(), "..") {
+		if len(exprs) != 3 {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%v with .. or ...", newDg.Name()), 3, len(exprs))
+		}
+		newDg.dotCommitExpr = exprs[0]
+		newDg.tableNameExpr = exprs[1]
+		newDg.columnExpr = exprs[2]
+	} else {
+		if len(exprs) != 4 {
+			return nil, sql.ErrInvalidArgumentNumber.New(newDg.Name(), 4, len(exprs))
+		}
+		newDg.fromCommitExpr = exprs[0]
+		newDg.toCommitExpr = exprs[1]
+		newDg.tableNameExpr = exprs[2]
+		newDg.columnExpr = exprs[3]
+	}
+
+	if err := newDg.generateSchema(ctx); err != nil {
+		return nil, err
+	}
+
+	return &newDg, nil
+}
+
+// generateSchema loads the table delta of the table given, and builds the schema of the results from the column the
+// diff is grouped by.
+func (dg *DiffGroupByTableFunction) generateSchema(ctx *sql.Context) error {
+	fromCommitVal, toCommitVal, dotCommitVal, tableName, columnName, err := dg.evaluateArguments(ctx)
+	if err != nil {
+		return err
+	}
+
+	sqledb, ok := dg.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", dg.database)
+	}
+
+	fromRefDetails, toRefDetails, err := loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return err
+	}
+
+	delta, err := tableDeltaForRefs(ctx, fromRefDetails, toRefDetails, tableName)
+	if err != nil {
+		return err
+	}
+
+	col, ok, err := diff.GroupByColumn(ctx, delta, columnName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sql.ErrTableColumnNotFound.New(tableName, columnName)
+	}
+
+	dg.tableDelta = delta
+	dg.columnName = col.Name
+	dg.sqlSch = sql.Schema{
+		&sql.Column{Name: col.Name, Type: col.TypeInfo.ToSqlType(), Nullable: true},
+		&sql.Column{Name: "rows_added", Type: types.Int64, Nullable: false},
+		&sql.Column{Name: "rows_modified", Type: types.Int64, Nullable: false},
+		&sql.Column{Name: "rows_deleted", Type: types.Int64, Nullable: false},
+	}
+	return nil
+}
+
+// RowIter implements the sql.Node interface
+func (dg *DiffGroupByTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	groups, err := diff.GroupByForTableDelta(ctx, dg.tableDelta, dg.columnName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(groups))
+	for i, g := range groups {
+		rows[i] = sql.Row{g.Value, int64(g.Adds), int64(g.Changes), int64(g.Removes)}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// evaluateArguments returns fromCommitVal, toCommitVal, dotCommitVal, tableName and columnName. It evaluates the
+// argument expressions to turn them into values this DiffGroupByTableFunction can use. Note that this method only
+// evals the expressions, and doesn't validate the values.
+func (dg *DiffGroupByTableFunction) evaluateArguments(ctx *sql.Context) (interface{}, interface{}, interface{}, string, string, error) {
+	for _, expr := range dg.Expressions() {
+		if !types.IsText(expr.Type(ctx)) {
+			return nil, nil, nil, "", "", sql.ErrInvalidArgumentDetails.New(dg.Name(), expr.String())
+		}
+	}
+
+	tableNameVal, err := dg.tableNameExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return nil, nil, nil, "", "", ErrInvalidTableName.New(dg.tableNameExpr.String())
+	}
+
+	columnVal, err := dg.columnExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+	columnName, ok := columnVal.(string)
+	if !ok {
+		return nil, nil, nil, "", "", sql.ErrInvalidArgumentDetails.New(dg.Name(), dg.columnExpr.String())
+	}
+
+	if dg.dotCommitExpr != nil {
+		dotCommitVal, err := dg.dotCommitExpr.Eval(ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", "", err
+		}
+		return nil, nil, dotCommitVal, tableName, columnName, nil
+	}
+
+	fromCommitVal, err := dg.fromCommitExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+	toCommitVal, err := dg.toCommitExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", "", err
+	}
+	return fromCommitVal, toCommitVal, nil, tableName, columnName, nil
+}
@@ -28,6 +28,7 @@ import (
 	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/mysql"
 
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -73,7 +74,12 @@ type PatchTableFunction struct {
 	toCommitExpr   sql.Expression
 	dotCommitExpr  sql.Expression
 	tableNameExpr  sql.Expression
+	optionExprs    []sql.Expression
 	database       sql.Database
+
+	// preimage is set by the --preimage option, which matches the rows changed by UPDATE and DELETE statements by all
+	// their old values
+	preimage bool
 }
 
 func (p *PatchTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
@@ -167,7 +173,7 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 	includeSchemaDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), schemaChangePartitionKey)
 	includeDataDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), dataChangePartitionKey)
 
-	patches, err := getPatchNodes(ctx, sqledb.DbData(), tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff)
+	patches, err := getPatchNodes(ctx, sqledb.DbData(), tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff, p.preimage)
 	if err != nil {
 		return nil, err
 	}
@@ -270,6 +276,13 @@ func (p *PatchTableFunction) commitsResolved() bool {
 
 // String implements the Stringer interface
 func (p *PatchTableFunction) String() string {
+	if len(p.optionExprs) > 0 {
+		args := make([]string, 0, 4)
+		for _, expr := range p.Expressions() {
+			args = append(args, expr.String())
+		}
+		return fmt.Sprintf("DOLT_PATCH(%s)", strings.Join(args, ", "))
+	}
 	if p.dotCommitExpr != nil {
 		if p.tableNameExpr != nil {
 			return fmt.Sprintf("DOLT_PATCH(%s, %s)", p.dotCommitExpr.String(), p.tableNameExpr.String())
@@ -349,16 +362,12 @@ func (p *PatchTableFunction) Expressions() []sql.Expression {
 	if p.tableNameExpr != nil {
 		exprs = append(exprs, p.tableNameExpr)
 	}
-	return exprs
+	return append(exprs, p.optionExprs...)
 }
 
 // WithExpressions implements the sql.Expressioner interface.
-func (p *PatchTableFunction) WithExpressions(ctx *sql.Context, expr ...sql.Expression) (sql.Node, error) {
-	if len(expr) < 1 {
-		return nil, sql.ErrInvalidArgumentNumber.New(p.Name(), "1 to 3", len(expr))
-	}
-
-	for _, expr := range expr {
+func (p *PatchTableFunction) WithExpressions(ctx *sql.Context, exprs ...sql.Expression) (sql.Node, error) {
+	for _, expr := range exprs {
 		if !expr.Resolved() {
 			return nil, ErrInvalidNonLiteralArgument.New(p.Name(), expr.String())
 		}
@@ -369,6 +378,25 @@ func (p *PatchTableFunction) WithExpressions(ctx *sql.Context, expr ...sql.Expre
 	}
 
 	newPtf := *p
+	newPtf.optionExprs, newPtf.preimage = nil, false
+	var expr []sql.Expression
+	for _, e := range exprs {
+		option, ok := patchOption(e)
+		if !ok {
+			expr = append(expr, e)
+			continue
+		}
+		if !strings.EqualFold(option, "--"+cli.PreimageFlag) {
+			return nil, fmt.Errorf("unknown option `%s' for %s", strings.TrimLeft(option, "-"), p.Name())
+		}
+		newPtf.optionExprs = append(newPtf.optionExprs, e)
+		newPtf.preimage = true
+	}
+
+	if len(expr) < 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(p.Name(), "1 to 3", len(expr))
+	}
+
 	if strings.Contains(expr[0].String(), "..") {
 		if len(expr) < 1 || len(expr) > 2 {
 			return nil, sql.ErrInvalidArgumentNumber.New(newPtf.Name(), "1 or 2", len(expr))
@@ -411,6 +439,19 @@ func (p *PatchTableFunction) WithExpressions(ctx *sql.Context, expr ...sql.Expre
 	return &newPtf, nil
 }
 
+// patchOption returns the option given by |expr|, if it's a string literal starting with --.
+func patchOption(expr sql.Expression) (string, bool) {
+	lit, ok := expr.(*expression.Literal)
+	if !ok {
+		return "", false
+	}
+	s, ok := lit.Value().(string)
+	if !ok || !strings.HasPrefix(s, "--") {
+		return "", false
+	}
+	return s, true
+}
+
 // Database implements the sql.Databaser interface
 func (p *PatchTableFunction) Database() sql.Database {
 	return p.database
@@ -483,7 +524,7 @@ type patchNode struct {
 	dataPatchStmts   []string
 }
 
-func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDeltas []diff.TableDelta, fromRefDetails, toRefDetails *refDetails, includeSchemaDiff, includeDataDiff bool) (patches []*patchNode, err error) {
+func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDeltas []diff.TableDelta, fromRefDetails, toRefDetails *refDetails, includeSchemaDiff, includeDataDiff, preimage bool) (patches []*patchNode, err error) {
 	for _, td := range tableDeltas {
 		if td.FromTable == nil && td.ToTable == nil {
 			// no diff
@@ -527,7 +568,7 @@ func getPatchNodes(ctx *sql.Context, dbData env.DbData[*sql.Context], tableDelta
 		// Get DATA DIFF
 		var dataStmts []string
 		if includeDataDiff && canGetDataDiff(ctx, td) {
-			dataStmts, err = getUserTableDataSqlPatch(ctx, dbData, td, fromRefDetails, toRefDetails)
+			dataStmts, err = getUserTableDataSqlPatch(ctx, dbData, td, fromRefDetails, toRefDetails, preimage)
 			if err != nil {
 				return nil, err
 			}
@@ -557,7 +598,7 @@ func canGetDataDiff(ctx *sql.Context, td diff.TableDelta) bool {
 	return true
 }
 
-func getUserTableDataSqlPatch(ctx *sql.Context, dbData env.DbData[*sql.Context], td diff.TableDelta, fromRefDetails, toRefDetails *refDetails) ([]string, error) {
+func getUserTableDataSqlPatch(ctx *sql.Context, dbData env.DbData[*sql.Context], td diff.TableDelta, fromRefDetails, toRefDetails *refDetails, preimage bool) ([]string, error) {
 	// ToTable is used as target table as it cannot be nil at this point
 	diffSch, projections, ri, err := getDiffQuery(ctx, dbData, td, fromRefDetails, toRefDetails)
 	if err != nil {
@@ -569,10 +610,16 @@ func getUserTableDataSqlPatch(ctx *sql.Context, dbData env.DbData[*sql.Context],
 		return nil, err
 	}
 
-	return getDataSqlPatchResults(ctx, diffSch, targetPkSch.Schema, projections, ri, td.ToName.Name, td.ToSch)
+	var fromSch schema.Schema
+	if preimage {
+		fromSch = td.FromSch
+	}
+	return getDataSqlPatchResults(ctx, diffSch, targetPkSch.Schema, projections, ri, td.ToName.Name, td.ToSch, fromSch)
 }
 
-func getDataSqlPatchResults(ctx *sql.Context, diffQuerySch, targetSch sql.Schema, projections []sql.Expression, iter sql.RowIter, tn string, tsch schema.Schema) ([]string, error) {
+// getDataSqlPatchResults returns the statements of the data diff rows of |iter|. If |fromSch| is set, the UPDATE and
+// DELETE statements match the rows they change by their old values in it.
+func getDataSqlPatchResults(ctx *sql.Context, diffQuerySch, targetSch sql.Schema, projections []sql.Expression, iter sql.RowIter, tn string, tsch, fromSch schema.Schema) ([]string, error) {
 	ds, err := diff.NewDiffSplitter(diffQuerySch, targetSch)
 	if err != nil {
 		return nil, err
@@ -599,14 +646,22 @@ func getDataSqlPatchResults(ctx *sql.Context, diffQuerySch, targetSch sql.Schema
 
 		var stmt string
 		if oldRow.Row != nil {
-			stmt, err = sqlfmt.GenerateDataDiffStatement(ctx, tn, tsch, oldRow.Row, oldRow.RowDiff, oldRow.ColDiffs)
+			if fromSch != nil {
+				stmt, err = sqlfmt.GenerateDataDiffStatementWithPreimage(ctx, tn, tsch, fromSch, oldRow.Row, oldRow.Row, oldRow.RowDiff, oldRow.ColDiffs)
+			} else {
+				stmt, err = sqlfmt.GenerateDataDiffStatement(ctx, tn, tsch, oldRow.Row, oldRow.RowDiff, oldRow.ColDiffs)
+			}
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if newRow.Row != nil {
-			stmt, err = sqlfmt.GenerateDataDiffStatement(ctx, tn, tsch, newRow.Row, newRow.RowDiff, newRow.ColDiffs)
+			if fromSch != nil {
+				stmt, err = sqlfmt.GenerateDataDiffStatementWithPreimage(ctx, tn, tsch, fromSch, newRow.Row, oldRow.Row, newRow.RowDiff, newRow.ColDiffs)
+			} else {
+				stmt, err = sqlfmt.GenerateDataDiffStatement(ctx, tn, tsch, newRow.Row, newRow.RowDiff, newRow.ColDiffs)
+			}
 			if err != nil {
 				return nil, err
 			}
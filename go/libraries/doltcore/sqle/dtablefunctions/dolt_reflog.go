@@ -114,8 +114,8 @@ func (rltf *ReflogTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.Row
 				return err
 			}
 
-			// Skip any internal refs
-			if doltRef.GetType() == ref.InternalRefType {
+			// Skip any internal refs, and refs that don't point to commits, like tuples
+			if _, ok := ref.HeadRefTypes[doltRef.GetType()]; !ok || doltRef.GetType() == ref.InternalRefType {
 				return nil
 			}
 			// skip workspace refs by default
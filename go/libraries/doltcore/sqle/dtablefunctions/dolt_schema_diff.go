@@ -70,6 +70,7 @@ var schemaDiffTableSchema = sql.Schema{
 	&sql.Column{Name: "to_table_name", Type: types.LongText, Nullable: false},     // 1
 	&sql.Column{Name: "from_create_statement", Type: types.Text, Nullable: false}, // 2
 	&sql.Column{Name: "to_create_statement", Type: types.Text, Nullable: false},   // 3
+	&sql.Column{Name: "renamed_columns", Type: types.JSON, Nullable: true},        // 4
 }
 
 // NewInstance creates a new instance of TableFunction interface
@@ -336,11 +337,20 @@ func (ds *SchemaDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.R
 			continue
 		}
 
+		var renamed interface{}
+		if delta.FromTable != nil && delta.ToTable != nil {
+			renamed, err = renamedColumns(ctx, delta.FromTable, delta.ToTable)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		row := sql.Row{
 			fromName.String(), // from_table_name
 			toName.String(),   // to_table_name
 			fromCreate,        // from_create_statement
 			toCreate,          // to_create_statement
+			renamed,           // renamed_columns
 		}
 		dataRows = append(dataRows, row)
 	}
@@ -353,6 +363,23 @@ func (ds *SchemaDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.R
 	return iter, nil
 }
 
+// renamedColumns returns the columns of |fromTbl| renamed in |toTbl| as a JSON object of the old column names to the
+// new ones, or nil if no columns were renamed.
+func renamedColumns(ctx *sql.Context, fromTbl, toTbl *doltdb.Table) (interface{}, error) {
+	renames, err := diff.DetectColumnRenames(ctx, fromTbl, toTbl, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(renames) == 0 {
+		return nil, nil
+	}
+	obj := make(map[string]interface{}, len(renames))
+	for _, rename := range renames {
+		obj[rename.From.Name] = rename.To.Name
+	}
+	return types.JSONDocument{Val: obj}, nil
+}
+
 // evaluateArguments returns fromCommitVal, toCommitVal, dotCommitVal, and tableName.
 // It evaluates the argument expressions to turn them into values this DiffSummaryTableFunction
 // can use. Note that this method only evals the expressions, and doesn't validate the values.
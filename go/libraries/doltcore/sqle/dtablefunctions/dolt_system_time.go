@@ -0,0 +1,662 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// SystemTimeTableFunctionName is the name of the table function that FOR SYSTEM_TIME ranges are rewritten to
+	SystemTimeTableFunctionName = "dolt_system_time"
+
+	// SystemTimeAll selects every version of each row, as FOR SYSTEM_TIME ALL does
+	SystemTimeAll = "all"
+	// SystemTimeFrom selects the versions valid at any time in [start, end), as FOR SYSTEM_TIME FROM start TO end does
+	SystemTimeFrom = "from"
+	// SystemTimeBetween selects the versions valid at any time in [start, end], as FOR SYSTEM_TIME BETWEEN does
+	SystemTimeBetween = "between"
+	// SystemTimeContained selects the versions that began and ended in [start, end], as FOR SYSTEM_TIME CONTAINED IN does
+	SystemTimeContained = "contained"
+
+	validFromColName = "valid_from"
+	validToColName   = "valid_to"
+
+	systemTimeDefaultRowCount = 1000
+)
+
+// systemTimeMaxValidTo is the valid_to of the versions still current at HEAD, matching the end of the period of
+// current rows in SQL Server temporal tables.
+var systemTimeMaxValidTo = time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC)
+
+var _ sql.TableFunction = (*SystemTimeTableFunction)(nil)
+var _ sql.ExecSourceRel = (*SystemTimeTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*SystemTimeTableFunction)(nil)
+
+// SystemTimeTableFunction implements the dolt_system_time table function, which returns the versions of the rows of a
+// table valid during a period of time, with the commit timestamps of the period each version was valid for:
+//
+//	dolt_system_time(<table>, 'all')
+//	dolt_system_time(<table>, {'from'|'between'|'contained'}, <start>, <end>)
+//
+// The versions are computed by diffing each commit on the first-parent history of HEAD with its parent, and each
+// begins at the commit that wrote it and ends at the commit that next changed or deleted it. Queries using
+// FOR SYSTEM_TIME {ALL|FROM..TO|BETWEEN..AND|CONTAINED IN} are rewritten to calls of this function.
+type SystemTimeTableFunction struct {
+	tableNameExpr sql.Expression
+	modeExpr      sql.Expression
+	startExpr     sql.Expression
+	endExpr       sql.Expression
+	database      sql.Database
+
+	tableName string
+	mode      string
+	doltSch   schema.Schema
+	sqlSch    sql.Schema
+}
+
+// NewInstance implements the sql.TableFunction interface
+func (stf *SystemTimeTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &SystemTimeTableFunction{
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(ctx, expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (stf *SystemTimeTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(stf.Schema(ctx))
+	numRows, _, err := stf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (stf *SystemTimeTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return systemTimeDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (stf *SystemTimeTableFunction) Database() sql.Database {
+	return stf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (stf *SystemTimeTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nstf := *stf
+	nstf.database = database
+	return &nstf, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (stf *SystemTimeTableFunction) Name() string {
+	return SystemTimeTableFunctionName
+}
+
+// Resolved implements the sql.Resolvable interface
+func (stf *SystemTimeTableFunction) Resolved() bool {
+	for _, expr := range stf.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (stf *SystemTimeTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (stf *SystemTimeTableFunction) String() string {
+	args := make([]string, 0, 4)
+	for _, expr := range stf.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_SYSTEM_TIME(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (stf *SystemTimeTableFunction) Schema(ctx *sql.Context) sql.Schema {
+	return stf.sqlSch
+}
+
+// Children implements the sql.Node interface.
+func (stf *SystemTimeTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (stf *SystemTimeTableFunction) WithChildren(ctx *sql.Context, children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return stf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (stf *SystemTimeTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	dbName, tableName := stf.database.Name(), stf.tableName
+	if db, name, ok, err := stf.sessionDatabase(ctx); err == nil && ok {
+		dbName, tableName = db.Name(), name
+	}
+
+	baseDB, _ := doltdb.SplitRevisionDbName(dbName)
+	subject := sql.PrivilegeCheckSubject{Database: baseDB, Table: tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (stf *SystemTimeTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{stf.tableNameExpr, stf.modeExpr}
+	if stf.startExpr != nil {
+		exprs = append(exprs, stf.startExpr, stf.endExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (stf *SystemTimeTableFunction) WithExpressions(ctx *sql.Context, exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != 2 && len(exprs) != 4 {
+		return nil, sql.ErrInvalidArgumentNumber.New(stf.Name(), "2 or 4", len(exprs))
+	}
+
+	for _, expr := range exprs {
+		if !expr.Resolved() || containsColumnReference(ctx, expr) {
+			return nil, ErrInvalidNonLiteralArgument.New(stf.Name(), expr.String())
+		}
+	}
+
+	newStf := *stf
+	newStf.tableNameExpr, newStf.modeExpr = exprs[0], exprs[1]
+	newStf.startExpr, newStf.endExpr = nil, nil
+	if len(exprs) == 4 {
+		newStf.startExpr, newStf.endExpr = exprs[2], exprs[3]
+	}
+
+	// the schema is needed before the arguments can be bound, so the table name and mode must be literals
+	for _, expr := range []sql.Expression{newStf.tableNameExpr, newStf.modeExpr} {
+		if !gmstypes.IsText(expr.Type(ctx)) {
+			return nil, sql.ErrInvalidArgumentDetails.New(stf.Name(), expr.String())
+		}
+	}
+	tableName, err := newStf.tableNameExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := newStf.modeExpr.Eval(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	newStf.tableName = tableName.(string)
+	newStf.mode = strings.ToLower(mode.(string))
+
+	switch newStf.mode {
+	case SystemTimeAll:
+		if len(exprs) != 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%s with '%s'", stf.Name(), SystemTimeAll), 2, len(exprs))
+		}
+	case SystemTimeFrom, SystemTimeBetween, SystemTimeContained:
+		if len(exprs) != 4 {
+			return nil, sql.ErrInvalidArgumentNumber.New(fmt.Sprintf("%s with '%s'", stf.Name(), newStf.mode), 4, len(exprs))
+		}
+	default:
+		return nil, sql.ErrInvalidArgumentDetails.New(stf.Name(), newStf.modeExpr.String())
+	}
+
+	if err = newStf.generateSchema(ctx); err != nil {
+		return nil, err
+	}
+
+	return &newStf, nil
+}
+
+// sessionDatabase returns the database holding the table and the name of the table in it. A table name qualified
+// with the name of a database, as in dolt_system_time('db.t', ...), refers to the table in that database.
+func (stf *SystemTimeTableFunction) sessionDatabase(ctx *sql.Context) (dsess.SqlDatabase, string, bool, error) {
+	sqledb, ok := stf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, "", false, fmt.Errorf("unexpected database type: %T", stf.database)
+	}
+
+	dbName, tableName, qualified := strings.Cut(stf.tableName, ".")
+	if !qualified {
+		return sqledb, stf.tableName, true, nil
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	if roots, ok := sess.GetRoots(ctx, sqledb.Name()); ok {
+		if _, _, ok, err := resolve.Table(ctx, roots.Working, stf.tableName); err != nil || ok {
+			return sqledb, stf.tableName, ok, err
+		}
+	}
+	db, ok, err := sess.Provider().SessionDatabase(ctx, dbName)
+	return db, tableName, ok, err
+}
+
+// generateSchema sets the schema of the function, which is the schema of the table in the working set followed by
+// the valid_from and valid_to columns. Versions from commits with other schemas are converted to this one.
+func (stf *SystemTimeTableFunction) generateSchema(ctx *sql.Context) error {
+	db, tableName, ok, err := stf.sessionDatabase(ctx)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrTableNotFound.New(stf.tableName)
+	}
+
+	roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, db.Name())
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(db.Name())
+	}
+	_, tbl, ok, err := resolve.Table(ctx, roots.Working, tableName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return fmt.Errorf("table %s has no primary key, which FOR SYSTEM_TIME requires", tableName)
+	}
+
+	sqlSch, err := sqlutil.FromDoltSchema(ctx, "", "", sch)
+	if err != nil {
+		return err
+	}
+
+	stf.doltSch = sch
+	stf.sqlSch = append(sqlSch.Schema.Copy(),
+		&sql.Column{Name: validFromColName, Type: gmstypes.DatetimeMaxPrecision, Nullable: false},
+		&sql.Column{Name: validToColName, Type: gmstypes.DatetimeMaxPrecision, Nullable: false},
+	)
+	return nil
+}
+
+// RowIter implements the sql.ExecSourceRel interface
+func (stf *SystemTimeTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	db, tableName, ok, err := stf.sessionDatabase(ctx)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(stf.tableName)
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, db.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	p := systemTimePeriod{mode: stf.mode}
+	if stf.mode != SystemTimeAll {
+		if p.start, err = stf.evaluateTime(ctx, db, stf.startExpr); err != nil {
+			return nil, err
+		}
+		if p.end, err = stf.evaluateTime(ctx, db, stf.endExpr); err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := firstParentHistory(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+
+	differ := &systemTimeDiffer{
+		tableName: tableName,
+		outSch:    stf.doltSch,
+		sqlSch:    stf.sqlSch[:len(stf.sqlSch)-2],
+		period:    p,
+		open:      make(map[string]systemTimeVersion),
+	}
+	return &systemTimeRowIter{differ: differ, commits: commits}, nil
+}
+
+// systemTimeRowIter returns the versions of the rows of a table as they close, applying the diff of one commit at a
+// time. The versions still open at HEAD are returned last.
+type systemTimeRowIter struct {
+	differ  *systemTimeDiffer
+	commits []systemTimeCommit
+	atHead  bool
+}
+
+var _ sql.RowIter = (*systemTimeRowIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (itr *systemTimeRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	d := itr.differ
+	for len(d.rows) == 0 {
+		if len(itr.commits) > 0 {
+			cm := itr.commits[0]
+			itr.commits = itr.commits[1:]
+			if err := d.apply(ctx, cm); err != nil {
+				return nil, err
+			}
+		} else if !itr.atHead {
+			itr.atHead = true
+			for _, v := range d.open {
+				d.emit(v, systemTimeMaxValidTo)
+			}
+			d.open = nil
+		} else {
+			return nil, io.EOF
+		}
+	}
+
+	row := d.rows[0]
+	d.rows[0] = nil
+	d.rows = d.rows[1:]
+	return row, nil
+}
+
+// Close implements the sql.RowIter interface
+func (itr *systemTimeRowIter) Close(_ *sql.Context) error {
+	return nil
+}
+
+// evaluateTime returns the time given by |expr|, which is either a datetime or a revision, such as a commit hash or
+// a branch, in which case the time is that of the commit.
+func (stf *SystemTimeTableFunction) evaluateTime(ctx *sql.Context, db dsess.SqlDatabase, expr sql.Expression) (time.Time, error) {
+	v, err := expr.Eval(ctx, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, _, err := gmstypes.DatetimeMaxPrecision.Convert(ctx, v)
+	if err == nil && t != nil {
+		return t.(time.Time), nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, sql.ErrInvalidArgumentDetails.New(stf.Name(), expr.String())
+	}
+	headRef, err := dsess.DSessFromSess(ctx.Session).CWBHeadRef(ctx, db.Name())
+	if err != nil {
+		return time.Time{}, err
+	}
+	cm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return meta.Committer.Date.Time(), nil
+}
+
+// systemTimePeriod is the period of time whose versions are selected, and how versions are matched against it
+type systemTimePeriod struct {
+	mode       string
+	start, end time.Time
+}
+
+// matches returns whether the version valid from |from| until |to| is selected
+func (p systemTimePeriod) matches(from, to time.Time) bool {
+	switch p.mode {
+	case SystemTimeFrom:
+		return from.Before(p.end) && to.After(p.start)
+	case SystemTimeBetween:
+		return !from.After(p.end) && to.After(p.start)
+	case SystemTimeContained:
+		return !from.Before(p.start) && !to.After(p.end)
+	default:
+		return true
+	}
+}
+
+// canBegin returns whether a version beginning at |t| can be selected
+func (p systemTimePeriod) canBegin(t time.Time) bool {
+	switch p.mode {
+	case SystemTimeFrom:
+		return t.Before(p.end)
+	case SystemTimeBetween, SystemTimeContained:
+		return !t.After(p.end)
+	default:
+		return true
+	}
+}
+
+// systemTimeCommit is a commit on the first-parent history of HEAD, and the time it was made
+type systemTimeCommit struct {
+	commit *doltdb.Commit
+	hash   hash.Hash
+	time   time.Time
+}
+
+// firstParentHistory returns the commits on the first-parent history of |head|, oldest first
+func firstParentHistory(ctx *sql.Context, head *doltdb.Commit) ([]systemTimeCommit, error) {
+	var commits []systemTimeCommit
+	for cm := head; ; {
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, systemTimeCommit{commit: cm, hash: h, time: meta.Committer.Date.Time()})
+
+		if cm.NumParents() == 0 {
+			break
+		}
+		optCmt, err := cm.GetParent(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if cm, ok = optCmt.ToCommit(); !ok {
+			// the history of a shallow clone ends at its ghost commits
+			break
+		}
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// systemTimeVersion is an open version of a row, valid from the commit that wrote it until the next commit to change it
+type systemTimeVersion struct {
+	row  sql.Row
+	from time.Time
+}
+
+// systemTimeDiffer computes the versions of the rows of a table from the diffs of consecutive commits
+type systemTimeDiffer struct {
+	tableName string
+	outSch    schema.Schema
+	sqlSch    sql.Schema
+	period    systemTimePeriod
+
+	prev    *doltdb.Table
+	prevSch schema.Schema
+	conv    dtables.ProllyRowConverter
+
+	// open are the versions valid as of the last commit applied, by the key of their row
+	open map[string]systemTimeVersion
+	// rows are the versions closed by the last commit applied, which haven't been returned yet
+	rows []sql.Row
+}
+
+// apply closes and opens the versions of the rows changed by |cm|
+func (d *systemTimeDiffer) apply(ctx *sql.Context, cm systemTimeCommit) error {
+	root, err := cm.commit.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	_, tbl, ok, err := resolve.Table(ctx, root, d.tableName)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// the table was dropped, or doesn't exist yet
+		for key, v := range d.open {
+			d.emit(v, cm.time)
+			delete(d.open, key)
+		}
+		d.prev, d.prevSch = nil, nil
+		return nil
+	}
+
+	if d.prev != nil {
+		prevHash, err := d.prev.HashOf()
+		if err != nil {
+			return err
+		}
+		currHash, err := tbl.HashOf()
+		if err != nil {
+			return err
+		} else if prevHash == currHash {
+			return nil
+		}
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	schemaChanged := d.prevSch == nil || !schema.SchemasAreEqual(d.prevSch, sch)
+	if schemaChanged {
+		if !schema.ArePrimaryKeySetsDiffable(d.outSch, sch) {
+			return fmt.Errorf("the primary key of table %s changed in commit %s, so its versions can't be computed", d.tableName, cm.hash)
+		}
+		if d.conv, err = dtables.NewProllyRowConverter(ctx, sch, d.outSch, nil, tbl.NodeStore()); err != nil {
+			return err
+		}
+	}
+
+	toIdx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	to, err := durable.ProllyMapFromIndex(toIdx)
+	if err != nil {
+		return err
+	}
+
+	prev := d.prev
+	d.prev, d.prevSch = tbl, sch
+
+	if prev == nil {
+		iter, err := to.IterAll(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			key, value, err := iter.Next(ctx)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err = d.change(ctx, cm.time, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	fromIdx, err := prev.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	from, err := durable.ProllyMapFromIndex(fromIdx)
+	if err != nil {
+		return err
+	}
+
+	// when the schema changed, every row is compared as converted to the output schema
+	err = prolly.DiffMaps(ctx, from, to, schemaChanged, func(_ context.Context, diff tree.Diff) error {
+		if diff.Type == tree.RemovedDiff {
+			return d.change(ctx, cm.time, val.Tuple(diff.Key), nil)
+		}
+		return d.change(ctx, cm.time, val.Tuple(diff.Key), val.Tuple(diff.To))
+	})
+	if err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// change closes the open version of the row with |key| at |t|, and opens the version with |value|, unless |value| is
+// nil because the row was deleted. Rows whose values are unchanged once converted to the output schema keep their
+// open version.
+func (d *systemTimeDiffer) change(ctx *sql.Context, t time.Time, key, value val.Tuple) error {
+	var row sql.Row
+	if value != nil {
+		row = make(sql.Row, len(d.sqlSch))
+		if err := d.conv.PutConverted(ctx, key, value, row); err != nil {
+			return err
+		}
+	}
+
+	k := string(key)
+	if v, ok := d.open[k]; ok {
+		if row != nil {
+			eq, err := v.row.Equals(ctx, row, d.sqlSch)
+			if err != nil {
+				return err
+			} else if eq {
+				return nil
+			}
+		}
+		d.emit(v, t)
+		delete(d.open, k)
+	}
+
+	if row != nil && d.period.canBegin(t) {
+		d.open[k] = systemTimeVersion{row: row, from: t}
+	}
+	return nil
+}
+
+// emit adds the version |v|, valid until |to|, to the rows to return if it's in the period
+func (d *systemTimeDiffer) emit(v systemTimeVersion, to time.Time) {
+	if d.period.matches(v.from, to) {
+		d.rows = append(d.rows, append(v.row, v.from.UTC(), to.UTC()))
+	}
+}
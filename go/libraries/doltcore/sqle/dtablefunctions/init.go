@@ -20,6 +20,7 @@ var DoltTableFunctions = []sql.TableFunction{
 	&DiffTableFunction{},
 	&DiffStatTableFunction{},
 	&DiffSummaryTableFunction{},
+	&DiffGroupByTableFunction{},
 	&BranchStatusTableFunction{},
 	&LogTableFunction{},
 	&PatchTableFunction{},
@@ -30,4 +31,5 @@ var DoltTableFunctions = []sql.TableFunction{
 	&QueryDiffTableFunction{},
 	&TestsRunTableFunction{},
 	&JsonDiffTableFunction{},
+	&SystemTimeTableFunction{},
 }
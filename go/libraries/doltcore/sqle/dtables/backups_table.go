@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 )
 
 type BackupsTable struct {
@@ -51,6 +52,11 @@ func (bt BackupsTable) Schema(ctx *sql.Context) sql.Schema {
 		{Name: "name", Type: types.Text, PrimaryKey: true, Nullable: false},
 		{Name: "url", Type: types.Text, PrimaryKey: false, Nullable: false},
 		{Name: "params", Type: types.JSON, PrimaryKey: false, Nullable: false},
+		{Name: "last_sync", Type: types.Datetime, PrimaryKey: false, Nullable: true},
+		{Name: "last_sync_error", Type: types.Text, PrimaryKey: false, Nullable: true},
+		{Name: "restore_points", Type: types.JSON, PrimaryKey: false, Nullable: true},
+		{Name: "last_verify", Type: types.Datetime, PrimaryKey: false, Nullable: true},
+		{Name: "last_verify_result", Type: types.Text, PrimaryKey: false, Nullable: true},
 	}
 }
 
@@ -67,6 +73,7 @@ func (bt BackupsTable) PartitionRows(context *sql.Context, _ sql.Partition) (sql
 }
 
 type backupsItr struct {
+	dbName string
 	names  []string
 	urls   map[string]string
 	params map[string]map[string]string
@@ -86,11 +93,44 @@ func (bi *backupsItr) Next(ctx *sql.Context) (sql.Row, error) {
 			return nil, err
 		}
 
-		return sql.NewRow(name, url, params), nil
+		row := sql.NewRow(name, url, params, nil, nil, nil, nil, nil)
+		if status, ok := sqlserver.GetBackupStatus(bi.dbName, name); ok {
+			if err = fillBackupStatus(ctx, row, status); err != nil {
+				return nil, err
+			}
+		}
+		return row, nil
 	}
 	return nil, io.EOF
 }
 
+// fillBackupStatus sets the status columns of |row| for a backup that the running server syncs on a schedule. Columns
+// for operations that haven't happened yet are left NULL.
+func fillBackupStatus(ctx *sql.Context, row sql.Row, status sqlserver.BackupStatus) error {
+	if !status.LastSync.IsZero() {
+		row[3] = status.LastSync.UTC()
+		if status.LastSyncError != "" {
+			row[4] = status.LastSyncError
+		}
+	}
+
+	restorePoints := make([]interface{}, len(status.RestorePoints))
+	for i, name := range status.RestorePoints {
+		restorePoints[i] = name
+	}
+	var err error
+	row[5], _, err = types.JSON.Convert(ctx, restorePoints)
+	if err != nil {
+		return err
+	}
+
+	if !status.LastVerify.IsZero() {
+		row[6] = status.LastVerify.UTC()
+		row[7] = status.LastVerifyResult
+	}
+	return nil
+}
+
 func (bi *backupsItr) Close(_ *sql.Context) error { return nil }
 
 func newBackupsIter(ctx *sql.Context, dbName string) (*backupsItr, error) {
@@ -122,5 +162,5 @@ func newBackupsIter(ctx *sql.Context, dbName string) (*backupsItr, error) {
 
 	sort.Strings(names)
 
-	return &backupsItr{names: names, urls: urls, params: params, idx: 0}, nil
+	return &backupsItr{dbName: dbName, names: names, urls: urls, params: params, idx: 0}, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -134,7 +135,17 @@ func (dt *CommitDiffTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 	if err != nil {
 		return nil, err
 	}
-	return index.MakeDiffTableIndexes(doltdb.DoltCommitDiffTablePrefix+dt.tableName.Name, sch, sch, dt.table.NodeStore(), true), nil
+	indexes := index.MakeDiffTableIndexes(dt.Name(), sch, sch, dt.table.NodeStore(), true)
+
+	// lookups of the commits and the to_ or from_ columns of a secondary index are pushed down together
+	to, from, err := secondaryDiffIndexes(ctx, dt.Name(), dt.table)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range append(to, from...) {
+		indexes = append(indexes, index.NewCommitKeyIndex("commits_"+idx.ID(), idx, index.ToCommitIndexId, index.FromCommitIndexId))
+	}
+	return indexes, nil
 }
 
 // IndexedAccess implements sql.IndexAddressable
@@ -157,6 +168,10 @@ func (dt *CommitDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, erro
 }
 
 func (dt *CommitDiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	if commitKeyIdx, ok := lookup.Index.(*index.CommitKeyIndex); ok {
+		return dt.secondaryLookupPartitions(ctx, commitKeyIdx, lookup)
+	}
+
 	ranges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
 	if !ok {
 		return nil, fmt.Errorf("commit diff table requires MySQL ranges")
@@ -193,42 +208,68 @@ func (dt *CommitDiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLo
 		return nil, fmt.Errorf("from_commit must be string, found %T", fromCommit)
 	}
 
-	toRoot, toHash, toDate, err := dt.rootValForHash(ctx, dt.toCommit)
+	lookup = copyIndexLookupWithoutCommitRanges(lookup)
+	prollyRanges, err := index.ProllyRangesFromIndexLookup(ctx, lookup)
 	if err != nil {
 		return nil, err
 	}
+	return dt.commitPartitions(ctx, prollyRanges, nil)
+}
 
-	fromRoot, fromHash, fromDate, err := dt.rootValForHash(ctx, dt.fromCommit)
+// secondaryLookupPartitions returns the partitions for a |lookup| of the commits and the columns of a secondary index
+func (dt *CommitDiffTable) secondaryLookupPartitions(ctx *sql.Context, idx *index.CommitKeyIndex, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	toCommits, toOk := idx.Commits(lookup, 0)
+	fromCommits, fromOk := idx.Commits(lookup, 1)
+	if !toOk || !fromOk || len(toCommits) != 1 || len(fromCommits) != 1 {
+		return nil, ErrInvalidCommitDiffTableArgs
+	}
+	dt.toCommit, dt.fromCommit = toCommits[0], fromCommits[0]
+
+	keyLookup, err := idx.KeyLookup(ctx, lookup)
 	if err != nil {
 		return nil, err
 	}
+	secondary, err := newSecondaryKeyLookup(ctx, dt.targetSchema, keyLookup)
+	if err != nil {
+		return nil, err
+	}
+	return dt.commitPartitions(ctx, nil, secondary)
+}
 
-	toTable, _, _, err := doltdb.GetTableInsensitive(ctx, toRoot, dt.tableName)
+// commitPartitions returns the partition of the diff between the to and from commits, restricted to the primary key
+// |ranges| or to the keys found by the |secondary| index lookup, if either is non-nil
+func (dt *CommitDiffTable) commitPartitions(ctx *sql.Context, ranges []prolly.Range, secondary *secondaryKeyLookup) (sql.PartitionIter, error) {
+	toRoot, toHash, toDate, err := dt.rootValForHash(ctx, dt.toCommit)
 	if err != nil {
 		return nil, err
 	}
 
-	fromTable, _, _, err := doltdb.GetTableInsensitive(ctx, fromRoot, dt.tableName)
+	fromRoot, fromHash, fromDate, err := dt.rootValForHash(ctx, dt.fromCommit)
 	if err != nil {
 		return nil, err
 	}
 
-	lookup = copyIndexLookupWithoutCommitRanges(lookup)
-	prollyRanges, err := index.ProllyRangesFromIndexLookup(ctx, lookup)
+	toTable, _, _, err := doltdb.GetTableInsensitive(ctx, toRoot, dt.tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTable, _, _, err := doltdb.GetTableInsensitive(ctx, fromRoot, dt.tableName)
 	if err != nil {
 		return nil, err
 	}
 
 	dp := DiffPartition{
-		to:       toTable,
-		from:     fromTable,
-		toName:   toHash,
-		fromName: fromHash,
-		toDate:   toDate,
-		fromDate: fromDate,
-		toSch:    dt.targetSchema,
-		fromSch:  dt.targetSchema,
-		ranges:   prollyRanges,
+		to:        toTable,
+		from:      fromTable,
+		toName:    toHash,
+		fromName:  fromHash,
+		toDate:    toDate,
+		fromDate:  fromDate,
+		toSch:     dt.targetSchema,
+		fromSch:   dt.targetSchema,
+		ranges:    ranges,
+		secondary: secondary,
 	}
 
 	isDiffable, _, err := dp.isDiffablePartition(ctx)
@@ -0,0 +1,88 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*DeletedRefsTable)(nil)
+
+// DeletedRefsTable is a sql.Table implementation for the dolt_deleted_refs system table, which shows the deleted
+// branches and tags that are kept so they can be restored, most recently deleted first.
+type DeletedRefsTable struct {
+	ddb       *doltdb.DoltDB
+	tableName string
+}
+
+// NewDeletedRefsTable creates a DeletedRefsTable
+func NewDeletedRefsTable(_ *sql.Context, ddb *doltdb.DoltDB, tableName string) sql.Table {
+	return &DeletedRefsTable{ddb: ddb, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (dt *DeletedRefsTable) Name() string {
+	return dt.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (dt *DeletedRefsTable) String() string {
+	return dt.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the deleted refs system table
+func (dt *DeletedRefsTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: types.Text, Source: dt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "type", Type: types.Text, Source: dt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "hash", Type: types.Text, Source: dt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "deleted_at", Type: types.Datetime, Source: dt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "expires_at", Type: types.Datetime, Source: dt.tableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (dt *DeletedRefsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (dt *DeletedRefsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *DeletedRefsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	deleted, err := dt.ddb.DeletedRefs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := doltdb.DeletedRefsRetention()
+	rows := make([]sql.Row, len(deleted))
+	for i, d := range deleted {
+		refType := "branch"
+		if d.Ref.Ref().GetType() == ref.TagRefType {
+			refType = "tag"
+		}
+		rows[i] = sql.NewRow(d.Ref.Ref().GetPath(), refType, d.Hash.String(), d.Ref.DeletedAt(), d.Ref.DeletedAt().Add(retention))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
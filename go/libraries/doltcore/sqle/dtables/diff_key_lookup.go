@@ -0,0 +1,202 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// secondaryKeyLookup restricts the rows of a diff to those whose to or from row is in ranges of a secondary index. The
+// index is looked up in the to or from table of each diff, and only the primary keys found are diffed.
+type secondaryKeyLookup struct {
+	indexType index.SecondaryDiffIndexType
+	// idx is the index looked up, as defined in the table the lookup was made against. Tables whose index is defined
+	// differently are diffed in full.
+	idx    schema.Index
+	ranges []prolly.Range
+}
+
+// newSecondaryKeyLookup returns the secondary index lookup of a diff table with the schema |sch| for a |lookup| of an
+// index returned by secondaryDiffIndexes. It returns nil if |lookup| is empty.
+func newSecondaryKeyLookup(ctx *sql.Context, sch schema.Schema, lookup sql.IndexLookup) (*secondaryKeyLookup, error) {
+	if lookup.IsEmpty() {
+		return nil, nil
+	}
+	indexType, indexName, _ := parseSecondaryDiffIndexID(lookup.Index.ID())
+	ranges, err := index.ProllyRangesFromIndexLookup(ctx, lookup)
+	if err != nil {
+		return nil, err
+	}
+	return &secondaryKeyLookup{
+		indexType: indexType,
+		idx:       sch.Indexes().GetByName(indexName),
+		ranges:    ranges,
+	}, nil
+}
+
+// primaryRanges returns the ranges of the primary index to diff between |from| and |to|, or nil if all rows must be
+// diffed
+func (l *secondaryKeyLookup) primaryRanges(ctx *sql.Context, to, from *doltdb.Table) ([]prolly.Range, error) {
+	tbl := to
+	if l.indexType == index.SecondaryDiffIndexType_From {
+		tbl = from
+	}
+	if tbl == nil || l.idx == nil {
+		return nil, nil
+	}
+	for _, rng := range l.ranges {
+		// rows that don't exist on the side looked up have NULL values there
+		matches, err := rng.Matches(ctx, val.EmptyTuple)
+		if err != nil || matches {
+			return nil, err
+		}
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := sch.Indexes().GetByName(l.idx.Name())
+	if idx == nil || !idx.DeepEquals(l.idx) {
+		return nil, nil
+	}
+	idxData, err := tbl.GetIndexRowData(ctx, idx.Name())
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := durable.ProllyMapFromIndex(idxData)
+	if err != nil {
+		return nil, err
+	}
+	kd, _ := secondary.Descriptors()
+	if len(l.ranges) > 0 && !kd.Equals(l.ranges[0].Desc) {
+		return nil, nil
+	}
+
+	primary, err := primaryMap(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	pkd, _ := primary.Descriptors()
+	for _, other := range []*doltdb.Table{to, from} {
+		if other == nil || other == tbl {
+			continue
+		}
+		otherPrimary, err := primaryMap(ctx, other)
+		if err != nil {
+			return nil, err
+		}
+		if otherPkd, _ := otherPrimary.Descriptors(); !otherPkd.Equals(pkd) {
+			return nil, nil
+		}
+	}
+
+	pkMap := schema.PrimaryIndexOrdinalToSecondaryIndexOrdinal(idx)
+	pkBld := val.NewTupleBuilder(pkd, primary.NodeStore())
+	ranges := make([]prolly.Range, 0)
+	for _, rng := range l.ranges {
+		iter, err := secondary.IterRange(ctx, rng)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			idxKey, _, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			for to := range pkMap {
+				pkBld.PutRaw(to, idxKey.GetField(pkMap.MapOrdinal(to)))
+			}
+			pk, err := pkBld.Build(ctx, primary.Pool())
+			if err != nil {
+				return nil, err
+			}
+			pkRange, err := prolly.PrefixRange(ctx, pk, pkd)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, pkRange)
+		}
+	}
+	return ranges, nil
+}
+
+func primaryMap(ctx *sql.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(rowData)
+}
+
+// secondaryDiffIndexes returns the indexes of the diff table |tableName| over the to_ and from_ columns of the
+// secondary indexes of |tbl|. Indexes that can't be looked up, and those whose ids would collide with the other
+// indexes of diff tables, are skipped.
+func secondaryDiffIndexes(ctx *sql.Context, tableName string, tbl *doltdb.Table) (to, from []sql.Index, err error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil, nil
+	}
+	for _, idx := range sch.Indexes().AllIndexes() {
+		if idx.Predicate() != "" {
+			// a partial index doesn't have every row of the table
+			continue
+		}
+		switch strings.ToLower(idx.Name()) {
+		case "pks", "commit":
+			continue
+		}
+		toIdx, err := index.MakeDiffTableSecondaryIndex(ctx, tableName, index.SecondaryDiffIndexType_To, tbl, sch, idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if toIdx == nil {
+			continue
+		}
+		fromIdx, err := index.MakeDiffTableSecondaryIndex(ctx, tableName, index.SecondaryDiffIndexType_From, tbl, sch, idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		to, from = append(to, toIdx), append(from, fromIdx)
+	}
+	return to, from, nil
+}
+
+// parseSecondaryDiffIndexID parses the id of an index returned by secondaryDiffIndexes into the side of the diff and
+// the name of the index of the underlying table
+func parseSecondaryDiffIndexID(id string) (indexType index.SecondaryDiffIndexType, indexName string, ok bool) {
+	if strings.HasPrefix(id, "to_") {
+		return index.SecondaryDiffIndexType_To, id[3:], true
+	}
+	if strings.HasPrefix(id, "from_") {
+		return index.SecondaryDiffIndexType_From, id[5:], true
+	}
+	return 0, "", false
+}
@@ -162,6 +162,12 @@ func (dt *DiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 }
 
 func (dt *DiffTable) PartitionRanges(ctx *sql.Context, ranges []prolly.Range) (sql.PartitionIter, error) {
+	return dt.keyPartitions(ctx, ranges, nil)
+}
+
+// keyPartitions returns the partitions of the diffs of every commit, restricted to the primary key |ranges| or to the
+// keys found by the |secondary| index lookup, if either is non-nil
+func (dt *DiffTable) keyPartitions(ctx *sql.Context, ranges []prolly.Range, secondary *secondaryKeyLookup) (sql.PartitionIter, error) {
 	cmItr := doltdb.CommitItrForRoots[*sql.Context](dt.ddb, dt.head)
 
 	sf, err := SelectFuncForFilters(ctx, dt.partitionFilters)
@@ -203,6 +209,7 @@ func (dt *DiffTable) PartitionRanges(ctx *sql.Context, ranges []prolly.Range) (s
 		toSch:           dt.targetSch,
 		fromSch:         dt.targetSch,
 		ranges:          ranges,
+		secondary:       secondary,
 	}, nil
 }
 
@@ -253,6 +260,27 @@ func (dt *DiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.Ro
 }
 
 func (dt *DiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	if commitKeyIdx, ok := lookup.Index.(*index.CommitKeyIndex); ok {
+		// a lookup of to_commit and the to_ columns of a key
+		keyLookup, err := commitKeyIdx.KeyLookup(ctx, lookup)
+		if err != nil {
+			return nil, err
+		}
+		ranges, secondary, err := dt.keyRestriction(ctx, keyLookup)
+		if err != nil {
+			return nil, err
+		}
+		hs, ok := commitKeyIdx.Commits(lookup, 0)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse commit lookup ranges: %s", sql.DebugString(ctx, lookup.Ranges))
+		}
+		hashes, commits, _ := index.HashesToCommits(ctx, dt.ddb, hs, dt.head, false)
+		if len(hashes) == 0 {
+			return sql.PartitionsToPartitionIter(), nil
+		}
+		return dt.toCommitLookupPartitions(ctx, hashes, commits, ranges, secondary)
+	}
+
 	switch lookup.Index.ID() {
 	case index.ToCommitIndexId:
 		hs, ok := index.LookupToPointSelectStr(lookup)
@@ -263,7 +291,7 @@ func (dt *DiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup)
 		if len(hashes) == 0 {
 			return sql.PartitionsToPartitionIter(), nil
 		}
-		return dt.toCommitLookupPartitions(ctx, hashes, commits)
+		return dt.toCommitLookupPartitions(ctx, hashes, commits, nil, nil)
 	case index.FromCommitIndexId:
 		hs, ok := index.LookupToPointSelectStr(lookup)
 		if !ok {
@@ -275,11 +303,27 @@ func (dt *DiffTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup)
 		}
 		return dt.fromCommitLookupPartitions(ctx, hashes, commits)
 	default:
-		ranges, err := index.ProllyRangesFromIndexLookup(ctx, lookup)
+		ranges, secondary, err := dt.keyRestriction(ctx, lookup)
 		if err != nil {
 			return nil, err
 		}
-		return dt.PartitionRanges(ctx, ranges)
+		return dt.keyPartitions(ctx, ranges, secondary)
+	}
+}
+
+// keyRestriction returns the primary key ranges to diff for a |lookup| of the to_ or from_ columns of a key of the
+// table, or the secondary index lookup to find the keys to diff with. Both are nil if |lookup| is empty.
+func (dt *DiffTable) keyRestriction(ctx *sql.Context, lookup sql.IndexLookup) ([]prolly.Range, *secondaryKeyLookup, error) {
+	if lookup.IsEmpty() {
+		return nil, nil, nil
+	}
+	switch lookup.Index.ID() {
+	case toPksIndexId, fromPksIndexId:
+		ranges, err := index.ProllyRangesFromIndexLookup(ctx, lookup)
+		return ranges, nil, err
+	default:
+		secondary, err := newSecondaryKeyLookup(ctx, dt.targetSch, lookup)
+		return nil, secondary, err
 	}
 }
 
@@ -479,7 +523,7 @@ func tableInfoForCommit(ctx *sql.Context, tableName doltdb.TableName, cm *doltdb
 // toCommitLookupPartitions creates a diff partition iterator for a set of
 // commits. The structure of the iter requires we pre-populate the parents
 // of to_commit for diffing.
-func (dt *DiffTable) toCommitLookupPartitions(ctx *sql.Context, hashes []hash.Hash, commits []*doltdb.Commit) (sql.PartitionIter, error) {
+func (dt *DiffTable) toCommitLookupPartitions(ctx *sql.Context, hashes []hash.Hash, commits []*doltdb.Commit, ranges []prolly.Range, secondary *secondaryKeyLookup) (sql.PartitionIter, error) {
 	t, ok, err := dt.workingRoot.GetTable(ctx, dt.tableName)
 	if err != nil {
 		return nil, err
@@ -573,12 +617,40 @@ func (dt *DiffTable) toCommitLookupPartitions(ctx *sql.Context, hashes []hash.Ha
 		selectFunc:      sf,
 		toSch:           dt.targetSch,
 		fromSch:         dt.targetSch,
+		ranges:          ranges,
+		secondary:       secondary,
 	}, nil
 }
 
+const (
+	toPksIndexId   = "to_pks"
+	fromPksIndexId = "from_pks"
+)
+
 // GetIndexes implements sql.IndexAddressable
 func (dt *DiffTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
-	return index.DoltDiffIndexesFromTable(ctx, "", dt.tableName.Name, dt.table)
+	indexes, err := index.DoltDiffIndexesFromTable(ctx, "", dt.tableName.Name, dt.table)
+	if err != nil || len(indexes) == 0 {
+		return indexes, err
+	}
+	to, from, err := secondaryDiffIndexes(ctx, dt.Name(), dt.table)
+	if err != nil {
+		return nil, err
+	}
+	indexes = append(indexes, to...)
+	indexes = append(indexes, from...)
+
+	// lookups of to_commit and the to_ columns of a key are pushed down together
+	for _, idx := range indexes {
+		if idx.ID() == toPksIndexId {
+			to = append([]sql.Index{idx}, to...)
+			break
+		}
+	}
+	for _, idx := range to {
+		indexes = append(indexes, index.NewCommitKeyIndex(index.ToCommitIndexId+"_"+idx.ID(), idx, index.ToCommitIndexId))
+	}
+	return indexes, nil
 }
 
 // IndexedAccess implements sql.IndexAddressable
@@ -623,6 +695,8 @@ type DiffPartition struct {
 	toSch   schema.Schema
 	fromSch schema.Schema
 	ranges  []prolly.Range
+	// secondary is a lookup of the keys to diff, or nil
+	secondary *secondaryKeyLookup
 }
 
 func NewDiffPartition(to, from *doltdb.Table, toName, fromName string, toDate, fromDate *types.Timestamp, toSch, fromSch schema.Schema, ranges []prolly.Range) *DiffPartition {
@@ -645,7 +719,14 @@ func (dp DiffPartition) Key() []byte {
 }
 
 func (dp DiffPartition) GetRowIter(ctx *sql.Context) (sql.RowIter, error) {
-	return newProllyDiffIter(ctx, dp, dp.fromSch, dp.toSch, dp.ranges)
+	ranges := dp.ranges
+	if dp.secondary != nil {
+		var err error
+		if ranges, err = dp.secondary.primaryRanges(ctx, dp.to, dp.from); err != nil {
+			return nil, err
+		}
+	}
+	return newProllyDiffIter(ctx, dp, dp.fromSch, dp.toSch, ranges)
 }
 
 // isDiffablePartition checks if the commit pair for this partition is "diffable".
@@ -727,6 +808,7 @@ type DiffPartitions struct {
 	selectFunc      partitionSelectFunc
 	tblName         doltdb.TableName
 	ranges          []prolly.Range
+	secondary       *secondaryKeyLookup
 	stopNext        bool
 }
 
@@ -752,15 +834,16 @@ func (dps *DiffPartitions) processCommit(ctx *sql.Context, cmHash hash.Hash, cm
 	var nextPartition *DiffPartition
 	if tblHash != toInfoForCommit.tblHash {
 		partition := DiffPartition{
-			to:       toInfoForCommit.tbl,
-			from:     tbl,
-			toName:   toInfoForCommit.name,
-			fromName: cmHashStr,
-			toDate:   toInfoForCommit.date,
-			fromDate: &ts,
-			fromSch:  dps.fromSch,
-			toSch:    dps.toSch,
-			ranges:   dps.ranges,
+			to:        toInfoForCommit.tbl,
+			from:      tbl,
+			toName:    toInfoForCommit.name,
+			fromName:  cmHashStr,
+			toDate:    toInfoForCommit.date,
+			fromDate:  &ts,
+			fromSch:   dps.fromSch,
+			toSch:     dps.toSch,
+			ranges:    dps.ranges,
+			secondary: dps.secondary,
 		}
 		selected, err := dps.selectFunc(ctx, partition)
 
@@ -0,0 +1,92 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*LocksTable)(nil)
+
+// LocksTable is a sql.Table implementation for the dolt_locks system table, which shows the row locks taken on the
+// branches of a database by locking reads such as SELECT ... FOR UPDATE, and the locks that sessions are waiting for.
+type LocksTable struct {
+	dbName    string
+	tableName string
+}
+
+// NewLocksTable creates a LocksTable
+func NewLocksTable(_ *sql.Context, dbName, tableName string) sql.Table {
+	return &LocksTable{dbName: dbName, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (lt *LocksTable) Name() string {
+	return lt.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (lt *LocksTable) String() string {
+	return lt.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the locks system table
+func (lt *LocksTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "connection_id", Type: types.Uint32, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "branch", Type: types.Text, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "table_name", Type: types.Text, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "lock_data", Type: types.Text, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "lock_mode", Type: types.Text, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "lock_status", Type: types.Text, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "since", Type: types.Datetime, Source: lt.tableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (lt *LocksTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (lt *LocksTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (lt *LocksTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	baseName, _ := doltdb.SplitRevisionDbName(lt.dbName)
+
+	var rows []sql.Row
+	for _, lock := range sess.Provider().RowLocks().Locks() {
+		if !strings.EqualFold(lock.Database, baseName) {
+			continue
+		}
+		status := "WAITING"
+		if lock.Granted {
+			status = "GRANTED"
+		}
+		rows = append(rows, sql.NewRow(lock.ConnectionID, lock.Branch, lock.Table, lock.Data, lock.Mode.String(), status, lock.Since))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
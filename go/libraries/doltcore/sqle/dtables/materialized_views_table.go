@@ -0,0 +1,93 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+var _ sql.Table = (*MaterializedViewsTable)(nil)
+
+// MaterializedViewsTable is the dolt_materialized_views system table, which lists the materialized views of the
+// database and their queries. Unlike other user space system tables it's read only, since it's written by the
+// dolt_create_materialized_view and dolt_drop_materialized_view procedures along with the tables of the views.
+type MaterializedViewsTable struct {
+	bst *UserSpaceSystemTable
+}
+
+func doltMaterializedViewsSchema() sql.Schema {
+	name := doltdb.GetMaterializedViewsTableName()
+	return []*sql.Column{
+		{Name: "name", Type: sqlTypes.Text, Source: name, PrimaryKey: true},
+		{Name: "query", Type: sqlTypes.LongText, Source: name, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// GetDoltMaterializedViewsSchema returns the schema of the dolt_materialized_views system table. This is used
+// by Doltgres to update the dolt_materialized_views schema using Doltgres types.
+var GetDoltMaterializedViewsSchema = doltMaterializedViewsSchema
+
+// NewMaterializedViewsTable creates a dolt_materialized_views table
+func NewMaterializedViewsTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &MaterializedViewsTable{&UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    GetDoltMaterializedViewsTableName(),
+		schema:       GetDoltMaterializedViewsSchema(),
+	}}
+}
+
+// NewEmptyMaterializedViewsTable creates an empty dolt_materialized_views table
+func NewEmptyMaterializedViewsTable(_ *sql.Context) sql.Table {
+	return &MaterializedViewsTable{&UserSpaceSystemTable{
+		tableName: GetDoltMaterializedViewsTableName(),
+		schema:    GetDoltMaterializedViewsSchema(),
+	}}
+}
+
+// GetDoltMaterializedViewsTableName returns the name of the dolt_materialized_views table
+func GetDoltMaterializedViewsTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMaterializedViewsTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMaterializedViewsTableName()}
+}
+
+func (mvt *MaterializedViewsTable) Name() string {
+	return mvt.bst.Name()
+}
+
+func (mvt *MaterializedViewsTable) String() string {
+	return mvt.bst.String()
+}
+
+func (mvt *MaterializedViewsTable) Schema(ctx *sql.Context) sql.Schema {
+	return mvt.bst.Schema(ctx)
+}
+
+func (mvt *MaterializedViewsTable) Collation() sql.CollationID {
+	return mvt.bst.Collation()
+}
+
+func (mvt *MaterializedViewsTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	return mvt.bst.Partitions(ctx)
+}
+
+func (mvt *MaterializedViewsTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	return mvt.bst.PartitionRows(ctx, partition)
+}
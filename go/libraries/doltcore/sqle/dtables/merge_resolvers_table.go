@@ -0,0 +1,65 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+// MergeResolversTable is the dolt_merge_resolvers system table, which registers a stored procedure per table that
+// a merge calls to resolve the conflicts of that table.
+type MergeResolversTable struct {
+	*UserSpaceSystemTable
+}
+
+func doltMergeResolversSchema() sql.Schema {
+	name := doltdb.GetMergeResolversTableName()
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: name, PrimaryKey: true},
+		{Name: "procedure_name", Type: sqlTypes.Text, Source: name, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// GetDoltMergeResolversSchema returns the schema of the dolt_merge_resolvers system table. This is used
+// by Doltgres to update the dolt_merge_resolvers schema using Doltgres types.
+var GetDoltMergeResolversSchema = doltMergeResolversSchema
+
+// NewMergeResolversTable creates a dolt_merge_resolvers table
+func NewMergeResolversTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &MergeResolversTable{&UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    getDoltMergeResolversTableName(),
+		schema:       GetDoltMergeResolversSchema(),
+	}}
+}
+
+// NewEmptyMergeResolversTable creates an empty dolt_merge_resolvers table
+func NewEmptyMergeResolversTable(_ *sql.Context) sql.Table {
+	return &MergeResolversTable{&UserSpaceSystemTable{
+		tableName: getDoltMergeResolversTableName(),
+		schema:    GetDoltMergeResolversSchema(),
+	}}
+}
+
+func getDoltMergeResolversTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMergeResolversTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMergeResolversTableName()}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+)
+
+var _ sql.CheckTable = (*MergeStrategiesTable)(nil)
+
+// MergeStrategiesTable is the dolt_merge_strategies system table, which declares how cells modified on both sides
+// of a merge are resolved for a table or column, instead of becoming conflicts.
+type MergeStrategiesTable struct {
+	*UserSpaceSystemTable
+}
+
+func doltMergeStrategiesSchema() sql.Schema {
+	name := doltdb.GetMergeStrategiesTableName()
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: name, PrimaryKey: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: name, PrimaryKey: true},
+		{Name: "strategy", Type: sqlTypes.Text, Source: name, PrimaryKey: false, Nullable: false},
+		{Name: "order_column", Type: sqlTypes.Text, Source: name, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// GetDoltMergeStrategiesSchema returns the schema of the dolt_merge_strategies system table. This is used
+// by Doltgres to update the dolt_merge_strategies schema using Doltgres types.
+var GetDoltMergeStrategiesSchema = doltMergeStrategiesSchema
+
+// NewMergeStrategiesTable creates a dolt_merge_strategies table
+func NewMergeStrategiesTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &MergeStrategiesTable{&UserSpaceSystemTable{
+		backingTable: backingTable,
+		tableName:    getDoltMergeStrategiesTableName(),
+		schema:       GetDoltMergeStrategiesSchema(),
+	}}
+}
+
+// NewEmptyMergeStrategiesTable creates an empty dolt_merge_strategies table
+func NewEmptyMergeStrategiesTable(_ *sql.Context) sql.Table {
+	return &MergeStrategiesTable{&UserSpaceSystemTable{
+		tableName: getDoltMergeStrategiesTableName(),
+		schema:    GetDoltMergeStrategiesSchema(),
+	}}
+}
+
+func getDoltMergeStrategiesTableName() doltdb.TableName {
+	if resolve.UseSearchPath {
+		return doltdb.TableName{Schema: doltdb.DoltNamespace, Name: doltdb.GetMergeStrategiesTableName()}
+	}
+	return doltdb.TableName{Name: doltdb.GetMergeStrategiesTableName()}
+}
+
+// GetChecks implements sql.CheckTable, rejecting unknown strategies, and last_writer_wins rules without the column
+// that orders the two sides, when they're written rather than when they're first used by a merge.
+func (mst *MergeStrategiesTable) GetChecks(_ *sql.Context) ([]sql.CheckDefinition, error) {
+	strategies := make([]string, len(merge.MergeStrategies()))
+	for i, s := range merge.MergeStrategies() {
+		strategies[i] = fmt.Sprintf("'%s'", s)
+	}
+	return []sql.CheckDefinition{
+		{
+			Name:            "strategy_check",
+			CheckExpression: fmt.Sprintf("strategy IN (%s)", strings.Join(strategies, ", ")),
+			Enforced:        true,
+		},
+		{
+			Name:            "order_column_check",
+			CheckExpression: fmt.Sprintf("strategy <> '%s' OR order_column IS NOT NULL", merge.LastWriterWins),
+			Enforced:        true,
+		},
+	}, nil
+}
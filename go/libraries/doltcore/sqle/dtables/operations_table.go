@@ -0,0 +1,101 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ sql.Table = (*OperationsTable)(nil)
+
+// OperationsTable is a sql.Table implementation for the dolt_operations system table, which shows the operation log of
+// a database. It has one row for every ref or working set changed by each operation, newest operation first.
+type OperationsTable struct {
+	ddb       *doltdb.DoltDB
+	tableName string
+}
+
+// NewOperationsTable creates an OperationsTable
+func NewOperationsTable(_ *sql.Context, ddb *doltdb.DoltDB, tableName string) sql.Table {
+	return &OperationsTable{ddb: ddb, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (ot *OperationsTable) Name() string {
+	return ot.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (ot *OperationsTable) String() string {
+	return ot.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the operations system table
+func (ot *OperationsTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Uint64, Source: ot.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "time", Type: types.Datetime, Source: ot.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "user", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: true},
+		{Name: "description", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: true},
+		{Name: "ref", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "before_hash", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: true},
+		{Name: "after_hash", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (ot *OperationsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (ot *OperationsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (ot *OperationsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	ops, err := ot.ddb.Operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, op := range ops {
+		for _, c := range op.Changes {
+			rows = append(rows, sql.NewRow(op.ID, op.Time, nullIfEmpty(op.User), nullIfEmpty(op.Description), c.Ref, hashOrNull(c.Before), hashOrNull(c.After)))
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func hashOrNull(h hash.Hash) interface{} {
+	if h.IsEmpty() {
+		return nil
+	}
+	return h.String()
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*QueryCacheTable)(nil)
+
+// QueryCacheTable is a sql.Table implementation for the dolt_query_cache system table, which shows the query results
+// cached by the server that read tables of a database, most recently used first.
+type QueryCacheTable struct {
+	dbName    string
+	tableName string
+}
+
+// NewQueryCacheTable creates a QueryCacheTable
+func NewQueryCacheTable(_ *sql.Context, dbName, tableName string) sql.Table {
+	return &QueryCacheTable{dbName: dbName, tableName: tableName}
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (qt *QueryCacheTable) Name() string {
+	return qt.tableName
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (qt *QueryCacheTable) String() string {
+	return qt.tableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the query cache system table
+func (qt *QueryCacheTable) Schema(ctx *sql.Context) sql.Schema {
+	return []*sql.Column{
+		{Name: "query", Type: types.LongText, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "database", Type: types.Text, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "result_rows", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "size", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "hits", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "created", Type: types.Datetime, Source: qt.tableName, PrimaryKey: false, Nullable: false},
+		{Name: "last_hit", Type: types.Datetime, Source: qt.tableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (qt *QueryCacheTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (qt *QueryCacheTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (qt *QueryCacheTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	baseName, _ := doltdb.SplitRevisionDbName(qt.dbName)
+
+	var rows []sql.Row
+	for _, entry := range sess.Provider().QueryCache().Entries() {
+		if !readsDatabase(entry, baseName) {
+			continue
+		}
+		var lastHit interface{}
+		if !entry.LastHit.IsZero() {
+			lastHit = entry.LastHit
+		}
+		rows = append(rows, sql.NewRow(entry.Query, entry.Database, uint64(entry.Rows), uint64(entry.Size), entry.Hits, entry.Created, lastHit))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// readsDatabase returns whether the query of |entry| read tables of the database |dbName|
+func readsDatabase(entry dsess.QueryCacheEntryInfo, dbName string) bool {
+	for _, db := range entry.Databases {
+		if strings.EqualFold(db, dbName) {
+			return true
+		}
+	}
+	return false
+}
@@ -102,16 +102,7 @@ func (sct *SchemaConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partit
 		return nil, errors.New("unexpected partition for schema conflicts table")
 	}
 
-	optCmt, err := doltdb.GetCommitAncestor(ctx, p.head, p.state.Commit())
-	if err != nil {
-		return nil, err
-	}
-	base, ok := optCmt.ToCommit()
-	if !ok {
-		return nil, doltdb.ErrGhostCommitEncountered
-	}
-
-	baseRoot, err := base.GetRootValue(ctx)
+	baseRoot, err := schemaConflictsBaseRoot(ctx, p)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +125,25 @@ func (sct *SchemaConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partit
 	}, nil
 }
 
+// schemaConflictsBaseRoot returns the root of the common ancestor of the merge. For a merge of unrelated histories
+// that's the root of the commit given with --base, or an empty root if there wasn't one.
+func schemaConflictsBaseRoot(ctx *sql.Context, p schemaConflictsPartition) (doltdb.RootValue, error) {
+	if base := p.state.BaseCommit(); base != nil {
+		return base.GetRootValue(ctx)
+	}
+	optCmt, err := doltdb.GetCommitAncestor(ctx, p.head, p.state.Commit())
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return doltdb.EmptyRootValue(ctx, p.ddb.ValueReadWriter(), p.ddb.NodeStore())
+	} else if err != nil {
+		return nil, err
+	}
+	base, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return base.GetRootValue(ctx)
+}
+
 type schemaConflictsPartition struct {
 	state     *doltdb.MergeState
 	head      *doltdb.Commit
@@ -242,6 +252,16 @@ func getCreateTableStatement(ctx *sql.Context, table string, sch schema.Schema,
 }
 
 func getSchemaConflictDescription(ctx *sql.Context, table doltdb.TableName, base, ours, theirs schema.Schema) (string, error) {
+	if base == nil {
+		// both sides added the table in a merge of unrelated histories
+		var ok bool
+		var err error
+		if theirs, base, ok, err = merge.AlignUnrelatedSchemas(ours, theirs); err != nil {
+			return "", err
+		} else if !ok {
+			return merge.ErrSameTblAddedTwice.New(table).Error(), nil
+		}
+	}
 	_, conflict, _, _, err := merge.SchemaMerge(ctx, noms.Format_DOLT, ours, theirs, base, table)
 	if err != nil {
 		return "", err
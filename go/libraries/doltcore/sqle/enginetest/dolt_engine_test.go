@@ -1291,6 +1291,36 @@ func TestDoltMergePrepared(t *testing.T) {
 	RunDoltMergePreparedTests(t, h)
 }
 
+func TestDoltMergeStrategies(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltMergeStrategiesTests(t, h)
+}
+
+func TestDoltMergeResolvers(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltMergeResolversTests(t, h)
+}
+
+func TestMaterializedViews(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunMaterializedViewsTests(t, h)
+}
+
+func TestColumnStoreIndexes(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunColumnStoreTests(t, h)
+}
+
+func TestQueryCache(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunQueryCacheTests(t, h)
+}
+
+func TestParallelQueries(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunParallelQueryTests(t, h)
+}
+
 func TestDoltRebase(t *testing.T) {
 	h := newDoltEnginetestHarness(t)
 	RunDoltRebaseTests(t, h)
@@ -1311,6 +1341,16 @@ func TestDoltSquashHistoryPrepared(t *testing.T) {
 	RunDoltSquashHistoryPreparedTests(t, h)
 }
 
+func TestDoltApplyPatch(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltApplyPatchTests(t, h)
+}
+
+func TestDoltApplyPatchPrepared(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltApplyPatchPreparedTests(t, h)
+}
+
 func TestDoltRevert(t *testing.T) {
 	h := newDoltEnginetestHarness(t)
 	RunDoltRevertTests(t, h)
@@ -1481,6 +1521,11 @@ func TestHistorySystemTablePrepared(t *testing.T) {
 	RunHistorySystemTableTestsPrepared(t, harness)
 }
 
+func TestSystemTime(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunSystemTimeTests(t, harness)
+}
+
 func TestBrokenHistorySystemTablePrepared(t *testing.T) {
 	t.Skip()
 	harness := newDoltHarness(t)
@@ -1549,6 +1594,16 @@ func TestDiffStatTableFunctionPrepared(t *testing.T) {
 	RunDiffStatTableFunctionTestsPrepared(t, harness)
 }
 
+func TestDiffGroupByTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDiffGroupByTableFunctionTests(t, harness)
+}
+
+func TestDiffGroupByTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDiffGroupByTableFunctionTestsPrepared(t, harness)
+}
+
 func TestDiffSummaryTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunDiffSummaryTableFunctionTests(t, harness)
@@ -2148,6 +2203,18 @@ func TestDoltStash(t *testing.T) {
 	RunDoltStashSystemTableTests(t, harness)
 }
 
+func TestDoltOperations(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	defer harness.Close()
+	RunDoltOperationsTests(t, harness)
+}
+
+func TestDoltDeletedRefs(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	defer harness.Close()
+	RunDoltDeletedRefsTests(t, harness)
+}
+
 func TestDoltRm(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	defer harness.Close()
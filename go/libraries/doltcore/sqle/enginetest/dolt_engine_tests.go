@@ -33,6 +33,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/transform"
 	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -652,6 +653,38 @@ func RunTransactionTests(t *testing.T, h DoltEnginetestHarness, prepared bool) {
 			}
 		}()
 	}
+	for _, script := range SerializableTransactionTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			if prepared {
+				enginetest.TestTransactionScriptPrepared(t, h, script)
+			} else {
+				enginetest.TestTransactionScript(t, h, script)
+			}
+		}()
+	}
+	for _, script := range XATransactionTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			if prepared {
+				enginetest.TestTransactionScriptPrepared(t, h, script)
+			} else {
+				enginetest.TestTransactionScript(t, h, script)
+			}
+		}()
+	}
+	// locking reads are recognized by the query of the context, which the prepared statement runner doesn't set
+	if !prepared {
+		for _, script := range LockingReadTransactionTests {
+			func() {
+				h := h.NewHarness(t)
+				defer h.Close()
+				enginetest.TestTransactionScript(t, h, script)
+			}()
+		}
+	}
 }
 
 func RunBranchTransactionTest(t *testing.T, h DoltEnginetestHarness) {
@@ -680,6 +713,14 @@ func RunMultiDbTransactionsTest(t *testing.T, h DoltEnginetestHarness) {
 			enginetest.TestTransactionScript(t, h, script)
 		}()
 	}
+
+	for _, script := range MultiBranchCommitTransactionTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
 }
 
 func RunMultiDbTransactionsPreparedTest(t *testing.T, h DoltEnginetestHarness) {
@@ -1014,6 +1055,77 @@ func RunDoltMergeTests(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunDoltMergeStrategiesTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range MergeStrategiesScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunDoltMergeResolversTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range MergeResolversScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunMaterializedViewsTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range MaterializedViewsScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunColumnStoreTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range ColumnStoreScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunQueryCacheTests(t *testing.T, h DoltEnginetestHarness) {
+	// the size of the cache is a global variable, so it's reset for the tests that follow
+	defer sql.SystemVariables.AssignValues(map[string]interface{}{
+		dsess.DoltQueryCacheSize:          int64(0),
+		dsess.DoltQueryCacheMaxResultSize: int64(1 << 20),
+	})
+	for _, script := range QueryCacheScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunParallelQueryTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range ParallelQueryScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			h.Setup(setup.MydbData)
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func RunDoltMergePreparedTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range MergeScripts {
 		// harness can't reset effectively when there are new commits / branches created, so use a new harness for
@@ -1050,6 +1162,28 @@ func RunDoltRebasePreparedTests(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunDoltApplyPatchTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range ApplyPatchScripts {
+		// harness can't reset effectively. Use a new harness for each script
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunDoltApplyPatchPreparedTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range ApplyPatchScripts {
+		// harness can't reset effectively. Use a new harness for each script
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScriptPrepared(t, h, script)
+		}()
+	}
+}
+
 func RunDoltRevertTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range RevertScripts {
 		// harness can't reset effectively. Use a new harness for each script
@@ -1301,6 +1435,38 @@ func RunHistorySystemTableTestsPrepared(t *testing.T, harness DoltEnginetestHarn
 	}
 }
 
+func RunSystemTimeTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range SystemTimeScriptTests {
+		harness = harness.NewHarness(t)
+		harness.Setup(setup.MydbData)
+		engine, err := harness.NewEngine(t)
+		require.NoError(t, err)
+
+		enginetest.TestScriptWithEngine(t, systemTimeEngine{Engine: engine.(*gms.Engine)}, harness, test)
+	}
+}
+
+// systemTimeEngine is an engine that parses the queries it's given with FOR SYSTEM_TIME ranges, as the engine of the
+// dolt command does
+type systemTimeEngine struct {
+	*gms.Engine
+}
+
+func (e systemTimeEngine) Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	return e.QueryWithBindings(ctx, query, nil, nil, nil)
+}
+
+func (e systemTimeEngine) QueryWithBindings(ctx *sql.Context, query string, parsed sqlparser.Statement, bindings map[string]sqlparser.Expr, qFlags *sql.QueryFlags) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	if parsed == nil && len(bindings) == 0 {
+		var err error
+		parsed, err = sqle.ParseSystemTimeQuery(ctx, e.Parser, query)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return e.Engine.QueryWithBindings(ctx, query, parsed, bindings, qFlags)
+}
+
 func RunDoltBranchesSystemTableTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range BranchesSystemTableTests {
 		harness = harness.NewHarness(t)
@@ -1422,6 +1588,26 @@ func RunDiffStatTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestH
 	}
 }
 
+func RunDiffGroupByTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range DiffGroupByTableFunctionScriptTests {
+		harness = harness.NewHarness(t)
+		harness.Setup(setup.MydbData)
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunDiffGroupByTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range DiffGroupByTableFunctionScriptTests {
+		harness = harness.NewHarness(t)
+		harness.Setup(setup.MydbData)
+		t.Run(test.Name, func(t *testing.T) {
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func RunDiffSummaryTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range DiffSummaryTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -2226,6 +2412,26 @@ func RunDoltStashSystemTableTests(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunDoltOperationsTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltOperationsTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunDoltDeletedRefsTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltDeletedRefsTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func RunDoltRmTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range DoltRmTests {
 		func() {
@@ -275,8 +275,10 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 		if err != nil {
 			return nil, err
 		}
-		e.Analyzer.ExecBuilder = rowexec.NewBuilder(kvexec.Builder{}, e.Analyzer.Overrides)
+		e.Analyzer.ExecBuilder = rowexec.NewBuilder(nil, e.Analyzer.Overrides)
+		e.Analyzer.ExecBuilder.PriorityBuilder = sqle.NewQueryCacheBuilder(kvexec.Builder{}, e.Analyzer.ExecBuilder)
 		e.Analyzer.ExecBuilder.Runner = e.Analyzer.Runner
+		doltProvider.SetStatementRunner(e)
 		d.engine = e
 
 		sqlCtx := enginetest.NewContext(d)
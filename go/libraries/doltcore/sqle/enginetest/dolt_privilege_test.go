@@ -1025,6 +1025,7 @@ var DoltOnlyRevisionTableFunctionPrivilegeTests = []queries.UserPrivilegeTest{
 					{"test", "test",
 						"CREATE TABLE `test` (\n  `pk` bigint NOT NULL,\n  `col1` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `test` (\n  `pk` bigint NOT NULL,\n  `word` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						types.MustJSON(`{"col1": "word"}`),
 					},
 				},
 			},
@@ -1048,6 +1049,7 @@ var DoltOnlyRevisionTableFunctionPrivilegeTests = []queries.UserPrivilegeTest{
 					{"test", "test",
 						"CREATE TABLE `test` (\n  `pk` bigint NOT NULL,\n  `col1` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `test` (\n  `pk` bigint NOT NULL,\n  `word` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						types.MustJSON(`{"col1": "word"}`),
 					},
 				},
 			},
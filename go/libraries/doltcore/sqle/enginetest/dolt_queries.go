@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -1979,6 +1980,129 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 			},
 		},
 	},
+	{
+		Name: "merge resolvers run with the privileges of the session's user",
+		SetUpScript: []string{
+			"CREATE TABLE mydb.prices (id int primary key, price int);",
+			"INSERT INTO mydb.prices VALUES (1, 10);",
+			"CREATE PROCEDURE mydb.take_theirs(base json, ours json, theirs json) SELECT theirs->>'$.id', theirs->>'$.price';",
+			"INSERT INTO mydb.dolt_merge_resolvers VALUES ('prices', 'take_theirs');",
+			"CALL mydb.dolt_commit('-Am', 'prices');",
+			"CALL mydb.dolt_branch('other');",
+			"UPDATE mydb.prices SET price = 11;",
+			"CALL mydb.dolt_commit('-am', 'main');",
+			"CALL mydb.dolt_checkout('other');",
+			"UPDATE mydb.prices SET price = 12;",
+			"CALL mydb.dolt_commit('-am', 'other');",
+			"CALL mydb.dolt_checkout('main');",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT, EXECUTE ON mydb.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				// The resolver's row is written to the conflicts table, which tester can't update
+				User:           "tester",
+				Host:           "localhost",
+				Query:          "CALL mydb.dolt_merge('other');",
+				ExpectedErrStr: "merge resolver take_theirs for table prices failed: command denied to user 'tester'@'localhost'",
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT INSERT, UPDATE, DELETE ON mydb.* TO tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "CALL mydb.dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT * FROM mydb.prices;",
+				Expected: []sql.Row{{1, 12}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch runs statements with the privileges of the session's user",
+		SetUpScript: []string{
+			"CREATE TABLE mydb.t (pk int primary key, c1 int);",
+			"CALL mydb.dolt_commit('-Am', 't');",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT, EXECUTE ON mydb.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				User:           "tester",
+				Host:           "localhost",
+				Query:          "CALL mydb.dolt_apply_patch('INSERT INTO `t` (`pk`,`c1`) VALUES (1,1);');",
+				ExpectedErrStr: "error applying statement INSERT INTO `t` (`pk`,`c1`) VALUES (1,1): command denied to user 'tester'@'localhost'",
+			},
+			{
+				User:           "tester",
+				Host:           "localhost",
+				Query:          "CALL mydb.dolt_apply_patch('ALTER TABLE `t` ADD `c2` int;');",
+				ExpectedErrStr: "error applying statement ALTER TABLE `t` ADD `c2` int: command denied to user 'tester'@'localhost'",
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT INSERT ON mydb.* TO tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "CALL mydb.dolt_apply_patch('INSERT INTO `t` (`pk`,`c1`) VALUES (1,1);');",
+				Expected: []sql.Row{{1, 0, 0}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "SELECT * FROM mydb.t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},	{
+		Name: "dolt_create_materialized_view checks the privileges of the session's user on the queried table",
+		SetUpScript: []string{
+			"CREATE TABLE mydb.t (pk int primary key, c1 int);",
+			"INSERT INTO mydb.t VALUES (1, 1);",
+			"CREATE TABLE mydb.u (pk int primary key);",
+			"CREATE USER tester@localhost;",
+			"GRANT EXECUTE ON mydb.* TO tester@localhost;",
+			"GRANT SELECT ON mydb.u TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "CALL mydb.dolt_create_materialized_view('mv', 'select pk, c1 from t');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "GRANT SELECT ON mydb.t TO tester@localhost;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "CALL mydb.dolt_create_materialized_view('mv', 'select pk, c1 from t');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT * FROM mydb.mv;",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},
 }
 
 // HistorySystemTableScriptTests contains working tests for both prepared and non-prepared
@@ -2761,6 +2885,33 @@ WHERE z IN (
 			},
 		},
 	},
+	{
+		Name: "lookups of commits and keys on dolt_history",
+		SetUpScript: []string{
+			"create table orders (id int primary key, customer_id int, total int, key (customer_id))",
+			"insert into orders values (1, 5, 10), (2, 6, 20), (3, 5, 30)",
+			"call dolt_commit_hash_out(@c1, '-Am', 'orders')",
+			"update orders set total = 11 where id = 1",
+			"update orders set customer_id = 5 where id = 2",
+			"delete from orders where id = 3",
+			"insert into orders values (4, 7, 1)",
+			"call dolt_commit_hash_out(@c2, '-am', 'changes')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select id, total from dolt_history_orders where commit_hash = @c2 and customer_id = 5 order by id",
+				Expected: []sql.Row{{1, 11}, {2, 20}},
+			},
+			{
+				Query:    "select id, total from dolt_history_orders where commit_hash = @c1 and id in (1, 3) order by id",
+				Expected: []sql.Row{{1, 10}, {3, 30}},
+			},
+			{
+				Query:    "select id, total from dolt_history_orders where commit_hash in (@c1, @c2) and customer_id = 6",
+				Expected: []sql.Row{{2, 20}},
+			},
+		},
+	},
 }
 
 // BrokenHistorySystemTableScriptTests contains tests that work for non-prepared, but don't work
@@ -2791,6 +2942,124 @@ var BrokenHistorySystemTableScriptTests = []queries.ScriptTest{
 	},
 }
 
+// SystemTimeScriptTests tests querying the versions of the rows of tables FOR SYSTEM_TIME ranges. They aren't run
+// prepared, because the prepared test harness caches statements parsed without the engine's parser.
+var SystemTimeScriptTests = []queries.ScriptTest{
+	{
+		Name: "FOR SYSTEM_TIME ranges",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c varchar(20));",
+			"insert into t values (1, 'a'), (2, 'b');",
+			"call dolt_commit('-Am', 'inserting into t', '--date', '2024-01-01T00:00:00');",
+			"update t set c = 'a2' where pk = 1;",
+			"call dolt_commit('-am', 'updating t', '--date', '2024-02-01T00:00:00');",
+			"delete from t where pk = 2;",
+			"insert into t values (3, 'c');",
+			"call dolt_commit('-am', 'deleting from and inserting into t', '--date', '2024-03-01T00:00:00');",
+			"alter table t add column d int;",
+			"call dolt_commit('-am', 'adding column d', '--date', '2024-04-01T00:00:00');",
+			"update t set d = 5 where pk = 3;",
+			"call dolt_commit('-am', 'updating d', '--date', '2024-05-01T00:00:00');",
+			"update t set c = 'uncommitted';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select * from t for system_time all order by pk, valid_from;",
+				Expected: []sql.Row{
+					{1, "a", nil, systemTime(2024, 1), systemTime(2024, 2)},
+					{1, "a2", nil, systemTime(2024, 2), systemTimeCurrent},
+					{2, "b", nil, systemTime(2024, 1), systemTime(2024, 3)},
+					{3, "c", nil, systemTime(2024, 3), systemTime(2024, 5)},
+					{3, "c", 5, systemTime(2024, 5), systemTimeCurrent},
+				},
+			},
+			{
+				Query:    "select pk, c from t for system_time from '2024-02-01' to '2024-03-01' order by pk;",
+				Expected: []sql.Row{{1, "a2"}, {2, "b"}},
+			},
+			{
+				Query:    "select pk, c from t for system_time between '2024-02-01' and '2024-03-01' order by pk;",
+				Expected: []sql.Row{{1, "a2"}, {2, "b"}, {3, "c"}},
+			},
+			{
+				Query:    "select pk, c from t for system_time contained in ('2024-01-01', '2024-03-01') order by pk;",
+				Expected: []sql.Row{{1, "a"}, {2, "b"}},
+			},
+			{
+				Query:    "select pk, c from t for system_time from 'HEAD~3' to 'HEAD~2' order by pk;",
+				Expected: []sql.Row{{1, "a2"}, {2, "b"}},
+			},
+			{
+				Query:    "select pk, d, valid_from from t for system_time from '2024-04-15' to '2024-06-01' order by pk, valid_from;",
+				Expected: []sql.Row{{1, nil, systemTime(2024, 2)}, {3, nil, systemTime(2024, 3)}, {3, 5, systemTime(2024, 5)}},
+			},
+			{
+				Query:    "select h.pk, h.valid_to from t for system_time all as h join t on h.pk = t.pk where h.c = 'a';",
+				Expected: []sql.Row{{1, systemTime(2024, 2)}},
+			},
+			{
+				Query:    "select count(*) from mydb.t for system_time all;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "select pk, c from dolt_system_time('t', 'between', '2024-02-01', '2024-03-01') order by pk;",
+				Expected: []sql.Row{{1, "a2"}, {2, "b"}, {3, "c"}},
+			},
+			{
+				Query:       "select * from dolt_system_time('t', 'sometime');",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from doesnotexist for system_time all;",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+		},
+	},
+	{
+		Name: "FOR SYSTEM_TIME on a dropped and recreated table",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'creating t', '--date', '2024-01-01T00:00:00');",
+			"drop table t;",
+			"call dolt_commit('-Am', 'dropping t', '--date', '2024-02-01T00:00:00');",
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'recreating t', '--date', '2024-03-01T00:00:00');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select * from t for system_time all order by valid_from;",
+				Expected: []sql.Row{
+					{1, 1, systemTime(2024, 1), systemTime(2024, 2)},
+					{1, 1, systemTime(2024, 3), systemTimeCurrent},
+				},
+			},
+		},
+	},
+	{
+		Name: "FOR SYSTEM_TIME on a keyless table",
+		SetUpScript: []string{
+			"create table t (c int);",
+			"call dolt_commit('-Am', 'creating t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "select * from t for system_time all;",
+				ExpectedErrStr: "table t has no primary key, which FOR SYSTEM_TIME requires",
+			},
+		},
+	},
+}
+
+// systemTime returns the first moment of |month| in |year|, the time of the commits in SystemTimeScriptTests
+func systemTime(year int, month time.Month) time.Time {
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// systemTimeCurrent is the valid_to of current row versions
+var systemTimeCurrent = time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC)
+
 var BranchesSystemTableTests = []queries.ScriptTest{
 	{
 		Name: "dolt_branches basic usage",
@@ -9025,10 +9294,14 @@ var DoltSystemVariables = []queries.ScriptTest{
 					{"dolt_conflicts_test"},
 					{"dolt_constraint_violations"},
 					{"dolt_constraint_violations_test"},
+					{"dolt_deleted_refs"},
 					{"dolt_diff_test"},
 					{"dolt_help"},
 					{"dolt_history_test"},
+					{"dolt_locks"},
 					{"dolt_log"},
+					{"dolt_operations"},
+					{"dolt_query_cache"},
 					{"dolt_remote_branches"},
 					{"dolt_remotes"},
 					{"dolt_stashes"},
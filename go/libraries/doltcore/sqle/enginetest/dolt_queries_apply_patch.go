@@ -0,0 +1,271 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+var ApplyPatchScripts = []queries.ScriptTest{
+	{
+		Name: "dolt_patch() with --preimage",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 varchar(20), j json);",
+			"insert into t values (1, 1, 'one', '{\"a\": 1}'), (2, 2, NULL, NULL);",
+			"call dolt_commit('-Am', 'create table');",
+			"update t set c1 = 10 where pk = 1;",
+			"delete from t where pk = 2;",
+			"insert into t values (3, 3, 'three', NULL);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select statement from dolt_patch('HEAD', 'WORKING', 't', '--preimage') order by statement_order;",
+				Expected: []sql.Row{
+					{"UPDATE `t` SET `c1`=10 WHERE `pk`=1 AND `c1`<=>1 AND `c2`<=>'one' AND `j`<=>CAST('{\\\"a\\\":1}' AS JSON);"},
+					{"DELETE FROM `t` WHERE `pk`=2 AND `c1`<=>2 AND `c2`<=>NULL AND `j`<=>NULL;"},
+					{"INSERT INTO `t` (`pk`,`c1`,`c2`,`j`) VALUES (3,3,'three',NULL);"},
+				},
+			},
+			{
+				Query: "select statement from dolt_patch('HEAD', 'WORKING', 't') order by statement_order;",
+				Expected: []sql.Row{
+					{"UPDATE `t` SET `c1`=10 WHERE `pk`=1;"},
+					{"DELETE FROM `t` WHERE `pk`=2;"},
+					{"INSERT INTO `t` (`pk`,`c1`,`c2`,`j`) VALUES (3,3,'three',NULL);"},
+				},
+			},
+			{
+				Query:          "select statement from dolt_patch('HEAD', 'WORKING', '--bogus');",
+				ExpectedErrStr: "unknown option `bogus' for dolt_patch",
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() applies and stages a patch",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 int);",
+			"insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3);",
+			"call dolt_commit('-Am', 'create table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('UPDATE `t` SET `c1`=10 WHERE `pk`=1 AND `c1`<=>1 AND `c2`<=>1;\n" +
+					"DELETE FROM `t` WHERE `pk`=2 AND `c1`<=>2 AND `c2`<=>2;\n" +
+					"INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (4,4,4);\n')",
+				Expected: []sql.Row{{3, 0, 0}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 10, 1}, {3, 3, 3}, {4, 4, 4}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status;",
+				Expected: []sql.Row{{"t", uint64(1), "modified"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() skips statements already applied",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 int);",
+			"insert into t values (1, 10, 1), (3, 3, 3), (4, 4, 4);",
+			"call dolt_commit('-Am', 'create table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('UPDATE `t` SET `c1`=10 WHERE `pk`=1 AND `c1`<=>1 AND `c2`<=>1;\n" +
+					"DELETE FROM `t` WHERE `pk`=2 AND `c1`<=>2 AND `c2`<=>2;\n" +
+					"INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (4,4,4);\n')",
+				Expected: []sql.Row{{0, 3, 0}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() merges changed rows and records conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 int);",
+			"insert into t values (1, 1, 1), (2, 20, 2), (3, 3, 30), (5, 50, 5);",
+			"call dolt_commit('-Am', 'create table');",
+			"set @@dolt_allow_commit_conflicts = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('UPDATE `t` SET `c1`=10 WHERE `pk`=1 AND `c1`<=>1 AND `c2`<=>1;\n" +
+					"UPDATE `t` SET `c1`=21 WHERE `pk`=2 AND `c1`<=>2 AND `c2`<=>2;\n" +
+					"UPDATE `t` SET `c1`=31 WHERE `pk`=3 AND `c1`<=>3 AND `c2`<=>3;\n" +
+					"UPDATE `t` SET `c2`=40 WHERE `pk`=4 AND `c1`<=>4 AND `c2`<=>4;\n" +
+					"INSERT INTO `t` (`pk`,`c1`,`c2`) VALUES (5,5,5);\n')",
+				Expected: []sql.Row{{2, 0, 3}},
+			},
+			{
+				Query:    "select * from t order by pk;",
+				Expected: []sql.Row{{1, 10, 1}, {2, 20, 2}, {3, 31, 30}, {5, 50, 5}},
+			},
+			{
+				Query: "select base_pk, base_c1, base_c2, our_pk, our_c1, our_c2, their_pk, their_c1, their_c2 " +
+					"from dolt_conflicts_t order by their_pk;",
+				Expected: []sql.Row{
+					{2, 2, 2, 2, 20, 2, 2, 21, 2},
+					{4, 4, 4, nil, nil, nil, 4, 4, 40},
+					{nil, nil, nil, 5, 50, 5, 5, 5, 5},
+				},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status order by status;",
+				Expected: []sql.Row{{"t", uint64(0), "conflict"}, {"t", uint64(0), "modified"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() stages only tables without conflicts",
+		SetUpScript: []string{
+			"create table t1 (pk int primary key, c1 int);",
+			"create table t2 (pk int primary key, c1 int);",
+			"insert into t1 values (1, 1);",
+			"insert into t2 values (1, 10);",
+			"call dolt_commit('-Am', 'create tables');",
+			"set @@dolt_allow_commit_conflicts = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('UPDATE `t1` SET `c1`=2 WHERE `pk`=1 AND `c1`<=>1;\n" +
+					"UPDATE `t2` SET `c1`=2 WHERE `pk`=1 AND `c1`<=>1;\n')",
+				Expected: []sql.Row{{1, 0, 1}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status order by table_name;",
+				Expected: []sql.Row{{"t1", uint64(1), "modified"}, {"t2", uint64(0), "conflict"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() errors",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int, c2 int);",
+			"insert into t values (1, 1, 1);",
+			"call dolt_commit('-Am', 'create table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_apply_patch();",
+				ExpectedErrStr: "error: dolt_apply_patch takes the text of the patch to apply",
+			},
+			{
+				Query:          "call dolt_apply_patch('UPDATE `t` SET `c1`=3 WHERE `pk`=1;', 'extra');",
+				ExpectedErrStr: "error: dolt_apply_patch takes the text of the patch to apply",
+			},
+			{
+				Query: "call dolt_apply_patch('UPDATE `t` SET `c1`=3 WHERE `pk`=1 AND `c1`<=>2;');",
+				ExpectedErrStr: "error applying statement UPDATE `t` SET `c1`=3 WHERE `pk`=1 AND `c1`<=>2: " +
+					"the row it changes no longer matches, and the WHERE clause has no old value of column c2 " +
+					"to record a conflict with; create the patch with --preimage",
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1, 1}},
+			},
+			{
+				Query:    "update t set c2 = 2;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:          "call dolt_apply_patch('UPDATE `t` SET `c1`=4 WHERE `pk`=1;');",
+				ExpectedErrStr: dprocedures.ErrApplyPatchUncommittedChanges.Error(),
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() applies schema changes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"create table old (pk int primary key);",
+			"create table gone (pk int primary key);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'create tables');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('ALTER TABLE `t` ADD `c2` int;\n" +
+					"ALTER TABLE `t` ADD INDEX `c2`(`c2`);\n" +
+					"UPDATE `t` SET `c2`=2 WHERE `pk`=1 AND `c1`<=>1;\n" +
+					"CREATE TABLE `t2` (\n  `pk` int NOT NULL,\n  PRIMARY KEY (`pk`)\n);\n" +
+					"RENAME TABLE `old` TO `new`;\n" +
+					"DROP TABLE `gone`;\n')",
+				Expected: []sql.Row{{6, 0, 0}},
+			},
+			{
+				Query:    "select * from t where c2 = 2;",
+				Expected: []sql.Row{{1, 1, 2}},
+			},
+			{
+				Query:    "show tables;",
+				Expected: []sql.Row{{"new"}, {"t"}, {"t2"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_apply_patch() only applies the statements of a patch",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 int);",
+			"call dolt_commit('-Am', 'create table');",
+			"create database other;",
+			"create table other.t (pk int primary key);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_apply_patch('INSERT INTO `t` (`pk`,`c1`) VALUES (1,1);\nCALL dolt_branch(''b'');');",
+				ExpectedErrStr: "error applying statement \nCALL dolt_branch('b'): " +
+					dprocedures.ErrApplyPatchUnsupportedStatement.Error(),
+			},
+			{
+				Query: "call dolt_apply_patch('CREATE VIEW v AS SELECT 1;');",
+				ExpectedErrStr: "error applying statement CREATE VIEW v AS SELECT 1: " +
+					dprocedures.ErrApplyPatchUnsupportedStatement.Error(),
+			},
+			{
+				Query: "call dolt_apply_patch('INSERT INTO `other`.`t` (`pk`) VALUES (1);');",
+				ExpectedErrStr: "error applying statement INSERT INTO `other`.`t` (`pk`) VALUES (1): " +
+					dprocedures.ErrApplyPatchUnsupportedStatement.Error(),
+			},
+			{
+				Query: "call dolt_apply_patch('DROP DATABASE other;');",
+				ExpectedErrStr: "error applying statement DROP DATABASE other: " +
+					dprocedures.ErrApplyPatchUnsupportedStatement.Error(),
+			},
+			{
+				Query: "call dolt_apply_patch('INSERT INTO `t` (`pk`,`c1`) SELECT `pk`, 1 FROM `other`.`t`;');",
+				ExpectedErrStr: "error applying statement INSERT INTO `t` (`pk`,`c1`) SELECT `pk`, 1 FROM `other`.`t`: " +
+					dprocedures.ErrApplyPatchUnsupportedStatement.Error(),
+			},
+			{
+				Query:    "select count(*) from t;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_branches where name = 'b';",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
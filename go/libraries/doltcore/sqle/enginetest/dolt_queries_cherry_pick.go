@@ -121,7 +121,7 @@ var DoltCherryPickTests = []queries.ScriptTest{
 		},
 	},
 	{
-		Name: "error cases: different primary keys",
+		Name: "primary key changes are cherry-picked",
 		SetUpScript: []string{
 			"create table t (pk int primary key, v varchar(100));",
 			"call dolt_commit('-Am', 'create table t');",
@@ -133,8 +133,12 @@ var DoltCherryPickTests = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "CALL Dolt_Cherry_Pick(@commit1);",
-				ExpectedErrStr: "error: cannot merge because table t has different primary keys",
+				Query:    "CALL Dolt_Cherry_Pick(@commit1);",
+				Expected: []sql.Row{{doltCommit, 0, 0, 0}},
+			},
+			{
+				Query:    "insert into t values (1, 'a'), (1, 'b');",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
 			},
 		},
 	},
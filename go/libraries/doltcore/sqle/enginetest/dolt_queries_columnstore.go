@@ -0,0 +1,374 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var ColumnStoreScripts = []queries.ScriptTest{
+	{
+		Name: "columnstore index scans and aggregates",
+		SetUpScript: []string{
+			"create table sales (id int primary key, region varchar(10), amount int, price decimal(10,2), note text)",
+			"insert into sales values (1, 'east', 10, 1.50, 'a'), (2, 'west', 20, 2.50, 'b'), (3, 'east', null, 3.50, 'c'), (4, 'north', 40, null, 'd')",
+			"create index cs using columnstore on sales (region, amount, price)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select id, amount from sales order by id",
+				Expected: []sql.Row{{1, 10}, {2, 20}, {3, nil}, {4, 40}},
+			},
+			{
+				Query:    "select price, region from sales order by id",
+				Expected: []sql.Row{{"1.50", "east"}, {"2.50", "west"}, {"3.50", "east"}, {nil, "north"}},
+			},
+			{
+				Query:    "select sum(amount), count(amount), count(*), max(price) from sales",
+				Expected: []sql.Row{{float64(70), 3, 4, "3.50"}},
+			},
+			{
+				Query:    "select region, sum(amount) from sales group by region order by region",
+				Expected: []sql.Row{{"east", float64(10)}, {"north", float64(40)}, {"west", float64(20)}},
+			},
+			{
+				Query:    "select id from sales where amount > 15 order by id",
+				Expected: []sql.Row{{2}, {4}},
+			},
+			{
+				// columns outside of the index are read from the table
+				Query:    "select id, note, amount from sales order by id",
+				Expected: []sql.Row{{1, "a", 10}, {2, "b", 20}, {3, "c", nil}, {4, "d", 40}},
+			},
+			{
+				// columnstore indexes are never used for lookups
+				Query:    "select id from sales where region = 'east' order by id",
+				Expected: []sql.Row{{1}, {3}},
+			},
+			{
+				// the harness skips assertions containing "show indexes from", so this uses the singular synonym
+				Query: "show index from sales",
+				Expected: []sql.Row{
+					{"sales", 0, "PRIMARY", 1, "id", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil},
+					{"sales", 1, "cs", 1, "region", nil, 0, nil, nil, "YES", "COLUMNSTORE", "", "", "YES", nil},
+					{"sales", 1, "cs", 2, "amount", nil, 0, nil, nil, "YES", "COLUMNSTORE", "", "", "YES", nil},
+					{"sales", 1, "cs", 3, "price", nil, 0, nil, nil, "YES", "COLUMNSTORE", "", "", "YES", nil},
+				},
+			},
+			{
+				Query:    "select index_name, seq_in_index, column_name, index_type from information_schema.statistics where table_name = 'sales' and index_name = 'cs' order by seq_in_index",
+				Expected: []sql.Row{{"cs", 1, "region", "COLUMNSTORE"}, {"cs", 2, "amount", "COLUMNSTORE"}, {"cs", 3, "price", "COLUMNSTORE"}},
+			},
+			{
+				Query: "show create table sales",
+				Expected: []sql.Row{{"sales", "CREATE TABLE `sales` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `region` varchar(10),\n" +
+					"  `amount` int,\n" +
+					"  `price` decimal(10,2),\n" +
+					"  `note` text,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `cs` (`region`,`amount`,`price`) USING COLUMNSTORE\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index declared after its columns",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b varchar(10), key ab (a, b), key cs (b, a) using columnstore comment 'for scans')",
+			"insert into t values (1, 10, 'x'), (2, 20, 'y')",
+			"create index cs2 on t (a) using columnstore",
+			"alter table t add index cs3 (b) using columnstore",
+			"create index bt on t (b) using btree",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select index_name, index_type from information_schema.statistics where table_name = 't' and seq_in_index = 1 order by index_name",
+				Expected: []sql.Row{{"ab", "BTREE"}, {"bt", "BTREE"}, {"cs", "COLUMNSTORE"}, {"cs2", "COLUMNSTORE"},
+					{"cs3", "COLUMNSTORE"}, {"PRIMARY", "BTREE"}},
+			},
+			{
+				Query: "show create table t",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `a` int,\n" +
+					"  `b` varchar(10),\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `ab` (`a`,`b`),\n" +
+					"  KEY `bt` (`b`),\n" +
+					"  KEY `cs` (`b`,`a`) USING COLUMNSTORE COMMENT 'for scans',\n" +
+					"  KEY `cs2` (`a`) USING COLUMNSTORE,\n" +
+					"  KEY `cs3` (`b`) USING COLUMNSTORE\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+			{
+				Query:    "select sum(a), count(b) from t",
+				Expected: []sql.Row{{float64(30), 2}},
+			},
+			{
+				Query:    "select id from t where a = 20",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "create index if not exists cs2 on t (a) using columnstore",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "create index cs2 on t (b) using columnstore",
+				ExpectedErr: sql.ErrDuplicateKey,
+			},
+			{
+				// foreign keys get an index of their own, since a columnstore index can't look up their rows
+				Query:    "create table child (id int primary key, ta int, key cs (ta) using columnstore, constraint fk foreign key (ta) references t (id))",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "select index_name, index_type from information_schema.statistics where table_name = 'child' order by index_name",
+				Expected: []sql.Row{{"cs", "COLUMNSTORE"}, {"fk", "BTREE"}, {"PRIMARY", "BTREE"}},
+			},
+			{
+				Query:       "insert into child values (1, 3)",
+				ExpectedErr: sql.ErrForeignKeyChildViolation,
+			},
+		},
+	},
+	{
+		Name: "columnstore index is maintained on writes",
+		SetUpScript: []string{
+			"create table t (pk1 int, pk2 varchar(10), a int, b varchar(20), c int, primary key (pk1, pk2))",
+			"insert into t values (1, 'x', 1, 'one', 100), (2, 'y', 2, 'two', 200), (3, 'z', 3, 'three', 300)",
+			"create index cs using columnstore on t (a, b)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "insert into t values (0, 'w', 0, 'zero', 0), (4, 'v', 4, 'four', 400)",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				Query:    "update t set a = a * 10 where pk1 >= 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 3, Info: plan.UpdateInfo{Matched: 3, Updated: 3}}}},
+			},
+			{
+				Query:    "delete from t where pk1 = 1",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "replace into t values (3, 'z', 33, 'thirty-three', 0)",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				Query:    "select pk1, pk2, a, b from t order by pk1",
+				Expected: []sql.Row{{0, "w", 0, "zero"}, {2, "y", 20, "two"}, {3, "z", 33, "thirty-three"}, {4, "v", 40, "four"}},
+			},
+			{
+				Query:    "select sum(a), count(b) from t",
+				Expected: []sql.Row{{float64(93), 4}},
+			},
+			{
+				Query:            "call dolt_commit('-Am', 'writes')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select a from t as of 'HEAD' order by pk1",
+				Expected: []sql.Row{{0}, {20}, {33}, {40}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index is merged",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b int)",
+			"insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3)",
+			"create index cs using columnstore on t (a, b)",
+			"call dolt_commit('-Am', 'base')",
+			"call dolt_branch('other')",
+			"insert into t values (4, 4, 4)",
+			"update t set a = 10 where id = 1",
+			"call dolt_commit('-am', 'main')",
+			"call dolt_checkout('other')",
+			"delete from t where id = 2",
+			"update t set b = 30 where id = 3",
+			"insert into t values (5, 5, 5)",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select id, a, b from t order by id",
+				Expected: []sql.Row{{1, 10, 1}, {3, 3, 30}, {4, 4, 4}, {5, 5, 5}},
+			},
+			{
+				Query:    "select sum(a), sum(b) from t",
+				Expected: []sql.Row{{float64(22), float64(40)}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index is merged with schema changes",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b int)",
+			"insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3)",
+			"create index cs using columnstore on t (a, b)",
+			"call dolt_commit('-Am', 'base')",
+			"call dolt_branch('other')",
+			"alter table t add column c int default 7",
+			"update t set a = 10 where id = 1",
+			"call dolt_commit('-am', 'main')",
+			"call dolt_checkout('other')",
+			"insert into t values (4, 4, 4)",
+			"update t set b = 30 where id = 3",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select id, a, b, c from t order by id",
+				Expected: []sql.Row{{1, 10, 1, 7}, {2, 2, 2, 7}, {3, 3, 30, 7}, {4, 4, 4, 7}},
+			},
+			{
+				Query:    "select sum(a), sum(b) from t",
+				Expected: []sql.Row{{float64(19), float64(37)}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index is built on tables with rows and dropped",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b int)",
+			"insert into t values (1, 1, 1), (2, 2, 2)",
+			"call dolt_commit('-Am', 't')",
+			"create index cs using columnstore on t (b)",
+			"call dolt_commit('-Am', 'index')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select b from t order by id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "select statement from dolt_patch('HEAD~1', 'HEAD') where diff_type = 'schema'",
+				Expected: []sql.Row{{"CREATE INDEX `cs` USING COLUMNSTORE ON `t` (`b`);"}},
+			},
+			{
+				Query:    "drop index cs on t",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:       "drop index cs on t",
+				ExpectedErr: sql.ErrCantDropFieldOrKey,
+			},
+			{
+				Query:    "select statement from dolt_patch('HEAD', 'WORKING') where diff_type = 'schema'",
+				Expected: []sql.Row{{"ALTER TABLE `t` DROP INDEX `cs`;"}},
+			},
+			{
+				Query:    "show index from t",
+				Expected: []sql.Row{{"t", 0, "PRIMARY", 1, "id", nil, 0, nil, nil, "", "BTREE", "", "", "YES", nil}},
+			},
+			{
+				Query:    "select b from t order by id",
+				Expected: []sql.Row{{1}, {2}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index patches apply",
+		SetUpScript: []string{
+			"create table t (id int primary key, a int, b int)",
+			"call dolt_commit('-Am', 't')",
+			"call dolt_branch('other')",
+			"create table t2 (id int primary key, a int, key cs (a) using columnstore)",
+			"create index cs using columnstore on t (a, b)",
+			"call dolt_commit('-Am', 'indexes')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select statement from dolt_patch('HEAD~1', 'HEAD') where diff_type = 'schema' order by statement_order",
+				Expected: []sql.Row{
+					{"CREATE INDEX `cs` USING COLUMNSTORE ON `t` (`a`,`b`);"},
+					{"CREATE TABLE `t2` (\n" +
+						"  `id` int NOT NULL,\n" +
+						"  `a` int,\n" +
+						"  PRIMARY KEY (`id`),\n" +
+						"  KEY `cs` (`a`) USING COLUMNSTORE\n" +
+						") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+				},
+			},
+			{
+				Query:            "call dolt_checkout('other')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query: "call dolt_apply_patch('CREATE INDEX `cs` USING COLUMNSTORE ON `t` (`a`,`b`);\n" +
+					"CREATE TABLE `t2` (`id` int NOT NULL, `a` int, PRIMARY KEY (`id`), KEY `cs` (`a`) USING COLUMNSTORE);\n')",
+				Expected: []sql.Row{{2, 0, 0}},
+			},
+			{
+				Query:    "select table_name, index_name, index_type from information_schema.statistics where index_name = 'cs' order by table_name",
+				Expected: []sql.Row{{"t", "cs", "COLUMNSTORE"}, {"t", "cs", "COLUMNSTORE"}, {"t2", "cs", "COLUMNSTORE"}},
+			},
+			{
+				Query:            "call dolt_commit('-Am', 'patch')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "call dolt_apply_patch('ALTER TABLE `t` DROP INDEX `cs`;')",
+				Expected: []sql.Row{{1, 0, 0}},
+			},
+			{
+				Query:    "select count(*) from information_schema.statistics where table_name = 't' and index_name = 'cs'",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "columnstore index errors",
+		SetUpScript: []string{
+			"create table keyless (a int, b int)",
+			"create table t (id int primary key, a int)",
+			"create index cs using columnstore on t (a)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "create index cs using columnstore on keyless (a)",
+				ExpectedErrStr: "columnstore indexes are not supported on tables without a primary key",
+			},
+			{
+				Query:          "create index pkonly using columnstore on t (id)",
+				ExpectedErrStr: "columnstore index pkonly must include a column that's not part of the primary key",
+			},
+			{
+				Query:          "alter table t drop primary key",
+				ExpectedErrStr: "columnstore indexes are not supported on tables without a primary key",
+			},
+			{
+				Query:    "select count(*) from t",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var DoltDeletedRefsTests = []queries.ScriptTest{
+	{
+		Name: "deleted branches and tags are listed in dolt_deleted_refs and can be restored",
+		SetUpScript: []string{
+			"create table t (pk int primary key)",
+			"call dolt_commit('-Am', 'create t')",
+			"call dolt_branch('feature')",
+			"call dolt_tag('-m', 'first release', 'v1')",
+			"set @main = hashof('main')",
+			"insert into t values (1)",
+			"call dolt_commit('-am', 'insert 1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_deleted_refs",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "call dolt_branch('-d', 'feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_tag('-d', 'v1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name, type, hash = @main, datediff(expires_at, deleted_at) from dolt_deleted_refs order by name",
+				Expected: []sql.Row{{"feature", "branch", true, 30}, {"v1", "tag", true, 30}},
+			},
+			{
+				Query:    "call dolt_branch('--restore', 'feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name, hash = @main from dolt_branches order by name",
+				Expected: []sql.Row{{"feature", true}, {"main", false}},
+			},
+			{
+				Query:    "call dolt_checkout('feature')",
+				Expected: []sql.Row{{0, "Switched to branch 'feature'"}},
+			},
+			{
+				Query:    "select count(*) from t",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_tag('--restore', 'v1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select tag_name, tag_hash = @main, message from dolt_tags",
+				Expected: []sql.Row{{"v1", true, "first release"}},
+			},
+			{
+				Query:    "select * from dolt_deleted_refs",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "restoring a branch uses its most recent deletion",
+		SetUpScript: []string{
+			"call dolt_branch('feature')",
+			"call dolt_branch('-d', 'feature')",
+			"call dolt_commit('--allow-empty', '-m', 'empty')",
+			"call dolt_branch('feature')",
+			"call dolt_branch('-d', 'feature')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(*) from dolt_deleted_refs where name = 'feature'",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_branch('--restore', 'feature')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select hash = hashof('main') from dolt_branches where name = 'feature'",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select count(*) from dolt_deleted_refs where name = 'feature'",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "renamed branches aren't deleted refs",
+		SetUpScript: []string{
+			"call dolt_branch('feature')",
+			"call dolt_branch('-m', 'feature', 'renamed')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select name from dolt_branches order by name",
+				Expected: []sql.Row{{"main"}, {"renamed"}},
+			},
+			{
+				Query:    "select * from dolt_deleted_refs",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "deleted refs aren't kept when dolt_deleted_refs_retention_days is 0",
+		SetUpScript: []string{
+			"call dolt_branch('b1')",
+			"call dolt_branch('b2')",
+			"call dolt_branch('-d', 'b1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select name from dolt_deleted_refs",
+				Expected: []sql.Row{{"b1"}},
+			},
+			{
+				Query:    "set @@global.dolt_deleted_refs_retention_days = 0",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				// Deleting a ref also prunes the deleted refs that expired
+				Query:    "call dolt_branch('-d', 'b2')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_deleted_refs",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "call dolt_branch('--restore', 'b2')",
+				ExpectedErrStr: "no deleted ref found for branch 'b2'",
+			},
+			{
+				Query:    "set @@global.dolt_deleted_refs_retention_days = 30",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+		},
+	},
+	{
+		Name: "restore errors",
+		SetUpScript: []string{
+			"call dolt_branch('feature')",
+			"call dolt_branch('-d', 'feature')",
+			"call dolt_branch('feature')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_branch('--restore', 'feature')",
+				ExpectedErrStr: "fatal: A branch named 'feature' already exists.",
+			},
+			{
+				Query:          "call dolt_branch('--restore', 'nope')",
+				ExpectedErrStr: "no deleted ref found for branch 'nope'",
+			},
+			{
+				Query:          "call dolt_branch('--restore')",
+				ExpectedErrStr: "error: invalid usage",
+			},
+			{
+				Query:          "call dolt_tag('--restore', 'nope')",
+				ExpectedErrStr: "no deleted ref found for tag 'nope'",
+			},
+			{
+				Query:          "call dolt_tag('--restore', '-d', 'nope')",
+				ExpectedErrStr: "delete and restore options are incompatible",
+			},
+			{
+				Query:          "insert into dolt_deleted_refs (name) values ('feature')",
+				ExpectedErrStr: "table doesn't support INSERT INTO",
+			},
+		},
+	},
+}
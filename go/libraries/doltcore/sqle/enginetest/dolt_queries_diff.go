@@ -48,6 +48,8 @@ var DiffSystemTableScriptTests = []queries.ScriptTest{
 					{"dolt_diff_foo", 0, "from_pks", 1, "from_id", nil, int64(0), nil, nil, "YES", "BTREE", "", "", "YES", nil},
 					{"dolt_diff_foo", 1, "to_commit", 1, "to_commit", nil, int64(0), nil, nil, "YES", "BTREE", "", "", "YES", nil},
 					{"dolt_diff_foo", 1, "from_commit", 1, "from_commit", nil, int64(0), nil, nil, "YES", "BTREE", "", "", "YES", nil},
+					{"dolt_diff_foo", 0, "to_commit_to_pks", 1, "to_commit", nil, int64(0), nil, nil, "YES", "BTREE", "", "", "YES", nil},
+					{"dolt_diff_foo", 0, "to_commit_to_pks", 2, "to_id", nil, int64(0), nil, nil, "YES", "BTREE", "", "", "YES", nil},
 				},
 			},
 		},
@@ -818,6 +820,47 @@ var DiffSystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "lookups of commits and keys on dolt_diff",
+		SetUpScript: []string{
+			"create table orders (id int primary key, customer_id int, total int, key (customer_id))",
+			"insert into orders values (1, 5, 10), (2, 6, 20), (3, 5, 30)",
+			"call dolt_commit_hash_out(@c1, '-Am', 'orders')",
+			"update orders set total = 11 where id = 1",
+			"update orders set customer_id = 5 where id = 2",
+			"delete from orders where id = 3",
+			"insert into orders values (4, 7, 1)",
+			"call dolt_commit_hash_out(@c2, '-am', 'changes')",
+			"update orders set customer_id = 5 where id = 4",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// rows whose other columns changed are found through the index too
+				Query:    "select to_id, to_total, from_customer_id, diff_type from dolt_diff_orders where to_commit = @c2 and to_customer_id = 5 order by to_id",
+				Expected: []sql.Row{{1, 11, 5, "modified"}, {2, 20, 6, "modified"}},
+			},
+			{
+				Query:    "select to_id, diff_type from dolt_diff_orders where to_customer_id = 5 order by to_id, diff_type",
+				Expected: []sql.Row{{1, "added"}, {1, "modified"}, {2, "modified"}, {3, "added"}, {4, "modified"}},
+			},
+			{
+				Query:    "select from_id, diff_type from dolt_diff_orders where from_customer_id = 5 order by from_id",
+				Expected: []sql.Row{{1, "modified"}, {3, "removed"}},
+			},
+			{
+				Query:    "select from_id, diff_type from dolt_diff_orders where to_customer_id is null",
+				Expected: []sql.Row{{3, "removed"}},
+			},
+			{
+				Query:    "select to_id, to_total, diff_type from dolt_diff_orders where to_commit = @c2 and to_id in (1, 4)",
+				Expected: []sql.Row{{1, 11, "modified"}, {4, 1, "added"}},
+			},
+			{
+				Query:    "select to_id, diff_type from dolt_diff_orders where to_commit in (@c1, @c2) and to_customer_id in (5, 6) order by to_id, diff_type",
+				Expected: []sql.Row{{1, "added"}, {1, "modified"}, {2, "added"}, {2, "modified"}, {3, "added"}},
+			},
+		},
+	},
 }
 
 var Dolt1DiffSystemTableScripts = []queries.ScriptTest{
@@ -919,6 +962,38 @@ var DiffTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_diff: --cell-diff shows line diffs of text and json cells",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk INT PRIMARY KEY, body TEXT, doc JSON, title VARCHAR(20));",
+			"INSERT INTO t VALUES (1, 'one\\ntwo\\nthree\\n', '{\"a\": 1, \"b\": [1, 2]}', 'first'), (2, 'x', NULL, 'second');",
+			"CALL DOLT_COMMIT('-Am', 'create table t');",
+			"SET @C1 = HASHOF('HEAD');",
+			"UPDATE t SET body = 'one\\n2\\nthree\\n', doc = '{\"a\": 2, \"b\": [1, 2]}' WHERE pk = 1;",
+			"UPDATE t SET title = 'changed' WHERE pk = 2;",
+			"INSERT INTO t VALUES (3, 'new', NULL, 'third');",
+			"CALL DOLT_COMMIT('-am', 'update t');",
+			"SET @C2 = HASHOF('HEAD');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT to_pk, diff_type, cell_diff->>'$.body', cell_diff->>'$.doc', json_length(cell_diff) FROM dolt_diff('--cell-diff', @C1, @C2, 't') ORDER BY to_pk;",
+				Expected: []sql.Row{
+					{1, "modified", "@@ -1,3 +1,3 @@\n one\n-two\n+2\n three", "-$.a: 1\n+$.a: 2", 2},
+					{2, "modified", nil, nil, nil},
+					{3, "added", nil, nil, nil},
+				},
+			},
+			{
+				Query:    "SELECT to_pk, cell_diff->>'$.body' FROM dolt_diff('--cell-diff', '--skinny', @C1, @C2, 't') WHERE to_pk = 1;",
+				Expected: []sql.Row{{1, "@@ -1,3 +1,3 @@\n one\n-two\n+2\n three"}},
+			},
+			{
+				Query:       "SELECT cell_diff FROM dolt_diff(@C1, @C2, 't');",
+				ExpectedErr: sql.ErrColumnNotFound,
+			},
+		},
+	},
 	{
 		Name: "dolt_diff: SELECT * skinny schema visibility",
 		SetUpScript: []string{
@@ -2720,6 +2795,150 @@ inner join t as of @Commit3 on rows_unmodified = t.pk;`,
 	},
 }
 
+var DiffGroupByTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"set @Commit1 = '';",
+			"call dolt_commit_hash_out(@Commit1, '-am', 'creating table t');",
+			"insert into t values (1, 'one');",
+			"set @Commit2 = '';",
+			"call dolt_commit_hash_out(@Commit2, '-am', 'inserting into t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 'c1', 'extra');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 123);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 'doesnotexist', 'c1');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 'doesnotexist');",
+				ExpectedErr: sql.ErrTableColumnNotFound,
+			},
+			{
+				Query:          "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', LOWER('c1'));",
+				ExpectedErrStr: "Invalid argument to dolt_diff_group_by: lower('c1') – only literal values supported",
+			},
+		},
+	},
+	{
+		Name: "counts changed rows by column value",
+		SetUpScript: []string{
+			"create table customers (id int primary key, country varchar(20), name varchar(20));",
+			"insert into customers values (1, 'US', 'a'), (2, 'US', 'b'), (3, 'FR', 'c'), (4, NULL, 'd'), (5, 'DE', 'e');",
+			"call dolt_commit('-Am', 'creating table customers');",
+			"set @Commit1 = hashof('HEAD');",
+			"update customers set name = 'A' where id in (1, 3);",
+			"delete from customers where id = 2;",
+			"insert into customers values (6, 'DE', 'f'), (7, NULL, 'g'), (8, 'JP', 'h');",
+			"update customers set country = 'DE' where id = 4;",
+			"call dolt_commit('-am', 'updating customers');",
+			"set @Commit2 = hashof('HEAD');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				// the row whose country changed from NULL to DE is deleted from one group and added to the other
+				Query: "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 'customers', 'country');",
+				Expected: []sql.Row{
+					{nil, 1, 0, 1},
+					{"DE", 2, 0, 0},
+					{"FR", 0, 1, 0},
+					{"JP", 1, 0, 0},
+					{"US", 0, 1, 1},
+				},
+			},
+			{
+				Query:    "SELECT country, rows_added FROM dolt_diff_group_by('HEAD~..HEAD', 'customers', 'COUNTRY') where rows_added > 0 and country is not null;",
+				Expected: []sql.Row{{"DE", 2}, {"JP", 1}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit2, @Commit1, 'customers', 'country') where country = 'US';",
+				Expected: []sql.Row{{"US", 1, 1, 0}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 'customers', 'id') where id < 3;",
+				Expected: []sql.Row{{1, 0, 1, 0}, {2, 0, 0, 1}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit2, @Commit2, 'customers', 'country');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT sum(rows_added), sum(rows_modified), sum(rows_deleted) from dolt_diff_group_by(@Commit1, @Commit2, 'customers', 'country');",
+				Expected: []sql.Row{{float64(4), float64(2), float64(2)}},
+			},
+		},
+	},
+	{
+		Name: "groups tables with virtual columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int as (c + 1) virtual, c int, s int as (c * 2) stored);",
+			"insert into t (pk, c) values (1, 1), (2, 2), (3, 3);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"set @Commit1 = hashof('HEAD');",
+			"update t set c = 20 where pk = 2;",
+			"insert into t (pk, c) values (4, 3);",
+			"call dolt_commit('-am', 'changing t');",
+			"set @Commit2 = hashof('HEAD');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 'c');",
+				Expected: []sql.Row{{2, 0, 0, 1}, {3, 1, 0, 0}, {20, 1, 0, 0}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 's');",
+				Expected: []sql.Row{{4, 0, 0, 1}, {6, 1, 0, 0}, {40, 1, 0, 0}},
+			},
+			{
+				Query:          "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 'v');",
+				ExpectedErrStr: "cannot group the diff of t by virtual column v",
+			},
+		},
+	},
+	{
+		Name: "groups across schema changes and keyless tables",
+		SetUpScript: []string{
+			"create table t (pk int primary key, region int);",
+			"insert into t values (1, 10), (2, 20);",
+			"create table k (region varchar(10), v int);",
+			"insert into k values ('east', 1), ('east', 1);",
+			"call dolt_commit('-Am', 'creating tables');",
+			"set @Commit1 = hashof('HEAD');",
+			"alter table t modify column region bigint;",
+			"insert into t values (3, 10);",
+			"delete from t where pk = 2;",
+			"insert into k values ('east', 1), ('west', 2);",
+			"delete from k where v = 1 limit 2;",
+			"call dolt_commit('-Am', 'changing tables');",
+			"set @Commit2 = hashof('HEAD');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 't', 'region');",
+				Expected: []sql.Row{{10, 1, 1, 0}, {20, 0, 0, 1}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_group_by(@Commit1, @Commit2, 'k', 'region');",
+				Expected: []sql.Row{{"east", 0, 0, 1}, {"west", 1, 0, 0}},
+			},
+		},
+	},
+}
+
 var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 	{
 		Name: "invalid arguments",
@@ -5961,6 +6180,33 @@ var CommitDiffSystemTableScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "lookups of secondary index columns on dolt_commit_diff",
+		SetUpScript: []string{
+			"create table orders (id int primary key, customer_id int, total int, key (customer_id))",
+			"insert into orders values (1, 5, 10), (2, 6, 20), (3, 5, 30)",
+			"call dolt_commit_hash_out(@c1, '-Am', 'orders')",
+			"update orders set total = 11 where id = 1",
+			"update orders set customer_id = 5 where id = 2",
+			"delete from orders where id = 3",
+			"insert into orders values (4, 7, 1)",
+			"call dolt_commit_hash_out(@c2, '-am', 'changes')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select to_id, to_total, diff_type from dolt_commit_diff_orders where from_commit = @c1 and to_commit = @c2 and to_customer_id = 5 order by to_id",
+				Expected: []sql.Row{{1, 11, "modified"}, {2, 20, "modified"}},
+			},
+			{
+				Query:    "select from_id, diff_type from dolt_commit_diff_orders where from_commit = @c1 and to_commit = @c2 and from_customer_id = 5 order by from_id",
+				Expected: []sql.Row{{1, "modified"}, {3, "removed"}},
+			},
+			{
+				Query:    "select to_id, diff_type from dolt_commit_diff_orders where from_commit = @c1 and to_commit = @c2 and to_customer_id > 6 order by to_id",
+				Expected: []sql.Row{{4, "added"}},
+			},
+		},
+	},
 }
 
 var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
@@ -6101,66 +6347,66 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1);",
 				Expected: []sql.Row{
-					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
-					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", nil},
+					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0);",
 				Expected: []sql.Row{
-					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
-					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", nil},
+					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			// Compare diffs with explicit table names
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'employees');",
 				Expected: []sql.Row{
-					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
+					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'employees');",
 				Expected: []sql.Row{
-					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'inventory');",
 				Expected: []sql.Row{
-					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'inventory');",
 				Expected: []sql.Row{
-					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
+					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'trips');",
 				Expected: []sql.Row{
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'trips');",
 				Expected: []sql.Row{
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'vacations');",
 				Expected: []sql.Row{
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'vacations');",
 				Expected: []sql.Row{
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", nil},
 				},
 			},
 			// Compare two different commits, get expected results
@@ -6172,6 +6418,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6183,6 +6430,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6194,6 +6442,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6205,6 +6454,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6216,6 +6466,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6227,6 +6478,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6238,6 +6490,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6249,6 +6502,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6260,6 +6514,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6271,6 +6526,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6283,6 +6539,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6294,6 +6551,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6305,6 +6563,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6316,6 +6575,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6327,6 +6587,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6338,6 +6599,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						nil,
 					},
 				},
 			},
@@ -6396,6 +6658,60 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "renamed columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a int, b varchar(20), c int);",
+			"insert into t values (1, 10, 'one', 100), (2, 20, 'two', 200), (3, 30, 'three', 300), (4, 40, 'four', 400), " +
+				"(5, 50, 'five', 500), (6, 60, 'six', 600), (7, 70, 'seven', 700), (8, 80, 'eight', 800), " +
+				"(9, 90, 'nine', 900), (10, 100, 'ten', 1000);",
+			"call dolt_commit('-Am', 'create t');",
+			"alter table t rename column a to a2;",
+			"call dolt_commit('-am', 'rename a');",
+			"alter table t add column b2 varchar(20);",
+			"update t set b2 = b;",
+			"alter table t drop column b;",
+			"alter table t drop column c, add column c2 int;",
+			"call dolt_commit('-am', 'replace b and c');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select renamed_columns from dolt_schema_diff('HEAD~2', 'HEAD~1', 't');",
+				Expected: []sql.Row{{gmstypes.MustJSON(`{"a": "a2"}`)}},
+			},
+			{
+				// b2 has the values of b, while c2 has none of the values of c
+				Query:    "select renamed_columns from dolt_schema_diff('HEAD~1', 'HEAD', 't');",
+				Expected: []sql.Row{{gmstypes.MustJSON(`{"b": "b2"}`)}},
+			},
+			{
+				Query:    "select renamed_columns from dolt_schema_diff('HEAD~2', 'HEAD', 't');",
+				Expected: []sql.Row{{gmstypes.MustJSON(`{"a": "a2", "b": "b2"}`)}},
+			},
+			{
+				Query:    "select renamed_columns from dolt_schema_diff('HEAD', 'HEAD~2', 't');",
+				Expected: []sql.Row{{gmstypes.MustJSON(`{"a2": "a", "b2": "b"}`)}},
+			},
+		},
+	},
+	{
+		Name: "renamed columns aren't matched by value in too few rows",
+		SetUpScript: []string{
+			"create table t (pk int primary key, b varchar(20));",
+			"insert into t values (1, 'one'), (2, 'two'), (3, 'three');",
+			"call dolt_commit('-Am', 'create t');",
+			"alter table t add column b2 varchar(20);",
+			"update t set b2 = b;",
+			"alter table t drop column b;",
+			"call dolt_commit('-am', 'replace b');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select renamed_columns from dolt_schema_diff('HEAD~1', 'HEAD', 't');",
+				Expected: []sql.Row{{nil}},
+			},
+		},
+	},
 }
 
 var DoltDatabaseCollationScriptTests = []queries.ScriptTest{
@@ -0,0 +1,310 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/matview"
+)
+
+var MaterializedViewsScripts = []queries.ScriptTest{
+	{
+		Name: "filtered projection is refreshed on commit",
+		SetUpScript: []string{
+			"create table items (id int primary key, name varchar(20), price int, qty int)",
+			"insert into items values (1, 'apple', 5, 10), (2, 'pear', 15, 2), (3, 'plum', 20, 3)",
+			"call dolt_commit('-Am', 'items')",
+			"call dolt_create_materialized_view('expensive', 'select id, name, price * qty as total from items where price > 10')",
+			"call dolt_commit('-Am', 'expensive')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from expensive order by id",
+				Expected: []sql.Row{{2, "pear", 30}, {3, "plum", 60}},
+			},
+			{
+				Query:    "select * from dolt_materialized_views",
+				Expected: []sql.Row{{"expensive", "select id, name, price * qty as total from items where price > 10"}},
+			},
+			{
+				Query:            "update items set price = 12 where id = 1",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "delete from items where id = 3",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "insert into items values (4, 'fig', 30, 1), (5, 'lime', 1, 1)",
+				SkipResultsCheck: true,
+			},
+			{
+				// views are refreshed when their table is committed
+				Query:    "select * from expensive order by id",
+				Expected: []sql.Row{{2, "pear", 30}, {3, "plum", 60}},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'changes')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select * from expensive order by id",
+				Expected: []sql.Row{{1, "apple", 120}, {2, "pear", 30}, {4, "fig", 30}},
+			},
+			{
+				Query:    "select * from expensive as of 'HEAD~1' order by id",
+				Expected: []sql.Row{{2, "pear", 30}, {3, "plum", 60}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "grouped sums and counts are refreshed on commit",
+		SetUpScript: []string{
+			"create table sales (id int primary key, region varchar(10), amount decimal(10,2), note varchar(20))",
+			"insert into sales values (1, 'east', 10.50, 'a'), (2, 'east', 4.50, null), (3, 'west', 7.00, 'b'), (4, 'north', null, null)",
+			"call dolt_create_materialized_view('totals', 'select region, sum(amount) as total, count(*) as n, count(note) as notes from sales group by region')",
+			"call dolt_commit('-Am', 'sales')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select region, total, n, notes from totals order by region",
+				Expected: []sql.Row{{"east", "15.00", 2, 1}, {"north", nil, 1, 0}, {"west", "7.00", 1, 1}},
+			},
+			{
+				Query:            "update sales set region = 'west' where id = 1",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "delete from sales where id = 2",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "insert into sales values (5, 'north', 1.25, 'c'), (6, 'south', 2.00, null)",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "call dolt_commit('-am', 'changes')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select region, total, n, notes from totals order by region",
+				Expected: []sql.Row{{"north", "1.25", 2, 1}, {"south", "2.00", 1, 0}, {"west", "17.50", 2, 2}},
+			},
+			{
+				Query:            "update sales set amount = null where id = 5",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "call dolt_commit('-am', 'null amount')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select region, total, n, notes from totals where region = 'north'",
+				Expected: []sql.Row{{"north", nil, 2, 1}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "groups follow the collation of the grouped column",
+		SetUpScript: []string{
+			"create table t (id int primary key, k varchar(10) collate utf8mb4_0900_ai_ci, v double)",
+			"insert into t values (1, 'a', 1), (2, 'A', 2), (3, 'b', 4)",
+			"call dolt_create_materialized_view('by_k', 'select k, sum(v) as s from t group by k')",
+			"call dolt_commit('-Am', 't')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select lower(k), s from by_k order by 1",
+				Expected: []sql.Row{{"a", 3.0}, {"b", 4.0}},
+			},
+			{
+				Query:            "delete from t where id in (1, 2)",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "call dolt_commit('-am', 'delete')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select k, s from by_k",
+				Expected: []sql.Row{{"b", 4.0}},
+			},
+		},
+	},
+	{
+		Name: "views are refreshed on demand in the working set",
+		SetUpScript: []string{
+			"create table t (id int primary key, v int)",
+			"insert into t values (1, 1), (2, 2)",
+			"call dolt_create_materialized_view('big', 'select * from t where v > 1')",
+			"call dolt_create_materialized_view('cnt', 'select count(*) as n from t')",
+			"call dolt_commit('-Am', 't')",
+			"insert into t values (3, 3)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_refresh_materialized_view('big')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from big order by id",
+				Expected: []sql.Row{{2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select n from cnt",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_refresh_materialized_view()",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select n from cnt",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				// a full refresh rebuilds the view after its table was changed directly
+				Query:            "delete from big",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "call dolt_refresh_materialized_view('--full', 'big')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from big order by id",
+				Expected: []sql.Row{{2, 2}, {3, 3}},
+			},
+			{
+				Query:          "call dolt_refresh_materialized_view('nope')",
+				ExpectedErrStr: "materialized view nope does not exist",
+			},
+		},
+	},
+	{
+		Name: "views are refreshed when merges are committed",
+		SetUpScript: []string{
+			"create table t (id int primary key, g int, v int)",
+			"insert into t values (1, 1, 10), (2, 2, 20)",
+			"call dolt_create_materialized_view('sums', 'select g, sum(v) as s from t group by g')",
+			"call dolt_commit('-Am', 't')",
+			"call dolt_checkout('-b', 'other')",
+			"insert into t values (3, 1, 5)",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"insert into t values (4, 2, 1)",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "call dolt_merge('other')",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "select g, s from sums order by g",
+				Expected: []sql.Row{{1, "15"}, {2, "21"}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dropping a view drops its table",
+		SetUpScript: []string{
+			"create table t (id int primary key, v int)",
+			"call dolt_create_materialized_view('mv', 'select v from t')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select v from t')",
+				ExpectedErr: sql.ErrTableAlreadyExists,
+			},
+			{
+				Query:    "call dolt_drop_materialized_view('mv')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "select * from mv",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:    "select count(*) from dolt_materialized_views",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:       "call dolt_drop_materialized_view('mv')",
+				ExpectedErr: matview.ErrViewNotFound,
+			},
+		},
+	},
+	{
+		Name: "unsupported queries are rejected",
+		SetUpScript: []string{
+			"create table t (id int primary key, g int, v int, w varchar(10))",
+			"create table u (id int primary key)",
+			"create table keyless (v int)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select t.v from t join u on t.id = u.id')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select g, avg(v) from t group by g')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select g, sum(w) from t group by g')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select g, sum(v) from t group by g having sum(v) > 1')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select distinct g from t')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select g, v, count(*) from t group by g')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select v from t where v in (select id from u)')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:       "call dolt_create_materialized_view('mv', 'select v from keyless')",
+				ExpectedErr: matview.ErrUnsupportedQuery,
+			},
+			{
+				Query:    "show tables",
+				Expected: []sql.Row{{"keyless"}, {"t"}, {"u"}},
+			},
+		},
+	},
+}
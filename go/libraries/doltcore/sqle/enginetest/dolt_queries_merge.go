@@ -2496,6 +2496,261 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "Merge applies their row changes to a column we recreated under a new name",
+		SetUpScript: []string{
+			"CREATE TABLE t (id int primary key, v varchar(20), n int);",
+			"INSERT INTO t VALUES (1, 'one', 1), (2, 'two', 2), (3, 'three', 3), (5, 'five', 5), (6, 'six', 6), " +
+				"(7, 'seven', 7), (8, 'eight', 8), (9, 'nine', 9), (10, 'ten', 10), (11, 'eleven', 11);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET v = 'TWO' WHERE id = 2;",
+			"INSERT INTO t VALUES (4, 'four', 4);",
+			"CREATE INDEX idx_v ON t (v);",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"ALTER TABLE t ADD COLUMN label varchar(20) AFTER id;",
+			"UPDATE t SET label = v;",
+			"ALTER TABLE t DROP COLUMN v;",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('--detect-renames', 'right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t WHERE id <= 5 ORDER BY id;",
+				Expected: []sql.Row{{1, "one", 1}, {2, "TWO", 2}, {3, "three", 3}, {4, "four", 4}, {5, "five", 5}},
+			},
+			{
+				Query:    "SELECT id FROM t WHERE label = 'TWO';",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query: "SHOW CREATE TABLE t;",
+				Expected: []sql.Row{{"t", "CREATE TABLE `t` (\n" +
+					"  `id` int NOT NULL,\n" +
+					"  `label` varchar(20),\n" +
+					"  `n` int,\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `idx_v` (`label`)\n" +
+					") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin"}},
+			},
+		},
+	},
+	{
+		Name: "Merge of a recreated column conflicts with their changes to the same rows",
+		SetUpScript: []string{
+			"SET dolt_allow_commit_conflicts = on;",
+			"CREATE TABLE t (id int primary key, v int);",
+			"INSERT INTO t VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6), (7, 7), (8, 8), (9, 9), (10, 10), (11, 11);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET v = 100 WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"ALTER TABLE t ADD COLUMN w int;",
+			"UPDATE t SET w = v;",
+			"ALTER TABLE t DROP COLUMN v;",
+			"UPDATE t SET w = 1000 WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('--detect-renames', 'right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT base_v, our_w, their_v FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 1000, 100}},
+			},
+		},
+	},
+	{
+		Name: "Merge doesn't match a recreated column by value without --detect-renames",
+		SetUpScript: []string{
+			"SET dolt_allow_commit_conflicts = on;",
+			"CREATE TABLE t (id int primary key, v int);",
+			"INSERT INTO t VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6), (7, 7), (8, 8), (9, 9), (10, 10), (11, 11);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET v = 100 WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"ALTER TABLE t ADD COLUMN w int;",
+			"UPDATE t SET w = v;",
+			"ALTER TABLE t DROP COLUMN v;",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT base_v, our_w, their_v FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 1, 100}},
+			},
+		},
+	},
+	{
+		Name: "Merge doesn't match a recreated column by value with too few rows to compare",
+		SetUpScript: []string{
+			"CREATE TABLE t (id int primary key, v varchar(20));",
+			"INSERT INTO t VALUES (1, 'one'), (2, 'two');",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"INSERT INTO t VALUES (3, 'three');",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"ALTER TABLE t ADD COLUMN label varchar(20);",
+			"UPDATE t SET label = v;",
+			"ALTER TABLE t DROP COLUMN v;",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('--detect-renames', 'right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY id;",
+				Expected: []sql.Row{{1, "one"}, {2, "two"}, {3, nil}},
+			},
+		},
+	},
+	{
+		Name: "Merge re-keys our rows when their side adds a key column with a default",
+		SetUpScript: []string{
+			"CREATE TABLE t (id int primary key, v int);",
+			"INSERT INTO t VALUES (1, 1), (2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"ALTER TABLE t ADD COLUMN region varchar(10) NOT NULL DEFAULT 'us', DROP PRIMARY KEY, ADD PRIMARY KEY (id, region);",
+			"INSERT INTO t VALUES (1, 10, 'eu');",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET v = 20 WHERE id = 2;",
+			"INSERT INTO t VALUES (3, 3);",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY id, region;",
+				Expected: []sql.Row{{1, 10, "eu"}, {1, 1, "us"}, {2, 20, "us"}, {3, 3, "us"}},
+			},
+			{
+				Query:          "INSERT INTO t VALUES (3, 30, 'us');",
+				ExpectedErrStr: "duplicate primary key given: [3,us]",
+			},
+		},
+	},
+	{
+		Name: "Merge re-keys their rows when our side widens or reorders the key",
+		SetUpScript: []string{
+			"CREATE TABLE t (a int, b int, v int, PRIMARY KEY (a, b), KEY v_idx (v));",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET v = 10 WHERE a = 1;",
+			"INSERT INTO t VALUES (3, 3, 3);",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"ALTER TABLE t MODIFY COLUMN a bigint, DROP PRIMARY KEY, ADD PRIMARY KEY (b, a);",
+			"INSERT INTO t VALUES (4000000000, 4, 4);",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY a;",
+				Expected: []sql.Row{{1, 1, 10}, {2, 2, 2}, {3, 3, 3}, {4000000000, 4, 4}},
+			},
+			{
+				Query:    "SELECT a FROM t WHERE v = 10;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT column_name FROM information_schema.key_column_usage WHERE table_name = 't' ORDER BY ordinal_position;",
+				Expected: []sql.Row{{"b"}, {"a"}},
+			},
+		},
+	},
+	{
+		Name: "Merge of re-keyed rows conflicts only where both sides changed the same row",
+		SetUpScript: []string{
+			"SET dolt_allow_commit_conflicts = on;",
+			"CREATE TABLE t (id int primary key, v int);",
+			"INSERT INTO t VALUES (1, 1), (2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"ALTER TABLE t ADD COLUMN k int NOT NULL DEFAULT 0, DROP PRIMARY KEY, ADD PRIMARY KEY (id, k);",
+			"UPDATE t SET v = 10 WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET v = 100 WHERE id = 1;",
+			"UPDATE t SET v = 200 WHERE id = 2;",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT base_id, base_k, base_v, our_id, our_k, our_v, their_id, their_k, their_v FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 0, 1, 1, 0, 100, 1, 0, 10}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY id;",
+				Expected: []sql.Row{{1, 100, 0}, {2, 200, 0}},
+			},
+		},
+	},
+	{
+		Name: "Merge errors when our rows collide under their new key",
+		SetUpScript: []string{
+			"CREATE TABLE t (a int, b int, v int, PRIMARY KEY (a, b));",
+			"INSERT INTO t VALUES (1, 1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'setup');",
+
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"ALTER TABLE t DROP PRIMARY KEY, ADD PRIMARY KEY (a);",
+			"CALL DOLT_COMMIT('-am', 'right commit');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO t VALUES (1, 2, 2);",
+			"CALL DOLT_COMMIT('-am', 'left commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('right');",
+				ExpectedErrStr: "error: cannot merge because the primary key of table t changed, and rows of the other side collide under the new key ( 1 )",
+			},
+		},
+	},
 	{
 		Name:        "`Delete from table` should keep artifacts - conflicts",
 		SetUpScript: createConflictsSetupScript,
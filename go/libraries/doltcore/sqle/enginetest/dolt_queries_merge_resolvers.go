@@ -0,0 +1,220 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var MergeResolversScripts = []queries.ScriptTest{
+	{
+		Name: "resolver procedure resolves every conflict and the merge is committed",
+		SetUpScript: []string{
+			"create table prices (id int primary key, price int, note varchar(20))",
+			"insert into prices values (1, 10, 'base'), (2, 20, 'base')",
+			`create procedure average_price(base json, ours json, theirs json)
+begin
+  select ours->>'$.id', (ours->>'$.price' + theirs->>'$.price') div 2, concat(ours->>'$.note', '+', theirs->>'$.note');
+end`,
+			"insert into dolt_merge_resolvers values ('prices', 'average_price')",
+			"call dolt_commit('-Am', 'prices')",
+			"call dolt_checkout('-b', 'other')",
+			"update prices set price = price + 10, note = 'other'",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update prices set price = price * 3, note = 'main'",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from prices order by id",
+				Expected: []sql.Row{{1, 25, "main+other"}, {2, 45, "main+other"}},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1",
+				Expected: []sql.Row{{"Merge branch 'other' into main"}},
+			},
+		},
+	},
+	{
+		Name: "conflicts the resolver returns no row for remain",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table prices (id int primary key, price int)",
+			"insert into prices values (1, 10), (2, 20)",
+			`create procedure resolve_small(base json, ours json, theirs json)
+begin
+  if ours->>'$.id' = 1 then
+    select ours->>'$.id', greatest(ours->>'$.price', theirs->>'$.price');
+  end if;
+end`,
+			"insert into dolt_merge_resolvers values ('prices', 'resolve_small')",
+			"call dolt_commit('-Am', 'prices')",
+			"call dolt_checkout('-b', 'other')",
+			"update prices set price = price + 5",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update prices set price = price + 1",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select our_id, our_price, their_price from dolt_conflicts_prices",
+				Expected: []sql.Row{{2, 21, 25}},
+			},
+			{
+				Query:    "select * from prices order by id",
+				Expected: []sql.Row{{1, 15}, {2, 21}},
+			},
+		},
+	},
+	{
+		Name: "resolver receives NULL for a deleted row and can restore it",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table prices (id int primary key, price int)",
+			"insert into prices values (1, 10)",
+			`create procedure keep_theirs(base text, ours text, theirs text)
+begin
+  if ours is null and base is not null then
+    select theirs->>'$.id', theirs->>'$.price';
+  end if;
+end`,
+			"insert into dolt_merge_resolvers values ('prices', 'keep_theirs')",
+			"call dolt_commit('-Am', 'prices')",
+			"call dolt_checkout('-b', 'other')",
+			"update prices set price = 11",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"delete from prices",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from prices",
+				Expected: []sql.Row{{1, 11}},
+			},
+			{
+				Query:    "select * from dolt_conflicts_prices",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "resolver returning the wrong number of columns fails the merge",
+		SetUpScript: []string{
+			"create table prices (id int primary key, price int)",
+			"insert into prices values (1, 10)",
+			"create procedure bad_resolver(base json, ours json, theirs json) select 1",
+			"insert into dolt_merge_resolvers values ('prices', 'bad_resolver')",
+			"call dolt_commit('-Am', 'prices')",
+			"call dolt_checkout('-b', 'other')",
+			"update prices set price = 11",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update prices set price = 12",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_merge('other')",
+				ExpectedErrStr: "merge resolver bad_resolver for table prices failed: returned 1 columns, expected 2",
+			},
+		},
+	},
+	{
+		Name: "resolvers resolve the conflicts of a cherry-pick",
+		SetUpScript: []string{
+			"create table prices (id int primary key, price int)",
+			"insert into prices values (1, 10)",
+			"create procedure take_max(base json, ours json, theirs json) select ours->>'$.id', greatest(cast(ours->>'$.price' as signed), cast(theirs->>'$.price' as signed))",
+			"insert into dolt_merge_resolvers values ('prices', 'take_max')",
+			"call dolt_commit('-Am', 'prices')",
+			"call dolt_checkout('-b', 'other')",
+			"update prices set price = 15",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update prices set price = 12",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_cherry_pick('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, 0}},
+			},
+			{
+				Query:    "select * from prices",
+				Expected: []sql.Row{{1, 15}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1",
+				Expected: []sql.Row{{"other"}},
+			},
+		},
+	},
+	{
+		Name: "resolvers resolve the conflicts of a revert",
+		SetUpScript: []string{
+			"create table prices (id int primary key, price int)",
+			"insert into prices values (1, 10)",
+			"create procedure take_max(base json, ours json, theirs json) select ours->>'$.id', greatest(cast(ours->>'$.price' as signed), cast(theirs->>'$.price' as signed))",
+			"insert into dolt_merge_resolvers values ('prices', 'take_max')",
+			"call dolt_commit('-Am', 'prices')",
+			"update prices set price = 5",
+			"call dolt_commit('-am', 'lower')",
+			"update prices set price = 7",
+			"call dolt_commit('-am', 'raise')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_revert('HEAD~1')",
+				Expected: []sql.Row{{doltCommit, 0, 0, 0}},
+			},
+			{
+				Query:    "select * from prices",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:    "select count(*) from dolt_status",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+}
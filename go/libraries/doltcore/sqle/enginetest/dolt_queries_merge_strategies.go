@@ -0,0 +1,356 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var MergeStrategiesScripts = []queries.ScriptTest{
+	{
+		Name: "sum strategy applies the changes of both sides to counters",
+		SetUpScript: []string{
+			"create table inventory (sku varchar(20) primary key, qty int, price decimal(10, 2))",
+			"insert into inventory values ('apple', 100, 1.00), ('pear', 50, 2.00)",
+			"insert into dolt_merge_strategies values ('inventory', 'qty', 'sum', null)",
+			"call dolt_commit('-Am', 'inventory')",
+			"call dolt_checkout('-b', 'east')",
+			"update inventory set qty = qty - 10 where sku = 'apple'",
+			"update inventory set qty = qty + 5, price = 2.50 where sku = 'pear'",
+			"insert into inventory values ('plum', 7, 3.00)",
+			"call dolt_commit('-am', 'east sales')",
+			"call dolt_checkout('main')",
+			"update inventory set qty = qty - 25 where sku = 'apple'",
+			"update inventory set qty = qty + 20 where sku = 'pear'",
+			"insert into inventory values ('plum', 3, 3.00)",
+			"call dolt_commit('-am', 'west sales')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('east')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				// Rows inserted on both sides count from zero
+				Query:    "select sku, qty, price from inventory order by sku",
+				Expected: []sql.Row{{"apple", 65, "1.00"}, {"pear", 75, "2.50"}, {"plum", 10, "3.00"}},
+			},
+		},
+	},
+	{
+		Name: "sum strategy adds up equal changes made on both sides, but takes rows inserted the same way once",
+		SetUpScript: []string{
+			"create table counters (id int primary key, n int, label varchar(20))",
+			"insert into counters values (1, 100, 'a'), (2, 100, 'b')",
+			"insert into dolt_merge_strategies values ('counters', 'n', 'sum', null)",
+			"call dolt_commit('-Am', 'counters')",
+			"call dolt_checkout('-b', 'other')",
+			"update counters set n = n + 10 where id = 1",
+			"update counters set n = n + 10, label = 'other' where id = 2",
+			"insert into counters values (3, 5, 'c'), (4, 5, 'd')",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update counters set n = n + 10 where id = 1",
+			"update counters set n = n + 10 where id = 2",
+			"insert into counters values (3, 5, 'c'), (4, 7, 'd')",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				// Row 3 was inserted the same way on both sides, so it's the same row. Row 4 was inserted with different
+				// counts, which are added up from zero.
+				Query:    "select * from counters order by id",
+				Expected: []sql.Row{{1, 120, "a"}, {2, 120, "other"}, {3, 5, "c"}, {4, 12, "d"}},
+			},
+		},
+	},
+	{
+		Name: "max, min, and table-wide strategies",
+		SetUpScript: []string{
+			"create table scores (id int primary key, high int, low int, note varchar(20))",
+			"insert into scores values (1, 10, 10, 'base')",
+			"insert into dolt_merge_strategies values ('scores', '*', 'max', null), ('scores', 'low', 'min', null)",
+			"call dolt_commit('-Am', 'scores')",
+			"call dolt_checkout('-b', 'other')",
+			"update scores set high = 30, low = 5, note = 'other'",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update scores set high = 20, low = 7, note = 'main'",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from scores",
+				Expected: []sql.Row{{1, 30, 5, "other"}},
+			},
+		},
+	},
+	{
+		Name: "last_writer_wins takes the value from the side with the newer order column",
+		SetUpScript: []string{
+			"create table profiles (id int primary key, email varchar(50), city varchar(50), updated_at datetime)",
+			"insert into profiles values (1, 'a@x.com', 'Paris', '2026-01-01 00:00:00'), (2, 'b@x.com', 'Rome', '2026-01-01 00:00:00')",
+			"insert into dolt_merge_strategies values ('profiles', '*', 'last_writer_wins', 'updated_at')",
+			"call dolt_commit('-Am', 'profiles')",
+			"call dolt_checkout('-b', 'other')",
+			"update profiles set email = 'a@other.com', city = 'Lyon', updated_at = '2026-03-01 00:00:00' where id = 1",
+			"update profiles set email = 'b@other.com', updated_at = '2026-02-01 00:00:00' where id = 2",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update profiles set email = 'a@main.com', updated_at = '2026-02-01 00:00:00' where id = 1",
+			"update profiles set email = 'b@main.com', city = 'Milan', updated_at = '2026-03-01 00:00:00' where id = 2",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				// Cells changed on only one side still merge as usual
+				Query: "select id, email, city, updated_at from profiles order by id",
+				Expected: []sql.Row{
+					{1, "a@other.com", "Lyon", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+					{2, "b@main.com", "Milan", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	},
+	{
+		Name: "union strategy merges JSON arrays as sets",
+		SetUpScript: []string{
+			"create table posts (id int primary key, tags json)",
+			`insert into posts values (1, '["a", "b", "c"]')`,
+			"insert into dolt_merge_strategies values ('posts', 'tags', 'union', null)",
+			"call dolt_commit('-Am', 'posts')",
+			"call dolt_checkout('-b', 'other')",
+			`update posts set tags = '["a", "c", "d"]'`,
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			`update posts set tags = '["a", "b", "e", {"x": 1}]'`,
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				// b was removed on the right, and c on the left
+				Query:    "select tags from posts",
+				Expected: []sql.Row{{types.MustJSON(`["a", "e", {"x": 1}, "d"]`)}},
+			},
+		},
+	},
+	{
+		Name: "cells a strategy can't resolve are still conflicts",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table t (pk int primary key, c1 int, c2 varchar(20), updated_at datetime)",
+			"insert into t values (1, 1, 'base', '2026-01-01'), (2, 100, 'base', '2026-01-01')",
+			"insert into dolt_merge_strategies values ('t', 'c1', 'sum', null), ('t', 'c2', 'last_writer_wins', 'updated_at')",
+			"call dolt_commit('-Am', 't')",
+			"call dolt_checkout('-b', 'other')",
+			"update t set c1 = 2147483600 where pk = 2",
+			"update t set c2 = 'other' where pk = 1",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update t set c1 = 2147483000 where pk = 2",
+			"update t set c2 = 'main' where pk = 1",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				// The sum overflows, and updated_at didn't change on either side
+				Query:    "select our_pk, our_c1, their_c1, our_c2, their_c2 from dolt_conflicts_t order by our_pk",
+				Expected: []sql.Row{{1, 1, 1, "main", "other"}, {2, 2147483000, 2147483600, "base", "base"}},
+			},
+		},
+	},
+	{
+		Name: "strategies are taken from our side of the merge",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table t (pk int primary key, c int)",
+			"insert into t values (1, 10)",
+			"call dolt_commit('-Am', 't')",
+			"call dolt_checkout('-b', 'other')",
+			"insert into dolt_merge_strategies values ('t', 'c', 'max', null)",
+			"update t set c = 30",
+			"call dolt_commit('-Am', 'other')",
+			"call dolt_checkout('main')",
+			"update t set c = 20",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "call dolt_merge('--abort')",
+				Expected: []sql.Row{{"", 0, 0, "merge aborted"}},
+			},
+			{
+				Query:    "insert into dolt_merge_strategies values ('t', 'c', 'max', null)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-Am', 'strategies on main')",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t",
+				Expected: []sql.Row{{1, 30}},
+			},
+		},
+	},
+	{
+		Name: "lines strategy merges changes to different lines of text",
+		SetUpScript: []string{
+			"create table docs (id int primary key, body longtext)",
+			"insert into docs values (1, concat('first\\n', repeat('filler\\n', 2000), 'last\\n')), (2, 'a\\nb\\nc\\n')",
+			"insert into dolt_merge_strategies values ('docs', 'body', 'lines', null)",
+			"call dolt_commit('-Am', 'docs')",
+			"call dolt_checkout('-b', 'other')",
+			"update docs set body = replace(body, 'last', 'their last') where id = 1",
+			"update docs set body = 'a\\nb\\nc\\nd\\n' where id = 2",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update docs set body = replace(body, 'first', 'our first') where id = 1",
+			"update docs set body = 'z\\na\\nb\\nc\\n' where id = 2",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select body = concat('our first\\n', repeat('filler\\n', 2000), 'their last\\n') from docs where id = 1",
+				Expected: []sql.Row{{true}},
+			},
+			{
+				Query:    "select body from docs where id = 2",
+				Expected: []sql.Row{{"z\na\nb\nc\nd\n"}},
+			},
+		},
+	},
+	{
+		Name: "lines strategy conflicts on lines changed on both sides",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table docs (id int primary key, title varchar(20), body text, key (title))",
+			"insert into docs values (1, 'one', 'a\\nb\\nc\\n'), (2, 'two', 'x\\n')",
+			"insert into dolt_merge_strategies values ('docs', 'body', 'lines', null)",
+			"call dolt_commit('-Am', 'docs')",
+			"call dolt_checkout('-b', 'other')",
+			"update docs set title = 'uno', body = 'a\\ntheirs\\nc\\n' where id = 1",
+			"update docs set body = null where id = 2",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update docs set body = 'a\\nours\\nc\\n' where id = 1",
+			"update docs set body = 'y\\n' where id = 2",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				// Our rows are left as they are, without the changes of the conflicting rows from their side
+				Query: "select id, title, body from docs order by id",
+				Expected: []sql.Row{
+					{1, "one", "a\nours\nc\n"},
+					{2, "two", "y\n"},
+				},
+			},
+			{
+				Query:    "select id from docs where title = 'one'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query: "select our_id, our_title, our_body, their_title, their_body from dolt_conflicts_docs order by our_id",
+				Expected: []sql.Row{
+					{1, "one", "a\nours\nc\n", "uno", "a\ntheirs\nc\n"},
+					{2, "two", "y\n", "two", nil},
+				},
+			},
+		},
+	},
+	{
+		Name: "sum strategy on a column that isn't numeric fails the merge",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int, label varchar(20))",
+			"insert into t values (1, 10, 'a')",
+			"insert into dolt_merge_strategies values ('t', 'label', 'sum', null)",
+			"call dolt_commit('-Am', 't')",
+			"call dolt_checkout('-b', 'other')",
+			"update t set c = 30",
+			"call dolt_commit('-am', 'other')",
+			"call dolt_checkout('main')",
+			"update t set c = 20",
+			"call dolt_commit('-am', 'main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_merge('other')",
+				ExpectedErrStr: "dolt_merge_strategies row ('t', 'label'): merge strategy sum needs a numeric column, but t.label is varchar(20)",
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies rejects invalid rules",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "insert into dolt_merge_strategies values ('t', 'c', 'average', null)",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:       "insert into dolt_merge_strategies values ('t', 'c', 'last_writer_wins', null)",
+				ExpectedErr: sql.ErrCheckConstraintViolated,
+			},
+			{
+				Query:    "insert into dolt_merge_strategies values ('t', 'c', 'last_writer_wins', 'updated_at')",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select * from dolt_merge_strategies",
+				Expected: []sql.Row{{"t", "c", "last_writer_wins", "updated_at"}},
+			},
+		},
+	},
+}
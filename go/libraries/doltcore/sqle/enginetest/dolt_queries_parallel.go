@@ -0,0 +1,181 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// parallelSetup creates a table with enough rows to be split into many partitions
+var parallelSetup = []string{
+	"set dolt_query_parallelism = 4",
+	"create table d (x int primary key)",
+	"insert into d values (0), (1), (2), (3), (4), (5), (6), (7), (8), (9)",
+	"create table t (id int primary key, g int, v int, s varchar(20), amt decimal(10,2))",
+	"insert into t select id, (id * 3) % 7, id % 1000, if(id % 10 = 0, null, concat('s', id % 13)), id / 100 " +
+		"from (select a.x * 1000 + b.x * 100 + c.x * 10 + e.x as id from d a, d b, d c, d e) as ids",
+}
+
+var ParallelQueryScripts = []queries.ScriptTest{
+	{
+		Name: "parallel execution is off by default",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select @@dolt_query_parallelism",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name:        "parallel scans return rows in the order of a serial scan",
+		SetUpScript: parallelSetup,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select @@dolt_query_parallelism",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				Query:    "select id from t where id % 1000 = 7",
+				Expected: []sql.Row{{7}, {1007}, {2007}, {3007}, {4007}, {5007}, {6007}, {7007}, {8007}, {9007}},
+			},
+			{
+				Query:    "select x.id, x.s from t as x where x.id % 2500 = 1",
+				Expected: []sql.Row{{1, "s1"}, {2501, "s5"}, {5001, "s9"}, {7501, "s0"}},
+			},
+			{
+				Query:    "select id from t limit 3",
+				Expected: []sql.Row{{0}, {1}, {2}},
+			},
+			{
+				Query:    "select id from t where v = 999 limit 2",
+				Expected: []sql.Row{{999}, {1999}},
+			},
+			{
+				Query:    "select id from t where id > 9996",
+				Expected: []sql.Row{{9997}, {9998}, {9999}},
+			},
+			{
+				Query:    "select count(*) from t where id in (select x from d)",
+				Expected: []sql.Row{{10}},
+			},
+		},
+	},
+	{
+		Name:        "warnings of parallel scans are added in the order of a serial scan",
+		SetUpScript: parallelSetup,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select id from t where id % 2500 = 1 and s + 0 = 0",
+				Expected: []sql.Row{{1}, {2501}, {5001}, {7501}},
+			},
+			{
+				// show warnings lists the most recent warning first
+				Query: "show warnings",
+				Expected: []sql.Row{
+					{"Warning", 1292, "Truncated incorrect double value: 's0'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's9'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's5'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's1'"},
+				},
+			},
+			{
+				Query:    "select count(*), sum(s + 0) from t where id % 2500 = 1",
+				Expected: []sql.Row{{4, 0.0}},
+			},
+			{
+				Query: "show warnings",
+				Expected: []sql.Row{
+					{"Warning", 1292, "Truncated incorrect double value: 's0'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's9'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's5'"},
+					{"Warning", 1292, "Truncated incorrect double value: 's1'"},
+				},
+			},
+		},
+	},
+	{
+		Name:        "parallel aggregations merge the partial results of partitions",
+		SetUpScript: parallelSetup,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(*), count(s), sum(v), min(v), max(v), cast(sum(amt) as char), min(s), max(s) from t",
+				Expected: []sql.Row{{10000, 9000, 4995000.0, 0, 999, "499950.00", "s0", "s9"}},
+			},
+			{
+				Query:    "select count(*) from t where v > 500",
+				Expected: []sql.Row{{4990}},
+			},
+			{
+				// groups are returned in the order they're first seen, like a serial aggregation
+				Query: "select g, count(*), sum(v), max(s) from t group by g",
+				Expected: []sql.Row{
+					{0, 1429, 713142.0, "s9"},
+					{3, 1429, 713571.0, "s9"},
+					{6, 1429, 714000.0, "s9"},
+					{2, 1429, 714429.0, "s9"},
+					{5, 1428, 713858.0, "s9"},
+					{1, 1428, 713286.0, "s9"},
+					{4, 1428, 712714.0, "s9"},
+				},
+			},
+			{
+				Query: "select g, count(*), sum(v), min(v) from t where v > 500 group by g",
+				Expected: []sql.Row{
+					{5, 714, 535358.0, 501},
+					{1, 713, 534573.0, 501},
+					{4, 712, 533787.0, 501},
+					{0, 712, 534000.0, 501},
+					{3, 712, 534213.0, 501},
+					{6, 713, 534927.0, 501},
+					{2, 714, 535642.0, 501},
+				},
+			},
+			{
+				Query:    "select count(*), sum(v), max(s) from t where v < 0",
+				Expected: []sql.Row{{0, nil, nil}},
+			},
+			{
+				Query:    "select g, count(*) from t where v < 0 group by g",
+				Expected: []sql.Row{},
+			},
+			{
+				// these aggregations can't be merged from partial results and aren't run in parallel
+				Query:    "select avg(v), count(distinct g), sum(distinct g), group_concat(id) from t where id % 2000 = 1",
+				Expected: []sql.Row{{1.0, 5, 18.0, "1,2001,4001,6001,8001"}},
+			},
+		},
+	},
+	{
+		Name:        "writes read their rows with parallel scans",
+		SetUpScript: parallelSetup,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "update t set v = v + 1 where v = 999",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 10, Info: plan.UpdateInfo{Matched: 10, Updated: 10}}}},
+			},
+			{
+				Query:    "delete from t where id % 1000 = 0",
+				Expected: []sql.Row{{types.NewOkResult(10)}},
+			},
+			{
+				Query:    "select count(*), sum(v), max(v) from t",
+				Expected: []sql.Row{{9990, 4995010.0, 1000}},
+			},
+		},
+	},
+}
@@ -51,8 +51,8 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_backups order by name;",
 				Expected: []sql.Row{
-					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
-					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
+					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
+					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
 				},
 			},
 			{
@@ -63,9 +63,9 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_backups order by name",
 				Expected: []sql.Row{
-					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
-					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
-					{"bak3", "invalid://url", gmstypes.JSONDocument{Val: map[string]interface{}{}}},
+					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
+					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
+					{"bak3", "invalid://url", gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
 				},
 			},
 			{
@@ -90,6 +90,7 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 								"aws-creds-profile": "<profile>",
 							},
 						},
+						nil, nil, nil, nil, nil,
 					},
 					{
 						"aws_partial",
@@ -100,6 +101,7 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 								"aws-region":        "eu-west-1",
 							},
 						},
+						nil, nil, nil, nil, nil,
 					},
 				},
 			},
@@ -151,8 +153,8 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_backups order by name;",
 				Expected: []sql.Row{
-					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
-					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
+					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
+					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
 				},
 			},
 			{
@@ -162,7 +164,7 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_backups order by name;",
 				Expected: []sql.Row{
-					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
+					{"bak1", fileUrl("dolt_backup1"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
 				},
 			},
 			{
@@ -176,7 +178,7 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_backups;",
 				Expected: []sql.Row{
-					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}},
+					{"bak2", fileUrl("dolt_backup2"), gmstypes.JSONDocument{Val: map[string]interface{}{}}, nil, nil, nil, nil, nil},
 				},
 			},
 			{
@@ -338,11 +340,11 @@ var DoltBackupProcedureScripts = []queries.ScriptTest{
 			},
 			{
 				Query:          "call dolt_backup('restore');",
-				ExpectedErrStr: "usage: dolt_backup('restore', 'remote_url', 'new_db_name', ['--force'], ['--aws-region=<region>'], ['--aws-creds-type=<type>'], ['--aws-creds-file=<file>'], ['--aws-creds-profile=<profile>'])",
+				ExpectedErrStr: "usage: dolt_backup('restore', 'remote_url', 'new_db_name', ['--force'], ['--to-time=<time>'], ['--to-working-set=<hash>'], ['--restore-point=<name>'], ['--aws-region=<region>'], ['--aws-creds-type=<type>'], ['--aws-creds-file=<file>'], ['--aws-creds-profile=<profile>'])",
 			},
 			{
 				Query:          fmt.Sprintf("call dolt_backup('restore', '%s');", fileUrl("dolt_backup1")),
-				ExpectedErrStr: "usage: dolt_backup('restore', 'remote_url', 'new_db_name', ['--force'], ['--aws-region=<region>'], ['--aws-creds-type=<type>'], ['--aws-creds-file=<file>'], ['--aws-creds-profile=<profile>'])",
+				ExpectedErrStr: "usage: dolt_backup('restore', 'remote_url', 'new_db_name', ['--force'], ['--to-time=<time>'], ['--to-working-set=<hash>'], ['--restore-point=<name>'], ['--aws-region=<region>'], ['--aws-creds-type=<type>'], ['--aws-creds-file=<file>'], ['--aws-creds-profile=<profile>'])",
 			},
 			{
 				Query:          fmt.Sprintf("call dolt_backup('restore', '%s', 'restored_db');", fileUrl("dolt_backup2")),
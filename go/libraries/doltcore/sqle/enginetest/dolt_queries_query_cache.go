@@ -0,0 +1,185 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var QueryCacheScripts = []queries.ScriptTest{
+	{
+		Name: "results are not cached by default",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 10), (2, 20)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "select count(*) from dolt_query_cache",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "results are served from the cache until a table they read changes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"create table u (pk int primary key, w int)",
+			"insert into t values (1, 10), (2, 20)",
+			"insert into u values (1, 100)",
+			"set @@global.dolt_query_cache_size = 1048576",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "SELECT *   FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "select query, result_rows, hits from dolt_query_cache",
+				Expected: []sql.Row{{"select * from t order by pk asc", uint64(2), uint64(1)}},
+			},
+			{
+				Query:    "select t.v, u.w from t join u on t.pk = u.pk",
+				Expected: []sql.Row{{10, 100}},
+			},
+			{
+				Query:    "insert into u values (2, 200)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "select t.v, u.w from t join u on t.pk = u.pk order by t.pk",
+				Expected: []sql.Row{{10, 100}, {20, 200}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				// the results of queries that read u before it changed are no longer used, but the query of t is
+				Query: "select query, result_rows, hits from dolt_query_cache order by query",
+				Expected: []sql.Row{
+					{"select * from t order by pk asc", uint64(2), uint64(2)},
+					{"select t.v, u.w from t join u on t.pk = u.pk", uint64(1), uint64(0)},
+					{"select t.v, u.w from t join u on t.pk = u.pk order by t.pk asc", uint64(2), uint64(0)},
+				},
+			},
+			{
+				Query:    "update t set v = 11 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 11}, {2, 20}},
+			},
+		},
+	},
+	{
+		Name: "results that depend on more than the tables read are not cached",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 10)",
+			"call dolt_commit('-Am', 'create t')",
+			"set @x = 1",
+			"set @@global.dolt_query_cache_size = 1048576",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select pk, now() > '2000-01-01' from t",
+				Expected: []sql.Row{{1, true}},
+			},
+			{
+				Query:    "select pk, @x from t",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select pk, active_branch() from t",
+				Expected: []sql.Row{{1, "main"}},
+			},
+			{
+				Query:    "select pk from t where v > (select rand() from dual where pk = t.pk)",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1",
+				Expected: []sql.Row{{"create t"}},
+			},
+			{
+				Query:    "select * from t for update",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:    "select 1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select query from dolt_query_cache",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "results larger than the limits of the cache are not cached",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v varchar(100))",
+			"insert into t values (1, repeat('a', 100)), (2, repeat('b', 100))",
+			"set @@global.dolt_query_cache_size = 1048576",
+			"set @@global.dolt_query_cache_max_result_size = 200",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select pk, length(v) from t order by pk",
+				Expected: []sql.Row{{1, 100}, {2, 100}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, strings.Repeat("a", 100)}, {2, strings.Repeat("b", 100)}},
+			},
+			{
+				Query:    "select query from dolt_query_cache",
+				Expected: []sql.Row{{"select pk, length(v) from t order by pk asc"}},
+			},
+			{
+				// shrinking the cache evicts the least recently used results
+				Query:    "select pk from t where pk = 1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "set @@global.dolt_query_cache_size = 100",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "select query from dolt_query_cache",
+				Expected: []sql.Row{{"select pk from t where pk = 1"}},
+			},
+		},
+	},
+}
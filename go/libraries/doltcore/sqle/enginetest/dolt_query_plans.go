@@ -23,12 +23,40 @@ var DoltDiffPlanTests = []queries.QueryPlanTest{
 	{
 		// See https://github.com/dolthub/dolt/issues/11159
 		Query: `select * from dolt_diff_one_pk where to_commit='abc' and to_pk=1`,
-		// A non-unique to_commit index is not a point lookup, so the planner prefers the primary key index.
+		// to_commit and the primary key are looked up together
 		ExpectedPlan: "Filter\n" +
 			" ├─ ((dolt_diff_one_pk.to_commit = 'abc') AND (dolt_diff_one_pk.to_pk = 1))\n" +
 			" └─ IndexedTableAccess(dolt_diff_one_pk)\n" +
-			"     ├─ index: [dolt_diff_one_pk.to_pk]\n" +
-			"     └─ filters: [{[1, 1]}]\n" +
+			"     ├─ index: [dolt_diff_one_pk.to_commit,dolt_diff_one_pk.to_pk]\n" +
+			"     └─ filters: [{[abc, abc], [1, 1]}]\n" +
+			"",
+	},
+	{
+		Query: `select * from dolt_diff_mytable where to_s='first row'`,
+		// secondary indexes are looked up in the table at each commit to find the keys to diff
+		ExpectedPlan: "Filter\n" +
+			" ├─ (dolt_diff_mytable.to_s = 'first row')\n" +
+			" └─ IndexedTableAccess(dolt_diff_mytable)\n" +
+			"     ├─ index: [dolt_diff_mytable.to_s]\n" +
+			"     └─ filters: [{[first row, first row]}]\n" +
+			"",
+	},
+	{
+		Query: `select * from dolt_diff_mytable where to_commit='abc' and to_s='first row'`,
+		ExpectedPlan: "Filter\n" +
+			" ├─ ((dolt_diff_mytable.to_commit = 'abc') AND (dolt_diff_mytable.to_s = 'first row'))\n" +
+			" └─ IndexedTableAccess(dolt_diff_mytable)\n" +
+			"     ├─ index: [dolt_diff_mytable.to_commit,dolt_diff_mytable.to_s]\n" +
+			"     └─ filters: [{[abc, abc], [first row, first row]}]\n" +
+			"",
+	},
+	{
+		Query: `select * from dolt_diff_mytable where from_s>'a'`,
+		ExpectedPlan: "Filter\n" +
+			" ├─ (dolt_diff_mytable.from_s > 'a')\n" +
+			" └─ IndexedTableAccess(dolt_diff_mytable)\n" +
+			"     ├─ index: [dolt_diff_mytable.from_s,dolt_diff_mytable.from_i]\n" +
+			"     └─ filters: [{(a, ∞), [NULL, ∞)}]\n" +
 			"",
 	},
 	{
@@ -112,6 +140,18 @@ var DoltDiffPlanTests = []queries.QueryPlanTest{
 			"     └─ filters: [{(NULL, 1), [10, 10]}]\n" +
 			"",
 	},
+	{
+		Query: `select * from dolt_history_mytable where commit_hash='abc' and s='first row'`,
+		ExpectedPlan: "Project\n" +
+			" ├─ columns: [dolt_history_mytable.i, dolt_history_mytable.s, dolt_history_mytable.commit_hash, dolt_history_mytable.committer, dolt_history_mytable.commit_date]\n" +
+			" └─ Filter\n" +
+			"     ├─ ((dolt_history_mytable.commit_hash = 'abc') AND (dolt_history_mytable.s = 'first row'))\n" +
+			"     └─ IndexedTableAccess(dolt_history_mytable)\n" +
+			"         ├─ index: [dolt_history_mytable.commit_hash,dolt_history_mytable.s]\n" +
+			"         ├─ filters: [{[abc, abc], [first row, first row]}]\n" +
+			"         └─ columns: [i s commit_hash committer commit_date]\n" +
+			"",
+	},
 }
 
 var DoltCommitPlanTests = []queries.QueryPlanTest{
@@ -3275,8 +3275,20 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 				},
 			},
 			{
-				Query:          "commit",
-				ExpectedErrStr: "Cannot commit changes on more than one branch / database",
+				Query:    "commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select * from `mydb/b1`.t1",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select table_name, staged, status from `mydb/b1`.dolt_status",
+				Expected: []sql.Row{{"t1", byte(0), "modified"}},
 			},
 		},
 	},
@@ -3306,6 +3318,43 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 					{types.OkResult{RowsAffected: 1}},
 				},
 			},
+			{
+				Query:    "commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t1",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select * from `mydb/main`.t1",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "committing to more than one branch at a time with @@dolt_transaction_commit",
+		SetUpScript: []string{
+			"create table t1 (a int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'new table')",
+			"call dolt_branch('b1')",
+			"set autocommit = 0",
+			"set dolt_transaction_commit = 1",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "insert into t1 values (1)",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1}},
+				},
+			},
+			{
+				Query: "insert into `mydb/b1`.t1 values (2)",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1}},
+				},
+			},
 			{
 				Query:          "commit",
 				ExpectedErrStr: "Cannot commit changes on more than one branch / database",
@@ -3337,7 +3386,164 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 			},
 			{
 				Query:          "commit",
-				ExpectedErrStr: "Cannot commit changes on more than one branch / database",
+				ExpectedErrStr: dsess.ErrMultipleDatabasesCommit.Error(),
+			},
+		},
+	},
+}
+
+var MultiBranchCommitTransactionTests = []queries.TransactionTest{
+	{
+		Name: "changes to several branches are committed together",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0), (2, 0)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('staging')",
+			"call dolt_branch('prod')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ update `mydb/staging`.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ update `mydb/prod`.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				// a concurrent change that merges cleanly
+				Query:    "/* client b */ update `mydb/prod`.t set v = 2 where pk = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/staging`.t order by pk",
+				Expected: []sql.Row{{1, 0}, {2, 0}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/staging`.t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 0}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/prod`.t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/main`.t order by pk",
+				Expected: []sql.Row{{1, 0}, {2, 0}},
+			},
+		},
+	},
+	{
+		Name: "a conflict on one branch commits none of them",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('staging')",
+			"call dolt_branch('prod')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ update `mydb/staging`.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ update `mydb/prod`.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update `mydb/prod`.t set v = 2 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:          "/* client a */ commit",
+				ExpectedErrStr: sql.ErrLockDeadlock.New(dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/staging`.t",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/prod`.t",
+				Expected: []sql.Row{{1, 2}},
+			},
+			{
+				Query:    "/* client a */ select * from `mydb/staging`.t",
+				Expected: []sql.Row{{1, 0}},
+			},
+		},
+	},
+	{
+		Name: "changes to more than one database can't be committed together",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0)",
+			"call dolt_commit('-Am', 'new table')",
+			"create database db2",
+			"create table db2.t (pk int primary key, v int)",
+			"insert into db2.t values (1, 0)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ update mydb.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ update db2.t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:          "/* client a */ commit",
+				ExpectedErrStr: dsess.ErrMultipleDatabasesCommit.Error(),
+			},
+			{
+				Query:    "/* client b */ select * from mydb.t",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ update mydb.t set v = 2 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from mydb.t",
+				Expected: []sql.Row{{1, 2}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t",
+				Expected: []sql.Row{{1, 0}},
 			},
 		},
 	},
@@ -3731,3 +3937,1035 @@ var AutoIncrementTransactionTests = []queries.TransactionTest{
 		},
 	},
 }
+
+// serializableConflictErr is the error a SERIALIZABLE transaction gets on commit when a concurrent transaction changed
+// rows of |table| that it read
+func serializableConflictErr(table string) string {
+	return sql.ErrLockDeadlock.New("serializable transaction read rows of table " + table +
+		" that were changed by a concurrent transaction: " + dsess.ErrRetryTransaction.Error()).Error()
+}
+
+var SerializableTransactionTests = []queries.TransactionTest{
+	{
+		Name: "write skew is allowed under repeatable read",
+		SetUpScript: []string{
+			"create table doctors (id int primary key, on_call bool)",
+			"insert into doctors values (1, true), (2, true)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client b */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client a */ update doctors set on_call = false where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update doctors set on_call = false where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "write skew is prevented under serializable",
+		SetUpScript: []string{
+			"create table doctors (id int primary key, on_call bool)",
+			"insert into doctors values (1, true), (2, true)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client a */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client b */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client b */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client a */ update doctors set on_call = false where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update doctors set on_call = false where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client b */ commit",
+				ExpectedErrStr: serializableConflictErr("doctors"),
+			},
+			{
+				Query:    "/* client b */ select * from doctors order by id",
+				Expected: []sql.Row{{1, 0}, {2, 1}},
+			},
+			{ // retrying the transaction sees the committed change
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select count(*) from doctors where on_call",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "write skew through a secondary index is prevented under serializable",
+		SetUpScript: []string{
+			"create table doctors (id int primary key, name varchar(20), on_call bool, key (name))",
+			"insert into doctors values (1, 'alice', true), (2, 'bob', true)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client a */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client b */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client c */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client c */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select count(*) from doctors where name in ('alice', 'bob') and on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client b */ select count(*) from doctors where name in ('alice', 'bob') and on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{ // only reads the index, which the update of on_call doesn't change
+				Query:    "/* client c */ select id from doctors where name = 'alice'",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ update doctors set on_call = false where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update doctors set on_call = false where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client c */ insert into doctors values (3, 'carol', true)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client b */ commit",
+				ExpectedErrStr: serializableConflictErr("doctors"),
+			},
+			{
+				Query:    "/* client c */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from doctors order by id",
+				Expected: []sql.Row{{1, "alice", 0}, {2, "bob", 1}, {3, "carol", 1}},
+			},
+		},
+	},
+	{
+		Name: "serializable transactions reading different keys both commit",
+		SetUpScript: []string{
+			"create table doctors (id int primary key, on_call bool)",
+			"insert into doctors values (1, true), (2, true), (3, true)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client a */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client b */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select on_call from doctors where id = 1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ select on_call from doctors where id in (2, 3)",
+				Expected: []sql.Row{{1}, {1}},
+			},
+			{
+				Query:    "/* client a */ update doctors set on_call = false where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update doctors set on_call = false where id = 3",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from doctors order by id",
+				Expected: []sql.Row{{1, 0}, {2, 1}, {3, 0}},
+			},
+		},
+	},
+	{
+		Name: "serializable transactions conflict on rows inserted into a range they read",
+		SetUpScript: []string{
+			"create table bookings (id int primary key, room int, key (room))",
+			"insert into bookings values (1, 100)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client a */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client b */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client c */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client c */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select id from bookings where room = 200",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select id from bookings where room = 200",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client c */ select id from bookings where room = 300",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into bookings values (2, 200)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ insert into bookings values (3, 200)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client c */ insert into bookings values (4, 300)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client b */ commit",
+				ExpectedErrStr: serializableConflictErr("bookings"),
+			},
+			{
+				Query:    "/* client c */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from bookings order by id",
+				Expected: []sql.Row{{1, 100}, {2, 200}, {4, 300}},
+			},
+		},
+	},
+	{
+		Name: "serializable transactions don't validate reads of past revisions",
+		SetUpScript: []string{
+			"create table doctors (id int primary key, on_call bool)",
+			"insert into doctors values (1, true), (2, true)",
+			"call dolt_commit('-Am', 'add doctors')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client a */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "/* client b */ set session transaction isolation level serializable",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select count(*) from doctors as of 'HEAD' where on_call",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client a */ update doctors set on_call = false where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update doctors set on_call = false where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+}
+
+func lockedRowChangedErr(table string) string {
+	return sql.ErrLockDeadlock.New("a row of table " + table + " locked by this transaction was changed by a " +
+		"concurrent transaction: " + dsess.ErrRetryTransaction.Error()).Error()
+}
+
+var LockingReadTransactionTests = []queries.TransactionTest{
+	{
+		Name: "select for update skip locked hands out different jobs",
+		SetUpScript: []string{
+			"create table jobs (id int primary key, status varchar(20), key (status))",
+			"insert into jobs values (1, 'pending'), (2, 'pending'), (3, 'pending')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select id from jobs where status = 'pending' limit 1 for update skip locked",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ select id from jobs where status = 'pending' limit 1 for update skip locked",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client a */ update jobs set status = 'running' where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update jobs set status = 'running' where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select id from jobs where status = 'pending' limit 1 for update skip locked",
+				Expected: []sql.Row{{3}},
+			},
+			{
+				Query:    "/* client a */ select * from jobs order by id",
+				Expected: []sql.Row{{1, "running"}, {2, "running"}, {3, "pending"}},
+			},
+		},
+	},
+	{
+		Name: "select for update nowait fails on a locked row",
+		SetUpScript: []string{
+			"create table accounts (id int primary key, balance int)",
+			"insert into accounts values (1, 100), (2, 100)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from accounts where id = 1 for update",
+				Expected: []sql.Row{{1, 100}},
+			},
+			{
+				Query:          "/* client b */ select * from accounts where id = 1 for update nowait",
+				ExpectedErrStr: dsess.ErrLockNoWait.Error(),
+			},
+			{
+				Query:    "/* client b */ select * from accounts where id = 2 for update nowait",
+				Expected: []sql.Row{{2, 100}},
+			},
+			{
+				// reads that don't lock aren't blocked
+				Query:    "/* client b */ select * from accounts order by id",
+				Expected: []sql.Row{{1, 100}, {2, 100}},
+			},
+			{
+				Query:    "/* client a */ select table_name, lock_data, lock_mode, lock_status from dolt_locks order by lock_data",
+				Expected: []sql.Row{{"accounts", "1", "EXCLUSIVE", "GRANTED"}, {"accounts", "2", "EXCLUSIVE", "GRANTED"}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from accounts where id = 1 for update nowait",
+				Expected: []sql.Row{{1, 100}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select count(*) from dolt_locks",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "select for update returns the latest version of a row committed by the previous lock holder",
+		SetUpScript: []string{
+			"create table counters (id int primary key, val int)",
+			"insert into counters values (1, 10), (2, 20)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from counters where id = 2",
+				Expected: []sql.Row{{2, 20}},
+			},
+			{
+				Query:    "/* client a */ select * from counters where id = 1 for update",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:          "/* client b */ select * from counters where id = 1 for update nowait",
+				ExpectedErrStr: dsess.ErrLockNoWait.Error(),
+			},
+			{
+				Query:    "/* client a */ update counters set val = val + 1 where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from counters where id = 1 for update",
+				Expected: []sql.Row{{1, 11}},
+			},
+			{
+				Query:    "/* client b */ update counters set val = val + 1 where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from counters order by id",
+				Expected: []sql.Row{{1, 12}, {2, 20}},
+			},
+		},
+	},
+	{
+		Name: "select for update of a changed row fails in a transaction with changes",
+		SetUpScript: []string{
+			"create table counters (id int primary key, val int)",
+			"insert into counters values (1, 10), (2, 20)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ update counters set val = val + 1 where id = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ update counters set val = val + 1 where id = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client b */ select * from counters where id = 1 for update",
+				ExpectedErrStr: lockedRowChangedErr("counters"),
+			},
+			{
+				Query:    "/* client b */ select * from counters order by id",
+				Expected: []sql.Row{{1, 11}, {2, 20}},
+			},
+			{
+				Query:    "/* client b */ select count(*) from dolt_locks",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "select for update waits up to the lock wait timeout",
+		SetUpScript: []string{
+			"create table t (id int primary key, val int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "/* client b */ set session dolt_lock_wait_timeout = 0",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t for update",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:          "/* client b */ select * from t for update",
+				ExpectedErrStr: dsess.ErrLockWaitTimeout.Error(),
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from t for update",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},
+	{
+		Name: "lock in share mode",
+		SetUpScript: []string{
+			"create table t (id int primary key, val int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client c */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t lock in share mode",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client b */ select * from t lock in share mode",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:          "/* client c */ select * from t for update nowait",
+				ExpectedErrStr: dsess.ErrLockNoWait.Error(),
+			},
+			{
+				Query:    "/* client c */ select lock_data, lock_mode, lock_status from dolt_locks",
+				Expected: []sql.Row{{"1", "SHARED", "GRANTED"}, {"1", "SHARED", "GRANTED"}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client c */ select * from t for update nowait",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},
+	{
+		Name: "select for update of locks only the tables named",
+		SetUpScript: []string{
+			"create table orders (id int primary key, customer int)",
+			"create table customers (id int primary key, name varchar(20))",
+			"insert into orders values (1, 1)",
+			"insert into customers values (1, 'alice')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select o.id, c.name from orders o join customers c on o.customer = c.id for update of o",
+				Expected: []sql.Row{{1, "alice"}},
+			},
+			{
+				Query:    "/* client a */ select table_name, lock_data from dolt_locks",
+				Expected: []sql.Row{{"orders", "1"}},
+			},
+			{
+				Query:    "/* client b */ select * from customers for update nowait",
+				Expected: []sql.Row{{1, "alice"}},
+			},
+			{
+				Query:          "/* client b */ select * from orders for update nowait",
+				ExpectedErrStr: dsess.ErrLockNoWait.Error(),
+			},
+		},
+	},
+}
+
+var XATransactionTests = []queries.TransactionTest{
+	{
+		Name: "a prepared xa transaction is committed by another session",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0), (2, 0)",
+			"call dolt_commit('-Am', 'new table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ update t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 0}, {2, 0}},
+			},
+			{
+				// a concurrent change that merges cleanly
+				Query:    "/* client b */ update t set v = 2 where pk = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('prepare', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 0}, {2, 2}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa_recover()",
+				Expected: []sql.Row{{int64(1), int64(3), int64(0), "tx1"}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa('commit', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa_recover()",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('commit', 'tx1')",
+				ExpectedErrStr: dsess.ErrXANotFound.Error(),
+			},
+		},
+	},
+	{
+		Name: "a prepared xa transaction commits all of its branches",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('staging')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1', 'branch1', 7)",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ update t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ insert into `mydb/staging`.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1', 'branch1', 7)",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('prepare', 'tx1', 'branch1', 7)",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa_recover('--convert-xid')",
+				Expected: []sql.Row{{int64(7), int64(3), int64(7), "0x7478316272616e636831"}},
+			},
+			{
+				Query:          "/* client b */ call dolt_xa('commit', 'tx1')",
+				ExpectedErrStr: dsess.ErrXANotFound.Error(),
+			},
+			{
+				Query:    "/* client b */ call dolt_xa('commit', 'tx1', 'branch1', 7)",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/staging`.t order by pk",
+				Expected: []sql.Row{{1, 0}, {2, 2}},
+			},
+		},
+	},
+	{
+		Name: "a prepared xa transaction is rolled back",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0)",
+			"call dolt_commit('-Am', 'new table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ insert into t values (2, 0)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('prepare', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client b */ call dolt_xa('start', 'tx1')",
+				ExpectedErrStr: dsess.ErrXADuplicate.Error(),
+			},
+			{
+				Query:    "/* client b */ call dolt_xa('rollback', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa_recover()",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 0}},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('rollback', 'tx1')",
+				ExpectedErrStr: dsess.ErrXANotFound.Error(),
+			},
+		},
+	},
+	{
+		Name: "an xa transaction that conflicts fails to prepare",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0)",
+			"call dolt_commit('-Am', 'new table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ update t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update t set v = 2 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('prepare', 'tx1')",
+				ExpectedErrStr: sql.ErrLockDeadlock.New(dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				Query:    "/* client a */ call dolt_xa_recover()",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('commit', 'tx1')",
+				ExpectedErrStr: dsess.ErrXANotFound.Error(),
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 2}},
+			},
+		},
+	},
+	{
+		Name: "a prepared xa transaction holds its branches until it's committed",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 0), (2, 0)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('other')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ update t set v = 1 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('prepare', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query: "/* client b */ update t set v = 2 where pk = 2",
+				ExpectedErrStr: sql.ErrLockDeadlock.New("branch main of database mydb is held by the prepared XA " +
+					"transaction 'tx1','',1 until it's committed or rolled back: " + dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				Query:    "/* client b */ update `mydb/other`.t set v = 2 where pk = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa('commit', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ update t set v = 2 where pk = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+		},
+	},
+	{
+		Name: "xa statements out of order",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "/* client a */ call dolt_xa('end', 'tx1')",
+				ExpectedErrStr: "XAER_RMFAIL: The command cannot be executed when global transaction is in the NON-EXISTING state (errno 1399) (sqlstate XAE07)",
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('start', '')",
+				ExpectedErrStr: dsess.ErrXAInvalid.Error(),
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('start', 'tx2')",
+				ExpectedErrStr: "XAER_RMFAIL: The command cannot be executed when global transaction is in the ACTIVE state (errno 1399) (sqlstate XAE07)",
+			},
+			{
+				Query:          "/* client b */ call dolt_xa('start', 'tx1')",
+				ExpectedErrStr: dsess.ErrXADuplicate.Error(),
+			},
+			{
+				Query:    "/* client a */ insert into t values (1, 1)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('prepare', 'tx1')",
+				ExpectedErrStr: "XAER_RMFAIL: The command cannot be executed when global transaction is in the ACTIVE state (errno 1399) (sqlstate XAE07)",
+			},
+			{
+				Query:          "/* client a */ commit",
+				ExpectedErrStr: "XAER_RMFAIL: The command cannot be executed when global transaction is in the ACTIVE state (errno 1399) (sqlstate XAE07)",
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('end', 'tx2')",
+				ExpectedErrStr: dsess.ErrXANotFound.Error(),
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "/* client a */ call dolt_xa('commit', 'tx1')",
+				ExpectedErrStr: "XAER_RMFAIL: The command cannot be executed when global transaction is in the IDLE state (errno 1399) (sqlstate XAE07)",
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('commit', '--one-phase', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},
+	{
+		Name: "a prepared xa transaction that changed nothing",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ call dolt_xa('start', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('end', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ call dolt_xa('prepare', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa_recover()",
+				Expected: []sql.Row{{int64(1), int64(3), int64(0), "tx1"}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa('commit', 'tx1')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client b */ call dolt_xa_recover()",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+}
@@ -15,6 +15,7 @@
 package enginetest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -28,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/val"
@@ -145,6 +147,62 @@ func validateIndexConsistency(
 	}
 }
 
+// validateColumnStoreIndex checks that the columnstore index |secondary| holds exactly one entry for each of its value
+// columns for each row of |primary|, in the order they're read by columnstore scans
+func validateColumnStoreIndex(ctx context.Context, sch schema.Schema, def schema.Index, primary, secondary prolly.MapInterface) error {
+	bld, err := index.NewColumnStoreBuilder(sch, def, primary.NodeStore())
+	if err != nil {
+		return err
+	}
+
+	totalSecondaryCount, err := secondary.Count()
+	if err != nil {
+		return err
+	}
+	totalPrimaryCount, err := primary.Count()
+	if err != nil {
+		return err
+	}
+	if totalSecondaryCount != totalPrimaryCount*bld.Columns() {
+		return fmt.Errorf("columnstore index %s has %d entries for %d rows and %d columns",
+			def.Name(), totalSecondaryCount, totalPrimaryCount, bld.Columns())
+	}
+
+	idxIter, err := secondary.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	kd, _ := secondary.Descriptors()
+	for col := 0; col < bld.Columns(); col++ {
+		iter, err := primary.IterAll(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			key, value, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			expKey, expValue, err := bld.EntryFromRow(ctx, col, key, value)
+			if err != nil {
+				return err
+			}
+			idxKey, idxValue, err := idxIter.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(expKey, idxKey) || !bytes.Equal(expValue, idxValue) {
+				return fmt.Errorf("columnstore index %s entry %s does not match expected entry %s",
+					def.Name(), kd.Format(ctx, idxKey), kd.Format(ctx, expKey))
+			}
+		}
+	}
+	return nil
+}
+
 // printIndexContents prints the contents of |prollyMap| to stdout. Intended for use debugging
 // index consistency issues.
 func printIndexContents(ctx context.Context, prollyMap prolly.MapInterface) {
@@ -244,6 +302,10 @@ func validatePkIndex(ctx context.Context, sch schema.Schema, def schema.Index, p
 		return nil
 	}
 
+	if def.IsColumnStore() {
+		return validateColumnStoreIndex(ctx, sch, def, primary, secondary)
+	}
+
 	// Indexes on virtual columns cannot be rebuilt via the method below
 	if isVirtualIndex(def, sch) {
 		return nil
@@ -103,8 +103,11 @@ func ResolveExpression(ctx *sql.Context, tableName string, predicateStr string)
 		if err != nil {
 			return nil, err
 		}
-		// comes out in ContextRootFinalizer, so unwrap it
-		parsed = parsed.Children()[0]
+		// comes out in ContextRootFinalizer, so unwrap it. Queries analyzed while another query runs, such as in a
+		// stored procedure, aren't wrapped.
+		if _, ok := parsed.(*plan.Project); !ok {
+			parsed = parsed.Children()[0]
+		}
 	}
 
 	proj, ok := parsed.(*plan.Project)
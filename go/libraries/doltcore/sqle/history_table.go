@@ -109,7 +109,19 @@ func (ht *HistoryTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 
 	// For index pushdown to work, we need to represent the indexes from the underlying table as belonging to this one
 	// Our results will also not be ordered, so we need to declare them as such
-	return index.DoltHistoryIndexesFromTable(ctx, ht.doltTable.db.Name(), ht.Name(), tbl, ht.doltTable.db.DbData().Ddb)
+	indexes, err := index.DoltHistoryIndexesFromTable(ctx, ht.doltTable.db.Name(), ht.Name(), tbl, ht.doltTable.db.DbData().Ddb)
+	if err != nil {
+		return nil, err
+	}
+
+	// lookups of commit_hash and a key are pushed down together
+	keyIndexes := indexes
+	for _, idx := range keyIndexes {
+		if idx.ID() != index.CommitHashIndexId && !idx.IsFullText() && !idx.IsSpatial() && !idx.IsVector() {
+			indexes = append(indexes, index.NewCommitKeyIndex(index.CommitHashIndexId+"_"+idx.ID(), idx, index.CommitHashIndexId))
+		}
+	}
+	return indexes, nil
 }
 
 func (ht *HistoryTable) IndexedAccess(ctx *sql.Context, lookup sql.IndexLookup) sql.IndexedTable {
@@ -122,47 +134,62 @@ func (ht *HistoryTable) PreciseMatch() bool {
 }
 
 func (ht *HistoryTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	if commitKeyIdx, ok := lookup.Index.(*index.CommitKeyIndex); ok {
+		keyLookup, err := commitKeyIdx.KeyLookup(ctx, lookup)
+		if err != nil {
+			return nil, err
+		}
+		hs, ok := commitKeyIdx.Commits(lookup, 0)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse commit hash lookup: %s", sql.DebugString(ctx, lookup.Ranges))
+		}
+		ht.indexLookup = keyLookup
+		return ht.commitLookupPartitions(ctx, hs)
+	}
 	if lookup.Index.ID() == index.CommitHashIndexId {
 		hs, ok := index.LookupToPointSelectStr(lookup)
 		if !ok {
 			return nil, fmt.Errorf("failed to parse commit hash lookup: %s", sql.DebugString(ctx, lookup.Ranges))
 		}
+		return ht.commitLookupPartitions(ctx, hs)
+	}
+	ht.indexLookup = lookup
+	return ht.Partitions(ctx)
+}
 
-		var hashes []hash.Hash
-		var commits []*doltdb.Commit
-		var metas []*datas.CommitMeta
-		for _, hs := range hs {
-			h, ok := hash.MaybeParse(hs)
-			if !ok {
-				continue
-			}
-			hashes = append(hashes, h)
-
-			cm, err := doltdb.HashToCommit(ctx, ht.doltTable.db.DbData().Ddb.ValueReadWriter(), ht.doltTable.db.DbData().Ddb.NodeStore(), h)
-			if err != nil {
-				return nil, err
-			}
-			commits = append(commits, cm)
-
-			meta, err := cm.GetCommitMeta(ctx)
-			if err != nil {
-				return nil, err
-			}
-			metas = append(metas, meta)
+// commitLookupPartitions returns the partitions of the commits with the hashes |hs|
+func (ht *HistoryTable) commitLookupPartitions(ctx *sql.Context, hs []string) (sql.PartitionIter, error) {
+	var hashes []hash.Hash
+	var commits []*doltdb.Commit
+	var metas []*datas.CommitMeta
+	for _, hs := range hs {
+		h, ok := hash.MaybeParse(hs)
+		if !ok {
+			continue
 		}
-		if len(hashes) == 0 {
-			return sql.PartitionsToPartitionIter(), nil
+		hashes = append(hashes, h)
+
+		cm, err := doltdb.HashToCommit(ctx, ht.doltTable.db.DbData().Ddb.ValueReadWriter(), ht.doltTable.db.DbData().Ddb.NodeStore(), h)
+		if err != nil {
+			return nil, err
 		}
+		commits = append(commits, cm)
 
-		iter, err := ht.filterIter(ctx, doltdb.NewCommitSliceIter[*sql.Context](commits, hashes))
+		meta, err := cm.GetCommitMeta(ctx)
 		if err != nil {
 			return nil, err
 		}
-		return &commitPartitioner{cmItr: iter}, nil
+		metas = append(metas, meta)
+	}
+	if len(hashes) == 0 {
+		return sql.PartitionsToPartitionIter(), nil
+	}
 
+	iter, err := ht.filterIter(ctx, doltdb.NewCommitSliceIter[*sql.Context](commits, hashes))
+	if err != nil {
+		return nil, err
 	}
-	ht.indexLookup = lookup
-	return ht.Partitions(ctx)
+	return &commitPartitioner{cmItr: iter}, nil
 }
 
 // NewHistoryTable creates a history table
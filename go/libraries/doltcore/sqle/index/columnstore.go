@@ -0,0 +1,279 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// A columnstore index stores each of its value columns in a segment of its map. The key of an entry is the position of
+// its column in the index followed by the primary key of its row, so the segment of column |j| of a table with |n| rows
+// is the range of ordinals [j*n, (j+1)*n) of the map, with its rows in the same order as the table's rows. The value of
+// an entry holds the value of its column alone. See schema.Index.IsColumnStore.
+
+// ErrColumnStoreKeyless is returned when a columnstore index is created on a table without a primary key, whose rows
+// aren't identified by their key.
+var ErrColumnStoreKeyless = fmt.Errorf("columnstore indexes are not supported on tables without a primary key")
+
+// ColumnStoreBuilder builds the entries of a columnstore index from the rows of its table
+type ColumnStoreBuilder struct {
+	pool    pool.BuffPool
+	ns      tree.NodeStore
+	keyBld  *val.TupleBuilder
+	valBld  *val.TupleBuilder
+	pkCount int
+
+	// values maps the value columns of the index to the fields of the value tuples of the table
+	values []int
+	// sqlKeys and sqlValues map the key fields and the value columns of the index to the fields of the table's sql rows
+	sqlKeys   []int
+	sqlValues []int
+}
+
+// NewColumnStoreBuilder returns a ColumnStoreBuilder for the columnstore index |def| of a table with the schema |sch|
+func NewColumnStoreBuilder(sch schema.Schema, def schema.Index, ns tree.NodeStore) (ColumnStoreBuilder, error) {
+	if schema.IsKeyless(sch) {
+		return ColumnStoreBuilder{}, ErrColumnStoreKeyless
+	}
+
+	idxSch := def.Schema()
+	b := ColumnStoreBuilder{
+		pool:    ns.Pool(),
+		ns:      ns,
+		keyBld:  val.NewTupleBuilder(idxSch.GetKeyDescriptor(ns), ns),
+		valBld:  val.NewTupleBuilder(idxSch.GetValueDescriptor(ns), ns),
+		pkCount: sch.GetPKCols().Size(),
+		values:  make([]int, idxSch.GetNonPKCols().Size()),
+	}
+
+	allCols := sch.GetAllCols()
+	for _, col := range sch.GetPKCols().GetColumns() {
+		b.sqlKeys = append(b.sqlKeys, allCols.TagToIdx[col.Tag])
+	}
+	for j, col := range idxSch.GetNonPKCols().GetColumns() {
+		if tblCol, ok := allCols.GetByTag(col.Tag); ok && tblCol.Virtual {
+			return ColumnStoreBuilder{}, fmt.Errorf("columnstore index %s can't include the virtual column %s", def.Name(), col.Name)
+		}
+		i, ok := sch.GetNonPKCols().StoredIndexByTag(col.Tag)
+		if !ok {
+			return ColumnStoreBuilder{}, fmt.Errorf("tag %d not found in stored non-PK columns", col.Tag)
+		}
+		b.values[j] = i
+		b.sqlValues = append(b.sqlValues, allCols.TagToIdx[col.Tag])
+	}
+	return b, nil
+}
+
+// Columns returns the number of value columns of the index, each of which is stored in its own segment
+func (b ColumnStoreBuilder) Columns() int {
+	return len(b.values)
+}
+
+// EntryFromRow builds the entry of the index for the value column |col| of the table row |k|, |v|
+func (b ColumnStoreBuilder) EntryFromRow(ctx context.Context, col int, k, v val.Tuple) (key, value val.Tuple, err error) {
+	if key, err = b.KeyFromRow(ctx, col, k); err != nil {
+		return nil, nil, err
+	}
+	// the value fields of the index have the same encodings as the table's, so they're copied as they are
+	b.valBld.PutRaw(col, v.GetField(b.values[col]))
+	value, err = b.valBld.Build(ctx, b.pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+// KeyFromRow builds the key of the entry of the index for the value column |col| of the table row with the key |k|
+func (b ColumnStoreBuilder) KeyFromRow(ctx context.Context, col int, k val.Tuple) (val.Tuple, error) {
+	b.keyBld.PutUint16(0, uint16(col))
+	for i := 0; i < b.pkCount; i++ {
+		b.keyBld.PutRaw(i+1, k.GetField(i))
+	}
+	return b.keyBld.Build(ctx, b.pool)
+}
+
+// EntryFromSqlRow builds the entry of the index for the value column |col| of the table row |r|
+func (b ColumnStoreBuilder) EntryFromSqlRow(ctx context.Context, col int, r sql.Row) (key, value val.Tuple, err error) {
+	if key, err = b.KeyFromSqlRow(ctx, col, r); err != nil {
+		return nil, nil, err
+	}
+	if err = tree.PutField(ctx, b.ns, b.valBld, col, r[b.sqlValues[col]]); err != nil {
+		return nil, nil, err
+	}
+	value, err = b.valBld.Build(ctx, b.pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+// KeyFromSqlRow builds the key of the entry of the index for the value column |col| of the table row |r|
+func (b ColumnStoreBuilder) KeyFromSqlRow(ctx context.Context, col int, r sql.Row) (val.Tuple, error) {
+	b.keyBld.PutUint16(0, uint16(col))
+	for i, from := range b.sqlKeys {
+		if err := tree.PutField(ctx, b.ns, b.keyBld, i+1, r[from]); err != nil {
+			return nil, err
+		}
+	}
+	return b.keyBld.Build(ctx, b.pool)
+}
+
+// ColumnStoreCovers returns whether the columnstore index |def| of a table with the schema |sch| holds all the columns
+// with the tags |projections|, and how many of its segments have to be read for them
+func ColumnStoreCovers(sch schema.Schema, def schema.Index, projections []uint64) (bool, int) {
+	valCols := def.Schema().GetNonPKCols()
+	if valCols.Size() == 0 {
+		return false, 0
+	}
+	segments := 0
+	for _, tag := range projections {
+		if _, ok := sch.GetPKCols().TagToIdx[tag]; ok {
+			continue
+		}
+		if _, ok := valCols.TagToIdx[tag]; !ok {
+			return false, 0
+		}
+		segments++
+	}
+	return true, max(segments, 1)
+}
+
+// NewColumnStoreRowIter returns an iterator over the rows [|start|, |end|) of a table with the schema |sch| and
+// |rowCount| rows, read from its columnstore index |def| with the map |idx|. The rows hold the columns with the tags
+// |projections|, which must be covered by the index, see ColumnStoreCovers. Only the segments of the projected columns
+// are read, and they're zipped together by position.
+func NewColumnStoreRowIter(ctx context.Context, sch schema.Schema, def schema.Index, idx prolly.Map, rowCount int, start, end uint64, projections []uint64) (sql.RowIter, error) {
+	valCols := def.Schema().GetNonPKCols()
+	kd, vd := idx.Descriptors()
+	it := &columnStoreRowIter{
+		kd:     kd,
+		vd:     vd,
+		ns:     idx.NodeStore(),
+		rowLen: len(projections),
+	}
+
+	segments := make(map[int]int)
+	addSegment := func(col int) (int, error) {
+		if i, ok := segments[col]; ok {
+			return i, nil
+		}
+		offset := uint64(col) * uint64(rowCount)
+		iter, err := idx.FetchOrdinalRange(ctx, offset+start, offset+end)
+		if err != nil {
+			return 0, err
+		}
+		segments[col] = len(it.iters)
+		it.iters = append(it.iters, iter)
+		it.cols = append(it.cols, col)
+		return segments[col], nil
+	}
+
+	for ord, tag := range projections {
+		if i, ok := sch.GetPKCols().TagToIdx[tag]; ok {
+			it.keyFields = append(it.keyFields, i+1)
+			it.keyOrds = append(it.keyOrds, ord)
+			continue
+		}
+		col, ok := valCols.TagToIdx[tag]
+		if !ok {
+			return nil, fmt.Errorf("column with tag %d is not in columnstore index %s", tag, def.Name())
+		}
+		i, err := addSegment(col)
+		if err != nil {
+			return nil, err
+		}
+		it.segments = append(it.segments, i)
+		it.valOrds = append(it.valOrds, ord)
+	}
+	if len(it.iters) == 0 {
+		// only primary key columns were projected, which are read from the keys of any segment
+		if _, err := addSegment(0); err != nil {
+			return nil, err
+		}
+	}
+	return it, nil
+}
+
+type columnStoreRowIter struct {
+	kd     *val.TupleDesc
+	vd     *val.TupleDesc
+	ns     tree.NodeStore
+	rowLen int
+
+	// iters iterate the segments read by the scan, and cols are the value columns they store
+	iters []prolly.MapIter
+	cols  []int
+	keys  []val.Tuple
+	vals  []val.Tuple
+
+	// keyFields are the fields of the index keys of the projected primary key columns, and keyOrds their ordinals in
+	// the rows returned
+	keyFields []int
+	keyOrds   []int
+	// segments are the segments of the projected value columns, and valOrds their ordinals in the rows returned
+	segments []int
+	valOrds  []int
+}
+
+var _ sql.RowIter = (*columnStoreRowIter)(nil)
+
+// Next implements sql.RowIter
+func (it *columnStoreRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if it.keys == nil {
+		it.keys = make([]val.Tuple, len(it.iters))
+		it.vals = make([]val.Tuple, len(it.iters))
+	}
+	for i, iter := range it.iters {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF && i > 0 {
+			return nil, fmt.Errorf("columnstore index segment for column %d ended before the first segment", it.cols[i])
+		} else if err != nil {
+			return nil, err
+		}
+		it.keys[i], it.vals[i] = k, v
+	}
+
+	row := make(sql.Row, it.rowLen)
+	for i, field := range it.keyFields {
+		v, err := tree.GetField(ctx, it.kd, field, it.keys[0], it.ns)
+		if err != nil {
+			return nil, err
+		}
+		row[it.keyOrds[i]] = v
+	}
+	for i, seg := range it.segments {
+		v, err := tree.GetField(ctx, it.vd, it.cols[seg], it.vals[seg], it.ns)
+		if err != nil {
+			return nil, err
+		}
+		row[it.valOrds[i]] = v
+	}
+	return row, nil
+}
+
+// Close implements sql.RowIter
+func (it *columnStoreRowIter) Close(*sql.Context) error {
+	return nil
+}
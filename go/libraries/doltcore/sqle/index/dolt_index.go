@@ -60,6 +60,7 @@ type DoltIndex interface {
 	IndexSchema() schema.Schema
 	Format() *types.NomsBinFormat
 	IsPrimaryKey() bool
+	IsColumnStore() bool
 
 	coversColumnsByTag(s *durableIndexState, columns []uint64) bool
 }
@@ -130,6 +131,131 @@ func (p *CommitIndex) CanSupport(c *sql.Context, ranges ...sql.Range) bool {
 
 var _ DoltIndex = (*CommitIndex)(nil)
 
+// CommitKeyIndex is an index of a system table over one or more commit columns followed by the columns of an index of
+// the underlying table, so that filters on both the commits and a key of the table are pushed down together.
+type CommitKeyIndex struct {
+	*doltIndex
+	keyIndex      *doltIndex
+	commitColumns int
+}
+
+var _ DoltIndex = (*CommitKeyIndex)(nil)
+
+// NewCommitKeyIndex returns an index with the id |id| over the |commitColumns| followed by the columns of |keyIndex|,
+// which must be an index returned by this package. It's unique if |keyIndex| is, so that the planner prefers it to a
+// lookup of the key alone.
+func NewCommitKeyIndex(id string, keyIndex sql.Index, commitColumns ...string) *CommitKeyIndex {
+	key := keyIndex.(*doltIndex)
+	idx := *key
+	idx.id = id
+	idx.columns = make([]schema.Column, 0, len(commitColumns)+len(key.columns))
+	for _, name := range commitColumns {
+		idx.columns = append(idx.columns, schema.NewColumn(name, schema.DiffCommitTag, types.StringKind, false))
+	}
+	idx.columns = append(idx.columns, key.columns...)
+	if len(key.prefixLengths) > 0 {
+		idx.prefixLengths = append(make([]uint16, len(commitColumns)), key.prefixLengths...)
+	}
+	idx.order = sql.IndexOrderNone
+	idx.constrainedToLookupExpression = false
+	idx.colExprTypes, idx.colExprNames = nil, nil
+	return &CommitKeyIndex{doltIndex: &idx, keyIndex: key, commitColumns: len(commitColumns)}
+}
+
+// CanSupportOrderBy implements the interface sql.Index.
+func (p *CommitKeyIndex) CanSupportOrderBy(_ sql.Expression) bool {
+	return false
+}
+
+// CanSupport implements the interface sql.Index. Only lookups of single commits are supported.
+func (p *CommitKeyIndex) CanSupport(c *sql.Context, ranges ...sql.Range) bool {
+	for _, r := range ranges {
+		mysqlRange, ok := r.(sql.MySQLRange)
+		if !ok || len(mysqlRange) < p.commitColumns {
+			return false
+		}
+		for _, col := range mysqlRange[:p.commitColumns] {
+			if _, ok := pointSelectStr(col); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Commits returns the distinct commits looked up in the |i|th commit column by |lookup|, or false if they aren't all
+// single values.
+func (p *CommitKeyIndex) Commits(lookup sql.IndexLookup, i int) ([]string, bool) {
+	mysqlRanges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
+	if !ok {
+		return nil, false
+	}
+	var commits []string
+	seen := make(map[string]struct{})
+	for _, r := range mysqlRanges {
+		if len(r) <= i {
+			return nil, false
+		}
+		commit, ok := pointSelectStr(r[i])
+		if !ok {
+			return nil, false
+		}
+		if _, ok := seen[commit]; !ok {
+			seen[commit] = struct{}{}
+			commits = append(commits, commit)
+		}
+	}
+	return commits, true
+}
+
+// KeyLookup returns a lookup on the index of the underlying table of the keys looked up by |lookup|, whatever commits
+// they're looked up with. The lookup is empty if every key is looked up.
+func (p *CommitKeyIndex) KeyLookup(ctx *sql.Context, lookup sql.IndexLookup) (sql.IndexLookup, error) {
+	mysqlRanges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
+	if !ok {
+		return sql.IndexLookup{}, fmt.Errorf("unexpected range collection type: %T", lookup.Ranges)
+	}
+	keyRanges := make([]sql.MySQLRange, 0, len(mysqlRanges))
+	all := true
+	for _, r := range mysqlRanges {
+		keyRange := r[p.commitColumns:]
+		for _, col := range keyRange {
+			all = all && col.Type() == sql.RangeType_All
+		}
+		keyRanges = append(keyRanges, keyRange)
+	}
+	if all {
+		return sql.IndexLookup{}, nil
+	}
+	// the same keys may be looked up with several commits
+	merged, err := sql.RemoveOverlappingRanges(ctx, keyRanges...)
+	if err != nil {
+		return sql.IndexLookup{}, err
+	}
+	return sql.NewIndexLookup(p.keyIndex, merged, false, lookup.IsEmptyRange, false, false), nil
+}
+
+// pointSelectStr returns the string that |col| selects, or false if it doesn't select a single string
+func pointSelectStr(col sql.MySQLRangeColumnExpr) (string, bool) {
+	lb, ok := col.LowerBound.(sql.Below)
+	if !ok {
+		return "", false
+	}
+	lk, ok := lb.Key.(string)
+	if !ok {
+		return "", false
+	}
+	ub, ok := col.UpperBound.(sql.Above)
+	if !ok {
+		return "", false
+	}
+	uk, ok := ub.Key.(string)
+	if !ok || uk != lk {
+		return "", false
+	}
+	return lk, true
+}
+
 func DoltDiffIndexesFromTable(ctx context.Context, db, tbl string, t *doltdb.Table) (indexes []sql.Index, err error) {
 	sch, err := t.GetSchema(ctx)
 	if err != nil {
@@ -277,9 +403,9 @@ func (t SecondaryDiffIndexType) Prefix() string {
 
 // MakeDiffTableSecondaryIndex creates a *doltIndex for a secondary index on the diff table function.
 // The prefix should be "to" or "from" and determines the column name prefix (e.g., "to_c1").
-// Returns nil, nil for fulltext, spatial, or vector indexes which are not supported.
+// Returns nil, nil for fulltext, spatial, vector, or columnstore indexes which are not supported.
 func MakeDiffTableSecondaryIndex(ctx context.Context, tableName string, indexType SecondaryDiffIndexType, t *doltdb.Table, sch schema.Schema, idx schema.Index) (*doltIndex, error) {
-	if idx.IsFullText() || idx.IsSpatial() || idx.IsVector() {
+	if idx.IsFullText() || idx.IsSpatial() || idx.IsVector() || idx.IsColumnStore() {
 		return nil, nil
 	}
 
@@ -355,13 +481,21 @@ func DoltIndexesFromTable(ctx context.Context, db, tbl string, t *doltdb.Table)
 		indexes = append(indexes, idx)
 	}
 
+	var columnStoreIndexes []sql.Index
 	for _, definition := range sch.Indexes().AllIndexes() {
 		idx, err := getSecondaryIndex(ctx, db, tbl, t, sch, definition)
 		if err != nil {
 			return nil, err
 		}
+		if definition.IsColumnStore() {
+			columnStoreIndexes = append(columnStoreIndexes, idx)
+			continue
+		}
 		indexes = append(indexes, idx)
 	}
+	// columnstore indexes come last, so that the engine prefers any other index on the same columns when they cost
+	// the same
+	indexes = append(indexes, columnStoreIndexes...)
 
 	return indexes, nil
 }
@@ -483,7 +617,7 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 	}
 	vrw := t.ValueReadWriter()
 
-	return &doltIndex{
+	di := &doltIndex{
 		id:                            idx.Name(),
 		tblName:                       tbl,
 		dbName:                        db,
@@ -505,7 +639,16 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 		prefixLengths:                 idx.PrefixLengths(),
 		fullTextProps:                 idx.FullTextProperties(),
 		vectorProps:                   idx.VectorProperties(),
-	}, nil
+	}
+	if idx.IsColumnStore() {
+		// columnstore indexes aren't ordered by their columns, so they can't be used for lookups or to elide sorts.
+		// The engine never picks an index with prefix lengths to back a foreign key, so give every column one.
+		di.columnstore = true
+		di.order = sql.IndexOrderNone
+		di.constrainedToLookupExpression = false
+		di.prefixLengths = make([]uint16, idx.Count())
+	}
+	return di, nil
 }
 
 // ConvertFullTextToSql converts a given Full-Text schema.Index into a sql.Index. As we do not need to write to a
@@ -620,11 +763,12 @@ type doltIndex struct {
 	order                         sql.IndexOrder
 	constrainedToLookupExpression bool
 
-	vector   bool
-	isPk     bool
-	unique   bool
-	spatial  bool
-	fulltext bool
+	vector      bool
+	isPk        bool
+	unique      bool
+	spatial     bool
+	fulltext    bool
+	columnstore bool
 }
 
 type LookupMeta struct {
@@ -679,7 +823,7 @@ func (di *doltIndex) String() string {
 
 // CanSupport implements sql.Index
 func (di *doltIndex) CanSupport(*sql.Context, ...sql.Range) bool {
-	return true
+	return !di.columnstore
 }
 
 // CanSupportOrderBy implements the interface sql.Index.
@@ -825,9 +969,21 @@ func (di *doltIndex) coversColumnsByTag(s *durableIndexState, cols []uint64) boo
 	return covers
 }
 
+// CoversProjection returns whether reading the columns with the tags in |projections| with a lookup of |idx| only
+// reads |idx|, without looking up the rows of its keys in the primary index. No projections reads every column.
+func CoversProjection(idx DoltIndex, projections []uint64) bool {
+	if idx.IsPrimaryKey() {
+		return true
+	}
+	if schema.IsKeyless(idx.Schema()) {
+		return false
+	}
+	return idx.coversColumnsByTag(&durableIndexState{}, projections)
+}
+
 // CoversColumns determines if this index covers the columns by name.
 func (di *doltIndex) CoversColumns(cols []string) bool {
-	if di.indexSch == nil {
+	if di.indexSch == nil || di.columnstore {
 		return false
 	}
 	idxCols := di.indexSch.GetAllCols()
@@ -914,7 +1070,7 @@ func (di *doltIndex) Order(ctx *sql.Context) sql.IndexOrder {
 }
 
 func (di *doltIndex) Reversible(ctx *sql.Context) bool {
-	if di.HasContentHashedField() {
+	if di.HasContentHashedField() || di.columnstore {
 		return false
 	}
 
@@ -978,6 +1134,11 @@ func (di *doltIndex) IsPrimaryKey() bool {
 	return di.isPk
 }
 
+// IsColumnStore implements DoltIndex.
+func (di *doltIndex) IsColumnStore() bool {
+	return di.columnstore
+}
+
 // Comment implements sql.Index
 func (di *doltIndex) Comment() string {
 	return di.comment
@@ -995,6 +1156,9 @@ func (di *doltIndex) PrefixLengths() []uint16 {
 
 // IndexType implements sql.Index
 func (di *doltIndex) IndexType() string {
+	if di.columnstore {
+		return "COLUMNSTORE"
+	}
 	return "BTREE"
 }
 
@@ -171,6 +171,18 @@ func (rp rangePartition) Key() []byte {
 	return rp.key
 }
 
+// PartitionRange returns the range of index keys read by |part|, or false when |part| isn't a range of keys, such as
+// for the partitions of vector indexes.
+func PartitionRange(part sql.Partition) (prolly.Range, bool) {
+	switch p := part.(type) {
+	case rangePartition:
+		return p.prollyRange, true
+	case pointPartition:
+		return p.r, true
+	}
+	return prolly.Range{}, false
+}
+
 func GetDurableIndex(ctx *sql.Context,
 	tab DoltTableable,
 	idx DoltIndex) (durable.Index, error) {
@@ -61,6 +61,9 @@ func (t *IndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexScanBuild
 }
 
 func (idt *IndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	if err := idt.RecordIndexRead(ctx, lookup); err != nil {
+		return nil, err
+	}
 	return index.NewRangePartitionIter(ctx, idt.DoltTable, lookup)
 }
 
@@ -75,6 +78,9 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 	if err != nil {
 		return nil, err
 	}
+	if read := idt.lockingRead(ctx); read != nil {
+		return newLockingIndexRowIter(ctx, idt.DoltTable, idt.idx, key, read, part)
+	}
 
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
 		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch)
@@ -93,6 +99,9 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 	if err != nil {
 		return nil, err
 	}
+	if read := idt.lockingRead(ctx); read != nil {
+		return newLockingIndexRowIter(ctx, idt.DoltTable, idt.idx, key, read, part)
+	}
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
 		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch)
 		if err != nil {
@@ -143,6 +152,9 @@ func (t *WritableIndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexS
 }
 
 func (t *WritableIndexedDoltTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
+	if err := t.RecordIndexRead(ctx, lookup); err != nil {
+		return nil, err
+	}
 	if lookup.VectorOrderAndLimit.OrderBy != nil {
 		return index.NewVectorPartitionIter(lookup)
 	}
@@ -160,6 +172,9 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 	if err != nil {
 		return nil, err
 	}
+	if read := t.lockingRead(ctx); read != nil {
+		return newLockingIndexRowIter(ctx, t.DoltTable, t.idx, key, read, part)
+	}
 	if t.lb == nil || !canCache || t.lb.Key() != key {
 		t.lb, err = index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.projectedCols, t.sqlSch)
 		if err != nil {
@@ -17,6 +17,7 @@ package kvexec
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
@@ -27,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -47,6 +49,11 @@ func (b Builder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, er
 	//  - compatible |val| encodings that we don't coerce
 	//  - filter/project ordering clash
 
+	// locking reads, like SELECT ... FOR UPDATE, lock each row as the table returns it
+	if dsess.IsLockingRead(ctx) {
+		return nil, nil
+	}
+
 	switch n := n.(type) {
 	case *plan.JoinNode:
 		switch {
@@ -135,6 +142,39 @@ func (b Builder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, er
 				}
 			}
 		}
+		if len(r) == 0 {
+			if agg, ok, err := getParallelAggregation(ctx, n); err != nil || ok {
+				if err != nil {
+					return nil, err
+				}
+				return newParallelAggregationIter(ctx, agg), nil
+			}
+		}
+	case *plan.CreateIndex:
+		if strings.EqualFold(n.Driver, columnStoreDriver) {
+			return createColumnStoreIndex(ctx, n)
+		}
+	case *plan.AlterIndex:
+		if iter, ok, err := alterColumnStoreIndex(ctx, n); err != nil || ok {
+			return iter, err
+		}
+	case *plan.CreateTable:
+		if iter, ok, err := createTableWithColumnStoreIndexes(ctx, n, r); err != nil || ok {
+			return iter, err
+		}
+	case *plan.ShowCreateTable:
+		if iter, ok, err := showCreateTableWithColumnStoreIndexes(ctx, n, r); err != nil || ok {
+			return iter, err
+		}
+	case *plan.Filter, *plan.ResolvedTable:
+		if len(r) == 0 {
+			if scan, ok, err := getParallelScan(ctx, n); err != nil || ok {
+				if err != nil {
+					return nil, err
+				}
+				return newParallelScanIter(ctx, scan), nil
+			}
+		}
 	default:
 		return nil, nil
 	}
@@ -364,9 +404,12 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 		}
 
 		var lb index.IndexScanBuilder
+		var doltTable *sqle.DoltTable
+		var idx index.DoltIndex
 		switch dt := n.UnderlyingTable().(type) {
 		case *sqle.WritableIndexedDoltTable:
 			tags = dt.ProjectedTags()
+			doltTable, idx = dt.DoltTable, dt.Index()
 			table, err = dt.DoltTable.DoltTable(ctx)
 			if err != nil {
 				return prolly.Map{}, nil, nil, nil, nil, nil, err
@@ -377,6 +420,7 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 			}
 		case *sqle.IndexedDoltTable:
 			tags = dt.ProjectedTags()
+			doltTable, idx = dt.DoltTable, dt.Index()
 			table, err = dt.DoltTable.DoltTable(ctx)
 			if err != nil {
 				return prolly.Map{}, nil, nil, nil, nil, nil, err
@@ -418,27 +462,39 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 			if err != nil {
 				return prolly.Map{}, nil, nil, nil, nil, nil, err
 			}
+			if err = doltTable.RecordIndexRead(ctx, l); err != nil {
+				return prolly.Map{}, nil, nil, nil, nil, nil, err
+			}
 		} else {
 			dstIter, _ = lb.NewSecondaryIter(n.IsStrictLookup(ctx), len(n.Expressions()), n.NullMask())
+			// the keys looked up aren't known until the join runs, so this counts as a read of the whole index
+			if err = doltTable.RecordIndexRead(ctx, sql.IndexLookup{Index: idx}); err != nil {
+				return prolly.Map{}, nil, nil, nil, nil, nil, err
+			}
 		}
 
 	case *plan.ResolvedTable:
+		var doltTable *sqle.DoltTable
 		switch dt := n.UnderlyingTable().(type) {
 		case *sqle.WritableDoltTable:
 			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable.DoltTable(ctx)
+			doltTable = dt.DoltTable
 		case *sqle.AlterableDoltTable:
 			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable.DoltTable(ctx)
+			doltTable = dt.DoltTable
 		case *sqle.DoltTable:
 			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable(ctx)
+			doltTable = dt
 		default:
 			return prolly.Map{}, nil, nil, nil, nil, nil, nil
 		}
+		table, err = doltTable.DoltTable(ctx)
 		if err != nil {
 			return prolly.Map{}, nil, nil, nil, nil, nil, err
 		}
+		if err = doltTable.RecordScan(ctx); err != nil {
+			return prolly.Map{}, nil, nil, nil, nil, nil, err
+		}
 
 		priSch, err = table.GetSchema(ctx)
 		if err != nil {
@@ -0,0 +1,313 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvexec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+// columnStoreDriver is the index driver of CREATE INDEX ... USING COLUMNSTORE
+const columnStoreDriver = "columnstore"
+
+// columnStoreIndexTable is a table that columnstore indexes can be created on, see
+// sqle.AlterableDoltTable.CreateColumnStoreIndex
+type columnStoreIndexTable interface {
+	sql.Table
+	CreateColumnStoreIndex(ctx *sql.Context, name string, columns []string, comment string) error
+}
+
+// getColumnStoreIndexTable returns |t|, or the table it wraps, as a columnStoreIndexTable
+func getColumnStoreIndexTable(t sql.Table) (columnStoreIndexTable, error) {
+	switch t := t.(type) {
+	case columnStoreIndexTable:
+		return t, nil
+	case sql.TableWrapper:
+		return getColumnStoreIndexTable(t.Underlying())
+	default:
+		return nil, fmt.Errorf("table %s does not support columnstore indexes", t.Name())
+	}
+}
+
+// createColumnStoreIndex runs CREATE INDEX ... USING COLUMNSTORE, which the engine otherwise treats as an index to
+// create with an external index driver
+func createColumnStoreIndex(ctx *sql.Context, n *plan.CreateIndex) (sql.RowIter, error) {
+	rt, ok := n.Table.(*plan.ResolvedTable)
+	if !ok {
+		return nil, plan.ErrNotIndexable.New()
+	}
+	tbl, err := getColumnStoreIndexTable(rt.UnderlyingTable())
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(n.Exprs))
+	for i, e := range n.Exprs {
+		gf, ok := e.(*expression.GetField)
+		if !ok {
+			return nil, fmt.Errorf("columnstore indexes can only include columns, found %s", e)
+		}
+		columns[i] = gf.Name()
+	}
+
+	if err := tbl.CreateColumnStoreIndex(ctx, n.Name, columns, n.Config["comment"]); err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(), nil
+}
+
+// alterColumnStoreIndex runs CREATE INDEX ... (..) USING COLUMNSTORE and ALTER TABLE ... ADD INDEX ... (..) USING
+// COLUMNSTORE, which the engine builds as an index to create with the default storage. It returns false if |n| doesn't
+// create a columnstore index.
+func alterColumnStoreIndex(ctx *sql.Context, n *plan.AlterIndex) (sql.RowIter, bool, error) {
+	if n.Action != plan.IndexAction_Create || n.Constraint != sql.IndexConstraint_None {
+		return nil, false, nil
+	}
+	stmt := parseColumnStoreQuery(ctx)
+	alter, ok := stmt.(*sqlparser.AlterTable)
+	if !ok || !strings.EqualFold(alter.Table.Name.String(), n.Table.Name()) {
+		return nil, false, nil
+	}
+	found := false
+	for _, ddl := range alter.Statements {
+		spec := ddl.IndexSpec
+		if spec != nil && strings.EqualFold(spec.Action, sqlparser.CreateStr) && usesColumnStore(spec.Options) &&
+			matchesIndexDef(spec.ToName, spec.Fields, n.IndexName, n.Columns) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	for _, col := range n.Columns {
+		if col.Expression != nil {
+			return nil, true, fmt.Errorf("columnstore indexes can only include columns, found %s", col.Expression)
+		}
+	}
+	table, ok, err := n.Db.GetTableInsensitive(ctx, n.Table.Name())
+	if err != nil {
+		return nil, true, err
+	}
+	if !ok {
+		return nil, true, sql.ErrTableNotFound.New(n.Table.Name())
+	}
+	if n.IfNotExists {
+		if exists, err := hasIndex(ctx, table, n.IndexName); err != nil || exists {
+			return sql.RowsToRowIter(), true, err
+		}
+	}
+	tbl, err := getColumnStoreIndexTable(table)
+	if err != nil {
+		return nil, true, err
+	}
+	if err = tbl.CreateColumnStoreIndex(ctx, n.IndexName, indexColumnNames(n.Columns), n.Comment); err != nil {
+		return nil, true, err
+	}
+	return sql.RowsToRowIter(), true, nil
+}
+
+// createTableWithColumnStoreIndexes runs a CREATE TABLE statement that declares columnstore indexes, which the engine
+// builds as indexes with the default storage. It creates the table without them, and then adds them. It returns
+// false if |n| doesn't declare any.
+func createTableWithColumnStoreIndexes(ctx *sql.Context, n *plan.CreateTable, r sql.Row) (sql.RowIter, bool, error) {
+	stmt := parseColumnStoreQuery(ctx)
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || !strings.EqualFold(ddl.Table.Name.String(), n.Name()) {
+		return nil, false, nil
+	}
+	var indexDefs, columnStoreDefs sql.IndexDefs
+	for _, def := range n.Indexes() {
+		isColumnStore := false
+		for _, idx := range ddl.TableSpec.Indexes {
+			if !idx.Info.Primary && !idx.Info.Unique && !idx.Info.Spatial && !idx.Info.Fulltext && !idx.Info.Vector &&
+				usesColumnStore(idx.Options) && matchesIndexDef(idx.Info.Name, idx.Fields, def.Name, def.Columns) {
+				isColumnStore = true
+			}
+		}
+		if isColumnStore {
+			columnStoreDefs = append(columnStoreDefs, def)
+		} else {
+			indexDefs = append(indexDefs, def)
+		}
+	}
+	if len(columnStoreDefs) == 0 {
+		return nil, false, nil
+	}
+
+	for _, def := range columnStoreDefs {
+		for _, col := range def.Columns {
+			if col.Expression != nil {
+				return nil, true, fmt.Errorf("columnstore indexes can only include columns, found %s", col.Expression)
+			}
+		}
+	}
+	// a table that already exists is left to the engine, which skips it with IF NOT EXISTS and errors otherwise
+	if _, exists, err := n.Db.GetTableInsensitive(ctx, n.Name()); err != nil || exists {
+		return nil, err != nil, err
+	}
+
+	withoutColumnStore, err := n.WithIndexDefs(indexDefs)
+	if err != nil {
+		return nil, true, err
+	}
+	iter, err := rowexec.NewBuilder(nil, overrides.EngineOverridesFromContext(ctx)).Build(ctx, withoutColumnStore, r)
+	if err != nil {
+		return nil, true, err
+	}
+	table, ok, err := n.Db.GetTableInsensitive(ctx, n.Name())
+	if err != nil {
+		return nil, true, err
+	}
+	if !ok {
+		return nil, true, sql.ErrTableCreatedNotFound.New(n.Name())
+	}
+	tbl, err := getColumnStoreIndexTable(table)
+	if err != nil {
+		return nil, true, err
+	}
+	for _, def := range columnStoreDefs {
+		if err = tbl.CreateColumnStoreIndex(ctx, def.Name, indexColumnNames(def.Columns), def.Comment); err != nil {
+			return nil, true, err
+		}
+	}
+	return iter, true, nil
+}
+
+// showCreateTableWithColumnStoreIndexes runs SHOW CREATE TABLE for a table with columnstore indexes, which the engine
+// prints as indexes with the default storage. It returns false if the table of |n| doesn't have any.
+func showCreateTableWithColumnStoreIndexes(ctx *sql.Context, n *plan.ShowCreateTable, r sql.Row) (sql.RowIter, bool, error) {
+	if n.IsView {
+		return nil, false, nil
+	}
+	formatter := overrides.SchemaFormatterFromContext(ctx)
+	definitions := make(map[string]string)
+	for _, idx := range n.Indexes {
+		di, ok := idx.(index.DoltIndex)
+		if !ok || !di.IsColumnStore() {
+			continue
+		}
+		cols := make([]string, len(idx.Expressions()))
+		for i, expr := range idx.Expressions() {
+			cols[i] = formatter.QuoteIdentifier(strings.TrimPrefix(expr, idx.Table()+"."))
+		}
+		definition, _ := formatter.GenerateCreateTableIndexDefinition(false, false, false, false, idx.ID(), cols, idx.Comment())
+		definitions[definition] = sqlfmt.GenerateCreateTableColumnStoreIndexDefinition(formatter, idx.ID(), cols, idx.Comment())
+	}
+	if len(definitions) == 0 {
+		return nil, false, nil
+	}
+
+	iter, err := rowexec.NewBuilder(nil, overrides.EngineOverridesFromContext(ctx)).Build(ctx, n, r)
+	if err != nil {
+		return nil, true, err
+	}
+	rows, err := sql.RowIterToRows(ctx, iter)
+	if err != nil {
+		return nil, true, err
+	}
+	for _, row := range rows {
+		stmt, ok := row[1].(string)
+		if !ok {
+			continue
+		}
+		lines := strings.Split(stmt, "\n")
+		for i, line := range lines {
+			if definition, ok := definitions[strings.TrimSuffix(line, ",")]; ok {
+				lines[i] = definition + line[len(strings.TrimSuffix(line, ",")):]
+			}
+		}
+		row[1] = strings.Join(lines, "\n")
+	}
+	return sql.RowsToRowIter(rows...), true, nil
+}
+
+// parseColumnStoreQuery returns the query of |ctx| parsed, or nil if it doesn't mention columnstore indexes. The
+// engine drops the USING clause that follows the columns of an index, so it's read from the query itself.
+func parseColumnStoreQuery(ctx *sql.Context) sqlparser.Statement {
+	query := ctx.Query()
+	if !strings.Contains(strings.ToLower(query), columnStoreDriver) {
+		return nil
+	}
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil
+	}
+	return stmt
+}
+
+// usesColumnStore returns whether |options| declare an index USING COLUMNSTORE
+func usesColumnStore(options []*sqlparser.IndexOption) bool {
+	for _, option := range options {
+		if strings.EqualFold(option.Using, columnStoreDriver) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesIndexDef returns whether the index |name| on |fields| in a statement is the index |defName| on |defColumns|
+// the engine built for it. The engine names indexes declared without a name itself.
+func matchesIndexDef(name sqlparser.ColIdent, fields []*sqlparser.IndexField, defName string, defColumns []sql.IndexColumn) bool {
+	if !name.IsEmpty() {
+		return strings.EqualFold(name.String(), defName)
+	}
+	if len(fields) != len(defColumns) {
+		return false
+	}
+	for i, field := range fields {
+		if field.Expression != nil || !strings.EqualFold(field.Column.String(), defColumns[i].Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexColumnNames returns the names of |columns|
+func indexColumnNames(columns []sql.IndexColumn) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// hasIndex returns whether |table| has an index named |name|
+func hasIndex(ctx *sql.Context, table sql.Table, name string) (bool, error) {
+	indexed, ok := table.(sql.IndexAddressable)
+	if !ok {
+		return false, nil
+	}
+	indexes, err := indexed.GetIndexes(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, idx := range indexes {
+		if strings.EqualFold(idx.ID(), name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,579 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvexec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/hash"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// parallelTable is a table whose partitions can be read concurrently, see sqle.DoltTable.ParallelPartitionRows
+type parallelTable interface {
+	sql.Table
+	ParallelPartitionRows(ctx *sql.Context) (func(*sql.Context, sql.Partition) (sql.RowIter, error), bool, error)
+}
+
+// parallelScan is a full scan of a table, and optionally a filter of its rows, whose partitions are read and filtered
+// by several goroutines at once. Results are returned in partition order, which is the order of a serial scan.
+type parallelScan struct {
+	parts   []sql.Partition
+	rows    func(*sql.Context, sql.Partition) (sql.RowIter, error)
+	filter  sql.Expression
+	workers int
+}
+
+// getParallelScan returns a parallel scan of |n| if it's a scan of a table that can be read in parallel, optionally
+// filtered, and the session has parallel execution enabled with @@dolt_query_parallelism.
+func getParallelScan(ctx *sql.Context, n sql.Node) (*parallelScan, bool, error) {
+	workers := dsess.QueryParallelism(ctx)
+	if workers < 2 {
+		return nil, false, nil
+	}
+	// the warnings of the goroutines are collected by handles to the session, see runPartitions
+	if _, ok := ctx.Session.(*dsess.DoltSession); !ok {
+		return nil, false, nil
+	}
+
+	var filter sql.Expression
+	for {
+		switch nn := n.(type) {
+		case *plan.Filter:
+			if filter != nil || !parallelSafe(ctx, nn.Expression) {
+				return nil, false, nil
+			}
+			filter, n = nn.Expression, nn.Child
+			continue
+		case *plan.TableAlias:
+			n = nn.Child
+			continue
+		case *plan.ResolvedTable:
+			if _, ok := plan.FindVirtualColumnTable(nn.Table); ok {
+				return nil, false, nil
+			}
+			pt, ok := nn.UnderlyingTable().(parallelTable)
+			if !ok {
+				return nil, false, nil
+			}
+			rows, ok, err := pt.ParallelPartitionRows(ctx)
+			if err != nil || !ok {
+				return nil, false, err
+			}
+			parts, err := collectPartitions(ctx, pt)
+			if err != nil || len(parts) < 2 {
+				return nil, false, err
+			}
+			return &parallelScan{parts: parts, rows: rows, filter: filter, workers: min(workers, len(parts))}, true, nil
+		}
+		return nil, false, nil
+	}
+}
+
+func collectPartitions(ctx *sql.Context, t sql.Table) ([]sql.Partition, error) {
+	iter, err := t.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close(ctx)
+
+	var parts []sql.Partition
+	for {
+		p, err := iter.Next(ctx)
+		if err == io.EOF {
+			return parts, nil
+		} else if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+	}
+}
+
+// parallelSafe returns whether |e| can be evaluated by several goroutines at once, each with its own copy of it.
+// Subqueries share state with the rest of the plan and can't be.
+func parallelSafe(ctx *sql.Context, e sql.Expression) bool {
+	return !transform.InspectExpr(ctx, e, func(_ *sql.Context, e sql.Expression) bool {
+		_, ok := e.(*plan.Subquery)
+		return ok
+	})
+}
+
+// scanPartition calls |cb| with each row of the |i|th partition of the scan that passes its filter
+func (s *parallelScan) scanPartition(ctx *sql.Context, i int, cb func(sql.Row) error) error {
+	var filter sql.Expression
+	if s.filter != nil {
+		var err error
+		if filter, err = transform.Clone(ctx, s.filter); err != nil {
+			return err
+		}
+	}
+
+	iter, err := s.rows(ctx, s.parts[i])
+	if err != nil {
+		return err
+	}
+	defer iter.Close(ctx)
+
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if filter != nil {
+			res, err := sql.EvaluateCondition(ctx, filter, row)
+			if err != nil {
+				return err
+			}
+			if !sql.IsTrue(res) {
+				continue
+			}
+		}
+		if err = cb(row); err != nil {
+			return err
+		}
+	}
+}
+
+// partitionResults computes a result for each partition of a parallel scan on a pool of goroutines, and returns them
+// in partition order. The goroutines run at most two results per goroutine ahead of the caller. Each goroutine has its
+// own handle to the session that collects the warnings raised computing a result, which are added to the session by
+// the caller along with the result, so they're added in the order a serial scan adds them.
+type partitionResults[T any] struct {
+	eg      *errgroup.Group
+	ctx     context.Context
+	cancel  context.CancelFunc
+	results []chan partitionResult[T]
+	window  chan struct{}
+	next    int
+}
+
+// partitionResult is the result of a partition and the warnings raised computing it
+type partitionResult[T any] struct {
+	res      T
+	warnings []*sql.Warning
+}
+
+func runPartitions[T any](ctx *sql.Context, s *parallelScan, fn func(*sql.Context, int) (T, error)) *partitionResults[T] {
+	c, cancel := context.WithCancel(ctx)
+	eg, c := errgroup.WithContext(c)
+	r := &partitionResults[T]{
+		eg:      eg,
+		ctx:     c,
+		cancel:  cancel,
+		results: make([]chan partitionResult[T], len(s.parts)),
+		window:  make(chan struct{}, 2*s.workers),
+	}
+	for i := range r.results {
+		r.results[i] = make(chan partitionResult[T], 1)
+	}
+
+	var claimed atomic.Int64
+	for w := 0; w < s.workers; w++ {
+		sess, flush := dsess.DSessFromSess(ctx.Session).WithWarningBuffer()
+		subCtx := ctx.WithContext(c)
+		subCtx.Session = sess
+		eg.Go(func() error {
+			for {
+				select {
+				case r.window <- struct{}{}:
+				case <-c.Done():
+					return c.Err()
+				}
+				i := int(claimed.Add(1) - 1)
+				if i >= len(r.results) {
+					return nil
+				}
+				res, err := fn(subCtx, i)
+				if err != nil {
+					return err
+				}
+				r.results[i] <- partitionResult[T]{res: res, warnings: flush()}
+			}
+		})
+	}
+	return r
+}
+
+// Next returns the result of the next partition, or io.EOF after the last one, and adds the warnings raised computing
+// it to the session of |ctx|
+func (r *partitionResults[T]) Next(ctx *sql.Context) (T, error) {
+	var res T
+	if r.next >= len(r.results) {
+		return res, io.EOF
+	}
+	select {
+	case pr := <-r.results[r.next]:
+		r.next++
+		<-r.window
+		for _, w := range pr.warnings {
+			ctx.Session.Warn(w)
+		}
+		return pr.res, nil
+	case <-r.ctx.Done():
+		if err := r.eg.Wait(); err != nil {
+			return res, err
+		}
+		return res, r.ctx.Err()
+	}
+}
+
+// Close stops the goroutines computing results and waits for them to return
+func (r *partitionResults[T]) Close() error {
+	r.cancel()
+	if err := r.eg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// parallelScanIter returns the rows of a parallel scan
+type parallelScanIter struct {
+	parts *partitionResults[[]sql.Row]
+	rows  []sql.Row
+}
+
+var _ sql.RowIter = (*parallelScanIter)(nil)
+
+func newParallelScanIter(ctx *sql.Context, s *parallelScan) *parallelScanIter {
+	return &parallelScanIter{
+		parts: runPartitions(ctx, s, func(ctx *sql.Context, i int) ([]sql.Row, error) {
+			var rows []sql.Row
+			err := s.scanPartition(ctx, i, func(row sql.Row) error {
+				rows = append(rows, row)
+				return nil
+			})
+			return rows, err
+		}),
+	}
+}
+
+func (it *parallelScanIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for len(it.rows) == 0 {
+		var err error
+		if it.rows, err = it.parts.Next(ctx); err != nil {
+			return nil, err
+		}
+	}
+	row := it.rows[0]
+	it.rows = it.rows[1:]
+	return row, nil
+}
+
+func (it *parallelScanIter) Close(*sql.Context) error {
+	return it.parts.Close()
+}
+
+// parallelAggregation is a GROUP BY over a parallel scan. Each partition is aggregated on its own into partial results,
+// which are merged in partition order, so groups are returned in the order that a serial aggregation returns them.
+type parallelAggregation struct {
+	scan       *parallelScan
+	selectDeps []sql.Expression
+	groupBy    []sql.Expression
+	keySch     sql.Schema
+}
+
+// getParallelAggregation returns a parallel aggregation for |n| if its child is a parallel scan and each of its
+// aggregate functions can be computed from the partial results of partitions.
+func getParallelAggregation(ctx *sql.Context, n *plan.GroupBy) (*parallelAggregation, bool, error) {
+	grouped := make(map[string]bool, len(n.GroupByExprs))
+	for _, e := range n.GroupByExprs {
+		if !parallelSafe(ctx, e) {
+			return nil, false, nil
+		}
+		grouped[e.String()] = true
+	}
+	for _, e := range n.SelectDeps {
+		if !mergeableAggregate(ctx, e, grouped) {
+			return nil, false, nil
+		}
+	}
+
+	scan, ok, err := getParallelScan(ctx, n.Child)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	keySch := make(sql.Schema, len(n.GroupByExprs))
+	for i, e := range n.GroupByExprs {
+		keySch[i] = &sql.Column{Type: e.Type(ctx)}
+	}
+	return &parallelAggregation{
+		scan:       scan,
+		selectDeps: n.SelectDeps,
+		groupBy:    n.GroupByExprs,
+		keySch:     keySch,
+	}, true, nil
+}
+
+// mergeableAggregate returns whether the result of |e| over a group can be computed from its results over parts of
+// the group. Expressions that aren't aggregate functions must be grouping expressions, which are the same for each
+// row of a group.
+func mergeableAggregate(ctx *sql.Context, e sql.Expression, grouped map[string]bool) bool {
+	switch e := e.(type) {
+	case *aggregation.Count, *aggregation.Sum, *aggregation.Min, *aggregation.Max:
+		agg := e.(sql.WindowAdaptableExpression)
+		if agg.Window() != nil || !parallelSafe(ctx, e) {
+			return false
+		}
+		// DISTINCT removes duplicates within a partition only
+		return !transform.InspectExpr(ctx, e, func(_ *sql.Context, e sql.Expression) bool {
+			_, ok := e.(*expression.DistinctExpression)
+			return ok
+		})
+	case sql.Aggregation:
+		return false
+	default:
+		return grouped[e.String()]
+	}
+}
+
+// partialGroups are the partial aggregation results of each group in a partition, in the order they were first seen
+type partialGroups struct {
+	keys   []uint64
+	groups map[uint64][]sql.AggregationBuffer
+}
+
+// aggregatePartition computes the partial results of the groups of the |i|th partition of the scan
+func (a *parallelAggregation) aggregatePartition(ctx *sql.Context, i int) (*partialGroups, error) {
+	pg := &partialGroups{groups: make(map[uint64][]sql.AggregationBuffer)}
+	keyRow := make(sql.Row, len(a.groupBy))
+	err := a.scan.scanPartition(ctx, i, func(row sql.Row) error {
+		key, err := groupingKey(ctx, a.groupBy, a.keySch, keyRow, row)
+		if err != nil {
+			return err
+		}
+		bufs, ok := pg.groups[key]
+		if !ok {
+			bufs = make([]sql.AggregationBuffer, len(a.selectDeps))
+			for j, e := range a.selectDeps {
+				if bufs[j], err = partialBuffer(ctx, e); err != nil {
+					return err
+				}
+			}
+			pg.groups[key] = bufs
+			pg.keys = append(pg.keys, key)
+		}
+		for _, b := range bufs {
+			if err = b.Update(ctx, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		pg.dispose(ctx)
+		return nil, err
+	}
+	return pg, nil
+}
+
+func (pg *partialGroups) dispose(ctx *sql.Context) {
+	for _, bufs := range pg.groups {
+		for _, b := range bufs {
+			b.Dispose(ctx)
+		}
+	}
+}
+
+// groupingKey returns the hash of the grouping expressions |groupBy| for |row|, the same way a serial GROUP BY does
+func groupingKey(ctx *sql.Context, groupBy []sql.Expression, keySch sql.Schema, keyRow, row sql.Row) (uint64, error) {
+	if len(groupBy) == 0 {
+		return 0, nil
+	}
+	for i, e := range groupBy {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return 0, err
+		}
+		if extTyp, ok := keySch[i].Type.(sql.ExtendedType); ok {
+			b, err := extTyp.SerializeValue(ctx, v)
+			if err != nil {
+				return 0, err
+			}
+			v = string(b)
+		}
+		keyRow[i] = v
+	}
+	return hash.HashOf(ctx, keySch, keyRow)
+}
+
+func partialBuffer(ctx *sql.Context, e sql.Expression) (sql.AggregationBuffer, error) {
+	if agg, ok := e.(sql.Aggregation); ok {
+		return agg.NewBuffer(ctx)
+	}
+	return aggregation.NewFirst(e).NewBuffer(ctx)
+}
+
+// mergeBuffer returns a buffer that merges the partial results of |e|, which are the |i|th field of partial rows
+func mergeBuffer(ctx *sql.Context, e sql.Expression, i int) (sql.AggregationBuffer, error) {
+	switch e := e.(type) {
+	case *aggregation.Count:
+		return &countMergeBuffer{idx: i}, nil
+	case *aggregation.Sum:
+		return aggregation.NewSum(expression.NewGetField(i, e.Child.Type(ctx), "", true)).NewBuffer(ctx)
+	case *aggregation.Min:
+		return aggregation.NewMin(expression.NewGetField(i, e.Child.Type(ctx), "", true)).NewBuffer(ctx)
+	case *aggregation.Max:
+		return aggregation.NewMax(expression.NewGetField(i, e.Child.Type(ctx), "", true)).NewBuffer(ctx)
+	default:
+		return aggregation.NewFirst(expression.NewGetField(i, e.Type(ctx), "", true)).NewBuffer(ctx)
+	}
+}
+
+// countMergeBuffer adds up partial counts
+type countMergeBuffer struct {
+	idx int
+	cnt int64
+}
+
+var _ sql.AggregationBuffer = (*countMergeBuffer)(nil)
+
+func (b *countMergeBuffer) Update(_ *sql.Context, row sql.Row) error {
+	b.cnt += row[b.idx].(int64)
+	return nil
+}
+
+func (b *countMergeBuffer) Eval(*sql.Context) (interface{}, error) {
+	return b.cnt, nil
+}
+
+func (b *countMergeBuffer) Dispose(*sql.Context) {}
+
+// parallelAggregationIter returns the groups of a parallel aggregation
+type parallelAggregationIter struct {
+	agg    *parallelAggregation
+	parts  *partitionResults[*partialGroups]
+	keys   []uint64
+	groups map[uint64][]sql.AggregationBuffer
+	merged bool
+	pos    int
+}
+
+var _ sql.RowIter = (*parallelAggregationIter)(nil)
+
+func newParallelAggregationIter(ctx *sql.Context, a *parallelAggregation) *parallelAggregationIter {
+	return &parallelAggregationIter{
+		agg:    a,
+		parts:  runPartitions(ctx, a.scan, a.aggregatePartition),
+		groups: make(map[uint64][]sql.AggregationBuffer),
+	}
+}
+
+func (it *parallelAggregationIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if !it.merged {
+		if err := it.merge(ctx); err != nil {
+			return nil, err
+		}
+		it.merged = true
+	}
+	if it.pos >= len(it.keys) {
+		return nil, io.EOF
+	}
+	bufs := it.groups[it.keys[it.pos]]
+	it.pos++
+
+	row := make(sql.Row, len(bufs))
+	for i, b := range bufs {
+		var err error
+		if row[i], err = b.Eval(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// merge merges the partial results of each partition, in partition order
+func (it *parallelAggregationIter) merge(ctx *sql.Context) error {
+	// without grouping expressions there's exactly one group, even when there are no rows
+	if len(it.agg.groupBy) == 0 {
+		if _, err := it.group(ctx, 0); err != nil {
+			return err
+		}
+	}
+	for {
+		pg, err := it.parts.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		err = it.mergePartition(ctx, pg)
+		pg.dispose(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (it *parallelAggregationIter) mergePartition(ctx *sql.Context, pg *partialGroups) error {
+	partial := make(sql.Row, len(it.agg.selectDeps))
+	for _, key := range pg.keys {
+		for i, b := range pg.groups[key] {
+			var err error
+			if partial[i], err = b.Eval(ctx); err != nil {
+				return err
+			}
+		}
+		bufs, err := it.group(ctx, key)
+		if err != nil {
+			return err
+		}
+		for _, b := range bufs {
+			if err = b.Update(ctx, partial); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// group returns the merge buffers of the group with |key|, creating them the first time the group is seen
+func (it *parallelAggregationIter) group(ctx *sql.Context, key uint64) ([]sql.AggregationBuffer, error) {
+	if bufs, ok := it.groups[key]; ok {
+		return bufs, nil
+	}
+	bufs := make([]sql.AggregationBuffer, len(it.agg.selectDeps))
+	for i, e := range it.agg.selectDeps {
+		var err error
+		if bufs[i], err = mergeBuffer(ctx, e, i); err != nil {
+			return nil, err
+		}
+	}
+	it.groups[key] = bufs
+	it.keys = append(it.keys, key)
+	return bufs, nil
+}
+
+func (it *parallelAggregationIter) Close(ctx *sql.Context) error {
+	for _, bufs := range it.groups {
+		for _, b := range bufs {
+			b.Dispose(ctx)
+		}
+	}
+	it.groups = nil
+	return it.parts.Close()
+}
@@ -0,0 +1,426 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// lockingRead returns the locking clause of the current statement if it locks the rows read from this table, such as
+// for SELECT ... FOR UPDATE. Tables locked to a root, such as with AS OF, and tables read with an overridden schema are
+// never locked.
+func (t *DoltTable) lockingRead(ctx *sql.Context) *dsess.LockingRead {
+	if t.lockedToRoot != nil || t.overriddenSchema != nil {
+		return nil
+	}
+	return dsess.LockingReadForTable(ctx, t.Name())
+}
+
+// allColumnTags returns the tags of every column of this table, the projection that locking reads scan the table with
+func (t *DoltTable) allColumnTags() []uint64 {
+	return t.sch.GetAllCols().Tags
+}
+
+// newLockingRowIter returns an iterator that locks each row returned by |iter|, which must return every column of the
+// table, before returning it with the projections of |t|. |idx| and |part| are the index and partition that |iter|
+// reads, when it reads an index of the table.
+func newLockingRowIter(ctx *sql.Context, t *DoltTable, read *dsess.LockingRead, iter sql.RowIter, idx index.DoltIndex, part sql.Partition) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	target, ok, err := sess.RowLockTarget(ctx, t.db.RevisionQualifiedName())
+	if err != nil || !ok {
+		// rows that aren't on a branch can't be changed by other transactions, so there is nothing to lock
+		return newProjectingRowIter(t, iter), err
+	}
+
+	table, err := t.DoltTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns := table.NodeStore()
+
+	itr := &lockingRowIter{
+		t:       t,
+		iter:    iter,
+		read:    read,
+		sess:    sess,
+		target:  target,
+		keyless: schema.IsKeyless(t.sch),
+		ns:      ns,
+		pool:    pool.NewBuffPool(),
+		pkOrds:  t.sch.GetPkOrdinals(),
+		proj:    newProjectingRowIter(t, nil),
+	}
+	if itr.keyless {
+		return itr, nil
+	}
+	itr.kb = val.NewTupleBuilder(t.sch.GetKeyDescriptor(ns), ns)
+	itr.schHash, err = table.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if idx != nil {
+		if rng, ok := index.PartitionRange(part); ok {
+			itr.rng = &rng
+			if !idx.IsPrimaryKey() {
+				def := t.sch.Indexes().GetByName(idx.ID())
+				secondary, err := table.GetIndexRowData(ctx, idx.ID())
+				if err != nil {
+					return nil, err
+				}
+				m, err := durable.ProllyMapFromIndex(secondary)
+				if err != nil {
+					return nil, err
+				}
+				secKd, _ := m.Descriptors()
+				skb, err := index.NewSecondaryKeyBuilder(ctx, t.Name(), t.sch, def, secKd, itr.pool, ns)
+				if err != nil {
+					return nil, err
+				}
+				itr.secondaryKeys = &skb
+			}
+		}
+	}
+	return itr, nil
+}
+
+// newLockingIndexRowIter returns the rows of |part| of the index |idx| of |t| for the locking read |read|. Locking reads
+// don't use the cached lookup builders of indexed tables, which are built for the projections of the table.
+func newLockingIndexRowIter(ctx *sql.Context, t *DoltTable, idx index.DoltIndex, key doltdb.DataCacheKey, read *dsess.LockingRead, part sql.Partition) (sql.RowIter, error) {
+	lb, err := index.NewIndexReaderBuilder(ctx, t, idx, key, t.allColumnTags(), t.sqlSch)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := lb.NewPartitionRowIter(ctx, part)
+	if err != nil {
+		return nil, err
+	}
+	return newLockingRowIter(ctx, t, read, iter, idx, part)
+}
+
+// lockingRowIter takes the row locks of a locking read. The rows it locks were read from the snapshot of the current
+// transaction, but the transaction that held a lock before this one might have committed changes to the row since
+// then. So once a row is locked, it's compared between the start of the transaction and the latest commit to its
+// branch. A row that changed is returned as it was committed, and the transaction is advanced past that commit so
+// that it goes on to update the latest version of the row, or is rolled back with a retryable error when it can't be.
+type lockingRowIter struct {
+	t      *DoltTable
+	iter   sql.RowIter
+	read   *dsess.LockingRead
+	sess   *dsess.DoltSession
+	target dsess.RowLockTarget
+	proj   *projectingRowIter
+
+	keyless bool
+	ns      tree.NodeStore
+	pool    pool.BuffPool
+	kb      *val.TupleBuilder
+	pkOrds  []int
+	schHash hash.Hash
+
+	// rng is the range of index keys the rows were read from, which a changed row must still be in to be returned
+	rng           *prolly.Range
+	secondaryKeys *index.SecondaryKeyBuilder
+
+	// start and latest are the rows of the table at the start of the transaction and at the latest commit, loaded
+	// when a row is first compared. latest is loaded again after a lock wait, or when any session releases its locks.
+	start, latest  *prolly.Map
+	startLoaded    bool
+	latestLoaded   bool
+	latestReleases uint64
+	// startMissing is set when the table didn't exist at the start of the transaction, and changed is set when it
+	// no longer exists or has a different schema in the latest commit
+	startMissing, changed bool
+}
+
+var _ sql.RowIter = (*lockingRowIter)(nil)
+
+func (itr *lockingRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		row, err := itr.iter.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var key val.Tuple
+		var lockKey, data string
+		if itr.keyless {
+			data = formatLockedValues(row)
+			lockKey = data
+		} else {
+			key, err = itr.primaryKey(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			lockKey = string(key)
+			data = formatLockedValues(pkValues(row, itr.pkOrds))
+		}
+
+		acquired, waited, err := itr.sess.LockRow(ctx, dsess.RowLockKey{
+			Database: itr.target.Database,
+			Branch:   itr.target.Branch,
+			Table:    strings.ToLower(itr.t.Name()),
+			Key:      lockKey,
+		}, data, itr.read)
+		if err != nil {
+			return nil, err
+		} else if !acquired {
+			continue
+		}
+
+		if !itr.keyless {
+			var ok bool
+			row, ok, err = itr.latestRow(ctx, key, row, waited)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		return itr.proj.project(row), nil
+	}
+}
+
+// primaryKey returns the primary key tuple of |row|
+func (itr *lockingRowIter) primaryKey(ctx context.Context, row sql.Row) (val.Tuple, error) {
+	for i, ord := range itr.pkOrds {
+		if err := tree.PutField(ctx, itr.ns, itr.kb, i, row[ord]); err != nil {
+			return nil, err
+		}
+	}
+	return itr.kb.Build(ctx, itr.pool)
+}
+
+// latestRow returns the row to return for the locked row |row| with primary key |key|. That's |row| unless a
+// transaction committed a change to the row since the current one began, when it's the committed version of the row,
+// or false if that was deleted or no longer in the range of index keys read.
+func (itr *lockingRowIter) latestRow(ctx *sql.Context, key val.Tuple, row sql.Row, waited bool) (sql.Row, bool, error) {
+	if err := itr.loadStart(ctx); err != nil {
+		return nil, false, err
+	}
+	if itr.startMissing {
+		// the table was created by this transaction, so no other transaction can have changed its rows
+		return row, true, nil
+	}
+	if err := itr.loadLatest(ctx, waited); err != nil {
+		return nil, false, err
+	}
+	if itr.changed {
+		return nil, false, dsess.AbortLockingRead(ctx, itr.t.Name())
+	}
+
+	startVal, startOk, err := getTuple(ctx, itr.start, key)
+	if err != nil {
+		return nil, false, err
+	}
+	latestVal, latestOk, err := getTuple(ctx, itr.latest, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if startOk == latestOk && bytes.Equal(startVal, latestVal) {
+		return row, true, nil
+	}
+
+	if err = itr.sess.AdvanceTransaction(ctx, itr.target, itr.t.Name()); err != nil {
+		return nil, false, err
+	}
+	itr.start = itr.latest
+	if !latestOk {
+		return nil, false, nil
+	}
+
+	if itr.rng != nil {
+		indexKey := key
+		if itr.secondaryKeys != nil {
+			indexKey, err = itr.secondaryKeys.SecondaryKeyFromRow(ctx, key, latestVal)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		ok, err := itr.rng.Matches(ctx, indexKey)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+	}
+	row, err = index.BuildRow(ctx, key, latestVal, itr.t.sch, itr.ns)
+	if err != nil {
+		return nil, false, err
+	}
+	return row, true, nil
+}
+
+func (itr *lockingRowIter) loadStart(ctx *sql.Context) error {
+	if itr.startLoaded {
+		return nil
+	}
+	root, ok, err := itr.sess.TransactionStartRoot(ctx, itr.target)
+	if err != nil {
+		return err
+	}
+	itr.startLoaded = true
+	if !ok {
+		// the branch was created by this transaction
+		itr.startMissing = true
+		return nil
+	}
+	m, ok, err := itr.tableRows(ctx, root)
+	if err != nil {
+		return err
+	}
+	itr.start, itr.startMissing = m, !ok
+	return nil
+}
+
+func (itr *lockingRowIter) loadLatest(ctx *sql.Context, waited bool) error {
+	releases := itr.sess.RowLockReleases()
+	if itr.latestLoaded && !waited && releases == itr.latestReleases {
+		return nil
+	}
+	root, err := itr.target.LatestRoot(ctx)
+	if err != nil {
+		return err
+	}
+	m, ok, err := itr.tableRows(ctx, root)
+	if err != nil {
+		return err
+	}
+	itr.latest, itr.changed = m, !ok
+	itr.latestLoaded, itr.latestReleases = true, releases
+	return nil
+}
+
+// tableRows returns the rows of this iterator's table in |root|. Returns false if the table doesn't exist there, or
+// has a different schema, which locking reads don't return rows of.
+func (itr *lockingRowIter) tableRows(ctx *sql.Context, root doltdb.RootValue) (*prolly.Map, bool, error) {
+	table, ok, err := root.GetTable(ctx, itr.t.TableName())
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	schHash, err := table.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if schHash != itr.schHash {
+		return nil, false, nil
+	}
+	idx, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	m, err := durable.ProllyMapFromIndex(idx)
+	if err != nil {
+		return nil, false, err
+	}
+	return &m, true, nil
+}
+
+func (itr *lockingRowIter) Close(ctx *sql.Context) error {
+	return itr.iter.Close(ctx)
+}
+
+// getTuple returns the value stored for |key| in |m|
+func getTuple(ctx context.Context, m *prolly.Map, key val.Tuple) (val.Tuple, bool, error) {
+	var value val.Tuple
+	var ok bool
+	err := m.Get(ctx, key, func(k, v val.Tuple) error {
+		if k != nil {
+			value, ok = v, true
+		}
+		return nil
+	})
+	return value, ok, err
+}
+
+func pkValues(row sql.Row, pkOrds []int) sql.Row {
+	values := make(sql.Row, len(pkOrds))
+	for i, ord := range pkOrds {
+		values[i] = row[ord]
+	}
+	return values
+}
+
+// formatLockedValues formats the key of a locked row for display in the dolt_locks system table
+func formatLockedValues(values sql.Row) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+			parts[i] = "NULL"
+		case string:
+			parts[i] = fmt.Sprintf("'%s'", v)
+		default:
+			parts[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// projectingRowIter projects rows with every column of a table down to the projected columns of the table
+type projectingRowIter struct {
+	iter sql.RowIter
+	// ordinals are the positions of the projected columns in a row with every column, nil when every column is projected
+	ordinals []int
+}
+
+func newProjectingRowIter(t *DoltTable, iter sql.RowIter) *projectingRowIter {
+	itr := &projectingRowIter{iter: iter}
+	if t.projectedCols != nil {
+		tagToIdx := t.sch.GetAllCols().TagToIdx
+		itr.ordinals = make([]int, len(t.projectedCols))
+		for i, tag := range t.projectedCols {
+			itr.ordinals[i] = tagToIdx[tag]
+		}
+	}
+	return itr
+}
+
+func (itr *projectingRowIter) project(row sql.Row) sql.Row {
+	if itr.ordinals == nil {
+		return row
+	}
+	projected := make(sql.Row, len(itr.ordinals))
+	for i, ord := range itr.ordinals {
+		projected[i] = row[ord]
+	}
+	return projected
+}
+
+func (itr *projectingRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	row, err := itr.iter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return itr.project(row), nil
+}
+
+func (itr *projectingRowIter) Close(ctx *sql.Context) error {
+	return itr.iter.Close(ctx)
+}
@@ -0,0 +1,448 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matview implements materialized views: tables holding the result of a query of another table, which are
+// refreshed by applying the changes to the rows of that table since the last refresh rather than by running the query
+// again. The views of a database are defined in the dolt_materialized_views system table, and are refreshed on every
+// commit, so the table of a view is up to date with its query in every commit, or on demand with
+// dolt_refresh_materialized_view.
+package matview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/prolly"
+)
+
+// ErrViewNotFound is returned when a materialized view that doesn't exist is refreshed or dropped
+var ErrViewNotFound = errors.NewKind("materialized view %s does not exist")
+
+func init() {
+	dsess.RefreshMaterializedViews = refreshOnCommit
+	dprocedures.MaterializedViewsImpl = procedures{}
+}
+
+// procedures implements the materialized view procedures of dprocedures
+type procedures struct{}
+
+var _ dprocedures.MaterializedViews = procedures{}
+
+func (procedures) Create(ctx *sql.Context, dbName, name, query string) error {
+	return Create(ctx, dbName, name, query)
+}
+
+func (procedures) Refresh(ctx *sql.Context, dbName string, names []string, full bool) error {
+	return Refresh(ctx, dbName, names, full)
+}
+
+func (procedures) Drop(ctx *sql.Context, dbName, name string) error {
+	return Drop(ctx, dbName, name)
+}
+
+// Definition is a materialized view of a database, as stored in the dolt_materialized_views table
+type Definition struct {
+	// Name is the name of the view and of the table its rows are stored in
+	Name string
+	// Query is the query the rows of the view are the result of
+	Query string
+}
+
+// LoadDefinitions returns the materialized views defined in |root|, in the order of their names
+func LoadDefinitions(ctx context.Context, root doltdb.RootValue) ([]Definition, error) {
+	tbl, ok, err := root.GetTable(ctx, dtables.GetDoltMaterializedViewsTableName())
+	if err != nil || !ok {
+		return nil, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := tableRows(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	codec := newRowCodec(sch, m.NodeStore())
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var defs []Definition
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return defs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		row, err := codec.decode(ctx, k, v)
+		if err != nil {
+			return nil, err
+		}
+		name, _ := row[0].(string)
+		query, _ := row[1].(string)
+		defs = append(defs, Definition{Name: name, Query: query})
+	}
+}
+
+// findDefinition returns the definition of the view |name| in |defs|, matched case-insensitively like table names
+func findDefinition(defs []Definition, name string) (Definition, bool) {
+	for _, def := range defs {
+		if strings.EqualFold(def.Name, name) {
+			return def, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Create creates the materialized view |name| of |query| in the working set of the database |dbName|, and fills its
+// table with the result of the query
+func Create(ctx *sql.Context, dbName, name, query string) error {
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	working := roots.Working
+
+	if !doltdb.IsValidTableName(name) || doltdb.HasDoltPrefix(name) {
+		return fmt.Errorf("%w: %s", doltdb.ErrInvTableName, name)
+	}
+	if _, ok, err := working.GetTable(ctx, doltdb.TableName{Name: name}); err != nil {
+		return err
+	} else if ok {
+		return sql.ErrTableAlreadyExists.New(name)
+	}
+
+	v, err := resolveView(ctx, dbName, Definition{Name: name, Query: query})
+	if err != nil {
+		return err
+	}
+	sch, err := viewSchema(ctx, sess, working, name, query, v)
+	if err != nil {
+		return err
+	}
+
+	working, err = doltdb.CreateEmptyTable(ctx, working, doltdb.TableName{Name: name}, sch)
+	if err != nil {
+		return err
+	}
+	working, err = putDefinition(ctx, working, Definition{Name: name, Query: query})
+	if err != nil {
+		return err
+	}
+	working, err = refreshView(ctx, roots.Head, working, v, true)
+	if err != nil {
+		return err
+	}
+	return sess.SetWorkingRoot(ctx, dbName, working)
+}
+
+// Refresh refreshes the materialized views |names|, or all views if there are none, in the working set of the
+// database |dbName|. Unless |full| is set, a view is refreshed by applying the changes to the rows of its table since
+// HEAD to the table of the view in HEAD.
+func Refresh(ctx *sql.Context, dbName string, names []string, full bool) error {
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	defs, err := LoadDefinitions(ctx, roots.Working)
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		var selected []Definition
+		for _, name := range names {
+			def, ok := findDefinition(defs, name)
+			if !ok {
+				return ErrViewNotFound.New(name)
+			}
+			selected = append(selected, def)
+		}
+		defs = selected
+	}
+
+	working := roots.Working
+	for _, def := range defs {
+		v, err := resolveView(ctx, dbName, def)
+		if err != nil {
+			return err
+		}
+		working, err = refreshView(ctx, roots.Head, working, v, full)
+		if err != nil {
+			return err
+		}
+	}
+	return sess.SetWorkingRoot(ctx, dbName, working)
+}
+
+// Drop drops the materialized view |name| and its table from the working set of the database |dbName|
+func Drop(ctx *sql.Context, dbName, name string) error {
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	defs, err := LoadDefinitions(ctx, roots.Working)
+	if err != nil {
+		return err
+	}
+	def, ok := findDefinition(defs, name)
+	if !ok {
+		return ErrViewNotFound.New(name)
+	}
+
+	working, err := deleteDefinition(ctx, roots.Working, def.Name)
+	if err != nil {
+		return err
+	}
+	if _, ok, err := working.GetTable(ctx, doltdb.TableName{Name: def.Name}); err != nil {
+		return err
+	} else if ok {
+		working, err = working.RemoveTables(ctx, false, false, doltdb.TableName{Name: def.Name})
+		if err != nil {
+			return err
+		}
+	}
+	return sess.SetWorkingRoot(ctx, dbName, working)
+}
+
+// refreshOnCommit refreshes the materialized views of the staged root of |roots| before it's committed. A view is
+// only refreshed when both its definition and its table are staged. The refreshed table is also written to the
+// working root when the view and its table are the same there, so that committing doesn't leave it modified.
+func refreshOnCommit(ctx *sql.Context, dbName string, roots doltdb.Roots) (doltdb.Roots, error) {
+	defs, err := LoadDefinitions(ctx, roots.Staged)
+	if err != nil || len(defs) == 0 {
+		return roots, err
+	}
+	workingDefs, err := LoadDefinitions(ctx, roots.Working)
+	if err != nil {
+		return roots, err
+	}
+
+	for _, def := range defs {
+		viewName := doltdb.TableName{Name: def.Name}
+		staged, ok, err := roots.Staged.GetTable(ctx, viewName)
+		if err != nil {
+			return roots, err
+		} else if !ok {
+			continue
+		}
+
+		v, err := resolveView(ctx, dbName, def)
+		if err != nil {
+			return roots, fmt.Errorf("materialized view %s could not be refreshed: %w", def.Name, err)
+		}
+		stagedBase, ok, err := roots.Staged.GetTable(ctx, doltdb.TableName{Name: v.shape.table})
+		if err != nil {
+			return roots, err
+		} else if !ok {
+			ctx.Warn(0, "materialized view %s was not refreshed because table %s is not staged", def.Name, v.shape.table)
+			continue
+		}
+
+		refreshed, err := refreshView(ctx, roots.Head, roots.Staged, v, false)
+		if err != nil {
+			return roots, fmt.Errorf("materialized view %s could not be refreshed: %w", def.Name, err)
+		}
+		refreshedTbl, _, err := refreshed.GetTable(ctx, viewName)
+		if err != nil {
+			return roots, err
+		}
+		roots.Staged = refreshed
+
+		workingDef, ok := findDefinition(workingDefs, def.Name)
+		if !ok || workingDef.Query != def.Query {
+			continue
+		}
+		working, ok, err := roots.Working.GetTable(ctx, viewName)
+		if err != nil {
+			return roots, err
+		} else if !ok || !sameTable(working, staged) {
+			continue
+		}
+		workingBase, ok, err := roots.Working.GetTable(ctx, doltdb.TableName{Name: v.shape.table})
+		if err != nil {
+			return roots, err
+		} else if !ok || !sameTable(workingBase, stagedBase) {
+			continue
+		}
+		roots.Working, err = roots.Working.PutTable(ctx, viewName, refreshedTbl)
+		if err != nil {
+			return roots, err
+		}
+	}
+	return roots, nil
+}
+
+// viewSchema returns the schema of the table of the view |name| of |query|. The key of the table is a hidden column
+// identifying the rows of the view, followed by the columns of the query and, for aggregate views, hidden columns
+// counting the rows of each group.
+func viewSchema(ctx *sql.Context, sess *dsess.DoltSession, root doltdb.RootValue, name, query string, v *view) (schema.Schema, error) {
+	resultSch, err := querySchema(ctx, sess, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(resultSch) != len(v.shape.columns) {
+		return nil, fmt.Errorf("materialized view %s: expected %d columns, query returned %d", name, len(v.shape.columns), len(resultSch))
+	}
+
+	sqlSch := sql.Schema{{Name: keyColumn, Type: keyType, PrimaryKey: true, HiddenSystem: true, Source: name}}
+	for i, col := range resultSch {
+		typ := col.Type
+		if v.shape.columns[i].kind == sumColumn {
+			argType := v.exprs[i].Type(ctx)
+			var ok bool
+			if typ, ok = sumType(argType); !ok {
+				return nil, ErrUnsupportedQuery.New(name, fmt.Sprintf("it sums %s, whose type is %s", col.Name, argType.String()))
+			}
+		}
+		sqlSch = append(sqlSch, &sql.Column{Name: col.Name, Type: typ, Nullable: true, Source: name})
+	}
+	if v.shape.aggregate {
+		sqlSch = append(sqlSch, &sql.Column{Name: countColumnName, Type: countType, Source: name, HiddenSystem: true})
+		for i, col := range v.shape.columns {
+			if col.kind == sumColumn {
+				sqlSch = append(sqlSch, &sql.Column{Name: sumCountColumnName(i), Type: countType, Source: name, HiddenSystem: true})
+			}
+		}
+	}
+	return sqlutil.ToDoltSchema(ctx, root, doltdb.TableName{Name: name}, sql.NewPrimaryKeySchema(sqlSch), nil, sql.Collation_Default)
+}
+
+// querySchema returns the schema of the result of |query|, which runs in the session, and so is checked against the
+// privileges of its user. The rows of the result aren't read.
+func querySchema(ctx *sql.Context, sess *dsess.DoltSession, query string) (sql.Schema, error) {
+	runner := sess.Provider().StatementRunner()
+	if runner == nil {
+		return nil, fmt.Errorf("cannot create a materialized view: no engine is registered with the database provider")
+	}
+
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	ctx.SetIgnoreAutoCommit(true)
+	defer ctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+
+	sch, iter, _, err := runner.QueryWithBindings(ctx, query, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = iter.Close(ctx); err != nil {
+		return nil, err
+	}
+	return sch, nil
+}
+
+// putDefinition writes |def| to the dolt_materialized_views table of |root|, creating the table if needed
+func putDefinition(ctx *sql.Context, root doltdb.RootValue, def Definition) (doltdb.RootValue, error) {
+	tblName := dtables.GetDoltMaterializedViewsTableName()
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		sch, err := sqlutil.ToDoltSchema(ctx, root, tblName, sql.NewPrimaryKeySchema(dtables.GetDoltMaterializedViewsSchema()), nil, sql.Collation_Default)
+		if err != nil {
+			return nil, err
+		}
+		if root, err = doltdb.CreateEmptyTable(ctx, root, tblName, sch); err != nil {
+			return nil, err
+		}
+		if tbl, _, err = root.GetTable(ctx, tblName); err != nil {
+			return nil, err
+		}
+	}
+	return updateDefinitions(ctx, root, tbl, func(mut *prolly.MutableMap, codec *rowCodec) error {
+		k, v, err := codec.encode(ctx, sql.Row{def.Name, def.Query})
+		if err != nil {
+			return err
+		}
+		return mut.Put(ctx, k, v)
+	})
+}
+
+// deleteDefinition deletes the view |name| from the dolt_materialized_views table of |root|, and drops the table
+// when it has no views left
+func deleteDefinition(ctx *sql.Context, root doltdb.RootValue, name string) (doltdb.RootValue, error) {
+	tblName := dtables.GetDoltMaterializedViewsTableName()
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return root, err
+	}
+	root, err = updateDefinitions(ctx, root, tbl, func(mut *prolly.MutableMap, codec *rowCodec) error {
+		k, _, err := codec.encode(ctx, sql.Row{name, ""})
+		if err != nil {
+			return err
+		}
+		return mut.Delete(ctx, k)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if defs, err := LoadDefinitions(ctx, root); err != nil {
+		return nil, err
+	} else if len(defs) == 0 {
+		return root.RemoveTables(ctx, true, false, tblName)
+	}
+	return root, nil
+}
+
+func updateDefinitions(ctx *sql.Context, root doltdb.RootValue, tbl *doltdb.Table, update func(*prolly.MutableMap, *rowCodec) error) (doltdb.RootValue, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := tableRows(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	mut := m.Mutate()
+	if err = update(mut, newRowCodec(sch, m.NodeStore())); err != nil {
+		return nil, err
+	}
+	if m, err = mut.Map(ctx); err != nil {
+		return nil, err
+	}
+	if tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m)); err != nil {
+		return nil, err
+	}
+	return root.PutTable(ctx, dtables.GetDoltMaterializedViewsTableName(), tbl)
+}
+
+func tableRows(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+func sameTable(a, b *doltdb.Table) bool {
+	ha, errA := a.HashOf()
+	hb, errB := b.HashOf()
+	return errA == nil && errB == nil && ha == hb
+}
@@ -0,0 +1,736 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matview
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// keyColumn is the primary key of the table of a view. For views that don't group rows it's the hash of the key
+	// of the row of the table the view's row was selected from, otherwise the hash of the values grouped by.
+	keyColumn = sql.HiddenSystemColumnPrefix + "dolt_key"
+	// countColumnName is the number of rows of the table in a group of an aggregate view
+	countColumnName = sql.HiddenSystemColumnPrefix + "dolt_count"
+)
+
+var keyType = types.MustCreateBinary(sqltypes.Binary, hash.ByteLen)
+
+var countType = types.Int64
+
+// sumCountColumnName is the name of the column counting the non-NULL values summed by the |i|th column of a view,
+// whose sum is NULL when there are none
+func sumCountColumnName(i int) string {
+	return fmt.Sprintf("%s!%d", countColumnName, i)
+}
+
+// sumMaxPrecision is the precision of the sums of integers and decimals
+const sumMaxPrecision = 65
+
+// sumType returns the type of the column of a view that sums values of type |typ|, or false if they can't be summed.
+// Sums of integers and decimals are decimals, as in MySQL, so that adding and subtracting the values of changed rows
+// keeps them exact. Only sums of floating point numbers are doubles.
+func sumType(typ sql.Type) (sql.Type, bool) {
+	switch {
+	case types.IsFloat(typ):
+		return types.Float64, true
+	case types.IsInteger(typ):
+		return types.MustCreateDecimalType(sumMaxPrecision, 0), true
+	case types.IsDecimal(typ):
+		return types.MustCreateDecimalType(sumMaxPrecision, typ.(sql.DecimalType).Scale()), true
+	default:
+		return nil, false
+	}
+}
+
+// view is a materialized view with its query resolved against the table it selects from
+type view struct {
+	def   Definition
+	shape *shape
+	// sch is the schema of the table that the expressions of the view were resolved against
+	sch schema.Schema
+	// filter is the WHERE clause of the view, or nil
+	filter sql.Expression
+	// exprs are the expressions the columns of the view are computed from, nil for COUNT(*)
+	exprs []sql.Expression
+}
+
+// resolveView resolves the query of |def| against the table it selects from in the working set of |dbName|
+func resolveView(ctx *sql.Context, dbName string, def Definition) (*view, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	tableSchema := func(table string) (schema.Schema, error) {
+		tbl, ok, err := roots.Working.GetTable(ctx, doltdb.TableName{Name: table})
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, sql.ErrTableNotFound.New(table)
+		}
+		return tbl.GetSchema(ctx)
+	}
+
+	s, err := parseShape(ctx, def.Name, def.Query, func(table string) ([]string, error) {
+		sch, err := tableSchema(table)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, col := range sch.GetAllCols().GetColumns() {
+			if !col.SystemHidden {
+				names = append(names, col.Name)
+			}
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sch, err := tableSchema(s.table)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, ErrUnsupportedQuery.New(def.Name, "it selects from a table without a primary key")
+	}
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if col.Virtual {
+			return nil, ErrUnsupportedQuery.New(def.Name, "it selects from a table with virtual columns")
+		}
+	}
+
+	v := &view{def: def, shape: s, sch: sch, exprs: make([]sql.Expression, len(s.columns))}
+	tableName := quoteIdentifier(dbName) + "." + quoteIdentifier(s.table)
+	if s.where != nil {
+		if v.filter, err = expranalysis.ResolveExpression(ctx, tableName, sqlparser.String(s.where)); err != nil {
+			return nil, err
+		}
+	}
+	for i, col := range s.columns {
+		if col.expr == nil {
+			continue
+		}
+		if v.exprs[i], err = expranalysis.ResolveExpression(ctx, tableName, sqlparser.String(col.expr)); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// refreshView returns |target| with the table of |v| refreshed. Unless |full| is set, and as long as the view, its
+// table and the table it selects from have the same definition and schemas in |head|, the table of the view in
+// |head| is updated with the difference between the rows of the table it selects from in |head| and |target|.
+// Otherwise the table of the view is built from scratch.
+func refreshView(ctx *sql.Context, head, target doltdb.RootValue, v *view, full bool) (doltdb.RootValue, error) {
+	viewName := doltdb.TableName{Name: v.def.Name}
+	viewTbl, ok, err := target.GetTable(ctx, viewName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(v.def.Name)
+	}
+	viewSch, err := viewTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseTbl, ok, err := target.GetTable(ctx, doltdb.TableName{Name: v.shape.table})
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(v.shape.table)
+	}
+	baseSch, err := baseTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	to, err := tableRows(ctx, baseTbl)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, viewRows prolly.Map
+	headView, headFrom, incremental, err := headState(ctx, head, v, viewSch, baseSch)
+	if err != nil {
+		return nil, err
+	}
+	incremental = incremental && !full
+	if incremental {
+		viewTbl, from = headView, headFrom
+		if viewRows, err = tableRows(ctx, viewTbl); err != nil {
+			return nil, err
+		}
+	} else {
+		if from, err = emptyRows(ctx, baseTbl, baseSch); err != nil {
+			return nil, err
+		}
+		if viewRows, err = emptyRows(ctx, viewTbl, viewSch); err != nil {
+			return nil, err
+		}
+		if viewTbl, err = viewTbl.ClearConflicts(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := newRefresher(ctx, v, viewSch, baseSch, viewRows)
+	if err != nil {
+		return nil, err
+	}
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, diff tree.Diff) error {
+		return r.apply(ctx, val.Tuple(diff.Key), val.Tuple(diff.From), val.Tuple(diff.To))
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if err = r.flush(ctx); err != nil {
+		return nil, err
+	}
+	m, err := r.mut.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if incremental && m.HashOf() == viewRows.HashOf() {
+		return target.PutTable(ctx, viewName, viewTbl)
+	}
+	if viewTbl, err = viewTbl.UpdateRows(ctx, durable.IndexFromProllyMap(m)); err != nil {
+		return nil, err
+	}
+	// Secondary indexes that were added to the table of the view are rebuilt from its new rows
+	for _, idx := range viewSch.Indexes().AllIndexes() {
+		rows, err := creation.BuildSecondaryIndex(ctx, viewTbl, idx, v.def.Name, editor.Options{}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if viewTbl, err = viewTbl.SetIndexRows(ctx, idx.Name(), rows); err != nil {
+			return nil, err
+		}
+	}
+	return target.PutTable(ctx, viewName, viewTbl)
+}
+
+// headState returns the table of view |v| in |head| and the rows of the table it selects from there, if they can be
+// brought up to date by applying a diff, which requires the same query and schemas as in the root being refreshed.
+func headState(ctx *sql.Context, head doltdb.RootValue, v *view, viewSch, baseSch schema.Schema) (*doltdb.Table, prolly.Map, bool, error) {
+	defs, err := LoadDefinitions(ctx, head)
+	if err != nil {
+		return nil, prolly.Map{}, false, err
+	}
+	if def, ok := findDefinition(defs, v.def.Name); !ok || def.Query != v.def.Query {
+		return nil, prolly.Map{}, false, nil
+	}
+	headView, ok, err := head.GetTable(ctx, doltdb.TableName{Name: v.def.Name})
+	if err != nil || !ok {
+		return nil, prolly.Map{}, false, err
+	}
+	headBase, ok, err := head.GetTable(ctx, doltdb.TableName{Name: v.shape.table})
+	if err != nil || !ok {
+		return nil, prolly.Map{}, false, err
+	}
+	for _, pair := range []struct {
+		tbl *doltdb.Table
+		sch schema.Schema
+	}{{headView, viewSch}, {headBase, baseSch}} {
+		sch, err := pair.tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, prolly.Map{}, false, err
+		}
+		if !schema.SchemasAreEqual(sch, pair.sch) {
+			return nil, prolly.Map{}, false, nil
+		}
+	}
+	from, err := tableRows(ctx, headBase)
+	if err != nil {
+		return nil, prolly.Map{}, false, err
+	}
+	return headView, from, true, nil
+}
+
+func emptyRows(ctx context.Context, tbl *doltdb.Table, sch schema.Schema) (prolly.Map, error) {
+	idx, err := durable.NewEmptyPrimaryIndex(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), sch)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+// refresher applies the changes to the rows of the table a view selects from to the rows of the view
+type refresher struct {
+	v   *view
+	mut *prolly.MutableMap
+	// conv converts the rows of the table to the schema the expressions of the view were resolved against
+	conv    dtables.ProllyRowConverter
+	rowLen  int
+	codec   *rowCodec
+	ns      tree.NodeStore
+	viewSch sql.Schema
+	// colIdx are the positions of the columns of the view in its rows
+	colIdx []int
+	// countIdx is the position of the count of rows of a group, and sumCountIdx of the count of values summed by each
+	// SUM column
+	countIdx    int
+	sumCountIdx []int
+	// groups are the changes to the groups of an aggregate view, by their keys
+	groups map[hash.Hash]*groupDelta
+}
+
+// groupDelta is the change to a group of an aggregate view
+type groupDelta struct {
+	// values are the values grouped by, and the change to each SUM
+	values sql.Row
+	// rows is the change to the number of rows in the group
+	rows int64
+	// counts is the change to each COUNT, or to the number of values summed by each SUM
+	counts []int64
+}
+
+func newRefresher(ctx *sql.Context, v *view, viewSch, baseSch schema.Schema, viewRows prolly.Map) (*refresher, error) {
+	ns := viewRows.NodeStore()
+	conv, err := dtables.NewProllyRowConverter(ctx, baseSch, v.sch, ctx.Warn, ns)
+	if err != nil {
+		return nil, err
+	}
+	sqlSch := make(sql.Schema, 0, viewSch.GetAllCols().Size())
+	for _, col := range viewSch.GetAllCols().GetColumns() {
+		sqlSch = append(sqlSch, &sql.Column{Name: col.Name, Type: col.TypeInfo.ToSqlType()})
+	}
+
+	r := &refresher{
+		v:           v,
+		mut:         viewRows.Mutate(),
+		conv:        conv,
+		rowLen:      v.sch.GetAllCols().Size(),
+		codec:       newRowCodec(viewSch, ns),
+		ns:          ns,
+		viewSch:     sqlSch,
+		countIdx:    sqlSch.IndexOfColName(countColumnName),
+		sumCountIdx: make([]int, len(v.shape.columns)),
+		groups:      make(map[hash.Hash]*groupDelta),
+	}
+	for i, col := range sqlSch {
+		if !strings.HasPrefix(col.Name, sql.HiddenSystemColumnPrefix) {
+			r.colIdx = append(r.colIdx, i)
+		}
+	}
+	if len(r.colIdx) != len(v.shape.columns) || sqlSch.IndexOfColName(keyColumn) != 0 || (v.shape.aggregate && r.countIdx < 0) {
+		return nil, fmt.Errorf("the table of materialized view %s doesn't match its query, drop and create the view again", v.def.Name)
+	}
+	for i, col := range v.shape.columns {
+		r.sumCountIdx[i] = -1
+		if col.kind == sumColumn {
+			if r.sumCountIdx[i] = sqlSch.IndexOfColName(sumCountColumnName(i)); r.sumCountIdx[i] < 0 {
+				return nil, fmt.Errorf("the table of materialized view %s doesn't match its query, drop and create the view again", v.def.Name)
+			}
+		}
+	}
+	return r, nil
+}
+
+// apply applies the change of the row |key| of the table from |from| to |to|, either of which may be nil
+func (r *refresher) apply(ctx *sql.Context, key, from, to val.Tuple) error {
+	var oldRow, newRow sql.Row
+	var err error
+	if from != nil {
+		if oldRow, err = r.selected(ctx, key, from); err != nil {
+			return err
+		}
+	}
+	if to != nil {
+		if newRow, err = r.selected(ctx, key, to); err != nil {
+			return err
+		}
+	}
+
+	if r.v.shape.aggregate {
+		if oldRow != nil {
+			if err = r.accumulate(ctx, oldRow, -1); err != nil {
+				return err
+			}
+		}
+		if newRow != nil {
+			return r.accumulate(ctx, newRow, 1)
+		}
+		return nil
+	}
+
+	viewKey := hash.Of(key)
+	if newRow != nil {
+		row, err := r.project(ctx, newRow)
+		if err != nil {
+			return err
+		}
+		row[0] = viewKey[:]
+		k, v, err := r.codec.encode(ctx, row)
+		if err != nil {
+			return err
+		}
+		return r.mut.Put(ctx, k, v)
+	} else if oldRow != nil {
+		k, _, err := r.codec.encode(ctx, sql.Row{viewKey[:]})
+		if err != nil {
+			return err
+		}
+		return r.mut.Delete(ctx, k)
+	}
+	return nil
+}
+
+// selected returns the row of the table stored as |key| and |value| if it passes the filter of the view, or nil
+func (r *refresher) selected(ctx *sql.Context, key, value val.Tuple) (sql.Row, error) {
+	row := make(sql.Row, r.rowLen)
+	if err := r.conv.PutConverted(ctx, key, value, row); err != nil {
+		return nil, err
+	}
+	if r.v.filter == nil {
+		return row, nil
+	}
+	res, err := sql.EvaluateCondition(ctx, r.v.filter, row)
+	if err != nil {
+		return nil, err
+	}
+	if !sql.IsTrue(res) {
+		return nil, nil
+	}
+	return row, nil
+}
+
+// project returns the row of a view that doesn't group rows selected from the table |row|
+func (r *refresher) project(ctx *sql.Context, row sql.Row) (sql.Row, error) {
+	viewRow := make(sql.Row, len(r.viewSch))
+	for i, expr := range r.v.exprs {
+		value, err := r.eval(ctx, expr, row, i)
+		if err != nil {
+			return nil, err
+		}
+		viewRow[r.colIdx[i]] = value
+	}
+	return viewRow, nil
+}
+
+// eval evaluates |expr| on |row|, converting the result to the type of the |i|th column of the view
+func (r *refresher) eval(ctx *sql.Context, expr sql.Expression, row sql.Row, i int) (any, error) {
+	value, err := expr.Eval(ctx, row)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	value, _, err = r.viewSch[r.colIdx[i]].Type.Convert(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	return sql.UnwrapAny(ctx, value)
+}
+
+// accumulate adds the row |row| selected from the table to its group if |sign| is 1, or removes it if |sign| is -1
+func (r *refresher) accumulate(ctx *sql.Context, row sql.Row, sign int64) error {
+	values := make(sql.Row, len(r.v.shape.columns))
+	for i, col := range r.v.shape.columns {
+		if col.kind != expressionColumn {
+			continue
+		}
+		value, err := r.eval(ctx, r.v.exprs[i], row, i)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	key, err := r.groupKey(ctx, values)
+	if err != nil {
+		return err
+	}
+	g, ok := r.groups[key]
+	if !ok {
+		g = &groupDelta{values: values, counts: make([]int64, len(values))}
+		r.groups[key] = g
+	}
+
+	g.rows += sign
+	for i, col := range r.v.shape.columns {
+		switch col.kind {
+		case countStarColumn:
+			g.counts[i] += sign
+		case countColumn:
+			value, err := r.v.exprs[i].Eval(ctx, row)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				g.counts[i] += sign
+			}
+		case sumColumn:
+			value, err := r.eval(ctx, r.v.exprs[i], row, i)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				g.counts[i] += sign
+				if g.values[i], err = addSum(g.values[i], value, sign); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// groupKey returns the key of the row of the group with the values |values|. Strings are compared by their collation,
+// so strings equal under it belong to the same group.
+func (r *refresher) groupKey(ctx *sql.Context, values sql.Row) (hash.Hash, error) {
+	var buf []byte
+	for i, col := range r.v.shape.columns {
+		if col.kind != expressionColumn {
+			continue
+		}
+		if values[i] == nil {
+			buf = append(buf, 0)
+			continue
+		}
+		buf = append(buf, 1)
+
+		typ := r.viewSch[r.colIdx[i]].Type
+		var encoded []byte
+		if st, ok := typ.(sql.StringType); ok && types.IsTextOnly(typ) {
+			str, ok := values[i].(string)
+			if !ok {
+				return hash.Hash{}, fmt.Errorf("unexpected value %v for column %s", values[i], r.viewSch[r.colIdx[i]].Name)
+			}
+			weights, err := st.Collation().HashToBytes(str)
+			if err != nil {
+				return hash.Hash{}, err
+			}
+			encoded = weights
+		} else {
+			desc := val.NewTupleDescriptor(r.codec.fieldType(r.colIdx[i]))
+			tb := val.NewTupleBuilder(desc, r.ns)
+			if err := tree.PutField(ctx, r.ns, tb, 0, values[i]); err != nil {
+				return hash.Hash{}, err
+			}
+			tup, err := tb.Build(ctx, r.ns.Pool())
+			if err != nil {
+				return hash.Hash{}, err
+			}
+			encoded = tup
+		}
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(encoded)))
+		buf = append(buf, encoded...)
+	}
+	return hash.Of(buf), nil
+}
+
+// flush applies the changes to the groups of an aggregate view to its rows
+func (r *refresher) flush(ctx *sql.Context) error {
+	for key, g := range r.groups {
+		k, _, err := r.codec.encode(ctx, sql.Row{key[:]})
+		if err != nil {
+			return err
+		}
+		var existing sql.Row
+		err = r.mut.Get(ctx, k, func(k, v val.Tuple) error {
+			if k == nil {
+				return nil
+			}
+			existing, err = r.codec.decode(ctx, k, v)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		count := func(row sql.Row, i int) int64 {
+			if row == nil || i < 0 {
+				return 0
+			}
+			n, _ := row[i].(int64)
+			return n
+		}
+
+		rows := count(existing, r.countIdx) + g.rows
+		if rows <= 0 {
+			if existing != nil {
+				if err = r.mut.Delete(ctx, k); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		row := make(sql.Row, len(r.viewSch))
+		row[0] = key[:]
+		row[r.countIdx] = rows
+		for i, col := range r.v.shape.columns {
+			idx := r.colIdx[i]
+			switch col.kind {
+			case expressionColumn:
+				row[idx] = g.values[i]
+			case countStarColumn, countColumn:
+				row[idx] = count(existing, idx) + g.counts[i]
+			case sumColumn:
+				n := count(existing, r.sumCountIdx[i]) + g.counts[i]
+				row[r.sumCountIdx[i]] = n
+				if n > 0 {
+					var sum any
+					if existing != nil {
+						sum = existing[idx]
+					}
+					if g.values[i] != nil {
+						if sum, err = addSum(sum, g.values[i], 1); err != nil {
+							return err
+						}
+					}
+					row[idx] = sum
+				}
+			}
+		}
+		_, v, err := r.codec.encode(ctx, row)
+		if err != nil {
+			return err
+		}
+		if err = r.mut.Put(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addSum returns |sum| plus |value| times |sign|, where a nil |sum| is zero. The values are either doubles or decimals,
+// as given by sumType.
+func addSum(sum, value any, sign int64) (any, error) {
+	if d, ok := value.(*apd.Decimal); ok {
+		if sum == nil {
+			sum = apd.New(0, 0)
+		}
+		res := new(apd.Decimal)
+		var err error
+		if sign < 0 {
+			_, err = apd.BaseContext.Sub(res, sum.(*apd.Decimal), d)
+		} else {
+			_, err = apd.BaseContext.Add(res, sum.(*apd.Decimal), d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	f := value.(float64) * float64(sign)
+	if sum == nil {
+		return f, nil
+	}
+	return sum.(float64) + f, nil
+}
+
+// rowCodec converts between the rows of a table and the key and value tuples they're stored as
+type rowCodec struct {
+	ns             tree.NodeStore
+	kd, vd         *val.TupleDesc
+	kb, vb         *val.TupleBuilder
+	keyIdx, valIdx []int
+}
+
+func newRowCodec(sch schema.Schema, ns tree.NodeStore) *rowCodec {
+	kd, vd := sch.GetMapDescriptors(ns)
+	c := &rowCodec{
+		ns: ns,
+		kd: kd,
+		vd: vd,
+		kb: val.NewTupleBuilder(kd, ns),
+		vb: val.NewTupleBuilder(vd, ns),
+	}
+	all := sch.GetAllCols()
+	for _, col := range sch.GetPKCols().GetColumns() {
+		c.keyIdx = append(c.keyIdx, all.TagToIdx[col.Tag])
+	}
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		c.valIdx = append(c.valIdx, all.TagToIdx[col.Tag])
+	}
+	return c
+}
+
+// fieldType returns the encoding of the field of the |i|th column of a row
+func (c *rowCodec) fieldType(i int) val.Type {
+	for j, idx := range c.keyIdx {
+		if idx == i {
+			return c.kd.Types[j]
+		}
+	}
+	for j, idx := range c.valIdx {
+		if idx == i {
+			return c.vd.Types[j]
+		}
+	}
+	panic(fmt.Sprintf("column %d out of range", i))
+}
+
+// encode returns the key and value tuples of |row|. Only the key is built if |row| only has key fields.
+func (c *rowCodec) encode(ctx context.Context, row sql.Row) (val.Tuple, val.Tuple, error) {
+	for j, idx := range c.keyIdx {
+		if err := tree.PutField(ctx, c.ns, c.kb, j, row[idx]); err != nil {
+			return nil, nil, err
+		}
+	}
+	k, err := c.kb.Build(ctx, c.ns.Pool())
+	if err != nil || len(row) <= len(c.keyIdx) {
+		return k, nil, err
+	}
+	for j, idx := range c.valIdx {
+		if err := tree.PutField(ctx, c.ns, c.vb, j, row[idx]); err != nil {
+			return nil, nil, err
+		}
+	}
+	v, err := c.vb.Build(ctx, c.ns.Pool())
+	return k, v, err
+}
+
+func (c *rowCodec) decode(ctx context.Context, k, v val.Tuple) (sql.Row, error) {
+	row := make(sql.Row, len(c.keyIdx)+len(c.valIdx))
+	var err error
+	for j, idx := range c.keyIdx {
+		if row[idx], err = tree.GetField(ctx, c.kd, j, k, c.ns); err != nil {
+			return nil, err
+		}
+	}
+	for j, idx := range c.valIdx {
+		if row[idx], err = tree.GetField(ctx, c.vd, j, v, c.ns); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/overrides"
+)
+
+// ErrUnsupportedQuery is returned when the query of a materialized view isn't one whose result can be maintained from
+// the changes to the rows of its table
+var ErrUnsupportedQuery = errors.NewKind("the query of materialized view %s is not supported: %s. " +
+	"Materialized views select columns and expressions from a single table, optionally filtered by a WHERE clause, " +
+	"or group them and select SUM and COUNT aggregates")
+
+// columnKind is how the values of a column of a materialized view are computed
+type columnKind int
+
+const (
+	// expressionColumn is an expression of the rows of the table. In aggregate views it's grouped by.
+	expressionColumn columnKind = iota
+	// countStarColumn is COUNT(*)
+	countStarColumn
+	// countColumn is COUNT(expr)
+	countColumn
+	// sumColumn is SUM(expr)
+	sumColumn
+)
+
+// shape is the query of a materialized view broken down into the parts its rows are computed from
+type shape struct {
+	// table is the name of the table the view selects from
+	table string
+	// where is the filter of the rows of the table, or nil
+	where sqlparser.Expr
+	// columns are the columns the view selects
+	columns []shapeColumn
+	// aggregate is whether the view groups the rows of the table
+	aggregate bool
+}
+
+type shapeColumn struct {
+	kind columnKind
+	// expr is the expression the column is computed from, or the argument of its aggregate. It's nil for COUNT(*).
+	expr sqlparser.Expr
+}
+
+// parseShape parses the |query| of the materialized view |name|, returning ErrUnsupportedQuery if its shape isn't
+// supported. A * in the select list is expanded to the |columns| of the table, which are looked up by name.
+func parseShape(ctx *sql.Context, name, query string, columns func(table string) ([]string, error)) (*shape, error) {
+	stmt, err := overrides.ParserFromContext(ctx).ParseSimple(query)
+	if err != nil {
+		return nil, err
+	}
+	unsupported := func(reason string, args ...any) error {
+		return ErrUnsupportedQuery.New(name, fmt.Sprintf(reason, args...))
+	}
+
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, unsupported("it is not a SELECT statement")
+	}
+	switch {
+	case sel.With != nil:
+		return nil, unsupported("it has a WITH clause")
+	case sel.QueryOpts.Distinct:
+		return nil, unsupported("it is SELECT DISTINCT")
+	case sel.Having != nil:
+		return nil, unsupported("it has a HAVING clause")
+	case len(sel.OrderBy) > 0:
+		return nil, unsupported("it has an ORDER BY clause")
+	case sel.Limit != nil:
+		return nil, unsupported("it has a LIMIT clause")
+	case len(sel.Window) > 0:
+		return nil, unsupported("it has a WINDOW clause")
+	case sel.Into != nil || (sel.Lock != nil && sel.Lock.Type != ""):
+		return nil, unsupported("it is not a plain SELECT statement")
+	}
+
+	if len(sel.From) != 1 {
+		return nil, unsupported("it selects from more than one table")
+	}
+	from, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return nil, unsupported("it selects from a join")
+	}
+	tableName, ok := from.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, unsupported("it selects from a subquery")
+	}
+	if from.AsOf != nil {
+		return nil, unsupported("it selects from a table AS OF a revision")
+	}
+	if !tableName.DbQualifier.IsEmpty() && !strings.EqualFold(tableName.DbQualifier.String(), ctx.GetCurrentDatabase()) {
+		return nil, unsupported("it selects from a table of another database")
+	}
+
+	s := &shape{table: tableName.Name.String()}
+	qualifiers := []string{s.table}
+	if !from.As.IsEmpty() {
+		qualifiers = []string{from.As.String()}
+	}
+	if sel.Where != nil {
+		if s.where, err = unqualified(sel.Where.Expr, qualifiers, unsupported); err != nil {
+			return nil, err
+		}
+	}
+
+	var aliases []string
+	for _, selectExpr := range sel.SelectExprs {
+		switch selectExpr := selectExpr.(type) {
+		case *sqlparser.StarExpr:
+			if !selectExpr.TableName.IsEmpty() && !qualifies(selectExpr.TableName, qualifiers) {
+				return nil, unsupported("it selects %s from another table", sqlparser.String(selectExpr))
+			}
+			names, err := columns(s.table)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				s.columns = append(s.columns, shapeColumn{kind: expressionColumn, expr: &sqlparser.ColName{Name: sqlparser.NewColIdent(name)}})
+				aliases = append(aliases, name)
+			}
+		case *sqlparser.AliasedExpr:
+			col, err := parseColumn(selectExpr.Expr, qualifiers, unsupported)
+			if err != nil {
+				return nil, err
+			}
+			s.aggregate = s.aggregate || col.kind != expressionColumn
+			s.columns = append(s.columns, col)
+			aliases = append(aliases, selectExpr.As.String())
+		default:
+			return nil, unsupported("it selects %s", sqlparser.String(selectExpr))
+		}
+	}
+
+	if !s.aggregate && len(sel.GroupBy) == 0 {
+		return s, nil
+	}
+	s.aggregate = true
+
+	// Every expression selected must be grouped by, and every expression grouped by must be selected, since the
+	// values grouped by identify the rows of the view
+	grouped := make([]bool, len(s.columns))
+	for _, groupBy := range sel.GroupBy {
+		expr, err := unqualified(groupBy, qualifiers, unsupported)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for i, col := range s.columns {
+			if col.kind != expressionColumn {
+				continue
+			}
+			if sqlparser.String(col.expr) == sqlparser.String(expr) {
+				grouped[i], found = true, true
+			} else if name, ok := expr.(*sqlparser.ColName); ok && aliases[i] != "" && strings.EqualFold(name.Name.String(), aliases[i]) {
+				grouped[i], found = true, true
+			}
+		}
+		if !found {
+			return nil, unsupported("it groups by %s, which it doesn't select", sqlparser.String(groupBy))
+		}
+	}
+	for i, col := range s.columns {
+		if col.kind == expressionColumn && !grouped[i] {
+			return nil, unsupported("it selects %s, which it doesn't group by", sqlparser.String(col.expr))
+		}
+	}
+	return s, nil
+}
+
+// parseColumn returns the column of a view computed from the selected expression |expr|
+func parseColumn(expr sqlparser.Expr, qualifiers []string, unsupported func(string, ...any) error) (shapeColumn, error) {
+	fn, ok := expr.(*sqlparser.FuncExpr)
+	if !ok || !fn.IsAggregate() {
+		expr, err := unqualified(expr, qualifiers, unsupported)
+		return shapeColumn{kind: expressionColumn, expr: expr}, err
+	}
+
+	name := fn.Name.Lowered()
+	if (name != "sum" && name != "count") || fn.Distinct || fn.Over != nil || len(fn.Exprs) != 1 {
+		return shapeColumn{}, unsupported("it selects %s", sqlparser.String(fn))
+	}
+	switch arg := fn.Exprs[0].(type) {
+	case *sqlparser.StarExpr:
+		if name == "count" && arg.TableName.IsEmpty() {
+			return shapeColumn{kind: countStarColumn}, nil
+		}
+	case *sqlparser.AliasedExpr:
+		argExpr, err := unqualified(arg.Expr, qualifiers, unsupported)
+		if err != nil {
+			return shapeColumn{}, err
+		}
+		if name == "count" {
+			return shapeColumn{kind: countColumn, expr: argExpr}, nil
+		}
+		return shapeColumn{kind: sumColumn, expr: argExpr}, nil
+	}
+	return shapeColumn{}, unsupported("it selects %s", sqlparser.String(fn))
+}
+
+// unqualified returns |expr| without the table qualifiers of its columns, which must name the table of the view, so
+// that it can be resolved against the table. Expressions with subqueries, aggregates or window functions aren't
+// supported, since their values don't only depend on the row they're computed from.
+func unqualified(expr sqlparser.Expr, qualifiers []string, unsupported func(string, ...any) error) (sqlparser.Expr, error) {
+	// the expression is formatted and parsed again, rather than modified in place
+	stmt, err := sqlparser.Parse("SELECT " + sqlparser.String(expr))
+	if err != nil {
+		return nil, err
+	}
+	copied := stmt.(*sqlparser.Select).SelectExprs[0].(*sqlparser.AliasedExpr).Expr
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, unsupported("it has the subquery %s", sqlparser.String(node))
+		case *sqlparser.FuncExpr:
+			if node.IsAggregate() || node.Over != nil {
+				return false, unsupported("it has %s in an expression", sqlparser.String(node))
+			}
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() {
+				if !qualifies(node.Qualifier, qualifiers) {
+					return false, unsupported("it references %s of another table", sqlparser.String(node))
+				}
+				node.Qualifier = sqlparser.TableName{}
+			}
+		}
+		return true, nil
+	}, copied)
+	return copied, err
+}
+
+func qualifies(name sqlparser.TableName, qualifiers []string) bool {
+	for _, q := range qualifiers {
+		if strings.EqualFold(name.Name.String(), q) {
+			return true
+		}
+	}
+	return false
+}
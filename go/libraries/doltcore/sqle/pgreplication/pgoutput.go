@@ -0,0 +1,405 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// LSN is a Postgres log sequence number, a byte position in the Postgres write-ahead log.
+type LSN uint64
+
+// String returns the LSN in the standard Postgres "XXX/XXX" format.
+func (lsn LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
+}
+
+// ParseLSN parses an LSN in the standard Postgres "XXX/XXX" format.
+func ParseLSN(s string) (LSN, error) {
+	var upper, lower uint32
+	if _, err := fmt.Sscanf(s, "%X/%X", &upper, &lower); err != nil {
+		return 0, fmt.Errorf("invalid LSN %q: %w", s, err)
+	}
+	return LSN(uint64(upper)<<32 | uint64(lower)), nil
+}
+
+// postgresEpoch is the zero point for timestamps sent in the replication protocol.
+var postgresEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// pgTimeToTime converts a replication protocol timestamp, expressed as microseconds since the Postgres epoch, into
+// a time.Time.
+func pgTimeToTime(micros int64) time.Time {
+	return postgresEpoch.Add(time.Duration(micros) * time.Microsecond)
+}
+
+// timeToPgTime converts |t| into microseconds since the Postgres epoch.
+func timeToPgTime(t time.Time) int64 {
+	return t.Sub(postgresEpoch).Microseconds()
+}
+
+// pgoutput message type bytes. See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+const (
+	messageTypeBegin    = 'B'
+	messageTypeCommit   = 'C'
+	messageTypeOrigin   = 'O'
+	messageTypeRelation = 'R'
+	messageTypeType     = 'Y'
+	messageTypeInsert   = 'I'
+	messageTypeUpdate   = 'U'
+	messageTypeDelete   = 'D'
+	messageTypeTruncate = 'T'
+	messageTypeMessage  = 'M'
+)
+
+// Tuple column kinds sent in pgoutput TupleData.
+const (
+	tupleColumnNull      = 'n'
+	tupleColumnUnchanged = 'u'
+	tupleColumnText      = 't'
+	tupleColumnBinary    = 'b'
+)
+
+// Tuple markers preceding TupleData in update and delete messages.
+const (
+	tupleMarkerNew = 'N'
+	tupleMarkerKey = 'K'
+	tupleMarkerOld = 'O'
+)
+
+// relationColumnFlagKey is set on a relation column that is part of the replica identity of the relation.
+const relationColumnFlagKey = 1
+
+// pgoutputMessage is a single logical replication message decoded from the pgoutput plugin's output.
+type pgoutputMessage interface {
+	messageType() byte
+}
+
+// BeginMessage marks the start of a transaction on the source.
+type BeginMessage struct {
+	// FinalLSN is the LSN of the transaction's commit record.
+	FinalLSN   LSN
+	CommitTime time.Time
+	Xid        uint32
+}
+
+// CommitMessage marks the end of a transaction on the source.
+type CommitMessage struct {
+	Flags     uint8
+	CommitLSN LSN
+	// TransactionEndLSN is the LSN just past the transaction's commit record. This is the LSN that is reported back
+	// to the source once the transaction has been durably applied.
+	TransactionEndLSN LSN
+	CommitTime        time.Time
+}
+
+// OriginMessage identifies the replication origin of the transaction that follows.
+type OriginMessage struct {
+	CommitLSN LSN
+	Name      string
+}
+
+// RelationColumn describes a single column in a RelationMessage.
+type RelationColumn struct {
+	Flags   uint8
+	Name    string
+	TypeOID uint32
+	TypeMod int32
+}
+
+// IsKey returns whether this column is part of the relation's replica identity.
+func (c RelationColumn) IsKey() bool {
+	return c.Flags&relationColumnFlagKey != 0
+}
+
+// RelationMessage describes the layout of a table on the source. It is always sent before the first data message
+// for a relation in a session, and again whenever the relation's definition changes.
+type RelationMessage struct {
+	RelationID      uint32
+	Namespace       string
+	Name            string
+	ReplicaIdentity uint8
+	Columns         []RelationColumn
+}
+
+// TypeMessage describes a custom data type used by a following relation.
+type TypeMessage struct {
+	TypeOID   uint32
+	Namespace string
+	Name      string
+}
+
+// TupleColumn is a single column value in a TupleData.
+type TupleColumn struct {
+	// Kind is one of 'n' (null), 'u' (unchanged TOASTed value), 't' (text) or 'b' (binary).
+	Kind byte
+	Data []byte
+}
+
+// IsNull returns whether this column holds a NULL value.
+func (c TupleColumn) IsNull() bool {
+	return c.Kind == tupleColumnNull
+}
+
+// IsUnchanged returns whether this column is an unchanged TOASTed value, whose actual value was not sent.
+func (c TupleColumn) IsUnchanged() bool {
+	return c.Kind == tupleColumnUnchanged
+}
+
+// TupleData holds the column values of a single row.
+type TupleData struct {
+	Columns []TupleColumn
+}
+
+// InsertMessage describes a row inserted on the source.
+type InsertMessage struct {
+	RelationID uint32
+	NewTuple   *TupleData
+}
+
+// UpdateMessage describes a row updated on the source. OldTuple is only set when the relation's replica identity
+// changed (OldTupleType 'K') or the relation uses REPLICA IDENTITY FULL (OldTupleType 'O').
+type UpdateMessage struct {
+	RelationID   uint32
+	OldTupleType byte
+	OldTuple     *TupleData
+	NewTuple     *TupleData
+}
+
+// DeleteMessage describes a row deleted on the source. OldTuple holds either only the replica identity columns
+// (OldTupleType 'K') or the full row (OldTupleType 'O').
+type DeleteMessage struct {
+	RelationID   uint32
+	OldTupleType byte
+	OldTuple     *TupleData
+}
+
+// TruncateMessage describes one or more tables truncated on the source.
+type TruncateMessage struct {
+	Options     uint8
+	RelationIDs []uint32
+}
+
+// LogicalDecodingMessage is a message emitted with pg_logical_emit_message on the source. These are not applied
+// to the replica.
+type LogicalDecodingMessage struct {
+	Flags   uint8
+	LSN     LSN
+	Prefix  string
+	Content []byte
+}
+
+func (*BeginMessage) messageType() byte           { return messageTypeBegin }
+func (*CommitMessage) messageType() byte          { return messageTypeCommit }
+func (*OriginMessage) messageType() byte          { return messageTypeOrigin }
+func (*RelationMessage) messageType() byte        { return messageTypeRelation }
+func (*TypeMessage) messageType() byte            { return messageTypeType }
+func (*InsertMessage) messageType() byte          { return messageTypeInsert }
+func (*UpdateMessage) messageType() byte          { return messageTypeUpdate }
+func (*DeleteMessage) messageType() byte          { return messageTypeDelete }
+func (*TruncateMessage) messageType() byte        { return messageTypeTruncate }
+func (*LogicalDecodingMessage) messageType() byte { return messageTypeMessage }
+
+// parsePgoutputMessage decodes a single pgoutput message (protocol version 1) from |data|, the payload of an
+// XLogData replication message.
+func parsePgoutputMessage(data []byte) (pgoutputMessage, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty pgoutput message")
+	}
+
+	r := &messageReader{buf: data[1:]}
+	var msg pgoutputMessage
+	switch data[0] {
+	case messageTypeBegin:
+		msg = &BeginMessage{
+			FinalLSN:   LSN(r.uint64()),
+			CommitTime: pgTimeToTime(int64(r.uint64())),
+			Xid:        r.uint32(),
+		}
+	case messageTypeCommit:
+		msg = &CommitMessage{
+			Flags:             r.uint8(),
+			CommitLSN:         LSN(r.uint64()),
+			TransactionEndLSN: LSN(r.uint64()),
+			CommitTime:        pgTimeToTime(int64(r.uint64())),
+		}
+	case messageTypeOrigin:
+		msg = &OriginMessage{
+			CommitLSN: LSN(r.uint64()),
+			Name:      r.cstring(),
+		}
+	case messageTypeRelation:
+		rel := &RelationMessage{
+			RelationID:      r.uint32(),
+			Namespace:       r.cstring(),
+			Name:            r.cstring(),
+			ReplicaIdentity: r.uint8(),
+		}
+		numColumns := int(r.uint16())
+		for i := 0; i < numColumns && r.err == nil; i++ {
+			rel.Columns = append(rel.Columns, RelationColumn{
+				Flags:   r.uint8(),
+				Name:    r.cstring(),
+				TypeOID: r.uint32(),
+				TypeMod: int32(r.uint32()),
+			})
+		}
+		msg = rel
+	case messageTypeType:
+		msg = &TypeMessage{
+			TypeOID:   r.uint32(),
+			Namespace: r.cstring(),
+			Name:      r.cstring(),
+		}
+	case messageTypeInsert:
+		insert := &InsertMessage{RelationID: r.uint32()}
+		if marker := r.uint8(); r.err == nil && marker != tupleMarkerNew {
+			return nil, fmt.Errorf("unexpected tuple marker in insert message: %q", marker)
+		}
+		insert.NewTuple = r.tupleData()
+		msg = insert
+	case messageTypeUpdate:
+		update := &UpdateMessage{RelationID: r.uint32()}
+		marker := r.uint8()
+		if marker == tupleMarkerKey || marker == tupleMarkerOld {
+			update.OldTupleType = marker
+			update.OldTuple = r.tupleData()
+			marker = r.uint8()
+		}
+		if r.err == nil && marker != tupleMarkerNew {
+			return nil, fmt.Errorf("unexpected tuple marker in update message: %q", marker)
+		}
+		update.NewTuple = r.tupleData()
+		msg = update
+	case messageTypeDelete:
+		del := &DeleteMessage{RelationID: r.uint32()}
+		del.OldTupleType = r.uint8()
+		if r.err == nil && del.OldTupleType != tupleMarkerKey && del.OldTupleType != tupleMarkerOld {
+			return nil, fmt.Errorf("unexpected tuple marker in delete message: %q", del.OldTupleType)
+		}
+		del.OldTuple = r.tupleData()
+		msg = del
+	case messageTypeTruncate:
+		numRelations := int(r.uint32())
+		truncate := &TruncateMessage{Options: r.uint8()}
+		for i := 0; i < numRelations && r.err == nil; i++ {
+			truncate.RelationIDs = append(truncate.RelationIDs, r.uint32())
+		}
+		msg = truncate
+	case messageTypeMessage:
+		logicalMessage := &LogicalDecodingMessage{
+			Flags:  r.uint8(),
+			LSN:    LSN(r.uint64()),
+			Prefix: r.cstring(),
+		}
+		logicalMessage.Content = r.bytes(int(r.uint32()))
+		msg = logicalMessage
+	default:
+		return nil, fmt.Errorf("unsupported pgoutput message type: %q", data[0])
+	}
+
+	if r.err != nil {
+		return nil, fmt.Errorf("unable to decode pgoutput message of type %q: %w", data[0], r.err)
+	}
+	return msg, nil
+}
+
+// messageReader reads big-endian values from a message buffer. The first read past the end of the buffer records
+// an error in |err|, and all subsequent reads return zero values, so callers only need to check |err| once after
+// decoding a whole message.
+type messageReader struct {
+	buf []byte
+	err error
+}
+
+func (r *messageReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.buf) < n {
+		r.err = fmt.Errorf("message truncated: needed %d bytes, %d remaining", n, len(r.buf))
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *messageReader) uint8() uint8 {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *messageReader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *messageReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *messageReader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *messageReader) bytes(n int) []byte {
+	return r.next(n)
+}
+
+func (r *messageReader) cstring() string {
+	if r.err != nil {
+		return ""
+	}
+	idx := bytes.IndexByte(r.buf, 0)
+	if idx < 0 {
+		r.err = fmt.Errorf("message truncated: unterminated string")
+		return ""
+	}
+	s := string(r.buf[:idx])
+	r.buf = r.buf[idx+1:]
+	return s
+}
+
+func (r *messageReader) tupleData() *TupleData {
+	numColumns := int(r.uint16())
+	tuple := &TupleData{Columns: make([]TupleColumn, 0, numColumns)}
+	for i := 0; i < numColumns && r.err == nil; i++ {
+		column := TupleColumn{Kind: r.uint8()}
+		switch column.Kind {
+		case tupleColumnNull, tupleColumnUnchanged:
+		case tupleColumnText, tupleColumnBinary:
+			column.Data = r.bytes(int(int32(r.uint32())))
+		default:
+			if r.err == nil {
+				r.err = fmt.Errorf("unexpected tuple column kind: %q", column.Kind)
+			}
+		}
+		tuple.Columns = append(tuple.Columns, column)
+	}
+	return tuple
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLSN(t *testing.T) {
+	lsn, err := ParseLSN("16/B374D848")
+	require.NoError(t, err)
+	require.Equal(t, LSN(0x16B374D848), lsn)
+	require.Equal(t, "16/B374D848", lsn.String())
+
+	_, err = ParseLSN("not an lsn")
+	require.Error(t, err)
+}
+
+func TestParsePgoutputMessage(t *testing.T) {
+	commitTime := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("recorded begin", func(t *testing.T) {
+		// A BEGIN message for xid 743, laid out byte by byte as Postgres sends it
+		data := []byte{'B',
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x5a, 0x3b, 0x88, // final LSN 0/15A3B88
+			0x00, 0x02, 0xd2, 0x2f, 0x8b, 0x3e, 0x40, 0x00, // commit time
+			0x00, 0x00, 0x02, 0xe7, // xid
+		}
+		msg, err := parsePgoutputMessage(data)
+		require.NoError(t, err)
+		begin, ok := msg.(*BeginMessage)
+		require.True(t, ok)
+		require.Equal(t, LSN(0x15A3B88), begin.FinalLSN)
+		require.Equal(t, uint32(743), begin.Xid)
+	})
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected pgoutputMessage
+	}{
+		{
+			name:     "commit",
+			data:     encodeCommit(0x100, 0x130, commitTime),
+			expected: &CommitMessage{CommitLSN: 0x100, TransactionEndLSN: 0x130, CommitTime: commitTime},
+		},
+		{
+			name: "relation",
+			data: encodeRelation(16385, "public", "accounts", relCol("id", oidInt4, true), relCol("name", 25, false)),
+			expected: &RelationMessage{
+				RelationID:      16385,
+				Namespace:       "public",
+				Name:            "accounts",
+				ReplicaIdentity: 'd',
+				Columns: []RelationColumn{
+					{Flags: 1, Name: "id", TypeOID: oidInt4, TypeMod: -1},
+					{Flags: 0, Name: "name", TypeOID: 25, TypeMod: -1},
+				},
+			},
+		},
+		{
+			name: "insert",
+			data: encodeInsert(16385, textCol("1"), nullCol()),
+			expected: &InsertMessage{
+				RelationID: 16385,
+				NewTuple:   &TupleData{Columns: []TupleColumn{{Kind: 't', Data: []byte("1")}, {Kind: 'n'}}},
+			},
+		},
+		{
+			name: "update with unchanged toast value",
+			data: encodeUpdate(16385, nil, textCol("1"), unchangedCol()),
+			expected: &UpdateMessage{
+				RelationID: 16385,
+				NewTuple:   &TupleData{Columns: []TupleColumn{{Kind: 't', Data: []byte("1")}, {Kind: 'u'}}},
+			},
+		},
+		{
+			name: "update with old key",
+			data: encodeUpdate(16385, []TupleColumn{textCol("1"), nullCol()}, textCol("2"), textCol("b")),
+			expected: &UpdateMessage{
+				RelationID:   16385,
+				OldTupleType: 'K',
+				OldTuple:     &TupleData{Columns: []TupleColumn{{Kind: 't', Data: []byte("1")}, {Kind: 'n'}}},
+				NewTuple:     &TupleData{Columns: []TupleColumn{{Kind: 't', Data: []byte("2")}, {Kind: 't', Data: []byte("b")}}},
+			},
+		},
+		{
+			name: "delete",
+			data: encodeDelete(16385, textCol("2"), nullCol()),
+			expected: &DeleteMessage{
+				RelationID:   16385,
+				OldTupleType: 'K',
+				OldTuple:     &TupleData{Columns: []TupleColumn{{Kind: 't', Data: []byte("2")}, {Kind: 'n'}}},
+			},
+		},
+		{
+			name:     "truncate",
+			data:     encodeTruncate(16385, 16390),
+			expected: &TruncateMessage{RelationIDs: []uint32{16385, 16390}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := parsePgoutputMessage(tt.data)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, msg)
+		})
+	}
+
+	t.Run("truncated message", func(t *testing.T) {
+		data := encodeInsert(16385, textCol("1"))
+		_, err := parsePgoutputMessage(data[:len(data)-1])
+		require.Error(t, err)
+	})
+
+	t.Run("unknown message type", func(t *testing.T) {
+		_, err := parsePgoutputMessage([]byte{'Z'})
+		require.Error(t, err)
+	})
+}
+
+//
+// Encoders for building pgoutput messages in tests
+//
+
+type encodedRelationColumn struct {
+	name  string
+	oid   uint32
+	isKey bool
+}
+
+func relCol(name string, oid uint32, isKey bool) encodedRelationColumn {
+	return encodedRelationColumn{name: name, oid: oid, isKey: isKey}
+}
+
+func textCol(s string) TupleColumn {
+	return TupleColumn{Kind: tupleColumnText, Data: []byte(s)}
+}
+
+func nullCol() TupleColumn {
+	return TupleColumn{Kind: tupleColumnNull}
+}
+
+func unchangedCol() TupleColumn {
+	return TupleColumn{Kind: tupleColumnUnchanged}
+}
+
+func encodeBegin(finalLSN LSN, commitTime time.Time, xid uint32) []byte {
+	b := []byte{messageTypeBegin}
+	b = binary.BigEndian.AppendUint64(b, uint64(finalLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(timeToPgTime(commitTime)))
+	return binary.BigEndian.AppendUint32(b, xid)
+}
+
+func encodeCommit(commitLSN, endLSN LSN, commitTime time.Time) []byte {
+	b := []byte{messageTypeCommit, 0}
+	b = binary.BigEndian.AppendUint64(b, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(endLSN))
+	return binary.BigEndian.AppendUint64(b, uint64(timeToPgTime(commitTime)))
+}
+
+func encodeRelation(relationID uint32, namespace, name string, columns ...encodedRelationColumn) []byte {
+	b := []byte{messageTypeRelation}
+	b = binary.BigEndian.AppendUint32(b, relationID)
+	b = append(append(b, namespace...), 0)
+	b = append(append(b, name...), 0)
+	b = append(b, 'd')
+	b = binary.BigEndian.AppendUint16(b, uint16(len(columns)))
+	for _, col := range columns {
+		var flags byte
+		if col.isKey {
+			flags = relationColumnFlagKey
+		}
+		b = append(b, flags)
+		b = append(append(b, col.name...), 0)
+		b = binary.BigEndian.AppendUint32(b, col.oid)
+		b = binary.BigEndian.AppendUint32(b, 0xFFFFFFFF)
+	}
+	return b
+}
+
+func appendTuple(b []byte, columns []TupleColumn) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(columns)))
+	for _, col := range columns {
+		b = append(b, col.Kind)
+		if col.Kind == tupleColumnText || col.Kind == tupleColumnBinary {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(col.Data)))
+			b = append(b, col.Data...)
+		}
+	}
+	return b
+}
+
+func encodeInsert(relationID uint32, columns ...TupleColumn) []byte {
+	b := []byte{messageTypeInsert}
+	b = binary.BigEndian.AppendUint32(b, relationID)
+	b = append(b, tupleMarkerNew)
+	return appendTuple(b, columns)
+}
+
+func encodeUpdate(relationID uint32, oldKey []TupleColumn, columns ...TupleColumn) []byte {
+	b := []byte{messageTypeUpdate}
+	b = binary.BigEndian.AppendUint32(b, relationID)
+	if oldKey != nil {
+		b = append(b, tupleMarkerKey)
+		b = appendTuple(b, oldKey)
+	}
+	b = append(b, tupleMarkerNew)
+	return appendTuple(b, columns)
+}
+
+func encodeDelete(relationID uint32, key ...TupleColumn) []byte {
+	b := []byte{messageTypeDelete}
+	b = binary.BigEndian.AppendUint32(b, relationID)
+	b = append(b, tupleMarkerKey)
+	return appendTuple(b, key)
+}
+
+func encodeTruncate(relationIDs ...uint32) []byte {
+	b := []byte{messageTypeTruncate}
+	b = binary.BigEndian.AppendUint32(b, uint32(len(relationIDs)))
+	b = append(b, 0)
+	for _, id := range relationIDs {
+		b = binary.BigEndian.AppendUint32(b, id)
+	}
+	return b
+}
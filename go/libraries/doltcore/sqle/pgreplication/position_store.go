@@ -0,0 +1,71 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const positionDirectory = ".doltcfg"
+const positionFilename = "pgoutput-position"
+
+// positionStore persists the LSN of the end of the last source transaction applied by the replica. Postgres also
+// tracks the confirmed flush position of the replication slot, but that position is only advanced when the replica
+// sends a standby status update, so after a crash the source may resend transactions that were already applied.
+// The stored position lets the applier skip those transactions instead of applying them twice.
+type positionStore struct {
+	fs filesys.Filesys
+	mu sync.Mutex
+}
+
+// Load returns the stored LSN from the .doltcfg/pgoutput-position file at the root of the store's filesystem, or
+// zero if no position has been stored.
+func (s *positionStore) Load() (LSN, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(positionDirectory, positionFilename)
+	if exists, _ := s.fs.Exists(path); !exists {
+		return 0, nil
+	}
+
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return ParseLSN(strings.TrimSpace(string(data)))
+}
+
+// Save persists |lsn| to the .doltcfg/pgoutput-position file at the root of the store's filesystem.
+func (s *positionStore) Save(lsn LSN) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exists, isDir := s.fs.Exists(positionDirectory)
+	if !exists {
+		if err := s.fs.MkDirs(positionDirectory); err != nil {
+			return fmt.Errorf("unable to save pgoutput replication position: %w", err)
+		}
+	} else if !isDir {
+		return fmt.Errorf("unable to save pgoutput replication position: %s exists as a file, not a dir", positionDirectory)
+	}
+
+	return s.fs.WriteFile(filepath.Join(positionDirectory, positionFilename), []byte(lsn.String()), 0666)
+}
@@ -0,0 +1,415 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// applierUser is the locked, super user account that is used to apply replicated changes. As with binlog
+// replication, we cannot assume the root account exists, so a dedicated account is created and locked so that it
+// cannot be used to log in.
+const applierUser = "dolt-pgoutput-applier"
+
+const connectTimeout = 4 * time.Second
+
+// reconnectDelay is how long the replica waits before reconnecting to the source after an error.
+var reconnectDelay = 10 * time.Second
+
+// ErrReplicationStopped is an internal error that signals that the replica was asked to stop.
+var ErrReplicationStopped = errors.New("replication stop requested")
+
+// Config describes the Postgres source and the Dolt target of a Postgres logical replication replica.
+type Config struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	// Database is the Postgres database containing the publication.
+	Database string
+	// SSL requires the connection to the source to use TLS.
+	SSL bool
+	// SlotName is the name of an existing logical replication slot created with the pgoutput plugin.
+	SlotName string
+	// Publication is the name of the publication whose changes are streamed.
+	Publication string
+	// TargetDatabase is the Dolt database that replicated changes are applied to. Relations on the source are
+	// mapped to the tables of the same name in this database.
+	TargetDatabase string
+	// CommitPerTransaction creates a Dolt commit for every source transaction that is applied. When false, applied
+	// changes are only committed to the working set.
+	CommitPerTransaction bool
+}
+
+// Validate returns an error if any required configuration is missing.
+func (cfg Config) Validate() error {
+	switch {
+	case cfg.Host == "":
+		return fmt.Errorf("postgres replication requires a source host")
+	case cfg.User == "":
+		return fmt.Errorf("postgres replication requires a source user")
+	case cfg.SlotName == "":
+		return fmt.Errorf("postgres replication requires a replication slot name")
+	case cfg.Publication == "":
+		return fmt.Errorf("postgres replication requires a publication name")
+	case cfg.TargetDatabase == "":
+		return fmt.Errorf("postgres replication requires a target database")
+	}
+	return nil
+}
+
+// Replica subscribes to a Postgres logical replication slot using the pgoutput protocol and applies the streamed
+// inserts, updates and deletes to the tables of a Dolt database, one SQL transaction per source transaction.
+//
+// This type is not used concurrently – there is a single applier goroutine – so its state is not protected by a
+// mutex, except for the running flag used to start and stop it.
+type Replica struct {
+	cfg    Config
+	engine *gms.Engine
+
+	// connect establishes a new replication stream starting at |startLSN|. It is replaced in tests to stream
+	// recorded messages instead of connecting to a live Postgres server.
+	connect func(ctx context.Context, startLSN LSN) (replicationStream, error)
+
+	positions *positionStore
+	// appliedLSN is the end LSN of the last source transaction that was applied.
+	appliedLSN LSN
+
+	relations map[uint32]*RelationMessage
+	mappings  map[uint32]*tableMapping
+
+	// txn is the source transaction currently being applied, or nil when between transactions.
+	txn *BeginMessage
+	// skipTxn is set when the current source transaction was already applied before a restart.
+	skipTxn bool
+	// dirty is set once the current transaction has changed any rows.
+	dirty bool
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  atomic.Bool
+}
+
+// NewReplica returns a new Replica that applies changes described by |cfg| using |engine|.
+func NewReplica(cfg Config, engine *gms.Engine) *Replica {
+	r := &Replica{
+		cfg:      cfg,
+		engine:   engine,
+		stopChan: make(chan struct{}),
+	}
+	r.connect = r.connectToSource
+	return r
+}
+
+// Go starts a new goroutine that streams and applies changes from the source until Stop is called. |ctx| must
+// have its own session, which is used exclusively by the replica.
+func (r *Replica) Go(ctx *sql.Context) error {
+	if err := r.cfg.Validate(); err != nil {
+		return err
+	}
+	if !r.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("postgres replication is already running")
+	}
+
+	r.configureApplierUser(ctx)
+	r.positions = &positionStore{fs: dsess.DSessFromSess(ctx.Session).Provider().FileSystem()}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer r.running.Store(false)
+		r.run(ctx)
+	}()
+	return nil
+}
+
+// IsRunning returns true if the replica's applier goroutine is running.
+func (r *Replica) IsRunning() bool {
+	return r.running.Load()
+}
+
+// Stop signals the applier goroutine to stop and waits for it to exit. Any partially applied source transaction
+// is rolled back, and will be streamed again by the source when the replica is restarted.
+func (r *Replica) Stop() {
+	if r.IsRunning() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r.wg.Wait()
+		}()
+		select {
+		case r.stopChan <- struct{}{}:
+		case <-done:
+		}
+		r.wg.Wait()
+	}
+}
+
+// configureApplierUser creates or configures the locked superuser account used to apply changes, and sets it as
+// the client of |ctx|.
+func (r *Replica) configureApplierUser(ctx *sql.Context) {
+	mySQLDb := r.engine.Analyzer.Catalog.MySQLDb
+	ed := mySQLDb.Editor()
+	mySQLDb.AddLockedSuperUser(ed, applierUser, "localhost", "")
+	ed.Close()
+
+	ctx.SetClient(sql.Client{
+		User:    applierUser,
+		Address: "localhost",
+	})
+}
+
+// run connects to the source and applies changes, reconnecting after errors, until the replica is stopped.
+func (r *Replica) run(ctx *sql.Context) {
+	ctx.SetCurrentDatabase(r.cfg.TargetDatabase)
+	// Constraints were already enforced by the source, and rows within a source transaction are not necessarily
+	// sent in an order that satisfies foreign keys (e.g. with deferred constraints), so don't check them again.
+	if err := ctx.SetSessionVariable(ctx, "foreign_key_checks", int8(0)); err != nil {
+		ctx.GetLogger().Warnf("unable to disable foreign key checks for postgres replication: %s", err.Error())
+	}
+
+	for {
+		err := r.connectAndApply(ctx)
+		if errors.Is(err, ErrReplicationStopped) {
+			return
+		}
+		if err != nil {
+			ctx.GetLogger().Errorf("postgres replication error: %s", err.Error())
+		}
+
+		select {
+		case <-r.stopChan:
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// connectAndApply opens a replication stream and applies changes from it until an error occurs or the replica is
+// stopped. Any partially applied transaction is rolled back before returning.
+func (r *Replica) connectAndApply(ctx *sql.Context) (err error) {
+	r.relations = make(map[uint32]*RelationMessage)
+	r.mappings = make(map[uint32]*tableMapping)
+
+	r.appliedLSN, err = r.positions.Load()
+	if err != nil {
+		return err
+	}
+
+	stream, err := r.connect(ctx, r.appliedLSN)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	defer func() {
+		if r.txn != nil {
+			r.rollback(ctx)
+		}
+	}()
+
+	ctx.GetLogger().Infof("postgres replication streaming from slot %s starting at LSN %s", r.cfg.SlotName, r.appliedLSN)
+	messages := make(chan replicationMessage)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			msg, err := stream.Receive()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-messages:
+			if err := r.handleMessage(ctx, stream, msg); err != nil {
+				return err
+			}
+		case err := <-errs:
+			return err
+		case <-r.stopChan:
+			return ErrReplicationStopped
+		}
+	}
+}
+
+// connectToSource dials the configured Postgres server and starts streaming from the replication slot.
+func (r *Replica) connectToSource(ctx context.Context, startLSN LSN) (replicationStream, error) {
+	conn, err := dialReplicationConn(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.startReplication(r.cfg.SlotName, r.cfg.Publication, startLSN); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// handleMessage processes a single message from the replication stream.
+func (r *Replica) handleMessage(ctx *sql.Context, stream replicationStream, msg replicationMessage) error {
+	switch msg := msg.(type) {
+	case *primaryKeepalive:
+		if !msg.ReplyRequested {
+			return nil
+		}
+		// When no transaction is in progress, every message up to the server's WAL end has been processed, so it
+		// is safe to acknowledge it. This lets the slot advance even if no changes are published.
+		lsn := r.appliedLSN
+		if r.txn == nil && msg.ServerWALEnd > lsn {
+			lsn = msg.ServerWALEnd
+		}
+		return stream.SendStandbyStatus(lsn)
+	case *xLogData:
+		pgMsg, err := parsePgoutputMessage(msg.Data)
+		if err != nil {
+			return err
+		}
+
+		if err := sql.SessionCommandBegin(ctx.Session); err != nil {
+			return err
+		}
+		defer sql.SessionCommandEnd(ctx.Session)
+		return r.applyMessage(ctx, stream, pgMsg)
+	default:
+		return fmt.Errorf("unexpected replication message: %T", msg)
+	}
+}
+
+// applyMessage applies a single pgoutput message to the target database.
+func (r *Replica) applyMessage(ctx *sql.Context, stream replicationStream, msg pgoutputMessage) error {
+	switch msg := msg.(type) {
+	case *BeginMessage:
+		if r.txn != nil {
+			return fmt.Errorf("received BEGIN for transaction %d while transaction %d is in progress", msg.Xid, r.txn.Xid)
+		}
+		r.txn = msg
+		r.dirty = false
+		r.skipTxn = msg.FinalLSN < r.appliedLSN
+		if r.skipTxn {
+			ctx.GetLogger().Debugf("skipping already applied transaction %d at LSN %s", msg.Xid, msg.FinalLSN)
+			return nil
+		}
+		_, err := r.execute(ctx, "START TRANSACTION", nil)
+		return err
+
+	case *CommitMessage:
+		if r.txn == nil {
+			return fmt.Errorf("received COMMIT at LSN %s with no transaction in progress", msg.CommitLSN)
+		}
+		if !r.skipTxn {
+			if err := r.commit(ctx, msg); err != nil {
+				return err
+			}
+			if err := r.positions.Save(msg.TransactionEndLSN); err != nil {
+				return fmt.Errorf("unable to store postgres replication position: %w", err)
+			}
+			r.appliedLSN = msg.TransactionEndLSN
+		}
+		r.txn = nil
+		r.skipTxn = false
+		// The mappings are rebuilt for each transaction, so that schema changes made on the replica are picked up
+		r.mappings = make(map[uint32]*tableMapping)
+		return stream.SendStandbyStatus(r.appliedLSN)
+
+	case *RelationMessage:
+		r.relations[msg.RelationID] = msg
+		delete(r.mappings, msg.RelationID)
+		return nil
+
+	case *InsertMessage, *UpdateMessage, *DeleteMessage, *TruncateMessage:
+		if r.txn == nil {
+			return fmt.Errorf("received row change with no transaction in progress")
+		}
+		if r.skipTxn {
+			return nil
+		}
+		r.dirty = true
+		return r.applyRowChange(ctx, msg)
+
+	case *OriginMessage, *TypeMessage, *LogicalDecodingMessage:
+		return nil
+
+	default:
+		return fmt.Errorf("unexpected pgoutput message: %T", msg)
+	}
+}
+
+// commit commits the SQL transaction for the current source transaction, creating a Dolt commit for it if the
+// replica is configured to do so.
+func (r *Replica) commit(ctx *sql.Context, msg *CommitMessage) error {
+	if r.dirty && r.cfg.CommitPerTransaction {
+		query := fmt.Sprintf("CALL dolt_commit('-Am', 'Dolt pgoutput replica commit: transaction %d, LSN %s', '--date', '%s')",
+			r.txn.Xid, msg.CommitLSN, msg.CommitTime.UTC().Format(time.RFC3339))
+		_, err := r.execute(ctx, query, nil)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := r.execute(ctx, "COMMIT", nil)
+	return err
+}
+
+// rollback abandons the SQL transaction for the current source transaction.
+func (r *Replica) rollback(ctx *sql.Context) {
+	if !r.skipTxn {
+		if _, err := r.execute(ctx, "ROLLBACK", nil); err != nil {
+			ctx.GetLogger().Errorf("unable to roll back postgres replication transaction: %s", err.Error())
+		}
+	}
+	r.txn = nil
+	r.skipTxn = false
+	r.dirty = false
+}
+
+// execute runs |query| with |bindings| against the engine and returns the number of rows affected.
+func (r *Replica) execute(ctx *sql.Context, query string, bindings map[string]sqlparser.Expr) (uint64, error) {
+	// Create a sub-context when running queries against the engine, so that we get an accurate query start time.
+	queryCtx := sql.NewContext(ctx, sql.WithSession(ctx.Session))
+	_, iter, _, err := r.engine.QueryWithBindings(queryCtx, query, nil, bindings, nil)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := sql.RowIterToRows(queryCtx, iter)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 1 && len(rows[0]) == 1 {
+		if okResult, ok := rows[0][0].(types.OkResult); ok {
+			return okResult.RowsAffected, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/gcctx"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// recordedStream is a replicationStream that replays recorded pgoutput messages, then blocks until it is closed.
+type recordedStream struct {
+	messages []replicationMessage
+	closed   chan struct{}
+
+	mu        sync.Mutex
+	confirmed LSN
+	closeOnce sync.Once
+}
+
+var _ replicationStream = (*recordedStream)(nil)
+
+func newRecordedStream(messages ...[]byte) *recordedStream {
+	s := &recordedStream{closed: make(chan struct{})}
+	for _, data := range messages {
+		s.messages = append(s.messages, &xLogData{Data: data})
+	}
+	return s
+}
+
+func (s *recordedStream) Receive() (replicationMessage, error) {
+	s.mu.Lock()
+	if len(s.messages) > 0 {
+		msg := s.messages[0]
+		s.messages = s.messages[1:]
+		s.mu.Unlock()
+		return msg, nil
+	}
+	s.mu.Unlock()
+
+	<-s.closed
+	return nil, io.EOF
+}
+
+func (s *recordedStream) SendStandbyStatus(lsn LSN) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.confirmed = lsn
+	return nil
+}
+
+func (s *recordedStream) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *recordedStream) Confirmed() LSN {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.confirmed
+}
+
+func TestReplicaAppliesRecordedMessages(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.Close()
+
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), editor.Options{})
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	useRootAccount(engine, sqlCtx)
+
+	runQuery(t, sqlCtx, engine, "create table accounts (id int primary key, name varchar(100), active bool, balance decimal(10,2), data blob, updated_at datetime(6))")
+	runQuery(t, sqlCtx, engine, "call dolt_commit('-Am', 'create accounts')")
+
+	commitTime := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	relation := encodeRelation(16385, "public", "accounts",
+		relCol("id", oidInt4, true),
+		relCol("name", 1043, false),
+		relCol("active", oidBool, false),
+		relCol("balance", oidNumeric, false),
+		relCol("data", oidBytea, false),
+		relCol("updated_at", oidTimestamptz, false),
+		relCol("only_in_postgres", 25, false))
+	messages := [][]byte{
+		relation,
+		encodeBegin(0x100, commitTime, 700),
+		encodeInsert(16385, textCol("1"), textCol("alice"), textCol("t"), textCol("10.50"), textCol(`\x0102`), textCol("2026-10-01 14:00:00.5+02"), textCol("x")),
+		encodeInsert(16385, textCol("2"), textCol("bob"), textCol("f"), textCol("3.00"), nullCol(), nullCol(), nullCol()),
+		encodeInsert(16385, textCol("3"), textCol("carol"), textCol("t"), textCol("0"), nullCol(), nullCol(), nullCol()),
+		encodeCommit(0x100, 0x130, commitTime),
+		encodeBegin(0x200, commitTime.Add(time.Minute), 701),
+		encodeUpdate(16385, nil, textCol("1"), textCol("alice"), textCol("f"), textCol("20.00"), unchangedCol(), unchangedCol(), textCol("x")),
+		encodeUpdate(16385, []TupleColumn{textCol("2"), nullCol(), nullCol(), nullCol(), nullCol(), nullCol(), nullCol()},
+			textCol("4"), textCol("bob"), textCol("f"), textCol("3.00"), nullCol(), nullCol(), nullCol()),
+		encodeDelete(16385, textCol("3"), nullCol(), nullCol(), nullCol(), nullCol(), nullCol(), nullCol()),
+		encodeCommit(0x200, 0x230, commitTime.Add(time.Minute)),
+	}
+
+	replica := newTestReplica(engine, true)
+	stream := newRecordedStream(messages...)
+	replica.connect = func(context.Context, LSN) (replicationStream, error) {
+		return stream, nil
+	}
+	runReplicaUntil(t, dEnv, sqlCtx, replica, func() bool { return stream.Confirmed() == 0x230 })
+
+	requireRows(t, sqlCtx, engine, "select id, name, active, cast(balance as char), hex(data), cast(updated_at as char) from accounts order by id", []sql.Row{
+		{int32(1), "alice", int8(0), "20.00", "0102", "2026-10-01 12:00:00.5"},
+		{int32(4), "bob", int8(0), "3.00", nil, nil},
+	})
+	requireRows(t, sqlCtx, engine, "select count(*) from dolt_log", []sql.Row{{int64(4)}})
+
+	// Restarting replication from the start of the slot must skip the transactions that were already applied
+	stream = newRecordedStream(messages...)
+	replica = newTestReplica(engine, true)
+	replica.connect = func(_ context.Context, startLSN LSN) (replicationStream, error) {
+		require.Equal(t, LSN(0x230), startLSN)
+		return stream, nil
+	}
+	runReplicaUntil(t, dEnv, sqlCtx, replica, func() bool { return stream.Confirmed() == 0x230 && len(stream.messages) == 0 })
+	requireRows(t, sqlCtx, engine, "select count(*) from accounts", []sql.Row{{int64(2)}})
+	requireRows(t, sqlCtx, engine, "select count(*) from dolt_log", []sql.Row{{int64(4)}})
+}
+
+func TestReplicaWithoutDoltCommits(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.Close()
+
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), editor.Options{})
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	useRootAccount(engine, sqlCtx)
+
+	runQuery(t, sqlCtx, engine, "create table items (id int, label text)")
+	runQuery(t, sqlCtx, engine, "call dolt_commit('-Am', 'create items')")
+
+	commitTime := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	stream := newRecordedStream(
+		encodeRelation(16400, "public", "items", relCol("id", oidInt4, false), relCol("label", 25, false)),
+		encodeBegin(0x100, commitTime, 700),
+		encodeInsert(16400, textCol("1"), textCol("a")),
+		encodeInsert(16400, textCol("1"), textCol("a")),
+		encodeInsert(16400, textCol("2"), textCol("b")),
+		encodeCommit(0x100, 0x130, commitTime),
+		encodeBegin(0x200, commitTime, 701),
+		// A keyless table with REPLICA IDENTITY FULL sends the whole old row, and only one duplicate is removed
+		appendTuple([]byte{messageTypeDelete, 0, 0, 0x40, 0x10, tupleMarkerOld}, []TupleColumn{textCol("1"), textCol("a")}),
+		encodeCommit(0x200, 0x230, commitTime),
+	)
+
+	replica := newTestReplica(engine, false)
+	replica.connect = func(context.Context, LSN) (replicationStream, error) {
+		return stream, nil
+	}
+	runReplicaUntil(t, dEnv, sqlCtx, replica, func() bool { return stream.Confirmed() == 0x230 })
+
+	requireRows(t, sqlCtx, engine, "select id, label from items order by id", []sql.Row{
+		{int32(1), "a"},
+		{int32(2), "b"},
+	})
+	requireRows(t, sqlCtx, engine, "select count(*) from dolt_log", []sql.Row{{int64(2)}})
+	requireRows(t, sqlCtx, engine, "select count(*) from dolt_status", []sql.Row{{int64(1)}})
+}
+
+func newTestReplica(engine *gms.Engine, commitPerTransaction bool) *Replica {
+	return NewReplica(Config{
+		Host:                 "localhost",
+		User:                 "replicator",
+		SlotName:             "dolt_slot",
+		Publication:          "dolt_pub",
+		TargetDatabase:       "dolt",
+		CommitPerTransaction: commitPerTransaction,
+	}, engine)
+}
+
+// runReplicaUntil runs |replica| on a new session until |done| returns true, then stops it.
+func runReplicaUntil(t *testing.T, dEnv *env.DoltEnv, ctx *sql.Context, replica *Replica, done func() bool) {
+	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+	replicaCtx := sqle.NewTestSQLCtxWithProvider(context.Background(), dsess.DSessFromSess(ctx.Session).Provider(), config, nil, gcctx.NewGCSafepointController())
+	require.NoError(t, replica.Go(replicaCtx))
+	require.Eventually(t, done, 10*time.Second, 10*time.Millisecond)
+	replica.Stop()
+	require.False(t, replica.IsRunning())
+}
+
+// useRootAccount gives |ctx| a root account, since adding the applier user enables privilege checks.
+func useRootAccount(engine *gms.Engine, ctx *sql.Context) {
+	engine.Analyzer.Catalog.MySQLDb.AddRootAccount()
+	ctx.SetClient(sql.Client{User: "root", Address: "localhost"})
+}
+
+func runQuery(t *testing.T, ctx *sql.Context, engine *gms.Engine, query string) {
+	_, iter, _, err := engine.Query(ctx, query)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+}
+
+func requireRows(t *testing.T, ctx *sql.Context, engine *gms.Engine, query string, expected []sql.Row) {
+	_, iter, _, err := engine.Query(ctx, query)
+	require.NoError(t, err)
+	rows, err := sql.RowIterToRows(ctx, iter)
+	require.NoError(t, err)
+	require.Equal(t, expected, rows)
+}
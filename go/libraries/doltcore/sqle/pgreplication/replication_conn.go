@@ -0,0 +1,516 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// replicationMessage is a message received from the source over the streaming replication protocol. It is either
+// an *xLogData or a *primaryKeepalive.
+type replicationMessage interface {
+	isReplicationMessage()
+}
+
+// xLogData carries a chunk of WAL data, which for logical replication is a single pgoutput message.
+type xLogData struct {
+	WALStart     LSN
+	ServerWALEnd LSN
+	ServerTime   time.Time
+	Data         []byte
+}
+
+// primaryKeepalive is sent periodically by the source. When ReplyRequested is set, the source expects a standby
+// status update promptly, or it will eventually time out the connection.
+type primaryKeepalive struct {
+	ServerWALEnd   LSN
+	ServerTime     time.Time
+	ReplyRequested bool
+}
+
+func (*xLogData) isReplicationMessage()         {}
+func (*primaryKeepalive) isReplicationMessage() {}
+
+// replicationStream is the source of replication messages for the applier. It is implemented by replicationConn for
+// a live Postgres connection, and by recorded message streams in tests.
+type replicationStream interface {
+	// Receive blocks until the next replication message is received from the source.
+	Receive() (replicationMessage, error)
+	// SendStandbyStatus reports to the source that all WAL up to |lsn| has been durably applied, allowing the
+	// source to advance the replication slot and recycle that WAL.
+	SendStandbyStatus(lsn LSN) error
+	// Close closes the stream and releases any resources held by it.
+	Close() error
+}
+
+// Frontend and backend message types used by the streaming replication protocol.
+// See https://www.postgresql.org/docs/current/protocol-message-formats.html
+const (
+	backendAuthentication   = 'R'
+	backendBackendKeyData   = 'K'
+	backendCopyBothResponse = 'W'
+	backendCopyData         = 'd'
+	backendCopyDone         = 'c'
+	backendErrorResponse    = 'E'
+	backendNoticeResponse   = 'N'
+	backendParameterStatus  = 'S'
+	backendReadyForQuery    = 'Z'
+
+	frontendCopyData  = 'd'
+	frontendPassword  = 'p'
+	frontendQuery     = 'Q'
+	frontendTerminate = 'X'
+
+	copyDataXLogData         = 'w'
+	copyDataPrimaryKeepalive = 'k'
+	copyDataStandbyStatus    = 'r'
+)
+
+// Authentication request codes.
+const (
+	authOk                = 0
+	authCleartextPassword = 3
+	authMD5Password       = 5
+	authSASL              = 10
+	authSASLContinue      = 11
+	authSASLFinal         = 12
+)
+
+const protocolVersion3 = 196608
+const sslRequestCode = 80877103
+const scramSha256 = "SCRAM-SHA-256"
+
+// replicationConn is a minimal Postgres frontend protocol client that implements only what is needed to stream
+// pgoutput messages from a logical replication slot: connection startup and authentication (cleartext, MD5 and
+// SCRAM-SHA-256), the START_REPLICATION command, and the CopyBoth sub-protocol that carries WAL data and standby
+// status updates.
+type replicationConn struct {
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+var _ replicationStream = (*replicationConn)(nil)
+
+// dialReplicationConn connects to the Postgres server described by |cfg| in logical replication mode and
+// authenticates. The returned connection is ready to execute replication commands.
+func dialReplicationConn(ctx context.Context, cfg Config) (*replicationConn, error) {
+	dialer := net.Dialer{Timeout: connectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &replicationConn{conn: conn, rd: bufio.NewReader(conn)}
+	if cfg.SSL {
+		if err = rc.upgradeToTLS(cfg.Host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err = rc.startup(cfg); err != nil {
+		rc.conn.Close()
+		return nil, err
+	}
+	return rc, nil
+}
+
+// upgradeToTLS sends an SSLRequest and, if the server accepts it, wraps the connection in TLS. Like libpq's
+// sslmode=require, the server certificate is not verified.
+func (rc *replicationConn) upgradeToTLS(host string) error {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint32(msg[0:4], 8)
+	binary.BigEndian.PutUint32(msg[4:8], sslRequestCode)
+	if _, err := rc.conn.Write(msg); err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(rc.conn, response); err != nil {
+		return err
+	}
+	if response[0] != 'S' {
+		return fmt.Errorf("postgres server at %s does not support SSL connections", host)
+	}
+
+	tlsConn := tls.Client(rc.conn, &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	rc.conn = tlsConn
+	rc.rd = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// startup sends the startup message, performs authentication, and waits for the server to be ready for queries.
+func (rc *replicationConn) startup(cfg Config) error {
+	var body []byte
+	body = binary.BigEndian.AppendUint32(body, protocolVersion3)
+	for _, kv := range [][2]string{
+		{"user", cfg.User},
+		{"database", cfg.Database},
+		{"replication", "database"},
+		{"application_name", "dolt"},
+	} {
+		body = append(body, kv[0]...)
+		body = append(body, 0)
+		body = append(body, kv[1]...)
+		body = append(body, 0)
+	}
+	body = append(body, 0)
+
+	msg := binary.BigEndian.AppendUint32(nil, uint32(len(body)+4))
+	if _, err := rc.conn.Write(append(msg, body...)); err != nil {
+		return err
+	}
+
+	var scram *scramClient
+	for {
+		msgType, msgBody, err := rc.readMessage()
+		if err != nil {
+			return err
+		}
+
+		switch msgType {
+		case backendAuthentication:
+			r := &messageReader{buf: msgBody}
+			code := r.uint32()
+			switch code {
+			case authOk:
+			case authCleartextPassword:
+				err = rc.writeMessage(frontendPassword, append([]byte(cfg.Password), 0))
+			case authMD5Password:
+				salt := r.bytes(4)
+				err = rc.writeMessage(frontendPassword, append([]byte(md5Password(cfg.User, cfg.Password, salt)), 0))
+			case authSASL:
+				var mechanisms []string
+				for len(r.buf) > 1 && r.err == nil {
+					mechanisms = append(mechanisms, r.cstring())
+				}
+				if !slices.Contains(mechanisms, scramSha256) {
+					return fmt.Errorf("unsupported SASL authentication mechanisms: %v", mechanisms)
+				}
+				scram, err = newScramClient(cfg.Password)
+				if err != nil {
+					return err
+				}
+				clientFirst := scram.clientFirstMessage()
+				var initial []byte
+				initial = append(initial, scramSha256...)
+				initial = append(initial, 0)
+				initial = binary.BigEndian.AppendUint32(initial, uint32(len(clientFirst)))
+				initial = append(initial, clientFirst...)
+				err = rc.writeMessage(frontendPassword, initial)
+			case authSASLContinue:
+				if scram == nil {
+					return fmt.Errorf("received unexpected SASL continue message")
+				}
+				var clientFinal string
+				clientFinal, err = scram.clientFinalMessage(string(r.buf))
+				if err == nil {
+					err = rc.writeMessage(frontendPassword, []byte(clientFinal))
+				}
+			case authSASLFinal:
+				if scram == nil {
+					return fmt.Errorf("received unexpected SASL final message")
+				}
+				err = scram.verifyServerFinal(string(r.buf))
+			default:
+				return fmt.Errorf("unsupported postgres authentication method: %d", code)
+			}
+			if err == nil {
+				err = r.err
+			}
+			if err != nil {
+				return err
+			}
+		case backendParameterStatus, backendBackendKeyData, backendNoticeResponse:
+		case backendReadyForQuery:
+			return nil
+		case backendErrorResponse:
+			return parseErrorResponse(msgBody)
+		default:
+			return fmt.Errorf("unexpected message type during connection startup: %q", msgType)
+		}
+	}
+}
+
+// startReplication issues START_REPLICATION for the logical replication slot |slot|, using the pgoutput plugin to
+// stream changes for |publication|, beginning at |startLSN|. A |startLSN| of zero starts from the slot's confirmed
+// flush position.
+func (rc *replicationConn) startReplication(slot, publication string, startLSN LSN) error {
+	query := fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names %s)",
+		quotePgIdentifier(slot), startLSN.String(), quotePgLiteral(quotePgIdentifier(publication)))
+	if err := rc.writeMessage(frontendQuery, append([]byte(query), 0)); err != nil {
+		return err
+	}
+
+	for {
+		msgType, msgBody, err := rc.readMessage()
+		if err != nil {
+			return err
+		}
+		switch msgType {
+		case backendCopyBothResponse:
+			return nil
+		case backendNoticeResponse, backendParameterStatus:
+		case backendErrorResponse:
+			return parseErrorResponse(msgBody)
+		default:
+			return fmt.Errorf("unexpected response to START_REPLICATION: %q", msgType)
+		}
+	}
+}
+
+// Receive implements replicationStream.
+func (rc *replicationConn) Receive() (replicationMessage, error) {
+	for {
+		msgType, msgBody, err := rc.readMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		switch msgType {
+		case backendCopyData:
+			if len(msgBody) == 0 {
+				return nil, fmt.Errorf("received empty CopyData message")
+			}
+			r := &messageReader{buf: msgBody[1:]}
+			switch msgBody[0] {
+			case copyDataXLogData:
+				msg := &xLogData{
+					WALStart:     LSN(r.uint64()),
+					ServerWALEnd: LSN(r.uint64()),
+					ServerTime:   pgTimeToTime(int64(r.uint64())),
+				}
+				msg.Data = r.buf
+				return msg, r.err
+			case copyDataPrimaryKeepalive:
+				msg := &primaryKeepalive{
+					ServerWALEnd:   LSN(r.uint64()),
+					ServerTime:     pgTimeToTime(int64(r.uint64())),
+					ReplyRequested: r.uint8() == 1,
+				}
+				return msg, r.err
+			default:
+				return nil, fmt.Errorf("unexpected CopyData message type: %q", msgBody[0])
+			}
+		case backendNoticeResponse, backendParameterStatus:
+		case backendCopyDone:
+			return nil, io.EOF
+		case backendErrorResponse:
+			return nil, parseErrorResponse(msgBody)
+		default:
+			return nil, fmt.Errorf("unexpected message type during replication: %q", msgType)
+		}
+	}
+}
+
+// SendStandbyStatus implements replicationStream.
+func (rc *replicationConn) SendStandbyStatus(lsn LSN) error {
+	var body []byte
+	body = append(body, copyDataStandbyStatus)
+	body = binary.BigEndian.AppendUint64(body, uint64(lsn)) // written
+	body = binary.BigEndian.AppendUint64(body, uint64(lsn)) // flushed
+	body = binary.BigEndian.AppendUint64(body, uint64(lsn)) // applied
+	body = binary.BigEndian.AppendUint64(body, uint64(timeToPgTime(time.Now())))
+	body = append(body, 0)
+	return rc.writeMessage(frontendCopyData, body)
+}
+
+// Close implements replicationStream.
+func (rc *replicationConn) Close() error {
+	// Best effort to let the server know we're going away; the connection is closed regardless.
+	_ = rc.writeMessage(frontendTerminate, nil)
+	return rc.conn.Close()
+}
+
+// readMessage reads a single backend message, returning its type and body.
+func (rc *replicationConn) readMessage() (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(rc.rd, header); err != nil {
+		return 0, nil, err
+	}
+	length := int(binary.BigEndian.Uint32(header[1:5])) - 4
+	if length < 0 {
+		return 0, nil, fmt.Errorf("invalid message length: %d", length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(rc.rd, body); err != nil {
+		return 0, nil, err
+	}
+	return header[0], body, nil
+}
+
+// writeMessage writes a single frontend message of type |msgType| with |body|.
+func (rc *replicationConn) writeMessage(msgType byte, body []byte) error {
+	msg := make([]byte, 0, len(body)+5)
+	msg = append(msg, msgType)
+	msg = binary.BigEndian.AppendUint32(msg, uint32(len(body)+4))
+	msg = append(msg, body...)
+	_, err := rc.conn.Write(msg)
+	return err
+}
+
+// PgError is an error reported by the Postgres source server.
+type PgError struct {
+	Severity string
+	Code     string
+	Message  string
+}
+
+func (e *PgError) Error() string {
+	return fmt.Sprintf("postgres error %s (%s): %s", e.Code, e.Severity, e.Message)
+}
+
+// parseErrorResponse decodes the fields of an ErrorResponse message body into a *PgError.
+func parseErrorResponse(body []byte) error {
+	pgErr := &PgError{}
+	r := &messageReader{buf: body}
+	for r.err == nil && len(r.buf) > 0 {
+		field := r.uint8()
+		if field == 0 {
+			break
+		}
+		value := r.cstring()
+		switch field {
+		case 'S':
+			pgErr.Severity = value
+		case 'C':
+			pgErr.Code = value
+		case 'M':
+			pgErr.Message = value
+		}
+	}
+	return pgErr
+}
+
+// md5Password computes the response to an MD5 password authentication request.
+func md5Password(user, password string, salt []byte) string {
+	inner := md5.Sum([]byte(password + user))
+	outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), salt...))
+	return "md5" + hex.EncodeToString(outer[:])
+}
+
+// scramClient implements the client side of SCRAM-SHA-256 authentication (RFC 5802 and RFC 7677). Postgres
+// ignores the user name in the SCRAM exchange in favor of the one sent in the startup message, so none is sent.
+type scramClient struct {
+	password        string
+	clientNonce     string
+	clientFirstBare string
+	authMessage     string
+	saltedPassword  []byte
+}
+
+func newScramClient(password string) (*scramClient, error) {
+	nonce := make([]byte, 18)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &scramClient{password: password, clientNonce: base64.StdEncoding.EncodeToString(nonce)}, nil
+}
+
+func (s *scramClient) clientFirstMessage() string {
+	s.clientFirstBare = "n=,r=" + s.clientNonce
+	return "n,," + s.clientFirstBare
+}
+
+func (s *scramClient) clientFinalMessage(serverFirst string) (string, error) {
+	var nonce, salt string
+	var iterations int
+	for _, attr := range strings.Split(serverFirst, ",") {
+		if len(attr) < 2 || attr[1] != '=' {
+			continue
+		}
+		switch attr[0] {
+		case 'r':
+			nonce = attr[2:]
+		case 's':
+			salt = attr[2:]
+		case 'i':
+			iterations, _ = strconv.Atoi(attr[2:])
+		}
+	}
+	if !strings.HasPrefix(nonce, s.clientNonce) || salt == "" || iterations <= 0 {
+		return "", fmt.Errorf("invalid SCRAM server-first message")
+	}
+
+	decodedSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return "", fmt.Errorf("invalid SCRAM salt: %w", err)
+	}
+	s.saltedPassword, err = pbkdf2.Key(sha256.New, s.password, decodedSalt, iterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+
+	clientFinalWithoutProof := "c=biws,r=" + nonce
+	s.authMessage = s.clientFirstBare + "," + serverFirst + "," + clientFinalWithoutProof
+
+	clientKey := hmacSha256(s.saltedPassword, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+	clientSignature := hmacSha256(storedKey[:], []byte(s.authMessage))
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ clientSignature[i]
+	}
+
+	return clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (s *scramClient) verifyServerFinal(serverFinal string) error {
+	if !strings.HasPrefix(serverFinal, "v=") {
+		return fmt.Errorf("invalid SCRAM server-final message")
+	}
+	signature, err := base64.StdEncoding.DecodeString(serverFinal[2:])
+	if err != nil {
+		return fmt.Errorf("invalid SCRAM server signature: %w", err)
+	}
+	serverKey := hmacSha256(s.saltedPassword, []byte("Server Key"))
+	if !hmac.Equal(signature, hmacSha256(serverKey, []byte(s.authMessage))) {
+		return fmt.Errorf("SCRAM server signature does not match")
+	}
+	return nil
+}
+
+func hmacSha256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// quotePgIdentifier quotes |s| as a Postgres identifier.
+func quotePgIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quotePgLiteral quotes |s| as a Postgres string literal.
+func quotePgLiteral(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
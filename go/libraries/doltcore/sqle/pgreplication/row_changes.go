@@ -0,0 +1,331 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgreplication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+)
+
+// Postgres type OIDs that need conversion from their text representation before being applied.
+const (
+	oidBool        = 16
+	oidBytea       = 17
+	oidInt8        = 20
+	oidInt2        = 21
+	oidInt4        = 23
+	oidOid         = 26
+	oidFloat4      = 700
+	oidFloat8      = 701
+	oidTimestamptz = 1184
+	oidNumeric     = 1700
+)
+
+// timestamptzLayouts are the layouts Postgres uses for timestamptz values with the ISO DateStyle.
+var timestamptzLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+}
+
+// tableMapping maps the columns of a source relation onto the columns of a Dolt table.
+type tableMapping struct {
+	// tableName is the exact name of the Dolt table.
+	tableName string
+	// columns holds, for each relation column, the name of the Dolt column it maps to, or "" if the Dolt table has
+	// no column of that name.
+	columns []string
+	// keyColumns holds the indexes of the relation columns that map to the Dolt table's primary key columns. It is
+	// empty for keyless tables.
+	keyColumns []int
+}
+
+// getTableMapping returns the mapping from the relation with |relationID| to its Dolt table, loading it from the
+// current working root of the target database if it isn't already cached.
+func (r *Replica) getTableMapping(ctx *sql.Context, relationID uint32) (*tableMapping, *RelationMessage, error) {
+	rel, ok := r.relations[relationID]
+	if !ok {
+		return nil, nil, fmt.Errorf("received row change for unknown relation ID %d", relationID)
+	}
+	if mapping, ok := r.mappings[relationID]; ok {
+		return mapping, rel, nil
+	}
+
+	roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, r.cfg.TargetDatabase)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to load roots for database %s", r.cfg.TargetDatabase)
+	}
+	tbl, tableName, ok, err := doltdb.GetTableInsensitive(ctx, roots.Working, doltdb.TableName{Name: rel.Name})
+	if err != nil {
+		return nil, nil, err
+	} else if !ok {
+		return nil, nil, fmt.Errorf("unable to find table %q in database %s for relation %s.%s",
+			rel.Name, r.cfg.TargetDatabase, rel.Namespace, rel.Name)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapping := &tableMapping{
+		tableName: tableName,
+		columns:   make([]string, len(rel.Columns)),
+	}
+	allCols := sch.GetAllCols()
+	for i, relCol := range rel.Columns {
+		if col, ok := allCols.GetByNameCaseInsensitive(relCol.Name); ok {
+			mapping.columns[i] = col.Name
+		} else {
+			ctx.GetLogger().Warnf("column %s of relation %s.%s does not exist in table %s and will not be replicated",
+				relCol.Name, rel.Namespace, rel.Name, tableName)
+		}
+	}
+	for _, pkCol := range sch.GetPKCols().GetColumns() {
+		idx := -1
+		for i, name := range mapping.columns {
+			if name == pkCol.Name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, nil, fmt.Errorf("primary key column %s of table %s is not present in relation %s.%s",
+				pkCol.Name, tableName, rel.Namespace, rel.Name)
+		}
+		mapping.keyColumns = append(mapping.keyColumns, idx)
+	}
+
+	r.mappings[relationID] = mapping
+	return mapping, rel, nil
+}
+
+// applyRowChange applies an insert, update, delete or truncate message to the target database.
+func (r *Replica) applyRowChange(ctx *sql.Context, msg pgoutputMessage) error {
+	switch msg := msg.(type) {
+	case *InsertMessage:
+		return r.applyInsert(ctx, msg)
+	case *UpdateMessage:
+		return r.applyUpdate(ctx, msg)
+	case *DeleteMessage:
+		return r.applyDelete(ctx, msg)
+	case *TruncateMessage:
+		for _, relationID := range msg.RelationIDs {
+			mapping, _, err := r.getTableMapping(ctx, relationID)
+			if err != nil {
+				return err
+			}
+			// TRUNCATE would implicitly commit the transaction, so delete all the rows instead
+			if _, err = r.execute(ctx, "DELETE FROM "+r.qualifiedTableName(ctx, mapping), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unexpected row change message: %T", msg)
+	}
+}
+
+func (r *Replica) applyInsert(ctx *sql.Context, msg *InsertMessage) error {
+	mapping, rel, err := r.getTableMapping(ctx, msg.RelationID)
+	if err != nil {
+		return err
+	}
+
+	b := newStatementBuilder()
+	var columns, values []string
+	for i, name := range mapping.columns {
+		if name == "" || i >= len(msg.NewTuple.Columns) || msg.NewTuple.Columns[i].IsUnchanged() {
+			continue
+		}
+		expr, err := tupleColumnToExpr(rel.Columns[i], msg.NewTuple.Columns[i])
+		if err != nil {
+			return err
+		}
+		columns = append(columns, sqlfmt.QuoteIdentifier(ctx, name))
+		values = append(values, b.bind(expr))
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", r.qualifiedTableName(ctx, mapping),
+		strings.Join(columns, ", "), strings.Join(values, ", "))
+	_, err = r.execute(ctx, query, b.bindings)
+	return err
+}
+
+func (r *Replica) applyUpdate(ctx *sql.Context, msg *UpdateMessage) error {
+	mapping, rel, err := r.getTableMapping(ctx, msg.RelationID)
+	if err != nil {
+		return err
+	}
+
+	b := newStatementBuilder()
+	var assignments []string
+	for i, name := range mapping.columns {
+		// Unchanged TOASTed values are not sent, so leave those columns as they are
+		if name == "" || i >= len(msg.NewTuple.Columns) || msg.NewTuple.Columns[i].IsUnchanged() {
+			continue
+		}
+		expr, err := tupleColumnToExpr(rel.Columns[i], msg.NewTuple.Columns[i])
+		if err != nil {
+			return err
+		}
+		assignments = append(assignments, sqlfmt.QuoteIdentifier(ctx, name)+" = "+b.bind(expr))
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	// When the replica identity didn't change, only the new tuple is sent, and it identifies the row
+	identity := msg.OldTuple
+	if identity == nil {
+		identity = msg.NewTuple
+	}
+	where, err := r.rowIdentity(ctx, b, mapping, rel, identity)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", r.qualifiedTableName(ctx, mapping), strings.Join(assignments, ", "), where)
+	_, err = r.execute(ctx, query, b.bindings)
+	return err
+}
+
+func (r *Replica) applyDelete(ctx *sql.Context, msg *DeleteMessage) error {
+	mapping, rel, err := r.getTableMapping(ctx, msg.RelationID)
+	if err != nil {
+		return err
+	}
+
+	b := newStatementBuilder()
+	where, err := r.rowIdentity(ctx, b, mapping, rel, msg.OldTuple)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", r.qualifiedTableName(ctx, mapping), where)
+	affected, err := r.execute(ctx, query, b.bindings)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		ctx.GetLogger().Warnf("replicated delete for relation %s.%s matched no rows in table %s", rel.Namespace, rel.Name, mapping.tableName)
+	}
+	return nil
+}
+
+// rowIdentity returns a WHERE clause that identifies the single row described by |tuple|. Rows in tables with a
+// primary key are identified by their primary key columns. Rows in keyless tables can only be identified when the
+// source relation uses REPLICA IDENTITY FULL, in which case every column is matched and only one of any identical
+// rows is affected.
+func (r *Replica) rowIdentity(ctx *sql.Context, b *statementBuilder, mapping *tableMapping, rel *RelationMessage, tuple *TupleData) (string, error) {
+	keyColumns := mapping.keyColumns
+	limit := ""
+	if len(keyColumns) == 0 {
+		for i, name := range mapping.columns {
+			if name != "" {
+				keyColumns = append(keyColumns, i)
+			}
+		}
+		limit = " LIMIT 1"
+	}
+
+	var conditions []string
+	for _, i := range keyColumns {
+		if i >= len(tuple.Columns) || tuple.Columns[i].IsUnchanged() {
+			return "", fmt.Errorf("unable to identify row in table %s: value for column %s was not sent; "+
+				"keyless tables require REPLICA IDENTITY FULL on the source", mapping.tableName, mapping.columns[i])
+		}
+		expr, err := tupleColumnToExpr(rel.Columns[i], tuple.Columns[i])
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, sqlfmt.QuoteIdentifier(ctx, mapping.columns[i])+" <=> "+b.bind(expr))
+	}
+	return strings.Join(conditions, " AND ") + limit, nil
+}
+
+func (r *Replica) qualifiedTableName(ctx *sql.Context, mapping *tableMapping) string {
+	return sqlfmt.QuoteIdentifier(ctx, r.cfg.TargetDatabase) + "." + sqlfmt.QuoteIdentifier(ctx, mapping.tableName)
+}
+
+// statementBuilder collects the bind variables for a parameterized statement.
+type statementBuilder struct {
+	bindings map[string]sqlparser.Expr
+}
+
+func newStatementBuilder() *statementBuilder {
+	return &statementBuilder{bindings: make(map[string]sqlparser.Expr)}
+}
+
+// bind adds |expr| as a new bind variable and returns the placeholder to use for it in the statement.
+func (b *statementBuilder) bind(expr sqlparser.Expr) string {
+	name := fmt.Sprintf("v%d", len(b.bindings)+1)
+	b.bindings[name] = expr
+	return ":" + name
+}
+
+// tupleColumnToExpr converts a value sent in the text format of the Postgres type of |column| into an expression
+// that the engine can convert to the type of the corresponding Dolt column. Most Postgres text representations are
+// accepted as-is by MySQL type conversion; only the types whose text representation differs are converted here.
+func tupleColumnToExpr(column RelationColumn, value TupleColumn) (sqlparser.Expr, error) {
+	switch value.Kind {
+	case tupleColumnNull:
+		return &sqlparser.NullVal{}, nil
+	case tupleColumnText:
+	default:
+		return nil, fmt.Errorf("unsupported value format %q for column %s", value.Kind, column.Name)
+	}
+
+	text := string(value.Data)
+	switch column.TypeOID {
+	case oidBool:
+		if text == "t" {
+			return sqlparser.NewIntVal([]byte("1")), nil
+		}
+		return sqlparser.NewIntVal([]byte("0")), nil
+	case oidBytea:
+		if !strings.HasPrefix(text, `\x`) {
+			return nil, fmt.Errorf("unsupported bytea format for column %s; bytea_output must be 'hex'", column.Name)
+		}
+		if len(text) == 2 {
+			return sqlparser.NewStrVal(nil), nil
+		}
+		return sqlparser.NewHexVal([]byte(text[2:])), nil
+	case oidInt2, oidInt4, oidInt8, oidOid:
+		return sqlparser.NewIntVal(value.Data), nil
+	case oidFloat4, oidFloat8, oidNumeric:
+		if strings.ContainsAny(text, "NI") {
+			// NaN and Infinity have no MySQL equivalent; let the engine report an error converting them
+			return sqlparser.NewStrVal(value.Data), nil
+		}
+		return sqlparser.NewFloatVal(value.Data), nil
+	case oidTimestamptz:
+		for _, layout := range timestamptzLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				return sqlparser.NewStrVal([]byte(t.UTC().Format("2006-01-02 15:04:05.999999"))), nil
+			}
+		}
+		return sqlparser.NewStrVal(value.Data), nil
+	default:
+		return sqlparser.NewStrVal(value.Data), nil
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// QueryCacheBuilder is a sql.NodeExecBuilder that serves the results of read-only queries from the engine's
+// dsess.QueryCache, and caches the results of those it runs. It must be the priority builder of |base|, the builder
+// that runs queries, and defers to |priority| for every node that isn't the root of a cacheable query.
+type QueryCacheBuilder struct {
+	priority sql.NodeExecBuilder
+	base     sql.NodeExecBuilder
+}
+
+var _ sql.NodeExecBuilder = (*QueryCacheBuilder)(nil)
+
+// NewQueryCacheBuilder returns a QueryCacheBuilder that defers to |priority|, which may be nil, and runs queries with
+// |base|. It's installed with:
+//
+//	builder := rowexec.NewBuilder(nil, overrides)
+//	builder.PriorityBuilder = sqle.NewQueryCacheBuilder(priority, builder)
+func NewQueryCacheBuilder(priority sql.NodeExecBuilder, base sql.NodeExecBuilder) *QueryCacheBuilder {
+	return &QueryCacheBuilder{priority: priority, base: base}
+}
+
+func (b *QueryCacheBuilder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, error) {
+	if len(r) == 0 {
+		if sess, ok := ctx.Session.(*dsess.DoltSession); ok && sess.StartQueryCacheStatement(ctx) {
+			if cache := sess.Provider().QueryCache(); cache != nil && cache.Enabled() {
+				iter, ok, err := b.buildCached(ctx, cache, n)
+				if err != nil || ok {
+					return iter, err
+				}
+			}
+		}
+	}
+	if b.priority == nil {
+		return nil, nil
+	}
+	return b.priority.Build(ctx, n, r)
+}
+
+// buildCached returns the iterator for the root node |n| of a query when the query is cacheable: its cached result,
+// or its rows as they're cached
+func (b *QueryCacheBuilder) buildCached(ctx *sql.Context, cache *dsess.QueryCache, n sql.Node) (sql.RowIter, bool, error) {
+	key, info, ok, err := queryCacheKey(ctx, n)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	if rows, ok := cache.Get(key); ok {
+		return &cachedRowIter{rows: rows}, true, nil
+	}
+
+	// |n| is no longer the first node built for the statement, so this builds it as usual
+	iter, err := b.base.Build(ctx, n, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	info.Size = int64(len(info.Query))
+	return &queryCacheRowIter{iter: iter, cache: cache, key: key, info: info, maxSize: cache.MaxResultSize()}, true, nil
+}
+
+// queryCacheKey returns the key that the result of the query with the plan |n| is cached under, or false if the
+// query's results can't be cached. Only SELECT statements that read nothing but tables of Dolt databases, and whose
+// result depends on nothing else, are cached. The key combines the normalized text of the query with the hashes of
+// every table it reads, along with the session state that could change its result.
+func queryCacheKey(ctx *sql.Context, n sql.Node) (hash.Hash, dsess.QueryCacheEntryInfo, bool, error) {
+	if dsess.IsLockingRead(ctx) || !plan.IsReadOnly(n) {
+		return hash.Hash{}, dsess.QueryCacheEntryInfo{}, false, nil
+	}
+	if tx, ok := ctx.GetTransaction().(*dsess.DoltTransaction); ok && tx.IsSerializable() {
+		// SERIALIZABLE transactions record the rows they read, which a cached result doesn't
+		return hash.Hash{}, dsess.QueryCacheEntryInfo{}, false, nil
+	}
+	query, ok := normalizeCacheableQuery(ctx, ctx.Query())
+	if !ok {
+		return hash.Hash{}, dsess.QueryCacheEntryInfo{}, false, nil
+	}
+
+	timeZone, err := ctx.GetSessionVariable(ctx, "time_zone")
+	if err != nil {
+		return hash.Hash{}, dsess.QueryCacheEntryInfo{}, false, err
+	}
+	var buf bytes.Buffer
+	for _, s := range []string{query, n.String(), ctx.GetCurrentDatabase(), sql.LoadSqlMode(ctx).String(), fmt.Sprint(timeZone)} {
+		buf.WriteString(s)
+		buf.WriteByte(0)
+	}
+
+	dbs := make(map[string]struct{})
+	ok, err = writeQueryCacheTables(ctx, n, &buf, dbs)
+	if err != nil || !ok || len(dbs) == 0 {
+		return hash.Hash{}, dsess.QueryCacheEntryInfo{}, false, err
+	}
+
+	info := dsess.QueryCacheEntryInfo{Query: query, Database: ctx.GetCurrentDatabase()}
+	for db := range dbs {
+		info.Databases = append(info.Databases, db)
+	}
+	sort.Strings(info.Databases)
+	return hash.Of(buf.Bytes()), info, true, nil
+}
+
+// normalizeCacheableQuery returns |query| as normalized by the parser, or false if it isn't a SELECT statement whose
+// result can be cached. Statements with bind variables are never cached, since their text doesn't include the values
+// they're run with.
+func normalizeCacheableQuery(ctx *sql.Context, query string) (string, bool) {
+	stmt, err := sqlparser.ParseWithOptions(ctx, query, sql.LoadSqlMode(ctx).ParserOptions())
+	if err != nil {
+		return "", false
+	}
+	switch stmt.(type) {
+	case *sqlparser.Select, *sqlparser.SetOp:
+	default:
+		return "", false
+	}
+
+	cacheable := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			cacheable = node.Into == nil && (node.Lock == nil || node.Lock.Type == "")
+		case *sqlparser.SetOp:
+			cacheable = node.Into == nil && (node.Lock == nil || node.Lock.Type == "")
+		case *sqlparser.SQLVal:
+			cacheable = node.Type != sqlparser.ValArg
+		}
+		return cacheable, nil
+	}, stmt)
+	if !cacheable {
+		return "", false
+	}
+	return sqlparser.String(stmt), true
+}
+
+// queryCacheTable is a table whose content can be identified by a hash
+type queryCacheTable interface {
+	queryCacheHash(ctx *sql.Context) (h hash.Hash, ok bool, err error)
+}
+
+// queryCacheHash returns the hash of the content of this table, or false if results read from it can't be cached
+func (t *DoltTable) queryCacheHash(ctx *sql.Context) (hash.Hash, bool, error) {
+	if t.overriddenSchema != nil {
+		return hash.Hash{}, false, nil
+	}
+	root, err := t.workingRoot(ctx)
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	return root.GetTableHash(ctx, t.TableName())
+}
+
+// writeQueryCacheTables writes the database, name and hash of each table read by |n| to |buf|, and adds their
+// databases to |dbs|. Returns false if the result of |n| can't be cached, because it reads a table that isn't a table
+// of a Dolt database or its result depends on something other than the tables it reads.
+func writeQueryCacheTables(ctx *sql.Context, n sql.Node, buf *bytes.Buffer, dbs map[string]struct{}) (ok bool, err error) {
+	ok = true
+	transform.InspectWithOpaque(ctx, n, func(ctx *sql.Context, n sql.Node) bool {
+		switch n := n.(type) {
+		case sql.TableFunction:
+			ok = false
+		case sql.TableNode:
+			ok, err = writeQueryCacheTable(ctx, n, buf, dbs)
+		}
+		if ex, isExpressioner := n.(sql.Expressioner); isExpressioner && ok && err == nil {
+			for _, e := range ex.Expressions() {
+				if ok, err = writeQueryCacheExpression(ctx, e, buf, dbs); !ok || err != nil {
+					break
+				}
+			}
+		}
+		return ok && err == nil
+	})
+	return ok, err
+}
+
+func writeQueryCacheTable(ctx *sql.Context, n sql.TableNode, buf *bytes.Buffer, dbs map[string]struct{}) (bool, error) {
+	t, ok := sql.GetUnderlyingTable(n.UnderlyingTable()).(queryCacheTable)
+	if !ok {
+		return false, nil
+	}
+	h, ok, err := t.queryCacheHash(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	db := n.Database()
+	if db == nil {
+		return false, nil
+	}
+	dbName := db.Name()
+	if rdb, ok := db.(dsess.SqlDatabase); ok {
+		dbName = rdb.RevisionQualifiedName()
+	}
+	baseName, _ := doltdb.SplitRevisionDbName(dbName)
+	dbs[baseName] = struct{}{}
+
+	buf.WriteString(dbName)
+	buf.WriteByte(0)
+	buf.WriteString(n.Name())
+	buf.WriteByte(0)
+	buf.Write(h[:])
+	return true, nil
+}
+
+// writeQueryCacheExpression writes the tables read by the subqueries of |e| to |buf|, see writeQueryCacheTables.
+// Returns false if the value of |e| depends on anything other than the tables it reads.
+func writeQueryCacheExpression(ctx *sql.Context, e sql.Expression, buf *bytes.Buffer, dbs map[string]struct{}) (ok bool, err error) {
+	ok = true
+	transform.InspectExpr(ctx, e, func(ctx *sql.Context, e sql.Expression) bool {
+		switch e := e.(type) {
+		case *plan.Subquery:
+			if ok = !e.IsNonDeterministic(); ok {
+				ok, err = writeQueryCacheTables(ctx, e.Query, buf, dbs)
+			}
+		case *expression.UserVar, *expression.SystemVar, *expression.ProcedureParam, *expression.BindVar, *function.Sleep:
+			ok = false
+		case *dfunctions.ActiveBranchFunc, *dfunctions.HasAncestor, *dfunctions.HashOf, *dfunctions.HashOfDatabase,
+			*dfunctions.HashOfTable, *dfunctions.MergeBase, *dfunctions.JoinCost:
+			// these read the session or refs of the database, rather than tables
+			ok = false
+		case sql.NonDeterministicExpression:
+			ok = !e.IsNonDeterministic()
+		}
+		return !ok || err != nil
+	})
+	return ok, err
+}
+
+// queryCacheRowIter returns the rows of |iter|, and caches them once it's exhausted unless they're larger than
+// |maxSize|
+type queryCacheRowIter struct {
+	iter    sql.RowIter
+	cache   *dsess.QueryCache
+	key     hash.Hash
+	info    dsess.QueryCacheEntryInfo
+	rows    []sql.Row
+	maxSize int64
+	// done is set once the rows are cached, or found to be too large to cache
+	done bool
+}
+
+var _ sql.RowIter = (*queryCacheRowIter)(nil)
+
+func (i *queryCacheRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	row, err := i.iter.Next(ctx)
+	if err == io.EOF && !i.done {
+		i.cache.Put(i.key, i.info, i.rows)
+		i.done, i.rows = true, nil
+	}
+	if err != nil || i.done {
+		return row, err
+	}
+
+	i.info.Size += estimateRowSize(row)
+	if i.info.Size > i.maxSize {
+		i.done, i.rows = true, nil
+	} else {
+		i.rows = append(i.rows, row.Copy())
+	}
+	return row, nil
+}
+
+func (i *queryCacheRowIter) Close(ctx *sql.Context) error {
+	return i.iter.Close(ctx)
+}
+
+// cachedRowIter returns a cached result. Cached rows are shared by every query they're returned to, so each is copied.
+type cachedRowIter struct {
+	rows []sql.Row
+	i    int
+}
+
+var _ sql.RowIter = (*cachedRowIter)(nil)
+
+func (i *cachedRowIter) Next(*sql.Context) (sql.Row, error) {
+	if i.i >= len(i.rows) {
+		return nil, io.EOF
+	}
+	row := i.rows[i.i].Copy()
+	i.i++
+	return row, nil
+}
+
+func (i *cachedRowIter) Close(*sql.Context) error {
+	return nil
+}
+
+// estimateRowSize returns roughly how many bytes |row| takes in memory. Values that are loaded lazily, like large
+// TEXT and JSON values, only count the size of their reference.
+func estimateRowSize(row sql.Row) int64 {
+	size := int64(24 + 16*len(row))
+	for _, v := range row {
+		switch v := v.(type) {
+		case string:
+			size += int64(len(v))
+		case []byte:
+			size += int64(len(v))
+		}
+	}
+	return size
+}
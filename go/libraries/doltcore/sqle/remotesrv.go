@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"google.golang.org/grpc"
@@ -129,7 +130,7 @@ func (s hooksFiringRemoteSrvStore) Commit(ctx context.Context, current, last has
 
 	// Fire hooks for each ref-typed dataset that was added or changed.
 	for id, newAddr := range newAddrs {
-		if !ref.IsRef(id) {
+		if !firesCommitHooks(id) {
 			continue
 		}
 		if oldAddr, existed := oldAddrs[id]; !existed || oldAddr != newAddr {
@@ -139,7 +140,7 @@ func (s hooksFiringRemoteSrvStore) Commit(ctx context.Context, current, last has
 
 	// Fire hooks for each ref-typed dataset that was deleted.
 	for id := range oldAddrs {
-		if !ref.IsRef(id) {
+		if !firesCommitHooks(id) {
 			continue
 		}
 		if _, exists := newAddrs[id]; !exists {
@@ -150,6 +151,14 @@ func (s hooksFiringRemoteSrvStore) Commit(ctx context.Context, current, last has
 	return true, nil
 }
 
+// firesCommitHooks returns whether a change to the dataset |id| fires commit hooks. Tuples, like the operation log,
+// and deleted refs are written without firing them.
+func firesCommitHooks(id string) bool {
+	return ref.IsRef(id) &&
+		!strings.HasPrefix(id, ref.PrefixForType(ref.TupleRefType)) &&
+		!strings.HasPrefix(id, ref.PrefixForType(ref.DeletedRefType))
+}
+
 // In the SQL context, the database provider that we use to expose the
 // remotesapi interface can choose to either create a newly accessed database
 // on first access or to return NotFound. Currently we allow creation in the
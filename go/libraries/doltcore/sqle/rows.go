@@ -32,6 +32,16 @@ func newRowIterator(ctx context.Context, tbl *doltdb.Table, projCols []uint64, p
 		return nil, err
 	}
 
+	scan, err := newColumnStoreScan(ctx, tbl, sch, projCols)
+	if err != nil {
+		return nil, err
+	}
+	if scan != nil {
+		if iter, ok, err := scan.partitionRows(ctx, partition); ok || err != nil {
+			return iter, err
+		}
+	}
+
 	return ProllyRowIterFromPartition(ctx, sch, projCols, partition)
 }
 
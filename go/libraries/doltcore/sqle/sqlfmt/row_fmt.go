@@ -291,18 +291,19 @@ func SqlRowAsDeleteStmt(ctx *sql.Context, r sql.Row, tableName string, tableSch
 // correctly, so Column.IsGenerated doesn't filter.
 func SqlRowAsUpdateStmt(ctx *sql.Context, r sql.Row, tableName string, tableSch schema.Schema, colsToUpdate *set.StrSet) (string, error) {
 	var b strings.Builder
-	b.WriteString("UPDATE ")
-	b.WriteString(QuoteIdentifier(ctx, tableName))
-	b.WriteString(" ")
+	err := writeUpdateSet(ctx, &b, r, tableName, tableSch, colsToUpdate)
+	if err != nil {
+		return "", err
+	}
 
-	b.WriteString("SET ")
+	b.WriteString(" WHERE ")
 
 	i := 0
 	seenOne := false
-	err := tableSch.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
-		if colsToUpdate.Contains(col.Name) {
+	err = tableSch.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
+		if col.IsPartOfPK {
 			if seenOne {
-				b.WriteRune(',')
+				b.WriteString(" AND ")
 			}
 			seenOne = true
 
@@ -322,14 +323,108 @@ func SqlRowAsUpdateStmt(ctx *sql.Context, r sql.Row, tableName string, tableSch
 		return "", err
 	}
 
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+// SqlRowAsUpdateStmtWithPreimage generates an UPDATE statement like SqlRowAsUpdateStmt, except that the row to change
+// is matched by its old value |preimage| with SqlRowAsPreimageCondition, rather than by its primary key alone.
+func SqlRowAsUpdateStmtWithPreimage(ctx *sql.Context, r, preimage sql.Row, tableName string, tableSch, fromSch schema.Schema, colsToUpdate *set.StrSet) (string, error) {
+	var b strings.Builder
+	err := writeUpdateSet(ctx, &b, r, tableName, tableSch, colsToUpdate)
+	if err != nil {
+		return "", err
+	}
+
+	cond, err := SqlRowAsPreimageCondition(ctx, preimage, tableSch, fromSch)
+	if err != nil {
+		return "", err
+	}
 	b.WriteString(" WHERE ")
+	b.WriteString(cond)
+	b.WriteString(";")
+	return b.String(), nil
+}
 
-	i = 0
-	seenOne = false
-	err = tableSch.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
+// SqlRowAsDeleteStmtWithPreimage generates a DELETE statement for the row |r| that matches it with
+// SqlRowAsPreimageCondition, so that it only deletes the row if it hasn't changed.
+func SqlRowAsDeleteStmtWithPreimage(ctx *sql.Context, r sql.Row, tableName string, tableSch, fromSch schema.Schema) (string, error) {
+	cond, err := SqlRowAsPreimageCondition(ctx, r, tableSch, fromSch)
+	if err != nil {
+		return "", err
+	}
+	return "DELETE FROM " + QuoteIdentifier(ctx, tableName) + " WHERE " + cond + ";", nil
+}
+
+// SqlRowAsPreimageCondition returns a condition matching the row |r| of |tableSch| only while it has every value it
+// has in |r|, for the WHERE clause of a statement that changes it. Primary key columns are matched with =, and the
+// other columns with the null-safe <=>. Columns missing from |fromSch|, which had no value before the change, are left
+// out, as are spatial columns, whose values can't be compared to a literal. A nil |fromSch| includes every column.
+func SqlRowAsPreimageCondition(ctx *sql.Context, r sql.Row, tableSch, fromSch schema.Schema) (string, error) {
+	cols := tableSch.GetAllCols()
+	if len(r) != cols.Size() {
+		return "", fmt.Errorf("expected %d values for table schema, got %d", cols.Size(), len(r))
+	}
+
+	var b strings.Builder
+	seenOne := false
+	for i, val := range r {
+		col := cols.GetByIndex(i)
+		if col.IsGenerated() || col.TypeInfo.ToSqlType().Type() == querypb.Type_GEOMETRY {
+			continue
+		}
+		if fromSch != nil {
+			if _, ok := fromSch.GetAllCols().GetByNameCaseInsensitive(col.Name); !ok {
+				continue
+			}
+		}
+
+		sqlString, err := interfaceValueAsSqlString(ctx, col.TypeInfo, val)
+		if err != nil {
+			return "", err
+		}
+		// JSON and FLOAT values don't compare equal to the literals they're written as unless they're cast back
+		switch col.TypeInfo.ToSqlType().Type() {
+		case querypb.Type_JSON:
+			if val != nil {
+				sqlString = "CAST(" + sqlString + " AS JSON)"
+			}
+		case querypb.Type_FLOAT32:
+			if val != nil {
+				sqlString = "CAST(" + sqlString + " AS FLOAT)"
+			}
+		}
+
+		if seenOne {
+			b.WriteString(" AND ")
+		}
+		seenOne = true
+		b.WriteString(QuoteIdentifier(ctx, col.Name))
 		if col.IsPartOfPK {
+			b.WriteRune('=')
+		} else {
+			b.WriteString("<=>")
+		}
+		b.WriteString(sqlString)
+	}
+	return b.String(), nil
+}
+
+// writeUpdateSet writes the start of an UPDATE statement of |tableName|, up to the end of its SET clause, setting the
+// columns of |r| named by |colsToUpdate|.
+func writeUpdateSet(ctx *sql.Context, b *strings.Builder, r sql.Row, tableName string, tableSch schema.Schema, colsToUpdate *set.StrSet) error {
+	b.WriteString("UPDATE ")
+	b.WriteString(QuoteIdentifier(ctx, tableName))
+	b.WriteString(" ")
+
+	b.WriteString("SET ")
+
+	i := 0
+	seenOne := false
+	return tableSch.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
+		if colsToUpdate.Contains(col.Name) {
 			if seenOne {
-				b.WriteString(" AND ")
+				b.WriteRune(',')
 			}
 			seenOne = true
 
@@ -344,13 +439,6 @@ func SqlRowAsUpdateStmt(ctx *sql.Context, r sql.Row, tableName string, tableSch
 		i++
 		return false, nil
 	})
-
-	if err != nil {
-		return "", err
-	}
-
-	b.WriteString(";")
-	return b.String(), nil
 }
 
 func interfaceValueAsSqlString(ctx *sql.Context, ti typeinfo.TypeInfo, value interface{}) (string, error) {
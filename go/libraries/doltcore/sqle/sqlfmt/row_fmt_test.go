@@ -126,6 +126,30 @@ func TestSqlRowAsTupleString(t *testing.T) {
 	}
 }
 
+func TestSqlRowAsPreimageCondition(t *testing.T) {
+	sch := dtestutils.CreateSchema(
+		schema.Column{Name: "id", Tag: 0, Kind: types.IntKind, IsPartOfPK: true, TypeInfo: typeinfo.Int64Type},
+		schema.Column{Name: "a", Tag: 1, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+		schema.Column{Name: "f", Tag: 2, Kind: types.FloatKind, TypeInfo: typeinfo.Float32Type},
+		schema.Column{Name: "j", Tag: 3, Kind: types.JSONKind, TypeInfo: typeinfo.JSONType},
+	)
+	fromSch := dtestutils.CreateSchema(
+		schema.Column{Name: "id", Tag: 0, Kind: types.IntKind, IsPartOfPK: true, TypeInfo: typeinfo.Int64Type},
+		schema.Column{Name: "a", Tag: 1, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+		schema.Column{Name: "f", Tag: 2, Kind: types.FloatKind, TypeInfo: typeinfo.Float32Type},
+	)
+	ctx := sql.NewEmptyContext()
+
+	cond, err := sqlfmt.SqlRowAsPreimageCondition(ctx, sql.Row{int64(1), nil, float32(1.5), `{"a": 1}`}, sch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "`id`=1 AND `a`<=>NULL AND `f`<=>CAST(1.5 AS FLOAT) AND `j`<=>CAST('{\\\"a\\\": 1}' AS JSON)", cond)
+
+	// columns the old row didn't have are left out
+	cond, err = sqlfmt.SqlRowAsPreimageCondition(ctx, sql.Row{int64(1), "x", nil, nil}, sch, fromSch)
+	require.NoError(t, err)
+	assert.Equal(t, "`id`=1 AND `a`<=>'x' AND `f`<=>NULL", cond)
+}
+
 // newGeneratedColSchema returns the schema of a table whose last
 // column is generated.
 func newGeneratedColSchema() schema.Schema {
@@ -58,6 +58,37 @@ func GenerateDataDiffStatement(ctx *sql.Context, tableName string, sch schema.Sc
 	}
 }
 
+// GenerateDataDiffStatementWithPreimage returns the same statements as GenerateDataDiffStatement, except that UPDATE
+// and DELETE statements match the row they change by its old value |preimage|, which has the columns of |sch|, rather
+// than by its primary key. Such a statement changes nothing if the row has changed since, instead of overwriting it.
+// |fromSch| is the schema of the table before the change, used to leave out columns the old row didn't have.
+func GenerateDataDiffStatementWithPreimage(ctx *sql.Context, tableName string, sch, fromSch schema.Schema, row, preimage sql.Row, rowDiffType diff.ChangeType, colDiffTypes []diff.ChangeType) (string, error) {
+	if len(row) != len(colDiffTypes) {
+		return "", fmt.Errorf("expected the same size for columns and diff types, got %d and %d", len(row), len(colDiffTypes))
+	}
+
+	switch rowDiffType {
+	case diff.Removed:
+		return SqlRowAsDeleteStmtWithPreimage(ctx, row, tableName, sch, fromSch)
+	case diff.ModifiedNew:
+		if preimage == nil {
+			return "", fmt.Errorf("missing old value of modified row")
+		}
+		updatedCols := set.NewEmptyStrSet()
+		for i, diffType := range colDiffTypes {
+			if diffType != diff.None {
+				updatedCols.Add(sch.GetAllCols().GetByIndex(i).Name)
+			}
+		}
+		if updatedCols.Size() == 0 {
+			return "", nil
+		}
+		return SqlRowAsUpdateStmtWithPreimage(ctx, row, preimage, tableName, sch, fromSch, updatedCols)
+	default:
+		return GenerateDataDiffStatement(ctx, tableName, sch, row, rowDiffType, colDiffTypes)
+	}
+}
+
 // GenerateSqlPatchSchemaStatements examines the table schema changes in the specified TableDelta |td| and returns
 // a slice of SQL path statements that represent the equivalent SQL DDL statements for those schema changes. The
 // specified RootValue, |toRoot|, must be the RootValue that was used as the "To" root when computing the specified
@@ -404,6 +435,14 @@ func RenameTableStmt(formatter sql.SchemaFormatter, fromName string, toName stri
 
 func AlterTableAddIndexStmt(formatter sql.SchemaFormatter, tableName string, idx schema.Index) string {
 	var b strings.Builder
+	if idx.IsColumnStore() {
+		b.WriteString("CREATE INDEX ")
+		b.WriteString(formatter.QuoteIdentifier(idx.Name()))
+		b.WriteString(" USING COLUMNSTORE ON ")
+		b.WriteString(formatter.QuoteIdentifier(tableName))
+		b.WriteString(" (" + strings.Join(indexColumnDDLExpressions(formatter, idx), ",") + ");")
+		return b.String()
+	}
 	b.WriteString("ALTER TABLE ")
 	b.WriteString(formatter.QuoteIdentifier(tableName))
 	b.WriteString(" ADD INDEX ")
@@ -513,6 +552,10 @@ func GenerateCreateTableStatement(ctx *sql.Context, tblName string, sch schema.S
 		} else {
 			indexCols = indexColumnDDLExpressions(formatter, index)
 		}
+		if index.IsColumnStore() {
+			colStmts = append(colStmts, GenerateCreateTableColumnStoreIndexDefinition(formatter, index.Name(), indexCols, index.Comment()))
+			continue
+		}
 		definition, shouldInclude := formatter.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(),
 			index.IsFullText(), index.IsVector(), index.Name(), indexCols, index.Comment())
 		if shouldInclude {
@@ -533,6 +576,14 @@ func GenerateCreateTableStatement(ctx *sql.Context, tblName string, sch schema.S
 	return fmt.Sprintf("%s;", createTableStmt), nil
 }
 
+// GenerateCreateTableColumnStoreIndexDefinition returns the definition of the columnstore index |name| on |indexCols|
+// for a CREATE TABLE statement, which is the definition of a plain index with USING COLUMNSTORE after its columns.
+func GenerateCreateTableColumnStoreIndexDefinition(formatter sql.SchemaFormatter, name string, indexCols []string, comment string) string {
+	definition, _ := formatter.GenerateCreateTableIndexDefinition(false, false, false, false, name, indexCols, "")
+	withComment, _ := formatter.GenerateCreateTableIndexDefinition(false, false, false, false, name, indexCols, comment)
+	return definition + " USING COLUMNSTORE" + strings.TrimPrefix(withComment, definition)
+}
+
 // isPrimaryKeyIndex returns whether the index given matches the table's primary key columns. Order is not considered.
 func isPrimaryKeyIndex(index schema.Index, sch schema.Schema) bool {
 	var pks = sch.GetPKCols().GetColumns()
@@ -534,7 +534,7 @@ func (sc *StatsController) updateTable(ctx *sql.Context, newStats *rootStats, ta
 	}
 
 	for _, sqlIdx := range indexes {
-		if sqlIdx.IsSpatial() || sqlIdx.IsFullText() || sqlIdx.IsGenerated() || sqlIdx.IsVector() {
+		if sqlIdx.IsSpatial() || sqlIdx.IsFullText() || sqlIdx.IsGenerated() || sqlIdx.IsVector() || strings.EqualFold(sqlIdx.IndexType(), "COLUMNSTORE") {
 			continue
 		}
 		var idx durable.Index
@@ -0,0 +1,169 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtablefunctions"
+)
+
+// systemTimeParser is a sql.Parser that rewrites tables queried FOR SYSTEM_TIME ALL, FROM .. TO, BETWEEN .. AND and
+// CONTAINED IN (..) to calls of the dolt_system_time table function, which returns the versions of their rows. The
+// engine only supports AS OF a single point in time.
+//
+// It only parses the statements clients send to the engine. The engine's builder keeps its own parser, for the
+// statements of views, triggers and procedures, since it checks for it to reject syntax that MySQL parses but doesn't
+// support.
+type systemTimeParser struct {
+	sql.Parser
+}
+
+var _ sql.Parser = systemTimeParser{}
+
+// NewSystemTimeParser returns |parser| wrapped to rewrite the tables queried for a range of system time
+func NewSystemTimeParser(parser sql.Parser) sql.Parser {
+	return systemTimeParser{Parser: parser}
+}
+
+// ParseSystemTimeQuery returns |query| parsed with |parser|, with the tables it queries for a range of system time
+// rewritten as NewSystemTimeParser does. It returns nil if |query| doesn't query any, or doesn't parse, so that the
+// engine parses it as usual.
+func ParseSystemTimeQuery(ctx *sql.Context, parser sql.Parser, query string) (sqlparser.Statement, error) {
+	if !strings.Contains(strings.ToLower(query), "system_time") {
+		return nil, nil
+	}
+	stmt, _, _, err := parser.ParseWithOptions(ctx, query, ';', false, sql.LoadSqlMode(ctx).ParserOptions())
+	if err != nil {
+		return nil, nil
+	}
+	rewritten, err := rewriteSystemTimeRanges(stmt)
+	if err != nil || !rewritten {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+func (p systemTimeParser) ParseSimple(query string) (sqlparser.Statement, error) {
+	stmt, err := p.Parser.ParseSimple(query)
+	if err != nil {
+		return nil, err
+	}
+	_, err = rewriteSystemTimeRanges(stmt)
+	return stmt, err
+}
+
+func (p systemTimeParser) Parse(ctx *sql.Context, query string, multi bool) (sqlparser.Statement, string, string, error) {
+	stmt, parsed, remainder, err := p.Parser.Parse(ctx, query, multi)
+	if err != nil {
+		return nil, "", "", err
+	}
+	_, err = rewriteSystemTimeRanges(stmt)
+	return stmt, parsed, remainder, err
+}
+
+func (p systemTimeParser) ParseWithOptions(ctx context.Context, query string, delimiter rune, multi bool, options sqlparser.ParserOptions) (sqlparser.Statement, string, string, error) {
+	stmt, parsed, remainder, err := p.Parser.ParseWithOptions(ctx, query, delimiter, multi, options)
+	if err != nil {
+		return nil, "", "", err
+	}
+	_, err = rewriteSystemTimeRanges(stmt)
+	return stmt, parsed, remainder, err
+}
+
+func (p systemTimeParser) ParseOneWithOptions(ctx context.Context, query string, options sqlparser.ParserOptions) (sqlparser.Statement, int, error) {
+	stmt, next, err := p.Parser.ParseOneWithOptions(ctx, query, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, err = rewriteSystemTimeRanges(stmt)
+	return stmt, next, err
+}
+
+// rewriteSystemTimeRanges replaces the tables in |stmt| queried for a range of system time with dolt_system_time, and
+// returns whether there were any
+func rewriteSystemTimeRanges(stmt sqlparser.Statement) (bool, error) {
+	rewritten := false
+	rewrite := func(expr sqlparser.TableExpr) sqlparser.TableExpr {
+		if funcExpr, ok := systemTimeTableExpr(expr); ok {
+			rewritten = true
+			return funcExpr
+		}
+		return expr
+	}
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case sqlparser.TableExprs:
+			for i := range node {
+				node[i] = rewrite(node[i])
+			}
+		case *sqlparser.JoinTableExpr:
+			node.LeftExpr = rewrite(node.LeftExpr)
+			node.RightExpr = rewrite(node.RightExpr)
+		}
+		return true, nil
+	}, stmt)
+	return rewritten, err
+}
+
+// systemTimeTableExpr returns the call of dolt_system_time that |expr| is rewritten to, or false if it isn't a table
+// queried for a range of system time
+func systemTimeTableExpr(expr sqlparser.TableExpr) (*sqlparser.TableFuncExpr, bool) {
+	aliased, ok := expr.(*sqlparser.AliasedTableExpr)
+	if !ok || aliased.AsOf == nil || aliased.AsOf.Time != nil {
+		return nil, false
+	}
+	tableName, ok := aliased.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil, false
+	}
+
+	asOf := aliased.AsOf
+	var mode string
+	switch {
+	case asOf.All:
+		mode = dtablefunctions.SystemTimeAll
+	case asOf.Start == nil || asOf.End == nil:
+		return nil, false
+	case asOf.StartInclusive && asOf.EndInclusive:
+		mode = dtablefunctions.SystemTimeContained
+	case asOf.EndInclusive:
+		mode = dtablefunctions.SystemTimeBetween
+	default:
+		mode = dtablefunctions.SystemTimeFrom
+	}
+
+	name := tableName.Name.String()
+	if !tableName.DbQualifier.IsEmpty() {
+		name = tableName.DbQualifier.String() + "." + name
+	}
+	args := sqlparser.SelectExprs{
+		&sqlparser.AliasedExpr{Expr: sqlparser.NewStrVal([]byte(name))},
+		&sqlparser.AliasedExpr{Expr: sqlparser.NewStrVal([]byte(mode))},
+	}
+	if !asOf.All {
+		args = append(args, &sqlparser.AliasedExpr{Expr: asOf.Start}, &sqlparser.AliasedExpr{Expr: asOf.End})
+	}
+
+	alias := aliased.As
+	if alias.IsEmpty() {
+		alias = tableName.Name
+	}
+	return &sqlparser.TableFuncExpr{Name: dtablefunctions.SystemTimeTableFunctionName, Alias: alias, Exprs: args}, true
+}
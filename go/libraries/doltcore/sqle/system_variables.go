@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
@@ -300,6 +301,41 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemStringType(actions.DoltCommitVerificationGroups),
 		Default: "",
 	},
+	&sql.MysqlSystemVariable{
+		Name:    doltdb.DeletedRefsRetentionDays,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(doltdb.DeletedRefsRetentionDays, 0, math.MaxInt32, false),
+		Default: int64(30),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltLockWaitTimeout,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.DoltLockWaitTimeout, 0, 1073741824, false),
+		Default: int64(50),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltQueryCacheSize,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.DoltQueryCacheSize, 0, math.MaxInt64, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltQueryCacheMaxResultSize,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.DoltQueryCacheMaxResultSize, 0, math.MaxInt64, false),
+		Default: int64(1 << 20),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltQueryParallelism,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.DoltQueryParallelism, 0, 1024, false),
+		Default: int64(1),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    dsess.DoltAuthorName,
 		Dynamic: true,
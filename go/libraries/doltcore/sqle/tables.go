@@ -52,6 +52,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/val"
 )
@@ -573,14 +575,51 @@ func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	if err != nil {
 		return nil, err
 	}
-	partitions, err := partitionsFromRows(ctx, rows)
+	// Only parallel scans need partitions at node boundaries, which take reading the tree to find
+	partitions, err := partitionsFromRows(ctx, rows, dsess.QueryParallelism(ctx) > 1)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = t.RecordScan(ctx); err != nil {
+		return nil, err
+	}
+
 	return newDoltTablePartitionIter(rows, partitions...), nil
 }
 
+// RecordScan records a read of every row of this table in the current transaction, when it is SERIALIZABLE. Tables
+// locked to a root, such as with AS OF, can't be changed by other transactions and their reads aren't recorded.
+func (t *DoltTable) RecordScan(ctx *sql.Context) error {
+	if t.lockedToRoot != nil {
+		return nil
+	}
+	return dsess.RecordTableScan(ctx, t.db.RevisionQualifiedName(), t.TableName())
+}
+
+// RecordIndexRead records the rows of this table read with |lookup| in the current transaction, when it is
+// SERIALIZABLE. A lookup whose ranges can't be given as key ranges of its index records a read of the entire index.
+// A lookup of a secondary index that doesn't have every projected column also records a read of the primary rows of
+// the keys it reads.
+func (t *DoltTable) RecordIndexRead(ctx *sql.Context, lookup sql.IndexLookup) error {
+	if tx, ok := ctx.GetTransaction().(*dsess.DoltTransaction); !ok || !tx.IsSerializable() || t.lockedToRoot != nil {
+		return nil
+	}
+	idx, ok := lookup.Index.(index.DoltIndex)
+	if !ok {
+		return nil
+	}
+	var ranges []prolly.Range
+	if _, ok := lookup.Ranges.(sql.MySQLRangeCollection); ok && lookup.VectorOrderAndLimit.OrderBy == nil {
+		var err error
+		if ranges, err = index.ProllyRangesForIndex(ctx, lookup.Index, lookup.Ranges); err != nil {
+			return err
+		}
+	}
+	primaryRows := !index.CoversProjection(idx, t.projectedCols)
+	return dsess.RecordIndexRead(ctx, t.db.RevisionQualifiedName(), t.TableName(), lookup.Index.ID(), ranges, primaryRows)
+}
+
 func (t *DoltTable) IsTemporary() bool {
 	return false
 }
@@ -623,6 +662,14 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 		return nil, err
 	}
 
+	if read := t.lockingRead(ctx); read != nil {
+		iter, err := partitionRows(ctx, table, t.allColumnTags(), partition)
+		if err != nil {
+			return nil, err
+		}
+		return newLockingRowIter(ctx, t, read, iter, nil, partition)
+	}
+
 	// If we DON'T have an overridden schema, then we can pass in our projected columns as the
 	// ones set in the table that the analyzer has told us need to be projected. This will limit our returned
 	// sql.Row to only the requested projected columns. If we DO have an overridden schema in use, then we need
@@ -650,6 +697,42 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	}
 }
 
+// ParallelPartitionRows returns a function that returns the rows of a partition of this table like PartitionRows, for
+// parallel scans. The table is read once, up front, so the function can be called from multiple goroutines at the same
+// time. Returns false for tables that can't be scanned in parallel.
+func (t *DoltTable) ParallelPartitionRows(ctx *sql.Context) (func(*sql.Context, sql.Partition) (sql.RowIter, error), bool, error) {
+	if t.overriddenSchema != nil || t.lockingRead(ctx) != nil {
+		return nil, false, nil
+	}
+
+	table, err := t.DoltTable(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	projCols := t.projectedCols
+	scan, err := newColumnStoreScan(ctx, table, sch, projCols)
+	if err != nil {
+		return nil, false, err
+	}
+	return func(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+		p, ok := partition.(doltTablePartition)
+		if !ok {
+			return nil, errors.New("unsupported partition type")
+		}
+		if scan != nil {
+			if iter, ok, err := scan.partitionRows(ctx, p); ok || err != nil {
+				return iter, err
+			}
+		}
+		return ProllyRowIterFromPartition(ctx, sch, projCols, p)
+	}, true, nil
+}
+
 func partitionRows(ctx *sql.Context, t *doltdb.Table, projCols []uint64, partition sql.Partition) (sql.RowIter, error) {
 	switch typedPartition := partition.(type) {
 	case doltTablePartition:
@@ -1433,7 +1516,7 @@ type doltTablePartition struct {
 	end     uint64
 }
 
-func partitionsFromRows(ctx context.Context, rows durable.Index) ([]doltTablePartition, error) {
+func partitionsFromRows(ctx context.Context, rows durable.Index, nodeBoundaries bool) ([]doltTablePartition, error) {
 	empty, err := rows.Empty()
 	if err != nil {
 		return nil, err
@@ -1444,10 +1527,13 @@ func partitionsFromRows(ctx context.Context, rows durable.Index) ([]doltTablePar
 		}, nil
 	}
 
-	return partitionsFromTableRows(rows)
+	return partitionsFromTableRows(ctx, rows, nodeBoundaries)
 }
 
-func partitionsFromTableRows(rows durable.Index) ([]doltTablePartition, error) {
+// partitionsFromTableRows splits |rows| into partitions of roughly equal size, each of which is a contiguous key range
+// of the table. With |nodeBoundaries|, partitions begin and end at the boundaries of the tree nodes at the highest
+// level of the table's prolly tree that has enough nodes, so that partitions read concurrently don't share any nodes.
+func partitionsFromTableRows(ctx context.Context, rows durable.Index, nodeBoundaries bool) ([]doltTablePartition, error) {
 	numElements, err := rows.Count()
 	if err != nil {
 		return nil, err
@@ -1465,20 +1551,54 @@ func partitionsFromTableRows(rows durable.Index) ([]doltTablePartition, error) {
 		}
 	}
 
-	partitions := make([]doltTablePartition, numPartitions)
-	for i := uint64(0); i < numPartitions-1; i++ {
-		partitions[i] = doltTablePartition{
-			start:   i * itemsPerPartition,
-			end:     (i + 1) * itemsPerPartition,
+	if numPartitions == 1 {
+		return []doltTablePartition{{start: 0, end: numElements, rowData: rows}}, nil
+	} else if !nodeBoundaries {
+		partitions := make([]doltTablePartition, numPartitions)
+		for i := uint64(0); i < numPartitions-1; i++ {
+			partitions[i] = doltTablePartition{
+				start:   i * itemsPerPartition,
+				end:     (i + 1) * itemsPerPartition,
+				rowData: rows,
+			}
+		}
+		partitions[numPartitions-1] = doltTablePartition{
+			start:   (numPartitions - 1) * itemsPerPartition,
+			end:     numElements,
 			rowData: rows,
 		}
+		return partitions, nil
+	}
+
+	m, err := durable.ProllyMapFromIndex(rows)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := tree.GetHistogramLevel(ctx, m.Tuples(), int(numPartitions))
+	if err != nil {
+		return nil, err
+	}
+
+	// each partition ends at the first node boundary at or after its share of the rows
+	partitions := make([]doltTablePartition, 0, numPartitions)
+	var start, boundary uint64
+	for _, nd := range nodes {
+		cnt, err := nd.TreeCount()
+		if err != nil {
+			return nil, err
+		}
+		boundary += uint64(cnt)
+		if boundary < numElements && boundary >= uint64(len(partitions)+1)*itemsPerPartition {
+			partitions = append(partitions, doltTablePartition{start: start, end: boundary, rowData: rows})
+			start = boundary
+		}
 	}
 
-	partitions[numPartitions-1] = doltTablePartition{
-		start:   (numPartitions - 1) * itemsPerPartition,
+	partitions = append(partitions, doltTablePartition{
+		start:   start,
 		end:     numElements,
 		rowData: rows,
-	}
+	})
 
 	return partitions, nil
 }
@@ -1756,6 +1876,14 @@ func (t *AlterableDoltTable) RewriteInserter(
 		}
 	}
 
+	if schema.IsKeyless(newSch) {
+		for _, idx := range newSch.Indexes().AllIndexes() {
+			if idx.IsColumnStore() {
+				return nil, index.ErrColumnStoreKeyless
+			}
+		}
+	}
+
 	// If we have an auto increment column, we need to set it here before we begin the rewrite process (it may have changed)
 	err = newSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		if col.AutoIncrement {
@@ -2037,6 +2165,7 @@ func modifyIndexesForTableRewrite(ctx *sql.Context, oldSch schema.Schema, oldCol
 				IsSpatial:          index.IsSpatial(),
 				IsFullText:         index.IsFullText(),
 				IsVector:           index.IsVector(),
+				IsColumnStore:      index.IsColumnStore(),
 				IsUserDefined:      index.IsUserDefined(),
 				Comment:            index.Comment(),
 				FullTextProperties: index.FullTextProperties(),
@@ -3347,6 +3476,10 @@ func FindIndexWithPrefix(sch schema.Schema, prefixCols []string) (schema.Index,
 	colLen := len(prefixCols)
 	var indexesWithLen []idxWithLen
 	for _, idx := range indexes {
+		// columnstore indexes can't be used to look up the rows of a foreign key
+		if idx.IsColumnStore() {
+			continue
+		}
 		idxCols := lowercaseSlice(idx.ColumnNames())
 		if ok, prefixCount := colsAreIndexSubset(prefixCols, idxCols); ok && prefixCount == colLen {
 			indexesWithLen = append(indexesWithLen, idxWithLen{idx, len(idxCols)})
@@ -15,12 +15,83 @@
 package sqle
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 func TestMinRowsPerPartitionInTests(t *testing.T) {
 	// If this fails then the method for determining if we are running in a test doesn't work all the time.
 	assert.Equal(t, uint64(2), MinRowsPerPartition)
 }
+
+func TestPartitionsFromTableRows(t *testing.T) {
+	ctx := context.Background()
+	ns := tree.NewTestNodeStore()
+	desc := val.NewTupleDescriptor(val.Type{Enc: val.Int64Enc})
+	tb := val.NewTupleBuilder(desc, ns)
+
+	newRows := func(n int) durable.Index {
+		tups := make([]val.Tuple, 0, 2*n)
+		for i := 0; i < n; i++ {
+			tb.PutInt64(0, int64(i))
+			k, err := tb.Build(ctx, ns.Pool())
+			require.NoError(t, err)
+			tb.PutInt64(0, int64(i))
+			v, err := tb.Build(ctx, ns.Pool())
+			require.NoError(t, err)
+			tups = append(tups, k, v)
+		}
+		m, err := prolly.NewMapFromTuples(ctx, ns, desc, desc, tups...)
+		require.NoError(t, err)
+		return durable.IndexFromProllyMap(m)
+	}
+
+	// leafBoundaries returns the ordinal that each leaf node of |rows| starts at
+	leafBoundaries := func(rows durable.Index) map[uint64]bool {
+		m, err := durable.ProllyMapFromIndex(rows)
+		require.NoError(t, err)
+		leaves, err := tree.GetHistogramLevel(ctx, m.Tuples(), 1<<30)
+		require.NoError(t, err)
+		boundaries := make(map[uint64]bool)
+		var ord uint64
+		for _, nd := range leaves {
+			require.True(t, nd.IsLeaf())
+			boundaries[ord] = true
+			ord += uint64(nd.Count())
+		}
+		return boundaries
+	}
+
+	defer func(max uint64) {
+		MaxRowsPerPartition = max
+	}(MaxRowsPerPartition)
+	MaxRowsPerPartition = 1024
+
+	for _, n := range []int{1, 100, 5000, 50_000} {
+		rows := newRows(n)
+		partitions, err := partitionsFromTableRows(ctx, rows, true)
+		require.NoError(t, err)
+		require.NotEmpty(t, partitions)
+
+		boundaries := leafBoundaries(rows)
+		var start uint64
+		for _, p := range partitions {
+			assert.Equal(t, start, p.start)
+			assert.Less(t, p.start, p.end)
+			assert.True(t, boundaries[p.start], "partition starting at %d splits a leaf node", p.start)
+			start = p.end
+		}
+		assert.Equal(t, uint64(n), start)
+		if n >= 50_000 {
+			assert.GreaterOrEqual(t, len(partitions), n/int(MaxRowsPerPartition)/2)
+		}
+	}
+}
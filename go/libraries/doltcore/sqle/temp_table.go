@@ -237,7 +237,7 @@ func (t *TempTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	if err != nil {
 		return nil, err
 	}
-	parts, err := partitionsFromRows(ctx, rows)
+	parts, err := partitionsFromRows(ctx, rows, false)
 	if err != nil {
 		return nil, err
 	}
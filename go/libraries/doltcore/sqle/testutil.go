@@ -137,6 +137,7 @@ func NewTestEngine(dEnv *env.DoltEnv, ctx context.Context, db dsess.SqlDatabase)
 	gcSafepointController := gcctx.NewGCSafepointController()
 
 	engine := sqle.NewDefault(pro)
+	pro.SetStatementRunner(engine)
 
 	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
 	sqlCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, gcSafepointController)
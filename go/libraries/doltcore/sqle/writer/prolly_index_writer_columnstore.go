@@ -0,0 +1,136 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+)
+
+// prollyColumnStoreWriter maintains a columnstore index, which holds an entry for each of its value columns for each
+// row of the table. See schema.Index.IsColumnStore.
+type prollyColumnStoreWriter struct {
+	name string
+	mut  prolly.MutableMapInterface
+	bld  index.ColumnStoreBuilder
+}
+
+var _ indexWriter = prollyColumnStoreWriter{}
+var _ UniqueKeyChangeReporter = prollyColumnStoreWriter{}
+
+func newColumnStoreWriter(schState *dsess.WriterState, name string, idxMap prolly.MapInterfaceWithMutable) (prollyColumnStoreWriter, error) {
+	def := schState.DoltSchema.Indexes().GetByName(name)
+	if def == nil {
+		return prollyColumnStoreWriter{}, fmt.Errorf("index %s not found", name)
+	}
+	bld, err := index.NewColumnStoreBuilder(schState.DoltSchema, def, idxMap.NodeStore())
+	if err != nil {
+		return prollyColumnStoreWriter{}, err
+	}
+	return prollyColumnStoreWriter{
+		name: name,
+		mut:  idxMap.MutateInterface(),
+		bld:  bld,
+	}, nil
+}
+
+// Name implements indexWriter.
+func (w prollyColumnStoreWriter) Name() string {
+	return w.name
+}
+
+// Map implements indexWriter.
+func (w prollyColumnStoreWriter) Map(ctx context.Context) (prolly.MapInterface, error) {
+	return w.mut.MapInterface(ctx)
+}
+
+// ValidateKeyViolations implements indexWriter. Columnstore indexes are never unique.
+func (w prollyColumnStoreWriter) ValidateKeyViolations(ctx context.Context, sqlRow sql.Row) error {
+	return nil
+}
+
+// Insert implements indexWriter.
+func (w prollyColumnStoreWriter) Insert(ctx context.Context, sqlRow sql.Row) error {
+	for col := 0; col < w.bld.Columns(); col++ {
+		k, v, err := w.bld.EntryFromSqlRow(ctx, col, sqlRow)
+		if err != nil {
+			return err
+		}
+		if err = w.mut.Put(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete implements indexWriter.
+func (w prollyColumnStoreWriter) Delete(ctx context.Context, sqlRow sql.Row) error {
+	for col := 0; col < w.bld.Columns(); col++ {
+		k, err := w.bld.KeyFromSqlRow(ctx, col, sqlRow)
+		if err != nil {
+			return err
+		}
+		if err = w.mut.Delete(ctx, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update implements indexWriter.
+func (w prollyColumnStoreWriter) Update(ctx context.Context, oldRow sql.Row, newRow sql.Row) error {
+	if err := w.Delete(ctx, oldRow); err != nil {
+		return err
+	}
+	return w.Insert(ctx, newRow)
+}
+
+// UpdateChangesUniqueKey implements UniqueKeyChangeReporter for this index.
+func (w prollyColumnStoreWriter) UpdateChangesUniqueKey(oldRow sql.Row, newRow sql.Row) bool {
+	return false
+}
+
+// Commit implements indexWriter.
+func (w prollyColumnStoreWriter) Commit(ctx context.Context) error {
+	return w.mut.Checkpoint(ctx)
+}
+
+// Discard implements indexWriter.
+func (w prollyColumnStoreWriter) Discard(ctx context.Context) error {
+	w.mut.Revert(ctx)
+	return nil
+}
+
+// HasEdits implements indexWriter.
+func (w prollyColumnStoreWriter) HasEdits(ctx context.Context) bool {
+	return w.mut.HasEdits()
+}
+
+// IterRange implements indexWriter.
+func (w prollyColumnStoreWriter) IterRange(ctx context.Context, rng prolly.Range) (prolly.MapIter, error) {
+	return w.mut.IterRange(ctx, rng)
+}
+
+// VisitGCRoots implements indexWriter.
+func (w prollyColumnStoreWriter) VisitGCRoots(ctx context.Context, roots func(hash.Hash) bool) error {
+	return w.mut.VisitGCRoots(ctx, roots)
+}
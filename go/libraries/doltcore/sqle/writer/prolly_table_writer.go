@@ -87,6 +87,14 @@ func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, schSta
 		}
 		idxMap := durable.MapFromIndex(idxRows)
 
+		if def.IsColumnStore {
+			writers[defName], err = newColumnStoreWriter(schState, defName, idxMap)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		keyDesc, _ := idxMap.Descriptors()
 
 		targetRowSize := schState.DoltSchema.GetTargetRowSize()
@@ -128,6 +136,9 @@ func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, schS
 		if err != nil {
 			return nil, err
 		}
+		if def.IsColumnStore {
+			return nil, index.ErrColumnStoreKeyless
+		}
 		m, err := durable.ProllyMapFromIndex(idxRows)
 		if err != nil {
 			return nil, err
@@ -124,6 +124,7 @@ func newWriterSchema(ctx *sql.Context, t *doltdb.Table, tableName string, dbName
 			IsFullText:      def.IsFullText(),
 			IsUnique:        def.IsUnique(),
 			IsSpatial:       def.IsSpatial(),
+			IsColumnStore:   def.IsColumnStore(),
 			PrefixLengths:   def.PrefixLengths(),
 			KeyVirtualExprs: keyVirtualExprs,
 		}
@@ -0,0 +1,73 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"sync"
+	"time"
+)
+
+// BackupStatus is the status of a backup that is synced on a schedule by the running SQL server.
+type BackupStatus struct {
+	// LastSync is the time the last scheduled sync finished, whether or not it succeeded.
+	LastSync time.Time
+	// LastSyncError is the error of the last scheduled sync, or empty if it succeeded.
+	LastSyncError string
+	// RestorePoints are the names of the restore points kept in the backup, oldest first.
+	RestorePoints []string
+	// LastVerify is the time the last verification of the backup finished.
+	LastVerify time.Time
+	// LastVerifyResult describes the outcome of the last verification of the backup.
+	LastVerifyResult string
+}
+
+// BackupStatuses provides the status of the backups synced on a schedule.
+type BackupStatuses interface {
+	// BackupStatus returns the status of the scheduled backup |backup| of the database |db|, and false if the backup
+	// is not synced on a schedule.
+	BackupStatus(db, backup string) (BackupStatus, bool)
+}
+
+var theBackupStatuses BackupStatuses
+var backupStatusesMu sync.Mutex
+
+// SetBackupStatuses sets |statuses| as the source of the status of the backups the running SQL server syncs on a
+// schedule.
+func SetBackupStatuses(statuses BackupStatuses) {
+	backupStatusesMu.Lock()
+	defer backupStatusesMu.Unlock()
+	theBackupStatuses = statuses
+}
+
+// UnsetBackupStatuses unsets |statuses| as the source of backup statuses, if it is the one set.
+func UnsetBackupStatuses(statuses BackupStatuses) {
+	backupStatusesMu.Lock()
+	defer backupStatusesMu.Unlock()
+	if theBackupStatuses == statuses {
+		theBackupStatuses = nil
+	}
+}
+
+// GetBackupStatus returns the status of the scheduled backup |backup| of the database |db|, and false if the running
+// SQL server doesn't sync the backup on a schedule.
+func GetBackupStatus(db, backup string) (BackupStatus, bool) {
+	backupStatusesMu.Lock()
+	statuses := theBackupStatuses
+	backupStatusesMu.Unlock()
+	if statuses == nil {
+		return BackupStatus{}, false
+	}
+	return statuses.BackupStatus(db, backup)
+}
@@ -41,6 +41,10 @@ const (
 // single prolly tree materialization by presorting the index keys in an
 // intermediate file format.
 func BuildProllyIndexExternal(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeStore, sch schema.Schema, tableName string, idx schema.Index, primary prolly.Map, uniqCb DupEntryCb, predicate sql.Expression) (durable.Index, error) {
+	if idx.IsColumnStore() {
+		return BuildColumnStoreIndex(ctx, vrw, ns, sch, idx, primary)
+	}
+
 	iter, err := primary.IterAll(ctx)
 	if err != nil {
 		return nil, err
@@ -189,6 +193,75 @@ func BuildProximityIndex(
 	return durable.IndexFromProximityMap(proximityMap), nil
 }
 
+// BuildColumnStoreIndex builds the columnstore index |idx| of the table with the schema |sch| and the rows |primary|.
+// The rows are already sorted by their primary key, so each segment of the index is built from one pass over them.
+func BuildColumnStoreIndex(ctx *sql.Context, vrw types.ValueReadWriter, ns tree.NodeStore, sch schema.Schema, idx schema.Index, primary prolly.Map) (durable.Index, error) {
+	bld, err := index.NewColumnStoreBuilder(sch, idx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	empty, err := durable.NewEmptyIndexFromTableSchema(ctx, vrw, ns, idx, sch)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := durable.ProllyMapFromIndex(empty)
+	if err != nil {
+		return nil, err
+	}
+
+	tupIter := &columnStoreTupleIter{primary: primary, bld: bld}
+	ret, err := prolly.MutateMapWithTupleIter(ctx, secondary, tupIter)
+	if err != nil {
+		return nil, err
+	}
+	if tupIter.err != nil {
+		return nil, tupIter.err
+	}
+
+	return durable.IndexFromProllyMap(ret), nil
+}
+
+// columnStoreTupleIter returns the entries of a columnstore index in order, one segment after another
+type columnStoreTupleIter struct {
+	primary prolly.Map
+	bld     index.ColumnStoreBuilder
+	iter    prolly.MapIter
+	col     int
+	err     error
+}
+
+var _ prolly.TupleIter = (*columnStoreTupleIter)(nil)
+
+func (t *columnStoreTupleIter) Next(ctx context.Context) (val.Tuple, val.Tuple) {
+	for t.col < t.bld.Columns() {
+		if t.iter == nil {
+			t.iter, t.err = t.primary.IterAll(ctx)
+			if t.err != nil {
+				return nil, nil
+			}
+		}
+
+		k, v, err := t.iter.Next(ctx)
+		if err == io.EOF {
+			t.iter = nil
+			t.col++
+			continue
+		} else if err != nil {
+			t.err = err
+			return nil, nil
+		}
+
+		key, value, err := t.bld.EntryFromRow(ctx, t.col, k, v)
+		if err != nil {
+			t.err = err
+			return nil, nil
+		}
+		return key, value
+	}
+	return nil, nil
+}
+
 type tupleIterWithCb struct {
 	iter sort.KeyIter
 	err  error
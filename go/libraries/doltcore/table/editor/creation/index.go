@@ -83,9 +83,10 @@ func CreateIndex(
 		return nil, fmt.Errorf("invalid index name `%s`", indexName)
 	}
 
-	// if an index was already created for the column set but was not generated by the user then we replace it
+	// if an index was already created for the column set but was not generated by the user then we replace it, unless
+	// the new index is a columnstore index, which can't be used in its place
 	existingIndex, ok := sch.Indexes().GetIndexByColumnNames(realColNames...)
-	if ok && !existingIndex.IsUserDefined() {
+	if ok && !existingIndex.IsUserDefined() && !props.IsColumnStore {
 		_, err = sch.Indexes().RemoveIndex(existingIndex.Name())
 		if err != nil {
 			return nil, err
@@ -36,9 +36,14 @@ type SqlDiffWriter struct {
 	writeCloser          io.WriteCloser
 	editOpts             editor.Options
 	autocommitOff        bool
+
+	// fromSch is the schema of the table before the diff when UPDATE and DELETE statements match rows by their old
+	// values, and preimage the old value of the modified row being written.
+	fromSch  schema.Schema
+	preimage sql.Row
 }
 
-var _ diff.SqlRowDiffWriter = SqlDiffWriter{}
+var _ diff.SqlRowDiffWriter = (*SqlDiffWriter)(nil)
 
 func NewSqlDiffWriter(tableName string, schema schema.Schema, wr io.WriteCloser) *SqlDiffWriter {
 	return &SqlDiffWriter{
@@ -49,18 +54,38 @@ func NewSqlDiffWriter(tableName string, schema schema.Schema, wr io.WriteCloser)
 	}
 }
 
-func (w SqlDiffWriter) WriteRow(ctx *sql.Context, row sql.Row, rowDiffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
-	stmt, err := sqlfmt.GenerateDataDiffStatement(ctx, w.tableName, w.sch, row, rowDiffType, colDiffTypes)
+// NewSqlPreimageDiffWriter returns a SqlDiffWriter whose UPDATE and DELETE statements match the rows they change by
+// all their old values, in the table's schema before the diff |fromSch|, rather than by primary key.
+func NewSqlPreimageDiffWriter(tableName string, schema, fromSch schema.Schema, wr io.WriteCloser) *SqlDiffWriter {
+	w := NewSqlDiffWriter(tableName, schema, wr)
+	w.fromSch = fromSch
+	return w
+}
+
+func (w *SqlDiffWriter) WriteRow(ctx *sql.Context, row sql.Row, rowDiffType diff.ChangeType, colDiffTypes []diff.ChangeType) error {
+	var stmt string
+	var err error
+	if w.fromSch != nil {
+		// the old value of a modified row is written just before its new value
+		if rowDiffType == diff.ModifiedOld {
+			w.preimage = row
+			return nil
+		}
+		stmt, err = sqlfmt.GenerateDataDiffStatementWithPreimage(ctx, w.tableName, w.sch, w.fromSch, row, w.preimage, rowDiffType, colDiffTypes)
+		w.preimage = nil
+	} else {
+		stmt, err = sqlfmt.GenerateDataDiffStatement(ctx, w.tableName, w.sch, row, rowDiffType, colDiffTypes)
+	}
 	if err != nil {
 		return err
 	}
 	return iohelp.WriteLine(w.writeCloser, stmt)
 }
 
-func (w SqlDiffWriter) WriteCombinedRow(ctx *sql.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
+func (w *SqlDiffWriter) WriteCombinedRow(ctx *sql.Context, oldRow, newRow sql.Row, mode diff.Mode) error {
 	return fmt.Errorf("sql format is unable to output diffs for combined rows")
 }
 
-func (w SqlDiffWriter) Close(ctx context.Context) error {
+func (w *SqlDiffWriter) Close(ctx context.Context) error {
 	return w.writeCloser.Close()
 }
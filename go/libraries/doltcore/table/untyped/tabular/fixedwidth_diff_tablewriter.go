@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -97,6 +98,17 @@ func (w FixedWidthDiffTableWriter) WriteCombinedRow(ctx *sql.Context, oldRow, ne
 		if err != nil {
 			return err
 		}
+		if mode == diff.ModeUnified && oldRowStrs[i+1] != newRowStrs[i+1] {
+			cellDiff, ok, err := diff.CellDiff(ctx, w.tableWriter.schema[i+1].Type, oldRow[i], newRow[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				combinedRow[i+1], widths[i+1] = generateUnifiedDiff(cellDiff)
+				columnDiffs[i+1] = true
+				continue
+			}
+		}
 		combinedRow[i+1], columnDiffs[i+1], widths[i+1] = w.generateTextDiff(oldRowStrs[i+1], newRowStrs[i+1], mode == diff.ModeInPlace)
 		hasNewlines = hasNewlines || (columnDiffs[i+1] && len(widths[i+1].Lines) > 2) || (!columnDiffs[i+1] && len(widths[i+1].Lines) > 1)
 	}
@@ -175,6 +187,68 @@ func (w FixedWidthDiffTableWriter) generateTextDiff(oldStr string, newStr string
 	return coloredStr.String(), true, ColoredStringWidth(coloredStr.String(), uncoloredStr.String())
 }
 
+// generateUnifiedDiff colors the lines of |cellDiff|, a diff returned by diff.CellDiff. Each removed line is paired
+// with the added line in the same position of the run of added lines that follows it, and the words that differ between
+// the two are highlighted.
+func generateUnifiedDiff(cellDiff string) (string, FixedWidthString) {
+	lines := strings.Split(cellDiff, "\n")
+	colored := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			colored[i] = colorHunkHeader.Sprint(line)
+		case strings.HasPrefix(line, "-"):
+			colored[i] = colorModifiedOld.Sprint(line)
+		case strings.HasPrefix(line, "+"):
+			colored[i] = colorModifiedNew.Sprint(line)
+		default:
+			colored[i] = line
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		removedEnd := i
+		for removedEnd < len(lines) && strings.HasPrefix(lines[removedEnd], "-") {
+			removedEnd++
+		}
+		addedEnd := removedEnd
+		for addedEnd < len(lines) && strings.HasPrefix(lines[addedEnd], "+") {
+			addedEnd++
+		}
+		for j := 0; j < removedEnd-i && removedEnd+j < addedEnd; j++ {
+			colored[i+j], colored[removedEnd+j] = highlightWords(lines[i+j], lines[removedEnd+j])
+		}
+		i = max(addedEnd, i+1)
+	}
+	coloredStr := strings.Join(colored, "\n")
+	return coloredStr, ColoredStringWidth(coloredStr, cellDiff)
+}
+
+var wordRegex = regexp.MustCompile(`\w+|\s+|[^\w\s]`)
+
+// highlightWords colors a removed and an added line of a unified diff, highlighting the words of each that are not in
+// the other.
+func highlightWords(removed, added string) (string, string) {
+	var oldStr, newStr strings.Builder
+	oldStr.WriteString(colorModifiedOld.Sprint(removed[:1]))
+	newStr.WriteString(colorModifiedNew.Sprint(added[:1]))
+	oldWords, newWords := wordRegex.FindAllString(removed[1:], -1), wordRegex.FindAllString(added[1:], -1)
+	for _, chunk := range computeDiff.DiffChunks(oldWords, newWords) {
+		if len(chunk.Deleted) > 0 {
+			oldStr.WriteString(colorWordRemoved.Sprint(strings.Join(chunk.Deleted, "")))
+		}
+		if len(chunk.Added) > 0 {
+			newStr.WriteString(colorWordAdded.Sprint(strings.Join(chunk.Added, "")))
+		}
+		if len(chunk.Equal) > 0 {
+			equal := strings.Join(chunk.Equal, "")
+			oldStr.WriteString(colorModifiedOld.Sprint(equal))
+			newStr.WriteString(colorModifiedNew.Sprint(equal))
+		}
+	}
+	return oldStr.String(), newStr.String()
+}
+
 func colorsForDiffTypes(colDiffTypes []diff.ChangeType) []*color.Color {
 	colors := make([]*color.Color, len(colDiffTypes))
 	for i := range colDiffTypes {
@@ -109,6 +109,9 @@ var (
 	colorModifiedOld = color.New(color.FgRed)
 	colorModifiedNew = color.New(color.FgGreen)
 	colorRemoved     = color.New(color.Bold, color.FgRed)
+	colorHunkHeader  = color.New(color.FgCyan)
+	colorWordRemoved = color.New(color.FgRed, color.ReverseVideo)
+	colorWordAdded   = color.New(color.FgGreen, color.ReverseVideo)
 )
 
 var colDiffColors = map[diff.ChangeType]*color.Color{
@@ -94,6 +94,12 @@ func NewCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.Valu
 	return newCommitForValue(ctx, cs, vrw, ns, v, opts)
 }
 
+// NewParentlessCommitForValue returns a commit of |v| without any parents. Unlike the first commit of a dataset, such
+// a commit isn't referenced by any ref, and records a value for the values that reference it, like merge artifacts.
+func NewParentlessCommitForValue(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeStore, v types.Value, meta *CommitMeta) (*Commit, error) {
+	return newCommitForValue(ctx, nil, vrw, ns, v, CommitOptions{Meta: meta})
+}
+
 // createStringIfDiffers writes |s| to |b| and returns its offset when |s| differs from |ref|.
 // When they match it returns a zero offset so the field is omitted from the serialized message.
 func createStringIfDiffers(b *flatbuffers.Builder, s, ref string) flatbuffers.UOffsetT {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -119,6 +120,12 @@ type Database interface {
 	// upon return as well.
 	UpdateWorkingSet(ctx context.Context, ds Dataset, workingSet WorkingSetSpec, prevHash hash.Hash) (Dataset, error)
 
+	// UpdateWorkingSets is UpdateWorkingSet for several working sets at once, which can also delete working sets. Every
+	// working set given must still have its |PrevHash|, otherwise none of them are updated and this method returns
+	// ErrOptimisticLockFailed. After this method runs, all of the datasets given are updated in the new root, or none
+	// of them are. The returned Datasets are the newest snapshots of the datasets given, in the same order.
+	UpdateWorkingSets(ctx context.Context, updates []WorkingSetUpdate) ([]Dataset, error)
+
 	// CommitWithWorkingSet combines Commit and UpdateWorkingSet, combining the parameters of both. It uses the
 	// pessimistic lock that UpdateWorkingSet does, asserting that the hash |prevWsHash| given is still the current one
 	// before attempting to write a new value. And it does the normal optimistic locking that Commit does, assuming the
@@ -136,6 +143,10 @@ type Database interface {
 	// Delete returns an 'ErrMergeNeeded' error.
 	Delete(ctx context.Context, ds Dataset, workingSetPath string) (Dataset, error)
 
+	// DeleteAndKeep is like Delete, but also sets the dataset |keepID| to the deleted head of |ds| in the same update,
+	// so that the head stays reachable under another name.
+	DeleteAndKeep(ctx context.Context, ds Dataset, workingSetPath string, keepID string) (Dataset, error)
+
 	// SetHead ignores any lineage constraints (e.g. the current head being
 	// an ancestor of the new Commit) and force-sets a mapping from
 	// datasetID: addr in this database. addr can point to a Commit or a
@@ -156,6 +167,24 @@ type Database interface {
 	// When |allowDirtyWorking| is true, the working set may have unstaged changes, such as ignored tables.
 	FastForward(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, workingSetPath string, allowDirtyWorking bool) (Dataset, error)
 
+	// SetHeads force-sets the head of every dataset named in |heads| to its
+	// address, or removes the dataset if its address is empty, in a single
+	// update of the root. Like SetHead, it ignores lineage constraints. The
+	// values at the given addresses must already be present in the Database.
+	SetHeads(ctx context.Context, heads map[string]hash.Hash) error
+
+	// CommitRootWithHeads sets the root of this Database to the datasets of
+	// the root |root|, with the heads of the datasets in |heads| set as in
+	// SetHeads, if the root is still |last|. Returns false if it isn't. The
+	// chunks of |root| and of the given heads must already be present in the
+	// Database. The datasets update hook isn't called.
+	CommitRootWithHeads(ctx context.Context, root, last hash.Hash, heads map[string]hash.Hash) (bool, error)
+
+	// SetDatasetsUpdateHook sets a hook that is called for every update of
+	// the Datasets map of this Database, before the update is committed. See
+	// DatasetsUpdateHook.
+	SetDatasetsUpdateHook(hook DatasetsUpdateHook)
+
 	// Stats may return some kind of struct that reports statistics about the
 	// ChunkStore that backs this Database instance. The type is
 	// implementation-dependent, and impls may return nil
@@ -180,6 +209,12 @@ type Database interface {
 	chunkStore() chunks.ChunkStore
 }
 
+// DatasetsUpdateHook is called with the Datasets map of a Database before and
+// after an update to it, and returns the map to commit in place of |after|.
+// Datasets the hook adds are committed atomically with the update. The hook
+// is called again if the update is retried.
+type DatasetsUpdateHook func(ctx context.Context, before, after prolly.AddressMap) (prolly.AddressMap, error)
+
 func NewDatabase(cs chunks.ChunkStore) Database {
 	vs := types.NewValueStore(cs)
 	ns := tree.NewNodeStore(cs)
@@ -38,6 +38,8 @@ type database struct {
 	*types.ValueStore
 	rt rootTracker
 	ns tree.NodeStore
+
+	updateHook DatasetsUpdateHook
 }
 
 var (
@@ -693,6 +695,60 @@ func (db *database) UpdateStashList(ctx context.Context, ds Dataset, stashListAd
 	})
 }
 
+func (db *database) SetHeads(ctx context.Context, heads map[string]hash.Hash) error {
+	return db.update(ctx, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		ae := am.Editor()
+		for id, addr := range heads {
+			var err error
+			if addr.IsEmpty() {
+				err = ae.Delete(ctx, id)
+			} else {
+				err = ae.Update(ctx, id, addr)
+			}
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
+		return ae.Flush(ctx)
+	})
+}
+
+func (db *database) CommitRootWithHeads(ctx context.Context, root, last hash.Hash, heads map[string]hash.Hash) (bool, error) {
+	datasets, err := db.loadDatasetsRefmap(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	ae := datasets.Editor()
+	for id, addr := range heads {
+		if addr.IsEmpty() {
+			err = ae.Delete(ctx, id)
+		} else {
+			err = ae.Update(ctx, id, addr)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	datasets, err = ae.Flush(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := db.WriteValue(ctx, types.SerialMessage(storeroot_flatbuffer(datasets)))
+	if err != nil {
+		return false, err
+	}
+	err = db.tryCommitChunks(ctx, r.TargetHash(), last)
+	if err == ErrOptimisticLockFailed {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *database) SetDatasetsUpdateHook(hook DatasetsUpdateHook) {
+	db.updateHook = hook
+}
+
 func (db *database) UpdateWorkingSet(ctx context.Context, ds Dataset, workingSetSpec WorkingSetSpec, prevHash hash.Hash) (Dataset, error) {
 	return db.doHeadUpdate(
 		ctx,
@@ -730,6 +786,59 @@ func (db *database) doUpdateWorkingSet(ctx context.Context, datasetID string, ad
 	})
 }
 
+func (db *database) UpdateWorkingSets(ctx context.Context, updates []WorkingSetUpdate) ([]Dataset, error) {
+	addrs := make([]hash.Hash, len(updates))
+	for i, u := range updates {
+		if u.Delete {
+			continue
+		}
+		addr, err := newWorkingSet(ctx, db, u.WorkingSet)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = addr
+	}
+
+	err := db.update(ctx, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		ae := am.Editor()
+		for i, u := range updates {
+			curr, err := am.Get(ctx, u.Dataset.ID())
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+			if curr != u.PrevHash {
+				return prolly.AddressMap{}, ErrOptimisticLockFailed
+			}
+			if u.Delete {
+				err = ae.Delete(ctx, u.Dataset.ID())
+			} else {
+				err = ae.Update(ctx, u.Dataset.ID(), addrs[i])
+			}
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
+		return ae.Flush(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currentDatasets, err := db.Datasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	datasets := make([]Dataset, len(updates))
+	for i, u := range updates {
+		datasets[i], err = db.datasetFromMap(ctx, u.Dataset.ID(), currentDatasets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return datasets, nil
+}
+
 func (db *database) PersistGhostCommitIDs(ctx context.Context, ghosts hash.HashSet) error {
 	cs := db.ChunkStore()
 
@@ -835,7 +944,14 @@ func (db *database) CommitWithWorkingSet(
 }
 
 func (db *database) Delete(ctx context.Context, ds Dataset, wsIDStr string) (Dataset, error) {
-	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID(), wsIDStr) })
+	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID(), wsIDStr, "") })
+}
+
+func (db *database) DeleteAndKeep(ctx context.Context, ds Dataset, wsIDStr string, keepID string) (Dataset, error) {
+	if err := ValidateDatasetId(keepID); err != nil {
+		return Dataset{}, fmt.Errorf("%w: %s", err, keepID)
+	}
+	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error { return db.doDelete(ctx, ds.ID(), wsIDStr, keepID) })
 }
 
 func (db *database) update(
@@ -860,11 +976,19 @@ func (db *database) update(
 			return err
 		}
 
+		before := datasets
 		datasets, err = editFB(ctx, datasets)
 		if err != nil {
 			return err
 		}
 
+		if db.updateHook != nil {
+			datasets, err = db.updateHook(ctx, before, datasets)
+			if err != nil {
+				return err
+			}
+		}
+
 		data := storeroot_flatbuffer(datasets)
 		r, err := db.WriteValue(ctx, types.SerialMessage(data))
 		if err != nil {
@@ -880,7 +1004,7 @@ func (db *database) update(
 	}
 }
 
-func (db *database) doDelete(ctx context.Context, datasetIDstr string, workingsetIDstr string) error {
+func (db *database) doDelete(ctx context.Context, datasetIDstr string, workingsetIDstr string, keepIDstr string) error {
 	var firstHash hash.Hash
 
 	return db.update(ctx, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
@@ -959,6 +1083,12 @@ func (db *database) doDelete(ctx context.Context, datasetIDstr string, workingse
 				return prolly.AddressMap{}, err
 			}
 		}
+		if keepIDstr != "" && !curr.IsEmpty() {
+			err = ae.Update(ctx, keepIDstr, curr)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
 
 		return ae.Flush(ctx)
 	})
@@ -220,6 +220,7 @@ type MergeState struct {
 	isCherryPick           bool       // true if this merge is a chery pick
 	isRevert               bool       // true if this merge is a revert
 	pendingRevertHashes    []string   // remaining commit hashes to process after resolving a conflict
+	mergeBaseCommitAddr    *hash.Hash // the commit given as the ancestor of unrelated histories
 }
 
 func (ms *MergeState) PreMergeWorkingAddr() (hash.Hash, error) {
@@ -253,6 +254,15 @@ func (ms *MergeState) PreMergeHeadCommit(ctx context.Context, vr types.ValueRead
 	return LoadCommitAddr(ctx, vr, *ms.preMergeHeadCommitAddr)
 }
 
+// MergeBaseCommit returns the commit given as the common ancestor of a merge of unrelated histories, or nil if there
+// was none.
+func (ms *MergeState) MergeBaseCommit(ctx context.Context, vr types.ValueReader) (*Commit, error) {
+	if ms.mergeBaseCommitAddr == nil {
+		return nil, nil
+	}
+	return LoadCommitAddr(ctx, vr, *ms.mergeBaseCommitAddr)
+}
+
 func (ms *MergeState) PendingRevertHashes() []string {
 	return ms.pendingRevertHashes
 }
@@ -375,6 +385,10 @@ func (h serialWorkingSetHead) HeadWorkingSet() (*WorkingSetHead, error) {
 		for i := range ret.MergeState.pendingRevertHashes {
 			ret.MergeState.pendingRevertHashes[i] = string(mergeState.PendingCommitHashes(i))
 		}
+		if addrBytes := mergeState.MergeBaseCommitAddrBytes(); len(addrBytes) > 0 {
+			ret.MergeState.mergeBaseCommitAddr = new(hash.Hash)
+			*ret.MergeState.mergeBaseCommitAddr = hash.New(addrBytes)
+		}
 	}
 
 	rebaseState, err := h.msg.TryRebaseState(nil)
@@ -650,6 +664,22 @@ func (ds Dataset) MaybeHeadValue() (types.Value, bool, error) {
 	return nil, false, nil
 }
 
+// LoadWorkingSetHead loads the working set stored at |addr|, which need not be the current head of any dataset.
+func LoadWorkingSetHead(ctx context.Context, vr types.ValueReader, addr hash.Hash) (*WorkingSetHead, error) {
+	val, err := vr.ReadValue(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	h, err := newHead(ctx, val, addr)
+	if err != nil {
+		return nil, err
+	}
+	if h.TypeName() != workingSetName {
+		return nil, fmt.Errorf("value at %s is not a working set", addr.String())
+	}
+	return h.HeadWorkingSet()
+}
+
 func NewHeadlessDataset(db Database, id string) Dataset {
 	return Dataset{
 		id:   id,
@@ -43,6 +43,16 @@ type WorkingSetSpec struct {
 	StagedRoot  types.Ref
 }
 
+// WorkingSetUpdate is one of the working sets written by Database.UpdateWorkingSets
+type WorkingSetUpdate struct {
+	Dataset    Dataset
+	WorkingSet WorkingSetSpec
+	// PrevHash is the hash the working set must still have for the update to succeed
+	PrevHash hash.Hash
+	// Delete removes the working set rather than setting it to |WorkingSet|
+	Delete bool
+}
+
 // newWorkingSet creates a new working set object.
 // A working set is a value that has been persisted but is not necessarily referenced by a Commit. As the name implies,
 // it's storage for data changes that have not yet been incorporated into the commit graph but need durable storage.
@@ -104,6 +114,10 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		if len(mergeState.pendingRevertHashes) > 0 {
 			pendingHashesOff = SerializeStringVector(builder, mergeState.pendingRevertHashes)
 		}
+		var baseCommitAddrOff flatbuffers.UOffsetT
+		if mergeState.mergeBaseCommitAddr != nil {
+			baseCommitAddrOff = builder.CreateByteVector((*mergeState.mergeBaseCommitAddr)[:])
+		}
 		serial.MergeStateStart(builder)
 		serial.MergeStateAddPreWorkingRootAddr(builder, prerootaddroff)
 		serial.MergeStateAddFromCommitAddr(builder, fromaddroff)
@@ -117,6 +131,9 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		if pendingHashesOff != 0 {
 			serial.MergeStateAddPendingCommitHashes(builder, pendingHashesOff)
 		}
+		if baseCommitAddrOff != 0 {
+			serial.MergeStateAddMergeBaseCommitAddr(builder, baseCommitAddrOff)
+		}
 		mergeStateOff = serial.MergeStateEnd(builder)
 	}
 
@@ -173,6 +190,7 @@ func NewMergeState(
 	isRevert bool,
 	headCommit *Commit,
 	pendingRevertHashes []string,
+	baseCommit *Commit,
 ) (*MergeState, error) {
 	ms := &MergeState{
 		preMergeWorkingAddr: new(hash.Hash),
@@ -189,6 +207,10 @@ func NewMergeState(
 		ms.preMergeHeadCommitAddr = new(hash.Hash)
 		*ms.preMergeHeadCommitAddr = headCommit.Addr()
 	}
+	if baseCommit != nil {
+		ms.mergeBaseCommitAddr = new(hash.Hash)
+		*ms.mergeBaseCommitAddr = baseCommit.Addr()
+	}
 	return ms, nil
 }
 
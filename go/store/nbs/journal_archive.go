@@ -0,0 +1,271 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// A journal archive is a sequence of segment blobs, keyed by consecutive sequence numbers starting at 1, holding
+// the records of a chunk journal in the order they were written. Blobstores cannot list their keys, so the segments
+// are found by probing for sequence numbers. Each segment has a fixed size header followed by complete journal
+// records:
+//
+// +-----------------+-------------------------+-----------------------+-----------------+
+// | magic (4 bytes) | journal id (16 bytes)   | start offset (uint64) | journal records |
+// +-----------------+-------------------------+-----------------------+-----------------+
+//
+// The journal id is the UUID of the journal file the records were copied from, which is stored next to the journal
+// file and changes when garbage collection replaces the journal. The start offset is the offset of the first record
+// within that journal file.
+
+const (
+	journalSegmentMagic    = "DJS1"
+	journalSegmentIDSz     = 16
+	journalSegmentHeaderSz = 4 + journalSegmentIDSz + uint64Size
+)
+
+var ErrInvalidJournalSegment = errors.New("invalid journal archive segment")
+
+func journalSegmentKey(seq uint64) string {
+	return fmt.Sprintf("journal-segment-%020d", seq)
+}
+
+// JournalShipper copies the records appended to a chunk journal file into a journal archive in a Blobstore. Only
+// records up to the last root hash record are shipped, so every segment ends at a root the database was committed
+// to. A JournalShipper is safe for concurrent use.
+type JournalShipper struct {
+	journalPath string
+	bs          blobstore.Blobstore
+
+	mu        sync.Mutex
+	loaded    bool
+	nextSeq   uint64
+	journalID uuid.UUID
+	shipped   int64
+}
+
+// NewJournalShipper returns a JournalShipper that archives the chunk journal at |journalPath| to |bs|.
+func NewJournalShipper(journalPath string, bs blobstore.Blobstore) *JournalShipper {
+	return &JournalShipper{journalPath: journalPath, bs: bs}
+}
+
+// Ship uploads a new segment containing the journal records written since the last shipped segment, and returns the
+// number of journal bytes it shipped. If the journal has been replaced since the last segment was shipped, the new
+// journal is shipped from its beginning.
+func (s *JournalShipper) Ship(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		if err := s.loadArchiveState(ctx); err != nil {
+			return 0, err
+		}
+		s.loaded = true
+	}
+
+	f, err := os.Open(s.journalPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	journalID, err := readJournalID(s.journalPath)
+	if err != nil {
+		return 0, err
+	}
+
+	start := s.shipped
+	if journalID != s.journalID {
+		start = 0
+	}
+	if _, err = f.Seek(start, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	end := start
+	_, _, _, err = processJournalRecordsReader(ctx, f, start, func(o int64, r journalRec) error {
+		if r.kind == rootHashJournalRecKind {
+			end = o + int64(r.length)
+		}
+		return nil
+	}, nil)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if end == start {
+		return 0, nil
+	}
+
+	segment := make([]byte, journalSegmentHeaderSz+int(end-start))
+	copy(segment, journalSegmentMagic)
+	copy(segment[4:], journalID[:])
+	writeUint64(segment[4+journalSegmentIDSz:], uint64(start))
+	if _, err = f.ReadAt(segment[journalSegmentHeaderSz:], start); err != nil {
+		return 0, err
+	}
+
+	if _, err = blobstore.PutBytes(ctx, s.bs, journalSegmentKey(s.nextSeq), segment); err != nil {
+		return 0, err
+	}
+	s.nextSeq++
+	s.journalID = journalID
+	s.shipped = end
+	return end - start, nil
+}
+
+// loadArchiveState finds the last segment in the archive and reads its header, so that shipping resumes after the
+// records it contains.
+func (s *JournalShipper) loadArchiveState(ctx context.Context) error {
+	count, err := countJournalSegments(ctx, s.bs)
+	if err != nil {
+		return err
+	}
+	s.nextSeq = count + 1
+	if count == 0 {
+		return nil
+	}
+
+	rc, size, _, err := s.bs.Get(ctx, journalSegmentKey(count), blobstore.NewBlobRange(0, journalSegmentHeaderSz))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	header := make([]byte, journalSegmentHeaderSz)
+	if _, err = io.ReadFull(rc, header); err != nil {
+		return err
+	}
+	journalID, start, err := parseJournalSegmentHeader(header)
+	if err != nil {
+		return err
+	}
+
+	s.journalID = journalID
+	s.shipped = int64(start) + int64(size) - journalSegmentHeaderSz
+	return nil
+}
+
+func parseJournalSegmentHeader(header []byte) (journalID uuid.UUID, start uint64, err error) {
+	if len(header) < journalSegmentHeaderSz || string(header[:4]) != journalSegmentMagic {
+		return uuid.UUID{}, 0, ErrInvalidJournalSegment
+	}
+	copy(journalID[:], header[4:])
+	return journalID, readUint64(header[4+journalSegmentIDSz:]), nil
+}
+
+// JournalArchiveExists returns whether |bs| holds a journal archive with at least one segment.
+func JournalArchiveExists(ctx context.Context, bs blobstore.Blobstore) (bool, error) {
+	return bs.Exists(ctx, journalSegmentKey(1))
+}
+
+// countJournalSegments returns the number of segments in the journal archive in |bs|.
+func countJournalSegments(ctx context.Context, bs blobstore.Blobstore) (uint64, error) {
+	// Segments are numbered consecutively, so find an upper bound by doubling, then binary search for the last one.
+	var lo, hi uint64 = 0, 1
+	for {
+		ok, err := bs.Exists(ctx, journalSegmentKey(hi))
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		lo, hi = hi, hi*2
+	}
+
+	// invariant: segment |lo| exists (or lo is 0), and segment |hi| does not
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := bs.Exists(ctx, journalSegmentKey(mid))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// ArchivedJournalRecord is a record read from a journal archive. Chunk records have a non-nil Chunk, and root hash
+// records have a Root and the Timestamp at which the root was committed.
+type ArchivedJournalRecord struct {
+	Chunk     *chunks.Chunk
+	Root      hash.Hash
+	Timestamp time.Time
+}
+
+// IterateJournalArchive calls |cb| with each record in the journal archive in |bs|, in the order the records were
+// written to the journal. Iteration stops early without error if |cb| returns io.EOF.
+func IterateJournalArchive(ctx context.Context, bs blobstore.Blobstore, cb func(rec ArchivedJournalRecord) error) error {
+	for seq := uint64(1); ; seq++ {
+		segment, _, err := blobstore.GetBytes(ctx, bs, journalSegmentKey(seq), blobstore.AllRange)
+		if blobstore.IsNotFoundError(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if _, _, err = parseJournalSegmentHeader(segment); err != nil {
+			return fmt.Errorf("%w: %s", err, journalSegmentKey(seq))
+		}
+
+		records := segment[journalSegmentHeaderSz:]
+		var stopped bool
+		_, end, _, err := processJournalRecordsReader(ctx, bytes.NewReader(records), 0, func(_ int64, r journalRec) error {
+			var err error
+			switch r.kind {
+			case chunkJournalRecKind:
+				var cc CompressedChunk
+				if cc, err = NewCompressedChunk(r.address, r.payload); err != nil {
+					return err
+				}
+				var c chunks.Chunk
+				if c, err = cc.ToChunk(); err != nil {
+					return err
+				}
+				err = cb(ArchivedJournalRecord{Chunk: &c})
+			case rootHashJournalRecKind:
+				err = cb(ArchivedJournalRecord{Root: r.address, Timestamp: r.timestamp})
+			}
+			stopped = err == io.EOF
+			return err
+		}, nil)
+		if stopped {
+			return nil
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+		if end != int64(len(records)) {
+			return fmt.Errorf("%w: %s contains an incomplete record at offset %d", ErrInvalidJournalSegment, journalSegmentKey(seq), end)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestJournalArchive(t *testing.T) {
+	ctx := context.Background()
+	store := makeTestJournalingStore(t)
+	journalPath, ok, err := store.ChunkJournalPath(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	bs := blobstore.NewInMemoryBlobstore("")
+	shipper := NewJournalShipper(journalPath, bs)
+
+	// nothing has been committed yet
+	n, err := shipper.Ship(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	first := commitTestChunks(t, store, "a", "b")
+	n, err = shipper.Ship(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, n)
+
+	n, err = shipper.Ship(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	second := commitTestChunks(t, store, "c")
+
+	// a new shipper resumes after the segments that were already shipped
+	shipper = NewJournalShipper(journalPath, bs)
+	n, err = shipper.Ship(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, n)
+
+	count, err := countJournalSegments(ctx, bs)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	var chunkData []string
+	var roots []hash.Hash
+	err = IterateJournalArchive(ctx, bs, func(rec ArchivedJournalRecord) error {
+		if rec.Chunk != nil {
+			chunkData = append(chunkData, string(rec.Chunk.Data()))
+		} else {
+			assert.False(t, rec.Timestamp.IsZero())
+			roots = append(roots, rec.Root)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, chunkData)
+	assert.Equal(t, []hash.Hash{first, second}, roots)
+
+	t.Run("stops early", func(t *testing.T) {
+		var visited int
+		err := IterateJournalArchive(ctx, bs, func(rec ArchivedJournalRecord) error {
+			visited++
+			if rec.Chunk == nil {
+				return io.EOF
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, visited)
+	})
+
+	t.Run("replaced journal is shipped from the start", func(t *testing.T) {
+		other := makeTestJournalingStore(t)
+		otherPath, _, err := other.ChunkJournalPath(ctx)
+		require.NoError(t, err)
+		third := commitTestChunks(t, other, "d")
+
+		n, err := NewJournalShipper(otherPath, bs).Ship(ctx)
+		require.NoError(t, err)
+		assert.Positive(t, n)
+
+		roots = nil
+		err = IterateJournalArchive(ctx, bs, func(rec ArchivedJournalRecord) error {
+			if rec.Chunk == nil {
+				roots = append(roots, rec.Root)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []hash.Hash{first, second, third}, roots)
+	})
+
+	t.Run("journal id", func(t *testing.T) {
+		id, err := readJournalID(journalPath)
+		require.NoError(t, err)
+		again, err := readJournalID(journalPath)
+		require.NoError(t, err)
+		assert.Equal(t, id, again)
+
+		// A journal created before journal files had ids is given one
+		dir := t.TempDir()
+		legacy, err := readJournalID(filepath.Join(dir, chunkJournalName))
+		require.NoError(t, err)
+		again, err = readJournalID(filepath.Join(dir, chunkJournalName))
+		require.NoError(t, err)
+		assert.Equal(t, legacy, again)
+		assert.NotEqual(t, id, legacy)
+	})
+
+	t.Run("journal with the same records but a new id is shipped from the start", func(t *testing.T) {
+		bs := blobstore.NewInMemoryBlobstore("")
+		shipper := NewJournalShipper(journalPath, bs)
+		shipped, err := shipper.Ship(ctx)
+		require.NoError(t, err)
+		require.Positive(t, shipped)
+
+		// as if the journal had been replaced by one with the same leading records
+		require.NoError(t, writeJournalID(journalPath, uuid.New()))
+		n, err := shipper.Ship(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, shipped, n)
+	})
+
+	t.Run("corrupt segment", func(t *testing.T) {
+		bs := blobstore.NewInMemoryBlobstore("")
+		_, err := blobstore.PutBytes(ctx, bs, journalSegmentKey(1), []byte("not a segment"))
+		require.NoError(t, err)
+		err = IterateJournalArchive(ctx, bs, func(ArchivedJournalRecord) error { return nil })
+		require.ErrorIs(t, err, ErrInvalidJournalSegment)
+	})
+}
+
+func makeTestJournalingStore(t *testing.T) *NomsBlockStore {
+	store, err := NewLocalJournalingStore(context.Background(), types.Format_DOLT.VersionString(), t.TempDir(), NewUnlimitedMemQuotaProvider(), false, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// commitTestChunks writes a chunk for each of |data| to |store|, and commits the last one as the store's root.
+func commitTestChunks(t *testing.T, store *NomsBlockStore, data ...string) hash.Hash {
+	ctx := context.Background()
+	noAddrs := func(chunks.Chunk) chunks.InsertAddrsCb {
+		return func(context.Context, hash.HashSet, chunks.PendingRefExists) error { return nil }
+	}
+
+	var root hash.Hash
+	for _, d := range data {
+		c := chunks.NewChunk([]byte(d))
+		require.NoError(t, store.Put(ctx, c, noAddrs))
+		root = c.Hash()
+	}
+	last, err := store.Root(ctx)
+	require.NoError(t, err)
+	ok, err := store.Commit(ctx, root, last)
+	require.NoError(t, err)
+	require.True(t, ok)
+	return root
+}
@@ -26,6 +26,7 @@ import (
 	"runtime/trace"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
@@ -45,6 +46,9 @@ const (
 	chunkJournalAddr = chunks.JournalFileID
 
 	journalIndexFileName = "journal.idx"
+	// journalIDFileName is the file holding the identifier of the journal file, which changes whenever the journal
+	// file is replaced.
+	journalIDFileName = "journal.id"
 
 	// journalIndexDefaultMaxNovel determines how often we flush
 	// records qto the out-of-band journal index file.
@@ -123,6 +127,10 @@ func createJournalWriter(ctx context.Context, path string) (wr *journalWriter, e
 	if f, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666); err != nil {
 		return nil, err
 	}
+	if err = writeJournalID(path, uuid.New()); err != nil {
+		f.Close()
+		return nil, err
+	}
 
 	return &journalWriter{
 		buf:     make([]byte, 0, journalWriterBuffSize),
@@ -136,7 +144,51 @@ func deleteJournalAndIndexFiles(ctx context.Context, path string) (err error) {
 		return err
 	}
 	idxPath := filepath.Join(filepath.Dir(path), journalIndexFileName)
-	return os.Remove(idxPath)
+	if err = os.Remove(idxPath); err != nil {
+		return err
+	}
+	idPath := filepath.Join(filepath.Dir(path), journalIDFileName)
+	if err = os.Remove(idPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// writeJournalID writes |id| as the identifier of the journal file at |path|, replacing the identifier of any
+// journal file that was there before.
+func writeJournalID(path string, id uuid.UUID) error {
+	idPath := filepath.Join(filepath.Dir(path), journalIDFileName)
+	return os.WriteFile(idPath, []byte(id.String()), 0666)
+}
+
+// readJournalID returns the identifier of the journal file at |path|. Journal files created before journal files had
+// identifiers are given one, which they keep until they're replaced.
+func readJournalID(path string) (uuid.UUID, error) {
+	idPath := filepath.Join(filepath.Dir(path), journalIDFileName)
+	buf, err := os.ReadFile(idPath)
+	if errors.Is(err, os.ErrNotExist) {
+		id := uuid.New()
+		f, err := os.OpenFile(idPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+		if errors.Is(err, os.ErrExist) {
+			// created concurrently
+			return readJournalID(path)
+		} else if err != nil {
+			return uuid.UUID{}, err
+		}
+		_, err = f.WriteString(id.String())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return id, err
+	} else if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	id, err := uuid.ParseBytes(buf)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("invalid journal id file %s: %w", idPath, err)
+	}
+	return id, nil
 }
 
 type journalWriter struct {
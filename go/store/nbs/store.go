@@ -214,6 +214,20 @@ func (nbs *NomsBlockStore) ChunkJournalSize(ctx context.Context) (int64, bool, e
 	return 0, false, nil
 }
 
+// ChunkJournalPath returns the path of this store's chunk journal file, and false if the store does not use a
+// chunk journal.
+func (nbs *NomsBlockStore) ChunkJournalPath(ctx context.Context) (string, bool, error) {
+	if err := nbs.ensureLoad(ctx); err != nil {
+		return "", false, err
+	}
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if cj := nbs.chunkJournal(); cj != nil {
+		return cj.path, true, nil
+	}
+	return "", false, nil
+}
+
 func (nbs *NomsBlockStore) GetChunkLocationsWithPaths(ctx context.Context, hashes hash.HashSet) (map[string]map[hash.Hash]Range, error) {
 	if err := nbs.ensureLoad(ctx); err != nil {
 		return nil, err
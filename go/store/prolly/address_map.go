@@ -143,6 +143,51 @@ func (c AddressMap) IterAllBytes(ctx context.Context, cb func(name []byte, addre
 	return nil
 }
 
+// IterRange calls |cb| with the name and address of every entry
+// in the map whose name is in [|start|, |stop|), in key order.
+// An empty |stop| iterates to the end of the map.
+func (c AddressMap) IterRange(ctx context.Context, start, stop string, cb func(name string, address hash.Hash) error) error {
+	iter, err := c.addresses.IterKeyRange(ctx, stringSlice(start), stringSlice(stop))
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, a, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = cb(string(n), hash.New(a)); err != nil {
+			return err
+		}
+	}
+}
+
+// DiffAddressMaps calls |cb| with the name of every entry that
+// differs between |from| and |to|, in key order, along with its
+// address in each map. An entry missing from one of the maps has
+// an empty address in it.
+func DiffAddressMaps(ctx context.Context, from, to AddressMap, cb func(ctx context.Context, name string, fromAddr, toAddr hash.Hash) error) error {
+	err := tree.DiffOrderedTrees(ctx, from.addresses, to.addresses, false, func(ctx context.Context, diff tree.Diff) error {
+		var fromAddr, toAddr hash.Hash
+		if diff.From != nil {
+			fromAddr = hash.New(diff.From)
+		}
+		if diff.To != nil {
+			toAddr = hash.New(diff.To)
+		}
+		return cb(ctx, string(diff.Key), fromAddr, toAddr)
+	})
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
 func (c AddressMap) Editor() AddressMapEditor {
 	return AddressMapEditor{
 		addresses: c.addresses.Mutate(),
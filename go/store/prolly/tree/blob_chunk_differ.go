@@ -231,3 +231,24 @@ func newBlobChunkDiffer(ctx context.Context, ns NodeStore, l, r val.AdaptiveValu
 	}
 	return d, nil
 }
+
+// DivergentBlobSuffixes returns the bytes of |l| and |r| that follow the leaf chunks the two values share at their
+// start. Shared subtrees are skipped by their addresses without being read, so two versions of a long value that were
+// edited near their end are diffed without loading the rest of either one. Against a NULL value, all of the other
+// value is returned.
+func DivergentBlobSuffixes(ctx context.Context, ns NodeStore, l, r val.AdaptiveValue) (lSuffix, rSuffix []byte, err error) {
+	d, err := newBlobChunkDiffer(ctx, ns, l, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		lChunk, rChunk, err := d.Next(ctx)
+		if err == io.EOF {
+			return lSuffix, rSuffix, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		lSuffix = append(lSuffix, lChunk...)
+		rSuffix = append(rSuffix, rChunk...)
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 // buildBlobTree writes |data| as a fixed-chunk blob tree and returns the root node. An empty
@@ -392,3 +393,46 @@ func leafAt(data []byte, chunkSize, idx int) []byte {
 	}
 	return data[start:end]
 }
+
+func TestDivergentBlobSuffixes(t *testing.T) {
+	ctx := context.Background()
+	ns := NewTestNodeStore()
+
+	const size = DefaultFixedChunkLength * 12
+	base := makeData(size)
+	edited := withByteChanged(base, size-100)
+	outOfBand := func(data []byte) val.AdaptiveValue {
+		v, err := val.NewOutOfBandAdaptiveValue(ctx, ns, data)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("shared chunks are skipped", func(t *testing.T) {
+		l, r, err := DivergentBlobSuffixes(ctx, ns, outOfBand(base), outOfBand(edited))
+		require.NoError(t, err)
+		require.Equal(t, len(l), len(r))
+		require.Less(t, len(l), size/2)
+		prefix := size - len(l)
+		require.Equal(t, base[prefix:], l)
+		require.Equal(t, edited[prefix:], r)
+	})
+	t.Run("identical values", func(t *testing.T) {
+		l, r, err := DivergentBlobSuffixes(ctx, ns, outOfBand(base), outOfBand(base))
+		require.NoError(t, err)
+		require.Empty(t, l)
+		require.Empty(t, r)
+	})
+	t.Run("inline value", func(t *testing.T) {
+		inline := val.AdaptiveValue(val.AdaptiveValueInlineBytes([]byte("abc")))
+		l, r, err := DivergentBlobSuffixes(ctx, ns, outOfBand(base), inline)
+		require.NoError(t, err)
+		require.Equal(t, base, l)
+		require.Equal(t, []byte("abc"), r)
+	})
+	t.Run("null value", func(t *testing.T) {
+		l, r, err := DivergentBlobSuffixes(ctx, ns, outOfBand(base), nil)
+		require.NoError(t, err)
+		require.Equal(t, base, l)
+		require.Empty(t, r)
+	})
+}
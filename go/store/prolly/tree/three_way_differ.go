@@ -185,7 +185,7 @@ func (d *ThreeWayDiffer[K, O]) Next(ctx *sql.Context) (ThreeWayDiff, error) {
 			return res, nil
 		case dsMatch:
 			if d.lDiff.To == nil && d.rDiff.To == nil {
-				res = d.newConvergentEdit(d.lDiff.Key, d.lDiff.To, d.lDiff.Type)
+				res = d.newConvergentEdit(d.lDiff.Key, d.lDiff.From, d.lDiff.To, d.lDiff.Type)
 			} else if d.lDiff.To == nil || d.rDiff.To == nil {
 				// Divergent delete. Attempt to resolve.
 				_, ok, err := d.resolveCb(ctx, val.Tuple(d.lDiff.To), val.Tuple(d.rDiff.To), val.Tuple(d.lDiff.From))
@@ -198,7 +198,7 @@ func (d *ThreeWayDiffer[K, O]) Next(ctx *sql.Context) (ThreeWayDiff, error) {
 					res = d.newDivergentDeleteResolved(d.lDiff.Key, d.lDiff.From, d.lDiff.To, d.rDiff.To)
 				}
 			} else if d.lDiff.Type == d.rDiff.Type && bytes.Equal(d.lDiff.To, d.rDiff.To) {
-				res = d.newConvergentEdit(d.lDiff.Key, d.lDiff.To, d.lDiff.Type)
+				res = d.newConvergentEdit(d.lDiff.Key, d.lDiff.From, d.lDiff.To, d.lDiff.Type)
 			} else {
 				resolved, ok, err := d.resolveCb(ctx, val.Tuple(d.lDiff.To), val.Tuple(d.rDiff.To), val.Tuple(d.lDiff.From))
 				if err != nil {
@@ -309,7 +309,7 @@ func (d *ThreeWayDiffer[K, O]) newRightEdit(key, base, right Item, typ DiffType)
 	}
 }
 
-func (d *ThreeWayDiffer[K, O]) newConvergentEdit(key, left Item, typ DiffType) ThreeWayDiff {
+func (d *ThreeWayDiffer[K, O]) newConvergentEdit(key, base, left Item, typ DiffType) ThreeWayDiff {
 	var op DiffOp
 	switch typ {
 	case AddedDiff:
@@ -324,6 +324,7 @@ func (d *ThreeWayDiffer[K, O]) newConvergentEdit(key, left Item, typ DiffType) T
 	return ThreeWayDiff{
 		Op:   op,
 		Key:  val.Tuple(key),
+		Base: val.Tuple(base),
 		Left: val.Tuple(left),
 	}
 }
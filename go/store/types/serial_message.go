@@ -660,6 +660,11 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 			if err = cb(hash.New(mergeState.FromCommitAddrBytes())); err != nil {
 				return err
 			}
+			if addr := mergeState.MergeBaseCommitAddrBytes(); len(addr) > 0 {
+				if err = cb(hash.New(addr)); err != nil {
+					return err
+				}
+			}
 		}
 	case serial.RootValueFileID:
 		var msg serial.RootValue
@@ -0,0 +1,116 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk INT PRIMARY KEY, c1 INT, c2 INT)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4)"
+    dolt commit -Am "Created table"
+
+    # a second database with the same data but none of the history
+    mkdir target
+    cd target
+    dolt init
+    dolt sql -q "CREATE TABLE test (pk INT PRIMARY KEY, c1 INT, c2 INT)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4)"
+    dolt commit -Am "Created table"
+    cd ..
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "apply: diff --preimage matches rows by their old values" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt sql -q "DELETE FROM test WHERE pk = 2"
+
+    run dolt diff -r sql --preimage
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'UPDATE `test` SET `c1`=10 WHERE `pk`=1 AND `c1`<=>1 AND `c2`<=>1;' ]] || false
+    [[ "$output" =~ 'DELETE FROM `test` WHERE `pk`=2 AND `c1`<=>2 AND `c2`<=>2;' ]] || false
+
+    run dolt diff --preimage
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--preimage" ]] || false
+}
+
+@test "apply: applies a patch and stages its tables" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt sql -q "DELETE FROM test WHERE pk = 2"
+    dolt sql -q "INSERT INTO test VALUES (5, 5, 5)"
+    dolt diff -r sql --preimage > patch.sql
+
+    cd target
+    run dolt apply ../patch.sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied 3 statements" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,10,1" ]] || false
+    [[ "$output" =~ "3,3,3" ]] || false
+    [[ "$output" =~ "4,4,4" ]] || false
+    [[ "$output" =~ "5,5,5" ]] || false
+    [[ ! "$output" =~ "2,2,2" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "Changes to be committed" ]] || false
+
+    # applying the patch again skips what is already there
+    dolt commit -m "Applied patch"
+    run dolt apply ../patch.sql
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applied 0 statements, skipped 3 statements already applied" ]] || false
+}
+
+@test "apply: records conflicts for rows changed in the target" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt sql -q "UPDATE test SET c1 = 21 WHERE pk = 2"
+    dolt sql -q "UPDATE test SET c2 = 30 WHERE pk = 3"
+    dolt diff -r sql --preimage > patch.sql
+
+    cd target
+    dolt sql -q "UPDATE test SET c1 = 20 WHERE pk = 2"
+    dolt sql -q "UPDATE test SET c1 = 31 WHERE pk = 3"
+    dolt commit -am "Changed rows"
+
+    run dolt apply ../patch.sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Applied 2 statements" ]] || false
+    [[ "$output" =~ "1 row could not be applied and recorded as conflicts." ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "1,10,1" ]] || false
+    [[ "$output" =~ "2,20,2" ]] || false
+    [[ "$output" =~ "3,31,30" ]] || false
+
+    run dolt sql -q "SELECT our_c1, their_c1 FROM dolt_conflicts_test" -r csv
+    [[ "$output" =~ "20,21" ]] || false
+
+    run dolt conflicts cat test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "theirs" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt sql -q "SELECT * FROM test WHERE pk = 2" -r csv
+    [[ "$output" =~ "2,21,2" ]] || false
+}
+
+@test "apply: requires a clean working set" {
+    dolt sql -q "UPDATE test SET c1 = 10 WHERE pk = 1"
+    dolt diff -r sql --preimage > patch.sql
+
+    cd target
+    dolt sql -q "UPDATE test SET c1 = 2 WHERE pk = 2"
+    run dolt apply ../patch.sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot apply a patch with uncommitted changes" ]] || false
+
+    run dolt apply missing.sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "failed to read patch file" ]] || false
+}
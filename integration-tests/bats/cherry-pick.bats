@@ -570,8 +570,11 @@ teardown() {
     dolt --branch branch1 commit -am "alter table test drop and add primary key"
 
     run dolt cherry-pick branch1
-    [ $status -eq 1 ]
-    [[ $output =~ "error: cannot merge because table test has different primary keys" ]] || false
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SHOW CREATE TABLE test;"
+    [ $status -eq 0 ]
+    [[ $output =~ 'PRIMARY KEY (`pk`,`v`)' ]] || false
 }
 
 @test "cherry-pick: author and timestamp preserved during cherry-pick" {
@@ -32,6 +32,26 @@ two_conflicts() {
     dolt commit -am "main commit"
 }
 
+cell_conflicts() {
+    dolt sql <<SQL
+create table t (pk int primary key, c1 int, c2 varchar(10), c3 int);
+insert into t values (1, 1, 'a', 1), (2, 2, 'b', 2), (3, 3, 'c', 3), (4, 4, 'd', 4);
+call dolt_commit('-Am', 'init commit');
+call dolt_checkout('-b', 'other');
+update t set c1 = 10, c2 = 'x' where pk = 1;
+update t set c1 = 20 where pk = 2;
+delete from t where pk = 3;
+update t set c3 = 40 where pk = 4;
+call dolt_commit('-am', 'other commit');
+call dolt_checkout('main');
+update t set c1 = 11, c2 = 'y' where pk = 1;
+update t set c1 = 21 where pk = 2;
+update t set c1 = 30 where pk = 3;
+delete from t where pk = 4;
+call dolt_commit('-am', 'main commit');
+SQL
+}
+
 teardown() {
     assert_feature_version
     teardown_common
@@ -317,3 +337,61 @@ teardown() {
     [ $status -eq 0 ]
     [[ $output =~ "main" ]] || false
 }
+
+@test "conflicts-resolve: interactive resolution can't be combined with --ours or --theirs" {
+    run dolt conflicts resolve -i --ours t
+    [ $status -eq 1 ]
+    [[ $output =~ "--interactive can't be used with --ours or --theirs" ]] || false
+}
+
+@test "conflicts-resolve: interactive resolution with no conflicts" {
+    run dolt conflicts resolve -i
+    [ $status -eq 0 ]
+    [[ $output =~ "No conflicts to resolve." ]] || false
+}
+
+# bats test_tags=no_lambda
+@test "conflicts-resolve: interactive resolution of cells and deleted rows" {
+    skiponwindows "Need to install expect and make this script work on windows."
+    cell_conflicts
+    run dolt merge other
+    [ $status -eq 1 ]
+
+    run $BATS_TEST_DIRNAME/conflicts-resolve-expect/cells.expect
+    [ $status -eq 0 ]
+
+    run dolt sql -q "select * from t order by pk" -r csv
+    [ $status -eq 0 ]
+    [[ "${lines[1]}" = "1,10,z,1" ]] || false
+    [[ "${lines[2]}" = "2,2,b,2" ]] || false
+    [[ "${lines[3]}" = "4,4,d,40" ]] || false
+    [ "${#lines[@]}" -eq 4 ]
+
+    run dolt conflicts cat .
+    [ $status -eq 0 ]
+    [[ ! $output =~ "theirs" ]] || false
+
+    dolt add t
+    dolt commit -m "merged other"
+}
+
+# bats test_tags=no_lambda
+@test "conflicts-resolve: interactive resolution can be skipped and quit" {
+    skiponwindows "Need to install expect and make this script work on windows."
+    cell_conflicts
+    run dolt merge other
+    [ $status -eq 1 ]
+
+    run $BATS_TEST_DIRNAME/conflicts-resolve-expect/skip_quit.expect
+    [ $status -eq 0 ]
+
+    run dolt sql -q "select * from t where pk = 1" -r csv
+    [[ "${lines[1]}" = "1,10,x,1" ]] || false
+
+    run dolt sql -q "select base_pk from dolt_conflicts_t order by base_pk" -r csv
+    [ $status -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
+    [[ "${lines[2]}" = "3" ]] || false
+    [[ "${lines[3]}" = "4" ]] || false
+    [ "${#lines[@]}" -eq 4 ]
+}
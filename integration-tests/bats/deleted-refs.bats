@@ -0,0 +1,94 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "deleted-refs: branch --restore brings back a deleted branch" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt branch feature
+    dolt sql -q "insert into t values (1);"
+    dolt commit -am "insert 1"
+
+    dolt branch -d feature
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "feature" ]] || false
+
+    run dolt sql -q "select name, type from dolt_deleted_refs" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature,branch" ]] || false
+
+    dolt branch --restore feature
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature" ]] || false
+
+    dolt checkout feature
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_deleted_refs" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "deleted-refs: tag --restore brings back a deleted tag with its message" {
+    dolt commit --allow-empty -m "first"
+    dolt tag -m "first release" v1
+    dolt tag -d v1
+
+    run dolt sql -q "select name, type from dolt_deleted_refs" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1,tag" ]] || false
+
+    dolt tag --restore v1
+    run dolt tag -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "first release" ]] || false
+}
+
+@test "deleted-refs: deleted branches survive gc" {
+    dolt branch feature
+    dolt checkout feature
+    dolt commit --allow-empty -m "only on feature"
+    dolt checkout main
+    dolt branch -D feature
+    dolt gc
+
+    dolt branch --restore feature
+    run dolt log feature -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "only on feature" ]] || false
+}
+
+@test "deleted-refs: restore errors" {
+    dolt branch feature
+    dolt branch -d feature
+    dolt branch feature
+
+    run dolt branch --restore feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "A branch named 'feature' already exists" ]] || false
+
+    run dolt branch --restore nope
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no deleted ref found for branch 'nope'" ]] || false
+
+    run dolt branch --restore nope -f
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--restore can't be combined with other options" ]] || false
+
+    run dolt tag --restore nope
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "no deleted ref found for tag 'nope'" ]] || false
+}
@@ -242,6 +242,27 @@ SQL
     [[ "$output" =~ "| > | modify2 | CREATE PROCEDURE modify2() SELECT 43 |" ]] || false
 }
 
+@test "diff: unified diff mode shows line diffs of text and json cells" {
+    dolt sql <<SQL
+DROP TABLE test;
+CREATE TABLE t (pk INT PRIMARY KEY, body TEXT, doc JSON, n INT);
+INSERT INTO t VALUES (1, 'one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n', '{"a": 1, "b": [1, 2]}', 1);
+SQL
+    dolt commit -Am "First commit"
+
+    dolt sql -q "UPDATE t SET body = 'one\ntwo\nthree\nfour\nfive\nsix changed here\nseven\neight\nnine\nten\n', doc = '{\"a\": 2, \"b\": [1, 2], \"c\": true}', n = 2"
+
+    run dolt diff --diff-mode=unified
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| * | 1  | @@ -3,7 +3,7 @@   | -\$.a: 1    | -1 |" ]] || false
+    [[ "$output" =~ "|   |    |  three            | +\$.a: 2    | +2 |" ]] || false
+    [[ "$output" =~ "|   |    |  five             |            |    |" ]] || false
+    [[ "$output" =~ "|   |    | -six              |            |    |" ]] || false
+    [[ "$output" =~ "|   |    | +six changed here |            |    |" ]] || false
+    [[ "$output" =~ "|   |    |  nine             |            |    |" ]] || false
+    [[ ! "$output" =~ "ten" ]] || false
+}
+
 @test "diff: reverse diff" {
     # We're not using the test table, so we might as well delete it
     dolt sql <<SQL
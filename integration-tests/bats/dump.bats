@@ -16,6 +16,24 @@ teardown() {
     [[ "$output" =~ "No tables to export." ]] || false
 }
 
+@test "dump: schema show, schema export and dump of a table" {
+    dolt sql -q "create table t (pk int primary key, c1 varchar(10))"
+    dolt sql -q "insert into t values (1, 'one')"
+
+    run dolt schema show t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CREATE TABLE \`t\`" ]] || false
+
+    run dolt schema export t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CREATE TABLE \`t\`" ]] || false
+
+    run dolt dump
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    grep "INSERT INTO \`t\`" doltdump.sql
+}
+
 @test "dump: roundtrip on database with leading space character and hyphen" {
     mkdir ' test-db'
     cd ' test-db'
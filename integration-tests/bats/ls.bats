@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 27 ]
+    [ "${#lines[@]}" -eq 31 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_status_ignored" ]] || false
@@ -73,6 +73,10 @@ teardown() {
     [[ "$output" =~ "dolt_branches" ]] || false
     [[ "$output" =~ "dolt_branch_activity" ]] || false
     [[ "$output" =~ "dolt_backups" ]] || false
+    [[ "$output" =~ "dolt_operations" ]] || false
+    [[ "$output" =~ "dolt_deleted_refs" ]] || false
+    [[ "$output" =~ "dolt_locks" ]] || false
+    [[ "$output" =~ "dolt_query_cache" ]] || false
     [[ "$output" =~ "dolt_remote_branches" ]] || false
     [[ "$output" =~ "dolt_help" ]] || false
     [[ "$output" =~ "dolt_constraint_violations_table_one" ]] || false
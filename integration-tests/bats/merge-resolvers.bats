@@ -0,0 +1,65 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "create table prices (id int primary key, price int)"
+    dolt sql -q "insert into prices values (1, 10), (2, 20)"
+    dolt commit -Am "prices"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "merge-resolvers: resolver procedure resolves conflicts and the merge is committed" {
+    dolt sql -q "create procedure average_price(base json, ours json, theirs json) select ours->>'\$.id', (ours->>'\$.price' + theirs->>'\$.price') div 2"
+    dolt sql -q "insert into dolt_merge_resolvers values ('prices', 'average_price')"
+    dolt commit -Am "resolver"
+
+    dolt checkout -b other
+    dolt sql -q "update prices set price = price + 10"
+    dolt commit -am "other"
+
+    dolt checkout main
+    dolt sql -q "update prices set price = price * 3"
+    dolt commit -am "main"
+
+    run dolt merge other -m "merge other"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "select * from prices order by id" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,25" ]] || false
+    [[ "$output" =~ "2,45" ]] || false
+
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "merge other" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "merge-resolvers: conflicts of tables without a resolver remain" {
+    dolt sql -q "create procedure average_price(base json, ours json, theirs json) select ours->>'\$.id', (ours->>'\$.price' + theirs->>'\$.price') div 2"
+    dolt sql -q "insert into dolt_merge_resolvers values ('other_table', 'average_price')"
+    dolt commit -Am "resolver"
+
+    dolt checkout -b other
+    dolt sql -q "update prices set price = price + 10"
+    dolt commit -am "other"
+
+    dolt checkout main
+    dolt sql -q "update prices set price = price * 3"
+    dolt commit -am "main"
+
+    run dolt merge other -m "merge other"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in prices" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_conflicts_prices" -r csv
+    [[ "$output" =~ "2" ]] || false
+}
@@ -0,0 +1,49 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "merge-strategies: counters merge without conflicts" {
+    dolt sql <<SQL
+create table inventory (sku varchar(20) primary key, qty int, updated_at datetime);
+insert into inventory values ('apple', 100, '2026-01-01'), ('pear', 50, '2026-01-01');
+insert into dolt_merge_strategies values
+    ('inventory', 'qty', 'sum', null),
+    ('inventory', 'updated_at', 'max', null);
+SQL
+    dolt commit -Am "inventory"
+
+    dolt checkout -b east
+    dolt sql -q "update inventory set qty = qty - 10, updated_at = '2026-02-01' where sku = 'apple'"
+    dolt commit -am "east"
+
+    dolt checkout main
+    dolt sql -q "update inventory set qty = qty - 25, updated_at = '2026-03-01' where sku = 'apple'"
+    dolt commit -am "west"
+
+    run dolt merge east -m "merge east"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "select sku, qty, updated_at from inventory order by sku" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "apple,65,2026-03-01 00:00:00" ]] || false
+    [[ "$output" =~ "pear,50,2026-01-01 00:00:00" ]] || false
+}
+
+@test "merge-strategies: invalid strategies are rejected" {
+    run dolt sql -q "insert into dolt_merge_strategies values ('t', 'c', 'average', null)"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "Check constraint \"strategy_check\" violated" ]] || false
+
+    run dolt sql -q "insert into dolt_merge_strategies values ('t', 'c', 'last_writer_wins', null)"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "Check constraint \"order_column_check\" violated" ]] || false
+}
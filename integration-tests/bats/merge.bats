@@ -1393,3 +1393,115 @@ SQL
     run dolt merge b1
     log_status_eq 0
 }
+
+@test "merge: merging unrelated histories" {
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 2, 2)"
+    dolt commit -am "add rows"
+
+    # an independent import of the same data, with its columns in a different order
+    mkdir other && cd other
+    dolt init
+    dolt sql -q "create table test1 (pk int primary key, c2 int, c1 int)"
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 22, 2), (3, 3, 3)"
+    dolt sql -q "create table test3 (pk int primary key)"
+    dolt commit -Am "import"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+
+    dolt remote add origin file://./remote
+    dolt fetch origin
+
+    run dolt merge origin/main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "refusing to merge unrelated histories" ]] || false
+
+    run dolt merge --base HEAD origin/main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--base can only be used with --allow-unrelated-histories" ]] || false
+
+    run dolt merge --allow-unrelated-histories origin/main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in test1" ]] || false
+
+    run dolt sql -q "select our_pk, our_c2, their_c2, base_pk from dolt_conflicts_test1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,2,22," ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+
+    dolt sql -q "set autocommit = 0; update test1 set c2 = 22 where pk = 2; delete from dolt_conflicts_test1; commit;"
+    run dolt sql -q "select * from test1 order by pk" -r csv
+    [[ "$output" =~ "1,1,1" ]] || false
+    [[ "$output" =~ "2,2,22" ]] || false
+    [[ "$output" =~ "3,3,3" ]] || false
+
+    dolt commit -am "merge unrelated histories"
+    run dolt ls
+    [[ "$output" =~ "test2" ]] || false
+    [[ "$output" =~ "test3" ]] || false
+}
+
+@test "merge: merging unrelated histories with a base" {
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 2, 2), (3, 3, 3)"
+    dolt commit -am "import"
+    dolt tag import
+
+    mkdir other && cd other
+    dolt init
+    dolt sql -q "create table test1 (pk int primary key, c1 int, c2 int)"
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 2, 2), (3, 3, 3)"
+    dolt commit -Am "import"
+    dolt sql -q "update test1 set c1 = 10 where pk = 1"
+    dolt commit -am "change row 1"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+
+    dolt sql -q "delete from test1 where pk = 3"
+    dolt commit -am "delete row 3"
+    dolt remote add origin file://./remote
+    dolt fetch origin
+
+    run dolt merge --allow-unrelated-histories --base import origin/main -m "merge"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select * from test1 order by pk" -r csv
+    [[ "$output" =~ "1,10,1" ]] || false
+    [[ "$output" =~ "2,2,2" ]] || false
+    [[ ! "$output" =~ "3,3,3" ]] || false
+
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "merge" ]] || false
+}
+
+@test "merge: schema conflicts of unrelated histories show the schema of their base" {
+    dolt tag import
+
+    mkdir other && cd other
+    dolt init
+    dolt sql -q "create table test1 (pk int primary key, c1 int, c2 int)"
+    dolt commit -Am "import"
+    dolt sql -q "alter table test1 modify c1 datetime"
+    dolt commit -am "change c1"
+    dolt remote add origin file://../remote
+    dolt push origin main
+    cd ..
+
+    dolt sql -q "alter table test1 modify c1 varchar(10)"
+    dolt commit -am "change c1"
+    dolt remote add origin file://./remote
+    dolt fetch origin
+
+    run dolt merge --allow-unrelated-histories --base import origin/main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test1" ]] || false
+
+    run dolt sql -q "select base_schema from dolt_schema_conflicts where table_name = 'test1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`c1` int' ]] || false
+
+    dolt gc
+    run dolt sql -q "select base_schema from dolt_schema_conflicts where table_name = 'test1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`c1` int' ]] || false
+}
@@ -39,6 +39,7 @@ teardown() {
     [[ "$output" =~ "conflicts - Commands for viewing and resolving merge conflicts." ]] || false
     [[ "$output" =~ "cherry-pick - Apply the changes introduced by an existing commit." ]] || false
     [[ "$output" =~ "revert - Undo the changes introduced in a commit." ]] || false
+    [[ "$output" =~ "apply - Apply a SQL patch to the working set." ]] || false
     [[ "$output" =~ "clone - Clone from a remote data repository." ]] || false
     [[ "$output" =~ "fetch - Update the database from a remote data repository." ]] || false
     [[ "$output" =~ "pull - Fetch from a dolt remote data repository and merge." ]] || false
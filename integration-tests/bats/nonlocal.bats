@@ -190,7 +190,7 @@ SQL
   [[ "$output" =~ "table not found" ]] || false
 }
 
-@test "nonlocal: a transaction that tries to update multiple branches fails as expected" {
+@test "nonlocal: a transaction that updates multiple branches commits all of them" {
   run dolt sql <<SQL
   CREATE TABLE aliased_table (pk char(8) PRIMARY KEY);
   CALL DOLT_CHECKOUT('-b', 'other');
@@ -201,6 +201,31 @@ SQL
   INSERT INTO local_table VALUES ("amzmapqt");
   INSERT INTO nonlocal_table VALUES ("eesekkgo");
   COMMIT;
+SQL
+  [ "$status" -eq 0 ]
+
+  run dolt sql -q "select * from aliased_table;"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "eesekkgo" ]] || false
+
+  dolt checkout other
+  run dolt sql -q "select * from local_table;"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "amzmapqt" ]] || false
+}
+
+@test "nonlocal: a transaction that updates multiple branches fails with @@dolt_transaction_commit" {
+  run dolt sql <<SQL
+  CREATE TABLE aliased_table (pk char(8) PRIMARY KEY);
+  CALL DOLT_CHECKOUT('-b', 'other');
+  CREATE TABLE local_table (pk char(8) PRIMARY KEY);
+  INSERT INTO dolt_nonlocal_tables(table_name, target_ref, ref_table, options) VALUES
+      ("nonlocal_table", "main", "aliased_table", "immediate");
+  set autocommit = 0;
+  set dolt_transaction_commit = 1;
+  INSERT INTO local_table VALUES ("amzmapqt");
+  INSERT INTO nonlocal_table VALUES ("eesekkgo");
+  COMMIT;
 SQL
   [ "$status" -eq 1 ]
   [[ "$output" =~ "Cannot commit changes on more than one branch / database" ]] || false
@@ -0,0 +1,95 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "op: log shows ref and working set changes of operations" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt branch feature
+    dolt sql -q "insert into t values (1), (2);"
+    dolt reset --hard
+
+    run dolt op log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "call dolt_reset('--hard')" ]] || false
+    [[ "$output" =~ "call dolt_branch('feature')" ]] || false
+    [[ "$output" =~ "call dolt_commit('-Am', 'create t')" ]] || false
+    [[ "$output" =~ "refs/heads/feature: (none) -> " ]] || false
+    [[ "$output" =~ "workingSets/heads/main: " ]] || false
+
+    # writes to tables aren't operations
+    [[ ! "$output" =~ "insert into t" ]] || false
+
+    run dolt sql -q "select count(distinct id) from dolt_operations where description like 'call dolt_%'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "op: restore undoes a reset --hard" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt sql -q "insert into t values (1), (2);"
+    dolt reset --hard
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "0" ]] || false
+
+    id=$(dolt sql -q "select max(id) - 1 from dolt_operations" -r csv | tail -n 1)
+    run dolt op restore $id
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select * from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "2" ]] || false
+
+    # the restore is itself an operation, and can be undone
+    run dolt op log
+    [[ "$output" =~ "call dolt_op('restore', '$id')" ]] || false
+
+    dolt op restore $((id + 1))
+    run dolt sql -q "select count(*) from t" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "op: restore brings back a deleted branch" {
+    dolt sql -q "create table t (pk int primary key);"
+    dolt commit -Am "create t"
+    dolt branch feature
+    id=$(dolt sql -q "select max(id) from dolt_operations" -r csv | tail -n 1)
+    dolt branch -D feature
+
+    run dolt branch
+    [[ ! "$output" =~ "feature" ]] || false
+
+    dolt op restore $id
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature" ]] || false
+
+    run dolt log feature --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "create t" ]] || false
+}
+
+@test "op: restore errors" {
+    run dolt op restore 100000
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "operation not found: 100000" ]] || false
+
+    run dolt op restore abc
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid operation id 'abc'" ]] || false
+
+    run dolt op restore
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "expected 1 argument" ]] || false
+}
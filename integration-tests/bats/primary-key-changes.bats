@@ -529,7 +529,7 @@ SQL
     [[ "$output" =~ "key column 'pk1' doesn't exist in table" ]] || false
 }
 
-@test "primary-key-changes: same primary key set in different order is detected and blocked on merge" {
+@test "primary-key-changes: same primary key set in different order is detected and re-keyed on merge" {
     dolt sql -q "CREATE table t (pk int, val int, primary key (pk, val))"
     dolt add .
     dolt commit -am "cm1"
@@ -563,13 +563,15 @@ SQL
     [[ ! "$output" =~ "Primary key sets differ between revisions for table 't'" ]] || false
 
     run dolt merge test -m "merge other"
-    [ "$status" -eq 1 ]
-    [[ "$output" =~ 'error: cannot merge because table t has different primary keys' ]] || false
+    [ "$status" -eq 0 ]
 
-    run dolt sql -q "call dolt_merge('test')"
-    [ "$status" -eq 1 ]
-    [[ "$output" =~ 'error: cannot merge because table t has different primary keys' ]] || false
+    run dolt sql -q "show create table t"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'PRIMARY KEY (`val`,`pk`)' ]] || false
 
+    run dolt sql -q "select * from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
 }
 
 @test "primary-key-changes: correct diff is returned even with a new added column" {
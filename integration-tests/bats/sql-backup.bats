@@ -463,3 +463,122 @@ SQL
     [[ "$output" =~ "42" ]] || false
     [[ ! "$output" =~ "99" ]] || false
 }
+
+@test "sql-backup: dolt_backup restore --to-time recovers working set changes from the journal archive" {
+    setup_backup
+    dolt sql -q "call dolt_backup('sync', 'b1')"
+
+    dolt sql -q "insert into t values (2), (3)"
+    dolt sql -q "call dolt_backup('ship-journal', 'b1')"
+    [ -d "$backup_dir/journal_archive" ] || false
+
+    sleep 2
+    before_delete=$(date -u +%Y-%m-%dT%H:%M:%S)
+    sleep 2
+    dolt sql -q "delete from t"
+    dolt sql -q "call dolt_backup('ship-journal', 'b1')"
+
+    dolt sql -q "call dolt_backup('restore', '--to-time', '$before_delete', 'file://$backup_dir', 'pitr')"
+    run dolt sql -r csv -q "use pitr; select pk from t order by pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "2" ]] || false
+    [[ "$output" =~ "3" ]] || false
+
+    # the restored changes are uncommitted, just as they were when the journal was shipped
+    run dolt sql -r csv -q "use pitr; select count(*) from dolt_log"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    run dolt sql -r csv -q "use pitr; select table_name from dolt_status"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+}
+
+@test "sql-backup: dolt_backup restore --to-working-set" {
+    setup_backup
+    dolt sql -q "call dolt_backup('sync', 'b1')"
+
+    dbname=$(dolt sql -r csv -q "select database()" | tail -n 1)
+    dolt sql -q "insert into t values (10)"
+    working=$(dolt sql -r csv -q "select @@${dbname}_working" | tail -n 1)
+    dolt sql -q "delete from t"
+    dolt sql -q "call dolt_backup('ship-journal', 'b1')"
+
+    dolt sql -q "call dolt_backup('restore', '--to-working-set', '$working', 'file://$backup_dir', 'pitr')"
+    run dolt sql -r csv -q "use pitr; select pk from t order by pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    [[ "$output" =~ "10" ]] || false
+}
+
+@test "sql-backup: dolt_backup journal archive errors" {
+    setup_backup
+    dolt sql -q "call dolt_backup('sync', 'b1')"
+
+    run dolt sql -q "call dolt_backup('restore', '--to-time', '2026-10-01T12:00', 'file://$backup_dir', 'pitr')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not have a journal archive" ]] || false
+
+    dolt sql -q "call dolt_backup('ship-journal', 'b1')"
+    run dolt sql -q "call dolt_backup('restore', '--to-time', '2000-01-01', 'file://$backup_dir', 'pitr')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not contain a root matching the restore target" ]] || false
+    run dolt sql -q "show databases"
+    [[ ! "$output" =~ "pitr" ]] || false
+
+    run dolt sql -q "call dolt_backup('restore', '--to-time', '2026-10-01T12:00', '--to-working-set', 'abc', 'file://$backup_dir', 'pitr')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+
+    run dolt sql -q "call dolt_backup('restore', '--to-working-set', 'not-a-hash', 'file://$backup_dir', 'pitr')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "is not a valid hash" ]] || false
+
+    run dolt sql -q "call dolt_backup('sync', '--to-time', '2026-10-01T12:00', 'b1')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "only supported with 'restore'" ]] || false
+
+    run dolt sql -q "call dolt_backup('ship-journal', '--interval', '1s', 'b1')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--interval is not supported" ]] || false
+
+    dolt backup add web https://dolthub.com/org/repo
+    run dolt sql -q "call dolt_backup('ship-journal', 'web')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "its storage is not a blobstore" ]] || false
+}
+
+@test "sql-backup: dolt_backup ships the journal to blobstore backups" {
+    mkdir "$BATS_TEST_TMPDIR/bs-backup"
+    dolt backup add bs "localbs://$BATS_TEST_TMPDIR/bs-backup"
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create t"
+    dolt backup sync bs
+    dolt sql -q "insert into t values (1)"
+    dolt sql -q "call dolt_backup('ship-journal', 'bs')"
+    [ -d "$BATS_TEST_TMPDIR/bs-backup/journal_archive" ] || false
+
+    dolt sql -q "call dolt_backup('restore', '--to-time', '2100-01-01', 'localbs://$BATS_TEST_TMPDIR/bs-backup', 'pitr')"
+    run dolt sql -q "select * from pitr.t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "sql-backup: dolt_backup restore --restore-point errors" {
+    setup_backup
+    dolt sql -q "call dolt_backup('sync', 'b1')"
+
+    run dolt sql -q "call dolt_backup('restore', '--restore-point', '20261001T120000.000000000Z', 'file://$backup_dir', 'restored')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "restore point not found" ]] || false
+    run dolt sql -q "show databases"
+    [[ ! "$output" =~ "restored" ]] || false
+
+    run dolt backup restore --restore-point 20261001T120000.000000000Z --to-time 2026-10-01T12:00 "file://$backup_dir" restored
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--restore-point cannot be used with --to-time or --to-working-set" ]] || false
+
+    run dolt sql -q "call dolt_backup('sync', '--restore-point', '20261001T120000.000000000Z', 'b1')"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "only supported with 'restore'" ]] || false
+}
@@ -511,6 +511,9 @@ SQL
 
     dolt checkout main
     run dolt sql -q "CALL DOLT_CHERRY_PICK('branch1')"
-    [ $status -eq 1 ]
-    [[ $output =~ "error: cannot merge because table test has different primary keys" ]] || false
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SHOW CREATE TABLE test;"
+    [ $status -eq 0 ]
+    [[ $output =~ 'PRIMARY KEY (`pk`,`v`)' ]] || false
 }